@@ -49,7 +49,7 @@ type StructsValueTransferMessage struct {
 
 // ManagementContractMetaData contains all meta data concerning the ManagementContract contract.
 var ManagementContractMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"ECDSAInvalidSignature\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"length\",\"type\":\"uint256\"}],\"name\":\"ECDSAInvalidSignatureLength\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"ECDSAInvalidSignatureS\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidInitialization\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NotInitializing\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"OwnableInvalidOwner\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"OwnableUnauthorizedAccount\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"newAddress\",\"type\":\"address\"}],\"name\":\"ImportantContractAddressUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"version\",\"type\":\"uint64\"}],\"name\":\"Initialized\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"messageBusAddress\",\"type\":\"address\"}],\"name\":\"LogManagementContractCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"requester\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"requestReport\",\"type\":\"string\"}],\"name\":\"NetworkSecretRequested\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"attester\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"requester\",\"type\":\"address\"}],\"name\":\"NetworkSecretResponded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"rollupHash\",\"type\":\"bytes32\"}],\"name\":\"RollupAdded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"enclaveID\",\"type\":\"address\"}],\"name\":\"SequencerEnclaveGranted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"enclaveID\",\"type\":\"address\"}],\"name\":\"SequencerEnclaveRevoked\",\"type\":\"event\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"Hash\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"Signature\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"LastSequenceNumber\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"BlockBindingHash\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"BlockBindingNumber\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"crossChainRoot\",\"type\":\"bytes32\"}],\"internalType\":\"structStructs.MetaRollup\",\"name\":\"r\",\"type\":\"tuple\"}],\"name\":\"AddRollup\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_addr\",\"type\":\"address\"}],\"name\":\"Attested\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"}],\"internalType\":\"structStructs.ValueTransferMessage\",\"name\":\"_msg\",\"type\":\"tuple\"},{\"internalType\":\"bytes32[]\",\"name\":\"proof\",\"type\":\"bytes32[]\"},{\"internalType\":\"bytes32\",\"name\":\"root\",\"type\":\"bytes32\"}],\"name\":\"ExtractNativeValue\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"GetChallengePeriod\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"GetImportantContractKeys\",\"outputs\":[{\"internalType\":\"string[]\",\"name\":\"\",\"type\":\"string[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"rollupHash\",\"type\":\"bytes32\"}],\"name\":\"GetRollupByHash\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"},{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"Hash\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"Signature\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"LastSequenceNumber\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"BlockBindingHash\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"BlockBindingNumber\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"crossChainRoot\",\"type\":\"bytes32\"}],\"internalType\":\"structStructs.MetaRollup\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_addr\",\"type\":\"address\"}],\"name\":\"GrantSequencerEnclave\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_enclaveID\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"_initSecret\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"_genesisAttestation\",\"type\":\"string\"}],\"name\":\"InitializeNetworkSecret\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_addr\",\"type\":\"address\"}],\"name\":\"IsSequencerEnclave\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"IsWithdrawalAvailable\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"requestReport\",\"type\":\"string\"}],\"name\":\"RequestNetworkSecret\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"attesterID\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"requesterID\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"attesterSig\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"responseSecret\",\"type\":\"bytes\"},{\"internalType\":\"bool\",\"name\":\"verifyAttester\",\"type\":\"bool\"}],\"name\":\"RespondNetworkSecret\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"RetrieveAllBridgeFunds\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_addr\",\"type\":\"address\"}],\"name\":\"RevokeSequencerEnclave\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_delay\",\"type\":\"uint256\"}],\"name\":\"SetChallengePeriod\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"},{\"internalType\":\"address\",\"name\":\"newAddress\",\"type\":\"address\"}],\"name\":\"SetImportantContractAddress\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"name\":\"importantContractAddresses\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"importantContractKeys\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes[]\",\"name\":\"crossChainHashes\",\"type\":\"bytes[]\"}],\"name\":\"isBundleAvailable\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"isBundleSaved\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"isWithdrawalSpent\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"lastBatchHash\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"lastBatchSeqNo\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"merkleMessageBus\",\"outputs\":[{\"internalType\":\"contractIMerkleTreeMessageBus\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"messageBus\",\"outputs\":[{\"internalType\":\"contractIMessageBus\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"ECDSAInvalidSignature\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"length\",\"type\":\"uint256\"}],\"name\":\"ECDSAInvalidSignatureLength\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"ECDSAInvalidSignatureS\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidInitialization\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NotInitializing\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"OwnableInvalidOwner\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"OwnableUnauthorizedAccount\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"newAddress\",\"type\":\"address\"}],\"name\":\"ImportantContractAddressUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"version\",\"type\":\"uint64\"}],\"name\":\"Initialized\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"messageBusAddress\",\"type\":\"address\"}],\"name\":\"LogManagementContractCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"requester\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"requestReport\",\"type\":\"string\"}],\"name\":\"NetworkSecretRequested\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"attester\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"requester\",\"type\":\"address\"}],\"name\":\"NetworkSecretResponded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"rollupHash\",\"type\":\"bytes32\"}],\"name\":\"RollupAdded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"enclaveID\",\"type\":\"address\"}],\"name\":\"SequencerEnclaveGranted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"enclaveID\",\"type\":\"address\"}],\"name\":\"SequencerEnclaveRevoked\",\"type\":\"event\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"Hash\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"Signature\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"LastSequenceNumber\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"BlockBindingHash\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"BlockBindingNumber\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"crossChainRoot\",\"type\":\"bytes32\"}],\"internalType\":\"structStructs.MetaRollup\",\"name\":\"r\",\"type\":\"tuple\"}],\"name\":\"AddRollup\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_addr\",\"type\":\"address\"}],\"name\":\"Attested\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"}],\"internalType\":\"structStructs.ValueTransferMessage\",\"name\":\"_msg\",\"type\":\"tuple\"},{\"internalType\":\"bytes32[]\",\"name\":\"proof\",\"type\":\"bytes32[]\"},{\"internalType\":\"bytes32\",\"name\":\"root\",\"type\":\"bytes32\"}],\"name\":\"ExtractNativeValue\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"GetChallengePeriod\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"GetImportantContractKeys\",\"outputs\":[{\"internalType\":\"string[]\",\"name\":\"\",\"type\":\"string[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"rollupHash\",\"type\":\"bytes32\"}],\"name\":\"GetRollupByHash\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"},{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"Hash\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"Signature\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"LastSequenceNumber\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"BlockBindingHash\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"BlockBindingNumber\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"crossChainRoot\",\"type\":\"bytes32\"}],\"internalType\":\"structStructs.MetaRollup\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_addr\",\"type\":\"address\"}],\"name\":\"GrantSequencerEnclave\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_enclaveID\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"_initSecret\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"_genesisAttestation\",\"type\":\"string\"}],\"name\":\"InitializeNetworkSecret\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_addr\",\"type\":\"address\"}],\"name\":\"IsSequencerEnclave\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"IsWithdrawalAvailable\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"requestReport\",\"type\":\"string\"}],\"name\":\"RequestNetworkSecret\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"attesterID\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"requesterID\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"attesterSig\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"responseSecret\",\"type\":\"bytes\"},{\"internalType\":\"bool\",\"name\":\"verifyAttester\",\"type\":\"bool\"}],\"name\":\"RespondNetworkSecret\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"RetrieveAllBridgeFunds\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_addr\",\"type\":\"address\"}],\"name\":\"RevokeSequencerEnclave\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_delay\",\"type\":\"uint256\"}],\"name\":\"SetChallengePeriod\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"},{\"internalType\":\"address\",\"name\":\"newAddress\",\"type\":\"address\"}],\"name\":\"SetImportantContractAddress\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_lastBatchHash\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"blockHash\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"blockNum\",\"type\":\"uint256\"},{\"internalType\":\"bytes[]\",\"name\":\"crossChainHashes\",\"type\":\"bytes[]\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"rollupNumber\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"forkID\",\"type\":\"bytes32\"}],\"name\":\"addCrossChainMessagesRoot\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"name\":\"importantContractAddresses\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"importantContractKeys\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes[]\",\"name\":\"crossChainHashes\",\"type\":\"bytes[]\"}],\"name\":\"isBundleAvailable\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"isBundleSaved\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"isWithdrawalSpent\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"lastBatchHash\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"lastBatchSeqNo\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"merkleMessageBus\",\"outputs\":[{\"internalType\":\"contractIMerkleTreeMessageBus\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"messageBus\",\"outputs\":[{\"internalType\":\"contractIMessageBus\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
 	Bin: "0x6080604052348015600f57600080fd5b50601733601b565b608c565b7f9016d09d72d40fdae2fd8ceac6b6234c7706214fd39c1cd1e609a0528c19930080546001600160a01b031981166001600160a01b03848116918217845560405192169182907f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e090600090a3505050565b614ef88061009b6000396000f3fe608060405234801561001057600080fd5b50600436106101ce5760003560e01c80637281099611610104578063a1a227fa116100a2578063db5d91b111610071578063db5d91b11461043e578063e34fbfc81461046a578063e874eb201461047d578063f2fde38b1461049057600080fd5b8063a1a227fa146103e8578063a4ab2faa14610408578063a52f433c1461041b578063d4fab8871461042b57600080fd5b806384154826116100de57806384154826146103625780638da5cb5b1461038557806395b6b662146103b557806398077e86146103c857600080fd5b806372810996146103315780638129fc1c146103395780638236a7ba1461034157600080fd5b8063476657381161017157806368e103831161014b57806368e10383146102ee5780636a30d26c146103015780636b9707d614610316578063715018a61461032957600080fd5b806347665738146102b55780635024621f146102c85780635371a216146102db57600080fd5b80632f0cb9e3116101ad5780632f0cb9e31461020f5780633e60a22f1461023f57806343348b2f14610280578063440c953b146102ac57600080fd5b80620ddd27146101d357806303e72e48146101f25780631aca00ab14610207575b600080fd5b6101dc600c5481565b6040516101e991906117c3565b60405180910390f35b6102056102003660046118f9565b6104a3565b005b600d546101dc565b61023261021d36600461195f565b600a6020526000908152604090205460ff1681565b6040516101e99190611986565b61027361024d366004611994565b80516020818301810180516003825292820191909301209152546001600160a01b031681565b6040516101e991906119e0565b61023261028e3660046119ee565b6001600160a01b031660009081526020819052604090205460ff1690565b6101dc60055481565b6102056102c33660046119ee565b6105ab565b6102056102d636600461195f565b610654565b6102056102e9366004611a7a565b610661565b6102056102fc366004611b30565b610806565b6103096108aa565b6040516101e99190611c89565b6102056103243660046119ee565b610983565b610205610a13565b610205610a27565b610205610aac565b61035461034f36600461195f565b610c82565b6040516101e9929190611d14565b61023261037036600461195f565b600b6020526000908152604090205460ff1681565b7f9016d09d72d40fdae2fd8ceac6b6234c7706214fd39c1cd1e609a0528c199300546001600160a01b0316610273565b6102056103c3366004611d49565b610da0565b6103db6103d636600461195f565b610f38565b6040516101e99190611d84565b6008546103fb906001600160a01b031681565b6040516101e99190611dd7565b610232610416366004611ea2565b610fe4565b600454610100900460ff16610232565b610205610439366004611ef0565b611062565b61023261044c3660046119ee565b6001600160a01b031660009081526001602052604090205460ff1690565b610205610478366004611f97565b61116a565b6009546103fb906001600160a01b031681565b61020561049e3660046119ee565b6111b1565b6104ab611208565b60006001600160a01b03166003836040516104c69190612001565b908152604051908190036020019020546001600160a01b03160361052257600280546001810182556000919091527f405787fa12a823e0f2b7631cc41b3ba8828b3321ca811111fa75cd3aa3bb5ace0161052083826120dd565b505b806003836040516105339190612001565b90815260405190819003602001812080546001600160a01b039390931673ffffffffffffffffffffffffffffffffffffffff19909316929092179091557f17b2f9f5748931099ffee882b5b64f4a560b5c55da9b4f4e396dae3bb9f98cb59061059f908490849061219d565b60405180910390a15050565b6105b3611208565b6001600160a01b03811660009081526020819052604090205460ff166105f45760405162461bcd60e51b81526004016105eb906121ef565b60405180910390fd5b6001600160a01b038116600090815260016020819052604091829020805460ff19169091179055517ffe64c7181f0fc60e300dc02cca368cdfa94d7ca45902de3b9a9d80070e760936906106499083906119e0565b60405180910390a150565b61065c611208565b600d55565b6009546040517fb201246f0000000000000000000000000000000000000000000000000000000081526001600160a01b039091169063b201246f906106b090879087908790879060040161231a565b60006040518083038186803b1580156106c857600080fd5b505afa1580156106dc573d6000803e3d6000fd5b505050506000846040516020016106f39190612353565b60408051601f1981840301815291815281516020928301206000818152600a90935291205490915060ff161561073b5760405162461bcd60e51b81526004016105eb90612393565b6001600a6000876040516020016107529190612353565b60408051808303601f190181529181528151602092830120835282820193909352908201600020805460ff1916931515939093179092556008546001600160a01b0316916399a3ad21916107ab919089019089016119ee565b87604001356040518363ffffffff1660e01b81526004016107cd9291906123a3565b600060405180830381600087803b1580156107e757600080fd5b505af11580156107fb573d6000803e3d6000fd5b505050505050505050565b60045460ff16156108295760405162461bcd60e51b81526004016105eb90612418565b60048054600160ff1991821681179092556001600160a01b0387166000908152602081815260408083208054851686179055908490529081902080549092169092179055517ffe64c7181f0fc60e300dc02cca368cdfa94d7ca45902de3b9a9d80070e7609369061089b9087906119e0565b60405180910390a15050505050565b60606002805480602002602001604051908101604052809291908181526020016000905b8282101561097a5783829060005260206000200180546108ed90612021565b80601f016020809104026020016040519081016040528092919081815260200182805461091990612021565b80156109665780601f1061093b57610100808354040283529160200191610966565b820191906000526020600020905b81548152906001019060200180831161094957829003601f168201915b5050505050815260200190600101906108ce565b50505050905090565b61098b611208565b6001600160a01b03811660009081526001602052604090205460ff166109c35760405162461bcd60e51b81526004016105eb9061245a565b6001600160a01b03811660009081526001602052604090819020805460ff19169055517f0f279980343c7ca542fde9fa5396555068efb5cd560d9cf9c191aa2911079b47906106499083906119e0565b610a1b611208565b610a25600061127c565b565b610a2f611208565b6008546040517f36d2da900000000000000000000000000000000000000000000000000000000081526001600160a01b03909116906336d2da9090610a789033906004016119e0565b600060405180830381600087803b158015610a9257600080fd5b505af1158015610aa6573d6000803e3d6000fd5b50505050565b7ff0c57e16840df040f15088dc2f81fe391c3923bec73e23a9662efc9c229c6a00805468010000000000000000810460ff16159067ffffffffffffffff16600081158015610af75750825b905060008267ffffffffffffffff166001148015610b145750303b155b905081158015610b22575080155b15610b59576040517ff92ee8a900000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b845467ffffffffffffffff191660011785558315610b8d57845468ff00000000000000001916680100000000000000001785555b610b96336112fa565b6000600555604051610ba7906117ae565b604051809103906000f080158015610bc3573d6000803e3d6000fd5b50600980546001600160a01b039290921673ffffffffffffffffffffffffffffffffffffffff1992831681179091556008805490921681179091556040517fbd726cf82ac9c3260b1495107182e336e0654b25c10915648c0cc15b2bb72cbf91610c2c916119e0565b60405180910390a18315610c7b57845468ff0000000000000000191685556040517fc7f505b2f371ae2175ee4913f4499e1f2633a7b5936321eed1cdaeb6115181d29061089b90600190612485565b5050505050565b6040805160c08101825260008082526060602083018190529282018190529181018290526080810182905260a081018290526000838152600660209081526040808320815160c08101909252805482526001810180549293919291840191610ce990612021565b80601f0160208091040260200160405190810160405280929190818152602001828054610d1590612021565b8015610d625780601f10610d3757610100808354040283529160200191610d62565b820191906000526020600020905b815481529060010190602001808311610d4557829003601f168201915b505050918352505060028201546020820152600382015460408201526004820154606082015260059091015460809091015280519094149492505050565b806000610def8235610db56020850185612493565b8080601f01602080910402602001604051908101604052809392919081815260200183838082843760009201919091525061130b92505050565b6001600160a01b03811660009081526020819052604090205490915060ff16610e2a5760405162461bcd60e51b81526004016105eb906121ef565b6001600160a01b03811660009081526001602052604090205460ff16610e625760405162461bcd60e51b81526004016105eb9061245a565b610e6b83611337565b60a083013560001914610efa576009546040517fb6aed0cb0000000000000000000000000000000000000000000000000000000081526001600160a01b039091169063b6aed0cb90610ec79060a08701359042906004016124e8565b600060405180830381600087803b158015610ee157600080fd5b505af1158015610ef5573d6000803e3d6000fd5b505050505b6040517fd6555bff8670bd3008dc064c30bb56d6ac7cb14ae801e36146fe4e7c6a504a5890610f2b908535906117c3565b60405180910390a1505050565b60028181548110610f4857600080fd5b906000526020600020016000915090508054610f6390612021565b80601f0160208091040260200160405190810160405280929190818152602001828054610f8f90612021565b8015610fdc5780601f10610fb157610100808354040283529160200191610fdc565b820191906000526020600020905b815481529060010190602001808311610fbf57829003601f168201915b505050505081565b600080805b83518110156110495781848281518110611005576110056124f6565b602002602001015161101690612516565b6040516020016110279291906124e8565b60408051601f1981840301815291905280516020909101209150600101610fe9565b506000908152600b602052604090205460ff1692915050565b6001600160a01b03851660009081526020819052604090205460ff168061109b5760405162461bcd60e51b81526004016105eb906125a4565b81156111135760006110cf8787866040516020016110bb939291906125dc565b60405160208183030381529060405261136c565b905060006110dd828761130b565b9050876001600160a01b0316816001600160a01b0316146111105760405162461bcd60e51b81526004016105eb90612658565b50505b6001600160a01b03808616600081815260208190526040808220805460ff191660011790555191928916917fb869e23ebc7c717d76e345eee8ec282612603e45c44f7ae5494b197c8d9d1be19190a3505050505050565b336001600160a01b03167f0b0ecdedd12079aa2d6c5e0186026c711cb0c8d04f1b724ba5880fb6328d430183836040516111a5929190612688565b60405180910390a25050565b6111b9611208565b6001600160a01b0381166111fc5760006040517f1e4fbdf70000000000000000000000000000000000000000000000000000000081526004016105eb91906119e0565b6112058161127c565b50565b3361123a7f9016d09d72d40fdae2fd8ceac6b6234c7706214fd39c1cd1e609a0528c199300546001600160a01b031690565b6001600160a01b031614610a2557336040517f118cdaa70000000000000000000000000000000000000000000000000000000081526004016105eb91906119e0565b7f9016d09d72d40fdae2fd8ceac6b6234c7706214fd39c1cd1e609a0528c199300805473ffffffffffffffffffffffffffffffffffffffff1981166001600160a01b03848116918217845560405192169182907f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e090600090a3505050565b6113026113a7565b6112058161140e565b60008060008061131b8686611416565b92509250925061132b8282611463565b50909150505b92915050565b803560009081526006602052604090208190611353828261285a565b5050600554604082013511156112055760400135600555565b60006113788251611569565b8260405160200161138a929190612864565b604051602081830303815290604052805190602001209050919050565b7ff0c57e16840df040f15088dc2f81fe391c3923bec73e23a9662efc9c229c6a005468010000000000000000900460ff16610a25576040517fd7e6bcf800000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b6111b96113a7565b600080600083516041036114505760208401516040850151606086015160001a6114428882858561160a565b95509550955050505061145c565b50508151600091506002905b9250925092565b6000826003811115611477576114776128a0565b03611480575050565b6001826003811115611494576114946128a0565b036114cb576040517ff645eedf00000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b60028260038111156114df576114df6128a0565b03611518576040517ffce698f70000000000000000000000000000000000000000000000000000000081526105eb9082906004016117c3565b600382600381111561152c5761152c6128a0565b0361156557806040517fd78bce0c0000000000000000000000000000000000000000000000000000000081526004016105eb91906117c3565b5050565b60606000611576836116cc565b600101905060008167ffffffffffffffff811115611596576115966117d1565b6040519080825280601f01601f1916602001820160405280156115c0576020820181803683370190505b5090508181016020015b600019017f3031323334353637383961626364656600000000000000000000000000000000600a86061a8153600a85049450846115ca575b509392505050565b600080807f7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a084111561164557506000915060039050826116c2565b60006001888888886040516000815260200160405260405161166a94939291906128bf565b6020604051602081039080840390855afa15801561168c573d6000803e3d6000fd5b5050604051601f1901519150506001600160a01b0381166116b8575060009250600191508290506116c2565b9250600091508190505b9450945094915050565b6000807a184f03e93ff9f4daa797ed6e38ed64bf6a1f0100000000000000008310611715577a184f03e93ff9f4daa797ed6e38ed64bf6a1f010000000000000000830492506040015b6d04ee2d6d415b85acef81000000008310611741576d04ee2d6d415b85acef8100000000830492506020015b662386f26fc10000831061175f57662386f26fc10000830492506010015b6305f5e1008310611777576305f5e100830492506008015b612710831061178b57612710830492506004015b6064831061179d576064830492506002015b600a83106113315760010192915050565b6125ce806128f583390190565b805b82525050565b6020810161133182846117bb565b634e487b7160e01b600052604160045260246000fd5b601f19601f830116810181811067ffffffffffffffff8211171561180d5761180d6117d1565b6040525050565b600061181f60405190565b905061182b82826117e7565b919050565b600067ffffffffffffffff82111561184a5761184a6117d1565b601f19601f83011660200192915050565b82818337506000910152565b600061187a61187584611830565b611814565b905082815283838301111561189157611891600080fd5b61189f83602083018461185b565b9392505050565b600082601f8301126118ba576118ba600080fd5b61189f83833560208501611867565b60006001600160a01b038216611331565b6118e3816118c9565b811461120557600080fd5b8035611331816118da565b6000806040838503121561190f5761190f600080fd5b823567ffffffffffffffff81111561192957611929600080fd5b611935858286016118a6565b92505061194584602085016118ee565b90509250929050565b806118e3565b80356113318161194e565b60006020828403121561197457611974600080fd5b61189f8383611954565b8015156117bd565b60208101611331828461197e565b6000602082840312156119a9576119a9600080fd5b813567ffffffffffffffff8111156119c3576119c3600080fd5b6119cf848285016118a6565b949350505050565b6117bd816118c9565b6020810161133182846119d7565b600060208284031215611a0357611a03600080fd5b61189f83836118ee565b600060808284031215611a2257611a22600080fd5b50919050565b60008083601f840112611a3d57611a3d600080fd5b50813567ffffffffffffffff811115611a5857611a58600080fd5b602083019150836020820283011115611a7357611a73600080fd5b9250929050565b60008060008060c08587031215611a9357611a93600080fd5b611a9d8686611a0d565b9350608085013567ffffffffffffffff811115611abc57611abc600080fd5b611ac887828801611a28565b9350935050611ada8660a08701611954565b905092959194509250565b60008083601f840112611afa57611afa600080fd5b50813567ffffffffffffffff811115611b1557611b15600080fd5b602083019150836001820283011115611a7357611a73600080fd5b600080600080600060608688031215611b4b57611b4b600080fd5b611b5587876118ee565b9450602086013567ffffffffffffffff811115611b7457611b74600080fd5b611b8088828901611ae5565b9450945050604086013567ffffffffffffffff811115611ba257611ba2600080fd5b611bae88828901611ae5565b92509250509295509295909350565b60005b83811015611bd8578181015183820152602001611bc0565b50506000910152565b6000611beb825190565b808452602084019350611c02818560208601611bbd565b601f01601f19169290920192915050565b600061189f8383611be1565b60200190565b6000611c2f825190565b80845260208401935083602082028501611c498560200190565b60005b84811015611c7d5783830388528151611c658482611c13565b93505060208201602098909801979150600101611c4c565b50909695505050505050565b6020808252810161189f8184611c25565b805160009060c0840190611cae85826117bb565b5060208301518482036020860152611cc68282611be1565b9150506040830151611cdb60408601826117bb565b506060830151611cee60608601826117bb565b506080830151611d0160808601826117bb565b5060a083015161160260a08601826117bb565b60408101611d22828561197e565b81810360208301526119cf8184611c9a565b600060c08284031215611a2257611a22600080fd5b600060208284031215611d5e57611d5e600080fd5b813567ffffffffffffffff811115611d7857611d78600080fd5b6119cf84828501611d34565b6020808252810161189f8184611be1565b60006113316001600160a01b038316611dac565b90565b6001600160a01b031690565b600061133182611d95565b600061133182611db8565b6117bd81611dc3565b602081016113318284611dce565b600067ffffffffffffffff821115611dff57611dff6117d1565b5060209081020190565b6000611e1761187584611de5565b83815290506020808201908402830185811115611e3657611e36600080fd5b835b81811015611e7557803567ffffffffffffffff811115611e5a57611e5a600080fd5b611e66888288016118a6565b84525060209283019201611e38565b5050509392505050565b600082601f830112611e9357611e93600080fd5b61189f83833560208501611e09565b600060208284031215611eb757611eb7600080fd5b813567ffffffffffffffff811115611ed157611ed1600080fd5b6119cf84828501611e7f565b8015156118e3565b803561133181611edd565b600080600080600060a08688031215611f0b57611f0b600080fd5b611f1587876118ee565b9450611f2487602088016118ee565b9350604086013567ffffffffffffffff811115611f4357611f43600080fd5b611f4f888289016118a6565b935050606086013567ffffffffffffffff811115611f6f57611f6f600080fd5b611f7b888289016118a6565b925050611f8b8760808801611ee5565b90509295509295909350565b60008060208385031215611fad57611fad600080fd5b823567ffffffffffffffff811115611fc757611fc7600080fd5b611fd385828601611ae5565b92509250509250929050565b6000611fe9825190565b611ff7818560208601611bbd565b9290920192915050565b6113318183611fdf565b634e487b7160e01b600052602260045260246000fd5b60028104600182168061203557607f821691505b602082108103611a2257611a2261200b565b6000611331611da98381565b61205c83612047565b815460001960089490940293841b1916921b91909117905550565b6000612084818484612053565b505050565b818110156115655761209c600082612077565b600101612089565b601f821115612084576000818152602090206020601f850104810160208510156120cb5750805b610c7b6020601f860104830182612089565b815167ffffffffffffffff8111156120f7576120f76117d1565b6121018254612021565b61210c8282856120a4565b506020601f82116001811461214157600083156121295750848201515b600019600885021c1981166002850217855550610c7b565b600084815260208120601f198516915b828110156121715787850151825560209485019460019092019101612151565b508482101561218e5783870151600019601f87166008021c191681555b50505050600202600101905550565b604080825281016121ae8185611be1565b905061189f60208301846119d7565b60168152602081017f656e636c6176654944206e6f742061747465737465640000000000000000000081529050611c1f565b60208082528101611331816121bd565b50600061133160208301836118ee565b5060006113316020830183611954565b67ffffffffffffffff81166118e3565b80356113318161221f565b506000611331602083018361222f565b67ffffffffffffffff81166117bd565b61226481806121ff565b61226e83826119d7565b5061227c60208201826121ff565b61228960208401826119d7565b50612297604082018261220f565b6122a460408401826117bb565b506122b2606082018261223a565b612084606084018261224a565b82818337505050565b81835260208301925060007f07ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff83111561230357612303600080fd5b6020830292506123148385846122bf565b50500190565b60c08101612328828761225a565b818103608083015261233b8185876122c8565b905061234a60a08301846117bb565b95945050505050565b60808101611331828461225a565b60188152602081017f7769746864726177616c20616c7265616479207370656e74000000000000000081529050611c1f565b6020808252810161133181612361565b604081016123b182856119d7565b61189f60208301846117bb565b60228152602081017f6e6574776f726b2073656372657420616c726561647920696e697469616c697a81527f6564000000000000000000000000000000000000000000000000000000000000602082015290505b60400190565b60208082528101611331816123be565b60198152602081017f656e636c6176654944206e6f7420612073657175656e6365720000000000000081529050611c1f565b6020808252810161133181612428565b600067ffffffffffffffff8216611331565b6117bd8161246a565b60208101611331828461247c565b6000808335601e19368590030181126124ae576124ae600080fd5b8301915050803567ffffffffffffffff8111156124cd576124cd600080fd5b602082019150600181023603821315611a7357611a73600080fd5b604081016123b182856117bb565b634e487b7160e01b600052603260045260246000fd5b6000611331825190565b6000612520825190565b6020830161252d8161250c565b9250506020811015611a22576000196020919091036008021b16919050565b60238152602081017f726573706f6e64696e67206174746573746572206973206e6f7420617474657381527f746564000000000000000000000000000000000000000000000000000000000060208201529050612412565b602080825281016113318161254c565b60006113318260601b90565b6000611331826125b4565b6117bd6125d7826118c9565b6125c0565b6125e681856125cb565b6014016125f381846125cb565b6014016119cf8183611fdf565b602c8152602081017f63616c63756c61746564206164647265737320616e642061747465737465724981527f4420646f6e74206d61746368000000000000000000000000000000000000000060208201529050612412565b6020808252810161133181612600565b81835260208301925061267c82848361185b565b50601f01601f19160190565b602080825281016119cf818486612668565b600081356113318161194e565b600081611331565b6126b8826126a7565b6126c4611da9826126a7565b8255505050565b8267ffffffffffffffff8111156126e4576126e46117d1565b6126ee8254612021565b6126f98282856120a4565b506000601f82116001811461272e57600083156127165750848201355b600019600885021c1981166002850217855550612788565b600084815260209020601f19841690835b8281101561275f578785013582556020948501946001909201910161273f565b508482101561277c576000196008601f8716021c19878501351681555b50506001600284020184555b505050505050565b6120848383836126cb565b6127a482612047565b806126c4565b81806127b58161269a565b90506127c181846126af565b50506127d06020830183612493565b6127de818360018601612790565b505060408201806127ee8261269a565b90506127fd816002850161279b565b5050606082018061280d8261269a565b905061281c81600385016126af565b5050608082018061282c8261269a565b905061283b816004850161279b565b505060a082018061284b8261269a565b9050610aa681600585016126af565b61156582826127aa565b7f19457468657265756d205369676e6564204d6573736167653a0a0000000000008152601a016128948184611fdf565b905061189f8183611fdf565b634e487b7160e01b600052602160045260246000fd5b60ff81166117bd565b608081016128cd82876117bb565b6128da60208301866128b6565b6128e760408301856117bb565b61234a60608301846117bb56fe608060405234801561001057600080fd5b5061001a33610027565b610022610098565b61014a565b7f9016d09d72d40fdae2fd8ceac6b6234c7706214fd39c1cd1e609a0528c19930080546001600160a01b031981166001600160a01b03848116918217845560405192169182907f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e090600090a3505050565b7ff0c57e16840df040f15088dc2f81fe391c3923bec73e23a9662efc9c229c6a00805468010000000000000000900460ff16156100e85760405163f92ee8a960e01b815260040160405180910390fd5b80546001600160401b03908116146101475780546001600160401b0319166001600160401b0390811782556040519081527fc7f505b2f371ae2175ee4913f4499e1f2633a7b5936321eed1cdaeb6115181d29060200160405180910390a15b50565b612475806101596000396000f3fe6080604052600436106101115760003560e01c80638da5cb5b116100a5578063b1454caa11610074578063b6aed0cb11610059578063b6aed0cb1461038b578063e138a8d2146103ab578063f2fde38b146103cb57610185565b8063b1454caa1461034b578063b201246f1461036b57610185565b80638da5cb5b146102a65780639730886d146102eb57806399a3ad211461030b578063ab53bddc1461032b57610185565b8063346633fb116100e1578063346633fb1461023e57806336d2da9014610251578063485cc95514610271578063715018a61461029157610185565b8062a1b815146101a65780630fcfbd11146101d15780630fe9188e146101f157806333a88c721461021157610185565b36610185576040517f346633fb000000000000000000000000000000000000000000000000000000008152309063346633fb903490610156903390839060040161120a565b6000604051808303818588803b15801561016f57600080fd5b505af1158015610183573d6000803e3d6000fd5b005b60405162461bcd60e51b815260040161019d90611259565b60405180910390fd5b3480156101b257600080fd5b506101bb6103eb565b6040516101c89190611269565b60405180910390f35b3480156101dd57600080fd5b506101bb6101ec366004611292565b610477565b3480156101fd57600080fd5b5061018361020c3660046112e5565b6104d6565b34801561021d57600080fd5b5061023161022c366004611292565b61051c565b6040516101c8919061130c565b61018361024c36600461132e565b61056e565b34801561025d57600080fd5b5061018361026c366004611366565b6106bd565b34801561027d57600080fd5b5061018361028c366004611385565b61073c565b34801561029d57600080fd5b506101836108a7565b3480156102b257600080fd5b507f9016d09d72d40fdae2fd8ceac6b6234c7706214fd39c1cd1e609a0528c199300546001600160a01b03166040516101c891906113b4565b3480156102f757600080fd5b506101836103063660046113c2565b6108bb565b34801561031757600080fd5b5061018361032636600461132e565b610a27565b34801561033757600080fd5b5061018361034636600461132e565b610ac7565b61035e61035936600461148b565b610b90565b6040516101c89190611518565b34801561037757600080fd5b50610183610386366004611586565b610c9d565b34801561039757600080fd5b506101836103a63660046115f1565b610d9e565b3480156103b757600080fd5b506101836103c6366004611611565b610de4565b3480156103d757600080fd5b506101836103e6366004611366565b610f2f565b600354604080517f1a90a21900000000000000000000000000000000000000000000000000000000815290516000926001600160a01b031691631a90a2199160048083019260209291908290030181865afa15801561044e573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906104729190611699565b905090565b6000808260405160200161048b9190611857565b60408051601f198184030181529181528151602092830120600081815292839052912054909150806104cf5760405162461bcd60e51b815260040161019d906118a6565b9392505050565b6104de610f86565b600081815260046020526040812054900361050b5760405162461bcd60e51b815260040161019d906118e8565b600090815260046020526040812055565b600080826040516020016105309190611857565b60408051601f19818403018152918152815160209283012060008181529283905291205490915080158015906105665750428111155b949350505050565b60003411801561057d57508034145b6105995760405162461bcd60e51b815260040161019d90611950565b60035434906001600160a01b03161561065d5760006105b66103eb565b9050803410156105d85760405162461bcd60e51b815260040161019d90611990565b6105e281346119b6565b6003546040519193506000916001600160a01b039091169083908381818185875af1925050503d8060008114610634576040519150601f19603f3d011682016040523d82523d6000602084013e610639565b606091505b505090508061065a5760405162461bcd60e51b815260040161019d90611a21565b50505b600061066833610ffa565b9050836001600160a01b0316336001600160a01b03167f50c536ac33a920f00755865b831d17bf4cff0b2e0345f65b16d52bfc004068b684846040516106af929190611a31565b60405180910390a350505050565b6106c5610f86565b6000816001600160a01b03164760405160006040518083038185875af1925050503d8060008114610712576040519150601f19603f3d011682016040523d82523d6000602084013e610717565b606091505b50509050806107385760405162461bcd60e51b815260040161019d90611a7e565b5050565b7ff0c57e16840df040f15088dc2f81fe391c3923bec73e23a9662efc9c229c6a00805468010000000000000000810460ff16159067ffffffffffffffff166000811580156107875750825b905060008267ffffffffffffffff1660011480156107a45750303b155b9050811580156107b2575080155b156107e9576040517ff92ee8a900000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b845467ffffffffffffffff19166001178555831561081d57845468ff00000000000000001916680100000000000000001785555b61082687611058565b6003805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b038816179055831561089e57845468ff0000000000000000191685556040517fc7f505b2f371ae2175ee4913f4499e1f2633a7b5936321eed1cdaeb6115181d29061089590600190611ab2565b60405180910390a15b50505050505050565b6108af610f86565b6108b96000611069565b565b60006108c8600130611ac0565b90506108fb7f9016d09d72d40fdae2fd8ceac6b6234c7706214fd39c1cd1e609a0528c199300546001600160a01b031690565b6001600160a01b0316336001600160a01b031614806109225750336001600160a01b038216145b61093e5760405162461bcd60e51b815260040161019d90611b15565b600061094a8342611b25565b905060008460405160200161095f9190611857565b60408051601f198184030181529181528151602092830120600081815292839052912054909150156109a35760405162461bcd60e51b815260040161019d90611b90565b6000818152602081815260408220849055600191906109c490880188611366565b6001600160a01b0316815260208101919091526040016000908120906109f06080880160608901611ba0565b63ffffffff1681526020808201929092526040016000908120805460018101825590825291902086916004020161089e8282611fde565b610a2f610f86565b80471015610a4f5760405162461bcd60e51b815260040161019d90611990565b6000826001600160a01b03168260405160006040518083038185875af1925050503d8060008114610a9c576040519150601f19603f3d011682016040523d82523d6000602084013e610aa1565b606091505b5050905080610ac25760405162461bcd60e51b815260040161019d90611a7e565b505050565b6000610ad4600130611ac0565b9050610b077f9016d09d72d40fdae2fd8ceac6b6234c7706214fd39c1cd1e609a0528c199300546001600160a01b031690565b6001600160a01b0316336001600160a01b03161480610b2e5750336001600160a01b038216145b610b4a5760405162461bcd60e51b815260040161019d90611b15565b826001600160a01b03167fcd9850463422a7449c406a036e35e5edb6fbe35a64c9f12a2354be98a750c0d383604051610b839190611269565b60405180910390a2505050565b6003546000906001600160a01b031615610c46576000610bae6103eb565b905080341015610bd05760405162461bcd60e51b815260040161019d90612040565b6003546040516000916001600160a01b03169083908381818185875af1925050503d8060008114610c1d576040519150601f19603f3d011682016040523d82523d6000602084013e610c22565b606091505b5050905080610c435760405162461bcd60e51b815260040161019d90611a21565b50505b610c4f33610ffa565b90507fb93c37389233beb85a3a726c3f15c2d15533ee74cb602f20f490dfffef77593733828888888888604051610c8c9796959493929190612050565b60405180910390a195945050505050565b6000818152600460205260408120549003610cca5760405162461bcd60e51b815260040161019d9061210b565b600081815260046020526040902054421015610cf85760405162461bcd60e51b815260040161019d90612157565b600084604051602001610d0b91906121dc565b60405160208183030381529060405280519060200120604051602001610d31919061221c565b604051602081830303815290604052805190602001209050610d7b84848484604051602001610d60919061223b565b604051602081830303815290604052805190602001206110e7565b610d975760405162461bcd60e51b815260040161019d906122a5565b5050505050565b610da6610f86565b60008281526004602052604090205415610dd25760405162461bcd60e51b815260040161019d9061230d565b60009182526004602052604090912055565b6000818152600460205260408120549003610e115760405162461bcd60e51b815260040161019d9061210b565b600081815260046020526040902054421015610e3f5760405162461bcd60e51b815260040161019d90612157565b6000610e4e6020860186611366565b610e5e604087016020880161231d565b610e6e6060880160408901611ba0565b610e7e6080890160608a01611ba0565b610e8b60808a018a611cf7565b610e9b60c08c0160a08d0161233c565b604051602001610eb19796959493929190612050565b604051602081830303815290604052805190602001209050600081604051602001610edc919061238d565b604051602081830303815290604052805190602001209050610f0b85858584604051602001610d60919061223b565b610f275760405162461bcd60e51b815260040161019d906123f5565b505050505050565b610f37610f86565b6001600160a01b038116610f7a5760006040517f1e4fbdf700000000000000000000000000000000000000000000000000000000815260040161019d91906113b4565b610f8381611069565b50565b33610fb87f9016d09d72d40fdae2fd8ceac6b6234c7706214fd39c1cd1e609a0528c199300546001600160a01b031690565b6001600160a01b0316146108b957336040517f118cdaa700000000000000000000000000000000000000000000000000000000815260040161019d91906113b4565b6001600160a01b0381166000908152600260205260408120805467ffffffffffffffff16916001919061102d8385612405565b92506101000a81548167ffffffffffffffff021916908367ffffffffffffffff160217905550919050565b6110606110ff565b610f8381611166565b7f9016d09d72d40fdae2fd8ceac6b6234c7706214fd39c1cd1e609a0528c199300805473ffffffffffffffffffffffffffffffffffffffff1981166001600160a01b03848116918217845560405192169182907f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e090600090a3505050565b6000826110f586868561116e565b1495945050505050565b7ff0c57e16840df040f15088dc2f81fe391c3923bec73e23a9662efc9c229c6a005468010000000000000000900460ff166108b9576040517fd7e6bcf800000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b610f376110ff565b600081815b848110156111a75761119d8287878481811061119157611191612429565b905060200201356111b0565b9150600101611173565b50949350505050565b60008183106111cc5760008281526020849052604090206111db565b60008381526020839052604090205b90505b92915050565b60006001600160a01b0382166111de565b6111fe816111e4565b82525050565b806111fe565b6040810161121882856111f5565b6104cf6020830184611204565b600b8152602081017f756e737570706f72746564000000000000000000000000000000000000000000815290505b60200190565b602080825281016111de81611225565b602081016111de8284611204565b600060c0828403121561128c5761128c600080fd5b50919050565b6000602082840312156112a7576112a7600080fd5b813567ffffffffffffffff8111156112c1576112c1600080fd5b61056684828501611277565b805b8114610f8357600080fd5b80356111de816112cd565b6000602082840312156112fa576112fa600080fd5b6111db83836112da565b8015156111fe565b602081016111de8284611304565b6112cf816111e4565b80356111de8161131a565b6000806040838503121561134457611344600080fd5b61134e8484611323565b915061135d84602085016112da565b90509250929050565b60006020828403121561137b5761137b600080fd5b6111db8383611323565b6000806040838503121561139b5761139b600080fd5b6113a58484611323565b915061135d8460208501611323565b602081016111de82846111f5565b600080604083850312156113d8576113d8600080fd5b823567ffffffffffffffff8111156113f2576113f2600080fd5b6113fe85828601611277565b92505061135d84602085016112da565b63ffffffff81166112cf565b80356111de8161140e565b60008083601f84011261143a5761143a600080fd5b50813567ffffffffffffffff81111561145557611455600080fd5b60208301915083600182028301111561147057611470600080fd5b9250929050565b60ff81166112cf565b80356111de81611477565b6000806000806000608086880312156114a6576114a6600080fd5b6114b0878761141a565b94506114bf876020880161141a565b9350604086013567ffffffffffffffff8111156114de576114de600080fd5b6114ea88828901611425565b93509350506114fc8760608801611480565b90509295509295909350565b67ffffffffffffffff81166111fe565b602081016111de8284611508565b60006080828403121561128c5761128c600080fd5b60008083601f84011261155057611550600080fd5b50813567ffffffffffffffff81111561156b5761156b600080fd5b60208301915083602082028301111561147057611470600080fd5b60008060008060c0858703121561159f5761159f600080fd5b6115a98686611526565b9350608085013567ffffffffffffffff8111156115c8576115c8600080fd5b6115d48782880161153b565b93509350506115e68660a087016112da565b905092959194509250565b6000806040838503121561160757611607600080fd5b61134e84846112da565b6000806000806060858703121561162a5761162a600080fd5b843567ffffffffffffffff81111561164457611644600080fd5b61165087828801611277565b945050602085013567ffffffffffffffff81111561167057611670600080fd5b61167c8782880161153b565b93509350506115e686604087016112da565b80516111de816112cd565b6000602082840312156116ae576116ae600080fd5b6111db838361168e565b5060006111de6020830183611323565b67ffffffffffffffff81166112cf565b80356111de816116c8565b5060006111de60208301836116d8565b5060006111de602083018361141a565b63ffffffff81166111fe565b6000808335601e193685900301811261172a5761172a600080fd5b830160208101925035905067ffffffffffffffff81111561174d5761174d600080fd5b3681900382131561147057611470600080fd5b82818337506000910152565b818352602083019250611780828483611760565b50601f01601f19160190565b5060006111de6020830183611480565b60ff81166111fe565b600060c083016117b583806116b8565b6117bf85826111f5565b506117cd60208401846116e3565b6117da6020860182611508565b506117e860408401846116f3565b6117f56040860182611703565b5061180360608401846116f3565b6118106060860182611703565b5061181e608084018461170f565b858303608087015261183183828461176c565b9250505061184260a084018461178c565b61184f60a086018261179c565b509392505050565b602080825281016111db81846117a5565b60218152602081017f54686973206d65737361676520776173206e65766572207375626d69747465648152601760f91b602082015290505b60400190565b602080825281016111de81611868565b601a8152602081017f537461746520726f6f7420646f6573206e6f742065786973742e00000000000081529050611253565b602080825281016111de816118b6565b60308152602081017f417474656d7074696e6720746f2073656e642076616c756520776974686f757481527f2070726f766964696e6720457468657200000000000000000000000000000000602082015290506118a0565b602080825281016111de816118f8565b60208082527f496e73756666696369656e742066756e647320746f2073656e642076616c75659101908152611253565b602080825281016111de81611960565b634e487b7160e01b600052601160045260246000fd5b818103818111156111de576111de6119a0565b60248152602081017f4661696c656420746f2073656e64206665657320746f206665657320636f6e7481527f7261637400000000000000000000000000000000000000000000000000000000602082015290506118a0565b602080825281016111de816119c9565b60408101611a3f8285611204565b6104cf6020830184611508565b60148152602081017f6661696c65642073656e64696e672076616c756500000000000000000000000081529050611253565b602080825281016111de81611a4c565b60006111de82611a9c565b90565b67ffffffffffffffff1690565b6111fe81611a8e565b602081016111de8284611aa9565b6001600160a01b039182169190811690828203908111156111de576111de6119a0565b60118152602081017f4e6f74206f776e6572206f722073656c6600000000000000000000000000000081529050611253565b602080825281016111de81611ae3565b808201808211156111de576111de6119a0565b60218152602081017f4d657373616765207375626d6974746564206d6f7265207468616e206f6e636581527f2100000000000000000000000000000000000000000000000000000000000000602082015290506118a0565b602080825281016111de81611b38565b600060208284031215611bb557611bb5600080fd5b6111db838361141a565b600081356111de8161131a565b60006001600160a01b03835b81169019929092169190911792915050565b60006111de826111e4565b60006111de82611bea565b611c0982611bf5565b611c14818354611bcc565b8255505050565b600081356111de816116c8565b60007bffffffffffffffff0000000000000000000000000000000000000000611bd88460a01b90565b60006111de67ffffffffffffffff8316611a9c565b611c6f82611c51565b611c14818354611c28565b600081356111de8161140e565b60007fffffffff00000000000000000000000000000000000000000000000000000000611bd88460e01b90565b600063ffffffff82166111de565b611ccb82611cb4565b611c14818354611c87565b600063ffffffff83611bd8565b611cec82611cb4565b611c14818354611cd6565b6000808335601e1936859003018112611d1257611d12600080fd5b8301915050803567ffffffffffffffff811115611d3157611d31600080fd5b60208201915060018102360382131561147057611470600080fd5b634e487b7160e01b600052604160045260246000fd5b634e487b7160e01b600052602260045260246000fd5b600281046001821680611d8c57607f821691505b60208210810361128c5761128c611d62565b60006111de611a998381565b611db383611d9e565b815460001960089490940293841b1916921b91909117905550565b6000610ac2818484611daa565b8181101561073857611dee600082611dce565b600101611ddb565b601f821115610ac2576000818152602090206020601f85010481016020851015611e1d5750805b610d976020601f860104830182611ddb565b8267ffffffffffffffff811115611e4857611e48611d4c565b611e528254611d78565b611e5d828285611df6565b506000601f821160018114611e925760008315611e7a5750848201355b600019600885021c1981166002850217855550610f27565b600084815260209020601f19841690835b82811015611ec35787850135825560209485019460019092019101611ea3565b5084821015611ee0576000196008601f8716021c19878501351681555b5050505060020260010190555050565b610ac2838383611e2f565b600081356111de81611477565b600060ff82166111de565b611f1c82611f08565b815460ff191660ff821617611c14565b808280611f3881611bbf565b9050611f448184611c00565b50506020830180611f5482611c1b565b9050611f608184611c66565b50506040830180611f7082611c7a565b9050611f7c8184611cc2565b5050506060820180611f8d82611c7a565b9050611f9c8160018501611ce3565b5050611fab6080830183611cf7565b611fb9818360028601611ef0565b505060a0820180611fc982611efb565b9050611fd88160038501611f13565b50505050565b6107388282611f2c565b60258152602081017f496e73756666696369656e742066756e647320746f207075626c697368206d6581527f7373616765000000000000000000000000000000000000000000000000000000602082015290506118a0565b602080825281016111de81611fe8565b60c0810161205e828a6111f5565b61206b6020830189611508565b6120786040830188611703565b6120856060830187611703565b818103608083015261209881858761176c565b90506120a760a083018461179c565b98975050505050505050565b602a8152602081017f526f6f74206973206e6f74207075626c6973686564206f6e2074686973206d6581527f7373616765206275732e00000000000000000000000000000000000000000000602082015290506118a0565b602080825281016111de816120b3565b60218152602081017f526f6f74206973206e6f7420636f6e736964657265642066696e616c207965748152601760f91b602082015290506118a0565b602080825281016111de8161211b565b5060006111de60208301836112da565b61218181806116b8565b61218b83826111f5565b5061219960208201826116b8565b6121a660208401826111f5565b506121b46040820182612167565b6121c16040840182611204565b506121cf60608201826116e3565b610ac26060840182611508565b608081016111de8284612177565b60018152602081017f760000000000000000000000000000000000000000000000000000000000000081529050611253565b6040808252810161222c816121ea565b90506111de6020830184611204565b6122458183611204565b602001919050565b60338152602081017f496e76616c696420696e636c7573696f6e2070726f6f6620666f722076616c7581527f65207472616e73666572206d6573736167652e00000000000000000000000000602082015290506118a0565b602080825281016111de8161224d565b60258152602081017f526f6f7420616c726561647920616464656420746f20746865206d657373616781527f6520627573000000000000000000000000000000000000000000000000000000602082015290506118a0565b602080825281016111de816122b5565b60006020828403121561233257612332600080fd5b6111db83836116d8565b60006020828403121561235157612351600080fd5b6111db8383611480565b60018152602081017f6d0000000000000000000000000000000000000000000000000000000000000081529050611253565b6040808252810161222c8161235b565b60308152602081017f496e76616c696420696e636c7573696f6e2070726f6f6620666f722063726f7381527f7320636861696e206d6573736167652e00000000000000000000000000000000602082015290506118a0565b602080825281016111de8161239d565b67ffffffffffffffff9182169190811690828201908111156111de576111de6119a0565b634e487b7160e01b600052603260045260246000fdfea2646970667358221220f582c47618345ca2599c3d254b6ce9e0ea5159411fd58e3d9a86019ef0bc297d64736f6c634300081c0033a2646970667358221220443137aad7904971a052a9b0d3a4782bac899e95e4c1fff7f4df07023783bb5a64736f6c634300081c0033",
 }
 
@@ -927,6 +927,27 @@ func (_ManagementContract *ManagementContractTransactorSession) SetImportantCont
 	return _ManagementContract.Contract.SetImportantContractAddress(&_ManagementContract.TransactOpts, key, newAddress)
 }
 
+// AddCrossChainMessagesRoot is a paid mutator transaction binding the contract method 0x073b6ef3.
+//
+// Solidity: function addCrossChainMessagesRoot(bytes32 _lastBatchHash, bytes32 blockHash, uint256 blockNum, bytes[] crossChainHashes, bytes signature, uint256 rollupNumber, bytes32 forkID) returns()
+func (_ManagementContract *ManagementContractTransactor) AddCrossChainMessagesRoot(opts *bind.TransactOpts, _lastBatchHash [32]byte, blockHash [32]byte, blockNum *big.Int, crossChainHashes [][]byte, signature []byte, rollupNumber *big.Int, forkID [32]byte) (*types.Transaction, error) {
+	return _ManagementContract.contract.Transact(opts, "addCrossChainMessagesRoot", _lastBatchHash, blockHash, blockNum, crossChainHashes, signature, rollupNumber, forkID)
+}
+
+// AddCrossChainMessagesRoot is a paid mutator transaction binding the contract method 0x073b6ef3.
+//
+// Solidity: function addCrossChainMessagesRoot(bytes32 _lastBatchHash, bytes32 blockHash, uint256 blockNum, bytes[] crossChainHashes, bytes signature, uint256 rollupNumber, bytes32 forkID) returns()
+func (_ManagementContract *ManagementContractSession) AddCrossChainMessagesRoot(_lastBatchHash [32]byte, blockHash [32]byte, blockNum *big.Int, crossChainHashes [][]byte, signature []byte, rollupNumber *big.Int, forkID [32]byte) (*types.Transaction, error) {
+	return _ManagementContract.Contract.AddCrossChainMessagesRoot(&_ManagementContract.TransactOpts, _lastBatchHash, blockHash, blockNum, crossChainHashes, signature, rollupNumber, forkID)
+}
+
+// AddCrossChainMessagesRoot is a paid mutator transaction binding the contract method 0x073b6ef3.
+//
+// Solidity: function addCrossChainMessagesRoot(bytes32 _lastBatchHash, bytes32 blockHash, uint256 blockNum, bytes[] crossChainHashes, bytes signature, uint256 rollupNumber, bytes32 forkID) returns()
+func (_ManagementContract *ManagementContractTransactorSession) AddCrossChainMessagesRoot(_lastBatchHash [32]byte, blockHash [32]byte, blockNum *big.Int, crossChainHashes [][]byte, signature []byte, rollupNumber *big.Int, forkID [32]byte) (*types.Transaction, error) {
+	return _ManagementContract.Contract.AddCrossChainMessagesRoot(&_ManagementContract.TransactOpts, _lastBatchHash, blockHash, blockNum, crossChainHashes, signature, rollupNumber, forkID)
+}
+
 // Initialize is a paid mutator transaction binding the contract method 0x8129fc1c.
 //
 // Solidity: function initialize() returns()
@@ -94,6 +94,36 @@ contract ManagementContract is Initializable, OwnableUpgradeable {
         return isBundleSaved[bundleHash];
     }
 
+    // addCrossChainMessagesRoot publishes the cross chain roots of a range of batches, signed by the sequencer enclave.
+    // The bundle is bound to a published rollup: rollupNumber is the last sequence number of the rollup and forkID is its hash,
+    // so the bundle is rejected when the rollup is not part of the L1 fork the transaction is executed on.
+    function addCrossChainMessagesRoot(bytes32 _lastBatchHash, bytes32 blockHash, uint256 blockNum, bytes[] memory crossChainHashes, bytes calldata signature, uint256 rollupNumber, bytes32 forkID) external {
+        require(block.number - blockNum < 255, "Block binding too old");
+        require(blockhash(blockNum) == blockHash, "Invalid block binding");
+
+        Structs.MetaRollup storage rollup = rollups.byHash[forkID];
+        require(rollup.Hash == forkID && rollup.LastSequenceNumber == rollupNumber, "Invalid forkID");
+
+        address enclaveID = ECDSA.recover(keccak256(abi.encode(_lastBatchHash, blockHash, blockNum, crossChainHashes)), signature);
+        require(attested[enclaveID], "enclaveID not attested");
+        require(sequencerEnclave[enclaveID], "enclaveID not a sequencer");
+
+        bytes32 bundleHash = bytes32(0);
+        for(uint256 i = 0; i < crossChainHashes.length; i++) {
+            bundleHash = keccak256(abi.encode(bundleHash, bytes32(crossChainHashes[i])));
+        }
+        require(!isBundleSaved[bundleHash], "Bundle already saved");
+
+        for(uint256 i = 0; i < crossChainHashes.length; i++) {
+            // a root can already be active if it was published as the root of a rollup. Any other failure reverts,
+            // so the bundle is not marked as saved and can be published again
+            try merkleMessageBus.addStateRoot(bytes32(crossChainHashes[i]), block.timestamp) {} catch Error(string memory reason) {
+                require(keccak256(bytes(reason)) == keccak256(bytes("Root already added to the message bus")), reason);
+            }
+        }
+        isBundleSaved[bundleHash] = true;
+    }
+
     function pushCrossChainMessages(Structs.HeaderCrossChainData calldata crossChainData) internal {
         uint256 messagesLength = crossChainData.messages.length;
         for (uint256 i = 0; i < messagesLength; ++i) {
@@ -48,4 +48,5 @@ func (b *ExtBatch) SDump() string {
 type BatchRequest struct {
 	Requester string   // The address of the requester, used to direct the response
 	FromSeqNo *big.Int // The requester's view of the current head seq no, or nil if they haven't stored any batches.
+	ToSeqNo   *big.Int `rlp:"optional"` // The last seq no of the requested range, or nil to get as many batches as the peer serves.
 }
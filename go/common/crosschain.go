@@ -20,6 +20,13 @@ type ExtCrossChainBundle struct {
 	CrossChainRootHashes CrossChainRootHashes // The CrossChainRoots of the batches that are being submitted
 }
 
+// PendingCrossChainBundle - the batch range of a published rollup whose cross chain bundle was not published yet
+type PendingCrossChainBundle struct {
+	RollupHash gethcommon.Hash
+	FromSeqNo  uint64
+	ToSeqNo    uint64
+}
+
 func (hashes CrossChainRootHashes) ToHexString() string {
 	hexStrings := make([]string, 0, len(hashes))
 
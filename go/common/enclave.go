@@ -2,7 +2,6 @@ package common
 
 import (
 	"context"
-	"encoding/json"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -11,7 +10,6 @@ import (
 
 	"github.com/ten-protocol/go-ten/go/common/errutil"
 
-	"github.com/ten-protocol/go-ten/go/common/tracers"
 	"github.com/ten-protocol/go-ten/go/responses"
 	"github.com/ten-protocol/go-ten/lib/gethfork/rpc"
 
@@ -123,14 +121,15 @@ type EnclaveClientRPC interface {
 	// the given ID, nothing is deleted.
 	Unsubscribe(id rpc.ID) SystemError
 
-	// DebugTraceTransaction returns the trace of a transaction
-	DebugTraceTransaction(ctx context.Context, hash gethcommon.Hash, config *tracers.TraceConfig) (json.RawMessage, SystemError)
-
 	GetCode(ctx context.Context, address gethcommon.Address, blockNrOrHash rpc.BlockNumberOrHash) ([]byte, SystemError)
 
 	// GetTotalContractCount returns the total number of contracts that have been deployed
 	GetTotalContractCount(context.Context) (*big.Int, SystemError)
 
+	// FeeHistoryRewards returns the priority fees paid at each of the percentiles by the transactions of the canonical
+	// batches between the heights, by batch height
+	FeeHistoryRewards(ctx context.Context, fromHeight uint64, toHeight uint64, rewardPercentiles []float64) (map[uint64][]*big.Int, SystemError)
+
 	// EnclavePublicConfig returns network data that is known to the enclave but can be shared publicly
 	EnclavePublicConfig(context.Context) (*EnclavePublicConfig, SystemError)
 }
@@ -0,0 +1,141 @@
+package gethapi
+
+// The types in this file are adapted from geth @ go-ethereum/internal/ethapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+// OverrideAccount indicates the overriding fields of account during the execution
+// of a message call.
+// Note, state and stateDiff can't be specified at the same time. If state is
+// set, message execution will only use the data in the given state. Otherwise
+// if statDiff is set, all diff will be applied first and then execute the call
+// message.
+type OverrideAccount struct {
+	Nonce     *hexutil.Uint64              `json:"nonce"`
+	Code      *hexutil.Bytes               `json:"code"`
+	Balance   **hexutil.Big                `json:"balance"`
+	State     *map[common.Hash]common.Hash `json:"state"`
+	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// StateOverride is the collection of overridden accounts.
+type StateOverride map[common.Address]OverrideAccount
+
+// Apply overrides the fields of specified accounts into the given state.
+func (diff *StateOverride) Apply(statedb *state.StateDB) error {
+	if diff == nil {
+		return nil
+	}
+	for addr, account := range *diff {
+		// Override account nonce.
+		if account.Nonce != nil {
+			statedb.SetNonce(addr, uint64(*account.Nonce))
+		}
+		// Override account(contract) code.
+		if account.Code != nil {
+			statedb.SetCode(addr, *account.Code)
+		}
+		// Override account balance.
+		if account.Balance != nil {
+			u256Balance, _ := uint256.FromBig((*big.Int)(*account.Balance))
+			statedb.SetBalance(addr, u256Balance, tracing.BalanceChangeUnspecified)
+		}
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+		// Replace entire state if caller requires.
+		if account.State != nil {
+			statedb.SetStorage(addr, *account.State)
+		}
+		// Apply state diff into specified accounts.
+		if account.StateDiff != nil {
+			for key, value := range *account.StateDiff {
+				statedb.SetState(addr, key, value)
+			}
+		}
+	}
+	// Now finalize the changes. Finalize is normally performed between transactions.
+	// By using finalize, the overrides are semantically behaving as
+	// if they were created in a transaction just before the tracing occur.
+	statedb.Finalise(false)
+	return nil
+}
+
+// AccessListResult returns an optional accesslist
+// It's the result of the `eth_createAccessList` RPC call.
+// It contains an error if the transaction itself failed.
+type AccessListResult struct {
+	Accesslist *types.AccessList `json:"accessList"`
+	Error      string            `json:"error,omitempty"`
+	GasUsed    hexutil.Uint64    `json:"gasUsed"`
+}
+
+// SimOpts are the inputs to `eth_simulateV1`.
+// Block overrides, transfer tracing and full transactions are not supported, because the simulated calls are executed
+// on top of an existing batch.
+type SimOpts struct {
+	BlockStateCalls        []SimBlock `json:"blockStateCalls"`
+	TraceTransfers         bool       `json:"traceTransfers"`
+	Validation             bool       `json:"validation"`
+	ReturnFullTransactions bool       `json:"returnFullTransactions"`
+}
+
+// SimBlock is a batch of calls to be simulated sequentially.
+type SimBlock struct {
+	BlockOverrides json.RawMessage   `json:"blockOverrides,omitempty"`
+	StateOverrides *StateOverride    `json:"stateOverrides"`
+	Calls          []TransactionArgs `json:"calls"`
+}
+
+// SimBlockResult - the results of the calls of a SimBlock
+type SimBlockResult struct {
+	Calls []SimCallResult `json:"calls"`
+}
+
+// SimCallResult - the result of a simulated call.
+// The logs are not returned, because they would bypass the visibility rules of the events.
+type SimCallResult struct {
+	ReturnValue hexutil.Bytes  `json:"returnData"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Status      hexutil.Uint64 `json:"status"`
+	Error       *SimCallError  `json:"error,omitempty"`
+}
+
+type SimCallError struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+	Data    string `json:"data,omitempty"`
+}
+
+// Validate - returns an error if the options use features that are not supported
+func (opts *SimOpts) Validate(maxBlocks int) error {
+	if len(opts.BlockStateCalls) == 0 {
+		return fmt.Errorf("empty input")
+	}
+	if len(opts.BlockStateCalls) > maxBlocks {
+		return fmt.Errorf("too many blocks. Maximum: %d", maxBlocks)
+	}
+	if opts.TraceTransfers {
+		return fmt.Errorf("traceTransfers is not supported")
+	}
+	if opts.ReturnFullTransactions {
+		return fmt.Errorf("returnFullTransactions is not supported")
+	}
+	for _, block := range opts.BlockStateCalls {
+		if len(block.BlockOverrides) > 0 && string(block.BlockOverrides) != "null" {
+			return fmt.Errorf("blockOverrides are not supported")
+		}
+	}
+	return nil
+}
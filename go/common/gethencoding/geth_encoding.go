@@ -166,6 +166,7 @@ func ExtractEthCall(param interface{}) (*gethapi.TransactionArgs, error) {
 	var to, from *gethcommon.Address
 	var data *hexutil.Bytes
 	var value, gasPrice, maxFeePerGas, maxPriorityFeePerGas *hexutil.Big
+	var accessList *types.AccessList
 	var ok bool
 	zeroUint := hexutil.Uint64(0)
 	nonce := &zeroUint
@@ -181,6 +182,15 @@ func ExtractEthCall(param interface{}) (*gethapi.TransactionArgs, error) {
 		if val == nil {
 			continue
 		}
+		// the access list is the only field that is not a string
+		if strings.ToLower(field) == callFieldAccessList {
+			al, err := extractAccessList(val)
+			if err != nil {
+				return nil, err
+			}
+			accessList = al
+			continue
+		}
 		valString, ok = val.(string)
 		if !ok {
 			return nil, fmt.Errorf("unexpected type supplied in `%s` field", field)
@@ -240,8 +250,6 @@ func ExtractEthCall(param interface{}) (*gethapi.TransactionArgs, error) {
 				return nil, fmt.Errorf("could not decode value in CallMsg - %w", err)
 			}
 			maxPriorityFeePerGas = (*hexutil.Big)(maxPriorityFeePerGasVal)
-		case callFieldAccessList:
-			// ignore access list for now
 		}
 	}
 
@@ -256,12 +264,24 @@ func ExtractEthCall(param interface{}) (*gethapi.TransactionArgs, error) {
 		Value:                value,
 		Data:                 data,
 		Nonce:                nonce,
-		AccessList:           nil,
+		AccessList:           accessList,
 	}
 
 	return callMsg, nil
 }
 
+func extractAccessList(param interface{}) (*types.AccessList, error) {
+	serialised, err := json.Marshal(param)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode access list in CallMsg - %w", err)
+	}
+	var accessList types.AccessList
+	if err := json.Unmarshal(serialised, &accessList); err != nil {
+		return nil, fmt.Errorf("could not decode access list in CallMsg - %w", err)
+	}
+	return &accessList, nil
+}
+
 // CreateEthHeaderForBatch - the EVM requires an Ethereum header.
 // We convert the Batch headers to Ethereum headers to be able to use the Geth EVM.
 // Special care must be taken to maintain a valid chain of these converted headers.
@@ -0,0 +1,32 @@
+package host
+
+import (
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// NodeSetEventType - the types of management contract events that change the set of network nodes
+type NodeSetEventType uint8
+
+const (
+	// HostRegistered - a host published the attestation of its enclave, either to initialise the network secret or to request it
+	HostRegistered NodeSetEventType = iota
+	// EnclaveAttested - an enclave received the network secret
+	EnclaveAttested
+	// SequencerGranted - an enclave was permissioned as a sequencer
+	SequencerGranted
+	// SequencerRevoked - an enclave is no longer permissioned as a sequencer
+	SequencerRevoked
+)
+
+// NodeSetEvent - a change of the set of network nodes, as recorded by the management contract
+type NodeSetEvent struct {
+	Type      NodeSetEventType
+	EnclaveID gethcommon.Address
+	// HostID - the address of the L1 wallet of the host which published the attestation. Only set for HostRegistered.
+	HostID gethcommon.Address
+	// P2PAddress - the address on which the host can be contacted by its peers. Only set for HostRegistered.
+	P2PAddress string
+	// BlockNumber and BlockHash - the L1 block which contains the event, used to roll the event back after a reorg
+	BlockNumber uint64
+	BlockHash   gethcommon.Hash
+}
@@ -46,8 +46,11 @@ type P2P interface {
 	// SendTxToSequencer sends the encrypted transaction to the sequencer.
 	SendTxToSequencer(tx common.EncryptedTx) error
 
-	// RequestBatchesFromSequencer asynchronously requests batches from the sequencer, from the given sequence number
-	RequestBatchesFromSequencer(fromSeqNo *big.Int) error
+	// RequestBatchesFromPeer asynchronously requests the range of batches from one of the validator peers, the peers
+	// take turns so the ranges requested one after the other are served in parallel
+	RequestBatchesFromPeer(fromSeqNo *big.Int, toSeqNo *big.Int) error
+	// RequestBatchesFromSequencer asynchronously requests the range of batches from the sequencer, toSeqNo can be nil
+	RequestBatchesFromSequencer(fromSeqNo *big.Int, toSeqNo *big.Int) error
 	// RespondToBatchRequest sends the requested batches to the requesting peer
 	RespondToBatchRequest(requestID string, batches []*common.ExtBatch) error
 
@@ -74,7 +77,8 @@ type P2PTxHandler interface {
 
 type P2PBatchRequestHandler interface {
 	// HandleBatchRequest will be called in a new goroutine for each new batch request as it arrives
-	HandleBatchRequest(requestID string, fromSeqNo *big.Int)
+	// (toSeqNo is nil when the requester did not bound the range)
+	HandleBatchRequest(requestID string, fromSeqNo *big.Int, toSeqNo *big.Int)
 }
 
 // L1DataService provides an interface for the host to request L1 block data (live-streaming and historical)
@@ -84,11 +88,15 @@ type L1DataService interface {
 	Subscribe(handler L1BlockHandler) func()
 
 	FetchBlockByHeight(height *big.Int) (*types.Header, error)
+	// FetchBlock returns the block with the given hash
+	FetchBlock(ctx context.Context, blockHash common.L1BlockHash) (*types.Header, error)
 	// FetchNextBlock returns the next canonical block after a given block hash
 	// It returns the new block, a bool which is true if the block is the current L1 head and a bool if the block is on a different fork to prevBlock
 	FetchNextBlock(prevBlock gethcommon.Hash) (*types.Header, bool, error)
 	// GetTenRelevantTransactions returns the events and transactions relevant to Ten
 	GetTenRelevantTransactions(block *types.Header) (*common.ProcessedL1Data, error)
+	// GetNodeSetEvents returns the events that changed the set of network nodes between the two heights (inclusive), in order
+	GetNodeSetEvents(fromBlock, toBlock *big.Int) ([]*NodeSetEvent, error)
 }
 
 // L1BlockHandler is an interface for receiving new blocks from the repository as they arrive
@@ -105,13 +113,14 @@ type L1Publisher interface {
 	RequestSecret(report *common.AttestationReport) (gethcommon.Hash, error)
 	// FindSecretResponseTx will return the secret response tx from an L1 block
 	FindSecretResponseTx(responseTxs []*common.L1TxData) []*common.L1RespondSecretTx
-	// PublishRollup will create and publish a rollup tx to the management contract - fire and forget we don't wait for receipt
+	// PublishRollup will create and publish a rollup tx to the management contract, waiting for the receipt
 	// todo (#1624) - With a single sequencer, it is problematic if rollup publication fails; handle this case better
-	PublishRollup(producedRollup *common.ExtRollup)
+	PublishRollup(producedRollup *common.ExtRollup) error
 	// PublishSecretResponse will create and publish a secret response tx to the management contract - fire and forget we don't wait for receipt
 	PublishSecretResponse(secretResponse *common.ProducedSecretResponse) error
 
-	// PublishCrossChainBundle will create and publish a cross-chain bundle tx to the management contract
+	// PublishCrossChainBundle will create and publish a cross-chain bundle tx to the management contract, bound to the
+	// rollup with the given last batch sequence number and hash. It is a no-op if the bundle was already published.
 	PublishCrossChainBundle(*common.ExtCrossChainBundle, *big.Int, gethcommon.Hash) error
 
 	FetchLatestSeqNo() (*big.Int, error)
@@ -47,6 +47,14 @@ type PublicTransaction struct {
 	Finality        FinalityType
 }
 
+// BatchFees - the gas data of a batch, used to calculate the fee history
+type BatchFees struct {
+	Height   uint64
+	BaseFee  *big.Int
+	GasUsed  uint64
+	GasLimit uint64
+}
+
 type PublicBatch struct {
 	SequencerOrderNo *big.Int              `json:"sequence"`
 	FullHash         common.Hash           `json:"fullHash"`
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
-// - protoc             v3.21.12
+// - protoc             (unknown)
 // source: enclave.proto
 
 package generated
@@ -39,9 +39,9 @@ const (
 	EnclaveProto_CreateBatch_FullMethodName           = "/generated.EnclaveProto/CreateBatch"
 	EnclaveProto_CreateRollup_FullMethodName          = "/generated.EnclaveProto/CreateRollup"
 	EnclaveProto_ExportCrossChainData_FullMethodName  = "/generated.EnclaveProto/ExportCrossChainData"
-	EnclaveProto_DebugTraceTransaction_FullMethodName = "/generated.EnclaveProto/DebugTraceTransaction"
 	EnclaveProto_StreamL2Updates_FullMethodName       = "/generated.EnclaveProto/StreamL2Updates"
 	EnclaveProto_GetTotalContractCount_FullMethodName = "/generated.EnclaveProto/GetTotalContractCount"
+	EnclaveProto_FeeHistoryRewards_FullMethodName     = "/generated.EnclaveProto/FeeHistoryRewards"
 	EnclaveProto_EnclavePublicConfig_FullMethodName   = "/generated.EnclaveProto/EnclavePublicConfig"
 	EnclaveProto_MakeActive_FullMethodName            = "/generated.EnclaveProto/MakeActive"
 )
@@ -70,9 +70,9 @@ type EnclaveProtoClient interface {
 	CreateBatch(ctx context.Context, in *CreateBatchRequest, opts ...grpc.CallOption) (*CreateBatchResponse, error)
 	CreateRollup(ctx context.Context, in *CreateRollupRequest, opts ...grpc.CallOption) (*CreateRollupResponse, error)
 	ExportCrossChainData(ctx context.Context, in *ExportCrossChainDataRequest, opts ...grpc.CallOption) (*ExportCrossChainDataResponse, error)
-	DebugTraceTransaction(ctx context.Context, in *DebugTraceTransactionRequest, opts ...grpc.CallOption) (*DebugTraceTransactionResponse, error)
 	StreamL2Updates(ctx context.Context, in *StreamL2UpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EncodedUpdateResponse], error)
 	GetTotalContractCount(ctx context.Context, in *GetTotalContractCountRequest, opts ...grpc.CallOption) (*GetTotalContractCountResponse, error)
+	FeeHistoryRewards(ctx context.Context, in *FeeHistoryRewardsRequest, opts ...grpc.CallOption) (*FeeHistoryRewardsResponse, error)
 	EnclavePublicConfig(ctx context.Context, in *EnclavePublicConfigRequest, opts ...grpc.CallOption) (*EnclavePublicConfigResponse, error)
 	MakeActive(ctx context.Context, in *MakeActiveRequest, opts ...grpc.CallOption) (*MakeActiveResponse, error)
 }
@@ -285,16 +285,6 @@ func (c *enclaveProtoClient) ExportCrossChainData(ctx context.Context, in *Expor
 	return out, nil
 }
 
-func (c *enclaveProtoClient) DebugTraceTransaction(ctx context.Context, in *DebugTraceTransactionRequest, opts ...grpc.CallOption) (*DebugTraceTransactionResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DebugTraceTransactionResponse)
-	err := c.cc.Invoke(ctx, EnclaveProto_DebugTraceTransaction_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
 func (c *enclaveProtoClient) StreamL2Updates(ctx context.Context, in *StreamL2UpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EncodedUpdateResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &EnclaveProto_ServiceDesc.Streams[0], EnclaveProto_StreamL2Updates_FullMethodName, cOpts...)
@@ -324,6 +314,16 @@ func (c *enclaveProtoClient) GetTotalContractCount(ctx context.Context, in *GetT
 	return out, nil
 }
 
+func (c *enclaveProtoClient) FeeHistoryRewards(ctx context.Context, in *FeeHistoryRewardsRequest, opts ...grpc.CallOption) (*FeeHistoryRewardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeeHistoryRewardsResponse)
+	err := c.cc.Invoke(ctx, EnclaveProto_FeeHistoryRewards_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *enclaveProtoClient) EnclavePublicConfig(ctx context.Context, in *EnclavePublicConfigRequest, opts ...grpc.CallOption) (*EnclavePublicConfigResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(EnclavePublicConfigResponse)
@@ -368,9 +368,9 @@ type EnclaveProtoServer interface {
 	CreateBatch(context.Context, *CreateBatchRequest) (*CreateBatchResponse, error)
 	CreateRollup(context.Context, *CreateRollupRequest) (*CreateRollupResponse, error)
 	ExportCrossChainData(context.Context, *ExportCrossChainDataRequest) (*ExportCrossChainDataResponse, error)
-	DebugTraceTransaction(context.Context, *DebugTraceTransactionRequest) (*DebugTraceTransactionResponse, error)
 	StreamL2Updates(*StreamL2UpdatesRequest, grpc.ServerStreamingServer[EncodedUpdateResponse]) error
 	GetTotalContractCount(context.Context, *GetTotalContractCountRequest) (*GetTotalContractCountResponse, error)
+	FeeHistoryRewards(context.Context, *FeeHistoryRewardsRequest) (*FeeHistoryRewardsResponse, error)
 	EnclavePublicConfig(context.Context, *EnclavePublicConfigRequest) (*EnclavePublicConfigResponse, error)
 	MakeActive(context.Context, *MakeActiveRequest) (*MakeActiveResponse, error)
 	mustEmbedUnimplementedEnclaveProtoServer()
@@ -443,15 +443,15 @@ func (UnimplementedEnclaveProtoServer) CreateRollup(context.Context, *CreateRoll
 func (UnimplementedEnclaveProtoServer) ExportCrossChainData(context.Context, *ExportCrossChainDataRequest) (*ExportCrossChainDataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExportCrossChainData not implemented")
 }
-func (UnimplementedEnclaveProtoServer) DebugTraceTransaction(context.Context, *DebugTraceTransactionRequest) (*DebugTraceTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DebugTraceTransaction not implemented")
-}
 func (UnimplementedEnclaveProtoServer) StreamL2Updates(*StreamL2UpdatesRequest, grpc.ServerStreamingServer[EncodedUpdateResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamL2Updates not implemented")
 }
 func (UnimplementedEnclaveProtoServer) GetTotalContractCount(context.Context, *GetTotalContractCountRequest) (*GetTotalContractCountResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTotalContractCount not implemented")
 }
+func (UnimplementedEnclaveProtoServer) FeeHistoryRewards(context.Context, *FeeHistoryRewardsRequest) (*FeeHistoryRewardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FeeHistoryRewards not implemented")
+}
 func (UnimplementedEnclaveProtoServer) EnclavePublicConfig(context.Context, *EnclavePublicConfigRequest) (*EnclavePublicConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EnclavePublicConfig not implemented")
 }
@@ -839,24 +839,6 @@ func _EnclaveProto_ExportCrossChainData_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
-func _EnclaveProto_DebugTraceTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DebugTraceTransactionRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(EnclaveProtoServer).DebugTraceTransaction(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: EnclaveProto_DebugTraceTransaction_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(EnclaveProtoServer).DebugTraceTransaction(ctx, req.(*DebugTraceTransactionRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
 func _EnclaveProto_StreamL2Updates_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(StreamL2UpdatesRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -886,6 +868,24 @@ func _EnclaveProto_GetTotalContractCount_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EnclaveProto_FeeHistoryRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeeHistoryRewardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnclaveProtoServer).FeeHistoryRewards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnclaveProto_FeeHistoryRewards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnclaveProtoServer).FeeHistoryRewards(ctx, req.(*FeeHistoryRewardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _EnclaveProto_EnclavePublicConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EnclavePublicConfigRequest)
 	if err := dec(in); err != nil {
@@ -1009,14 +1009,14 @@ var EnclaveProto_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExportCrossChainData",
 			Handler:    _EnclaveProto_ExportCrossChainData_Handler,
 		},
-		{
-			MethodName: "DebugTraceTransaction",
-			Handler:    _EnclaveProto_DebugTraceTransaction_Handler,
-		},
 		{
 			MethodName: "GetTotalContractCount",
 			Handler:    _EnclaveProto_GetTotalContractCount_Handler,
 		},
+		{
+			MethodName: "FeeHistoryRewards",
+			Handler:    _EnclaveProto_FeeHistoryRewards_Handler,
+		},
 		{
 			MethodName: "EnclavePublicConfig",
 			Handler:    _EnclaveProto_EnclavePublicConfig_Handler,
@@ -1,6 +1,11 @@
 package rpc
 
-import "github.com/ten-protocol/go-ten/go/common/viewingkey"
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/ten-protocol/go-ten/go/common/viewingkey"
+)
 
 // EncRPC - the RPC method through which all encypted calls get routed
 const EncRPC = "ten_encryptedRPC"
@@ -18,10 +23,15 @@ const (
 	ERPCSendRawTransaction      = "ten_sendRawTransaction"
 	ERPCResend                  = "ten_resend"
 	ERPCEstimateGas             = "ten_estimateGas"
+	ERPCCreateAccessList        = "ten_createAccessList"
+	ERPCSimulate                = "ten_simulateV1"
 	ERPCGetLogs                 = "ten_getLogs"
 	ERPCGetStorageAt            = "ten_getStorageAt"
 	ERPCDebugLogs               = "debug_eventLogRelevancy"
+	ERPCDebugTraceTransaction   = "debug_traceTransaction"
 	ERPCGetPersonalTransactions = "scan_getPersonalTransactions"
+	ERPCGetPendingTransactions  = "ten_getPendingTransactions"
+	ERPCGetProof                = "ten_getProof"
 )
 
 var encryptedMethods = []string{
@@ -34,25 +44,51 @@ var encryptedMethods = []string{
 	ERPCSendRawTransaction,
 	ERPCResend,
 	ERPCEstimateGas,
+	ERPCCreateAccessList,
+	ERPCSimulate,
 	ERPCGetLogs,
 	ERPCGetStorageAt,
 	ERPCDebugLogs,
+	ERPCDebugTraceTransaction,
 	ERPCGetPersonalTransactions,
+	ERPCGetPendingTransactions,
+	ERPCGetProof,
+}
+
+// versionSuffix - encrypted methods can have multiple versions. E.g.: "ten_call_v2"
+// The method name without a suffix is version 1
+var versionSuffix = regexp.MustCompile(`_v[0-9]+$`)
+
+// VersionedMethod returns the name of the given version of an encrypted method
+func VersionedMethod(method string, version uint) string {
+	if version <= 1 {
+		return method
+	}
+	return fmt.Sprintf("%s_v%d", method, version)
 }
 
 // IsEncryptedMethod indicates whether the RPC method's requests and responses should be encrypted.
 func IsEncryptedMethod(method string) bool {
+	baseMethod := versionSuffix.ReplaceAllString(method, "")
 	for _, m := range encryptedMethods {
-		if m == method {
+		if m == method || m == baseMethod {
 			return true
 		}
 	}
 	return false
 }
 
+// MaxViewingKeysPerRequest - the maximum number of viewing keys a single request can carry
+const MaxViewingKeysPerRequest = 32
+
 // RequestWithVk - wraps the eth parameters with a viewing key
+// When ExtraVKs are set, the request is executed once for the account of each viewing key and the response is
+// responses.AccountResponses. All the viewing keys must be signatures of the same viewing key pair, because the response
+// is encrypted once. Only the methods which read logs accept ExtraVKs.
+// The field is omitted when empty, so the requests of older gateways are unchanged. Older enclaves ignore it.
 type RequestWithVk struct {
-	VK     *viewingkey.RPCSignedViewingKey
-	Method string // can be only one of the encrypted methods above
-	Params []any
+	VK       *viewingkey.RPCSignedViewingKey
+	ExtraVKs []*viewingkey.RPCSignedViewingKey `json:",omitempty"`
+	Method   string                            // can be only one of the encrypted methods above
+	Params   []any
 }
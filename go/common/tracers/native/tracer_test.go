@@ -0,0 +1,20 @@
+package native
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/tracers"
+)
+
+func TestNativeTracersRegistered(t *testing.T) {
+	for _, name := range []string{"callTracer", "prestateTracer", "4byteTracer", "noopTracer"} {
+		tracer, err := tracers.DefaultDirectory.New(name, &tracers.Context{}, nil)
+		require.NoError(t, err, name)
+		require.NotNil(t, tracer.Hooks, name)
+	}
+
+	// there is no js evaluator, so arbitrary code is rejected
+	_, err := tracers.DefaultDirectory.New("{result: function() { return 1 }}", &tracers.Context{}, nil)
+	require.Error(t, err)
+}
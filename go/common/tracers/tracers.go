@@ -6,9 +6,11 @@ package tracers
 
 import (
 	"encoding/json"
-	"errors"
+	"fmt"
+	"math/big"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
 	gethlogger "github.com/ethereum/go-ethereum/eth/tracers/logger"
 )
 
@@ -26,43 +28,50 @@ type TraceConfig struct {
 // Context contains some contextual infos for a transaction execution that is not
 // available from within the EVM object.
 type Context struct {
-	BlockHash gethcommon.Hash // Hash of the block the tx is contained within (zero if dangling tx or call)
-	TxIndex   int             // Index of the transaction within a block (zero if dangling tx or call)
-	TxHash    gethcommon.Hash // Hash of the transaction being traced (zero if dangling call)
+	BlockHash   gethcommon.Hash // Hash of the block the tx is contained within (zero if dangling tx or call)
+	BlockNumber *big.Int        // Number of the block the tx is contained within (zero if dangling tx or call)
+	TxIndex     int             // Index of the transaction within a block (zero if dangling tx or call)
+	TxHash      gethcommon.Hash // Hash of the transaction being traced (zero if dangling call)
 }
 
-// Tracer interface extends vm.EVMLogger and additionally
-// allows collecting the tracing result.
-type Tracer interface {
-	// vm.EVMLogger
-	GetResult() (json.RawMessage, error)
+// Tracer - the set of methods that must be exposed by a tracer
+// for it to be available through the RPC interface.
+// This involves a method to retrieve results and one to
+// stop tracing.
+type Tracer struct {
+	*tracing.Hooks
+	GetResult func() (json.RawMessage, error)
 	// Stop terminates execution of the tracer at the first opportune moment.
-	Stop(err error)
+	Stop func(err error)
 }
 
-type lookupFunc func(string, *Context) (Tracer, error)
+type ctorFn func(*Context, json.RawMessage) (*Tracer, error)
 
-var lookups []lookupFunc
+// DefaultDirectory is the collection of tracers bundled by default.
+// Unlike geth, there is no JS evaluator, so only the registered native tracers are available.
+var DefaultDirectory = directory{elems: make(map[string]ctorFn)}
 
-// RegisterLookup registers a method as a lookup for tracers, meaning that
-// users can invoke a named tracer through that lookup. If 'wildcard' is true,
-// then the lookup will be placed last. This is typically meant for interpreted
-// engines (js) which can evaluate dynamic user-supplied code.
-func RegisterLookup(wildcard bool, lookup lookupFunc) {
-	if wildcard {
-		lookups = append(lookups, lookup)
-	} else {
-		lookups = append([]lookupFunc{lookup}, lookups...)
+// directory provides functionality to lookup a tracer by name
+// and a function to instantiate it.
+type directory struct {
+	elems map[string]ctorFn
+}
+
+// Register registers a method as a lookup for tracers, meaning that
+// users can invoke a named tracer through that lookup.
+// The isJS flag is kept for compatibility with the geth tracers and must be false.
+func (d *directory) Register(name string, f ctorFn, isJS bool) {
+	if isJS {
+		panic(fmt.Sprintf("js tracer %s is not supported", name))
 	}
+	d.elems[name] = f
 }
 
-// New returns a new instance of a tracer, by iterating through the
-// registered lookups.
-func New(code string, ctx *Context) (Tracer, error) {
-	for _, lookup := range lookups {
-		if tracer, err := lookup(code, ctx); err == nil {
-			return tracer, nil
-		}
+// New returns a new instance of a tracer, by looking up the
+// registered tracers.
+func (d *directory) New(name string, ctx *Context, cfg json.RawMessage) (*Tracer, error) {
+	if ctor, ok := d.elems[name]; ok {
+		return ctor(ctx, cfg)
 	}
-	return nil, errors.New("tracer not found")
+	return nil, fmt.Errorf("tracer %s not found", name)
 }
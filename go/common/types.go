@@ -118,6 +118,7 @@ type AttestationReport struct {
 	PubKey      []byte         // a public key that can be used to send encrypted data back to the TEE securely (should only be used once Report has been verified)
 	EnclaveID   common.Address // address identifying the owner of the TEE which signed this report, can also be verified from the encrypted Report data
 	HostAddress string         // the IP address on which the host can be contacted by other Obscuro hosts for peer-to-peer communication
+	HostID      common.Address `rlp:"optional"` // the address of the L1 wallet of the host which publishes the report, can also be verified from the encrypted Report data
 }
 
 type (
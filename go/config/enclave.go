@@ -9,6 +9,9 @@ type EnclaveConfig struct {
 	// EnableAttestation specifies whether the enclave will produce verified attestation report.
 	EnableAttestation         bool `mapstructure:"enableAttestation"`
 	StoreExecutedTransactions bool `mapstructure:"storeExecutedTransactions"`
+	// SignedConfigPath is the path to the signed configuration file, applied after all the other config sources
+	// (can be empty if the protected fields keep the values of the embedded config).
+	SignedConfigPath string `mapstructure:"signedConfigPath"`
 
 	DB    *EnclaveDB    `mapstructure:"db"`
 	Debug *EnclaveDebug `mapstructure:"debug"`
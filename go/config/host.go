@@ -57,6 +57,12 @@ type HostP2P struct {
 	// (note: this is not the publicly advertised host address, which is currently on node config).
 	BindAddress string        `mapstructure:"bindAddress"`
 	Timeout     time.Duration `mapstructure:"timeout"`
+	// IsCompressionEnabled specifies whether the large messages sent to other hosts are compressed.
+	// The received messages are decompressed regardless of this setting.
+	IsCompressionEnabled bool `mapstructure:"compression"`
+	// IsLoopbackExempt specifies whether the inbound connections from the loopback interface are exempt from the limit
+	// of connections per IP. Only meant for the local networks running all the hosts on one machine.
+	IsLoopbackExempt bool `mapstructure:"exemptLoopback"`
 }
 
 // HostL1 contains the configuration for the host's L1 client and interactions.
@@ -68,6 +74,8 @@ type HostL1 struct {
 	L1BeaconUrl string `mapstructure:"beaconURL"`
 	// L1BlobArchiveUrl of the blob archive to fetch expired blob data
 	L1BlobArchiveUrl string `mapstructure:"blobArchiveURL"`
+	// L1BlobArchiveStore where the published rollup blobs are archived (file://, s3:// or mem://). Disabled when empty
+	L1BlobArchiveStore string `mapstructure:"blobArchiveStore"`
 	// RPCTimeout is the timeout for L1 client operations.
 	RPCTimeout time.Duration `mapstructure:"rpcTimeout"`
 }
@@ -6,8 +6,10 @@ import (
 	"os"
 	"strings"
 
+	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
+	"github.com/ten-protocol/go-ten/go/common/log"
 )
 
 // Any yaml files in the default config directory will be embedded into the binary
@@ -22,12 +24,20 @@ const _defaultBaseConfig = "defaults/0-base-config.yaml"
 func LoadTenConfig(files ...string) (*TenConfig, error) {
 	configFiles := []string{_defaultBaseConfig}
 	configFiles = append(configFiles, files...)
-	return load(configFiles)
+	return load(configFiles, false)
+}
+
+// LoadEnclaveTenConfig loads the config like LoadTenConfig for an enclave process, then applies the signed configuration
+// file **after** even the env variable overrides. Unsigned overrides of the protected fields are ignored.
+func LoadEnclaveTenConfig(files ...string) (*TenConfig, error) {
+	configFiles := []string{_defaultBaseConfig}
+	configFiles = append(configFiles, files...)
+	return load(configFiles, true)
 }
 
 // load reads and applies the config files and environment variables, returning a TenConfig struct
 // This method is not publicly available as we want callers to always use the base config file to avoid gotchas.
-func load(filePaths []string) (*TenConfig, error) {
+func load(filePaths []string, isEnclave bool) (*TenConfig, error) {
 	// parse yaml file with viper
 	v := viper.New()
 
@@ -35,6 +45,36 @@ func load(filePaths []string) (*TenConfig, error) {
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv()
 
+	if err := readConfigFiles(v, filePaths); err != nil {
+		return nil, err
+	}
+
+	if isEnclave {
+		if err := applySignedConfig(v, filePaths, signedConfigSigner, signedConfigNetwork, signedConfigMinVersion, gethlog.New(log.CmpKey, "config")); err != nil {
+			fmt.Println("Error applying signed config: ", err)
+			return nil, err
+		}
+	}
+
+	var tenCfg TenConfig
+	err := v.Unmarshal(&tenCfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(), // handle string -> time.Duration
+		mapstructure.StringToSliceHookFunc(","),     // handle string -> []string
+		mapstructure.TextUnmarshallerHookFunc(),     // handle all types that implement encoding.TextUnmarshaler
+		bigIntHookFunc(),                            // handle int values -> big.Int fields
+	)))
+	if err != nil {
+		fmt.Println("Error unmarshalling config: ", err)
+		return nil, err
+	}
+
+	fmt.Println("Successfully loaded Ten config.")
+	tenCfg.PrettyPrint()
+	return &tenCfg, nil
+}
+
+// readConfigFiles reads the first config file and merges the following ones into the viper instance
+func readConfigFiles(v *viper.Viper, filePaths []string) error {
 	for i, filePath := range filePaths {
 		var content []byte
 		var err error
@@ -54,7 +94,7 @@ func load(filePaths []string) (*TenConfig, error) {
 			_, err = os.Stat(filePath)
 			if os.IsNotExist(err) {
 				fmt.Println("Config file not found: ", filePath)
-				return nil, err
+				return err
 			}
 
 			v.SetConfigFile(filePath)
@@ -69,25 +109,8 @@ func load(filePaths []string) (*TenConfig, error) {
 
 		if err != nil {
 			fmt.Println("Error reading config file: ", filePath)
-			return nil, err
+			return err
 		}
 	}
-
-	// todo (@matt) for enclave processes apply signed configuration file **after** even the env variable overrides
-
-	var tenCfg TenConfig
-	err := v.Unmarshal(&tenCfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
-		mapstructure.StringToTimeDurationHookFunc(), // handle string -> time.Duration
-		mapstructure.StringToSliceHookFunc(","),     // handle string -> []string
-		mapstructure.TextUnmarshallerHookFunc(),     // handle all types that implement encoding.TextUnmarshaler
-		bigIntHookFunc(),                            // handle int values -> big.Int fields
-	)))
-	if err != nil {
-		fmt.Println("Error unmarshalling config: ", err)
-		return nil, err
-	}
-
-	fmt.Println("Successfully loaded Ten config.")
-	tenCfg.PrettyPrint()
-	return &tenCfg, nil
+	return nil
 }
@@ -47,6 +47,15 @@ type GasConfig struct {
 	PaymentAddress      gethcommon.Address `mapstructure:"paymentAddress"`
 	BatchExecutionLimit uint64             `mapstructure:"batchExecutionLimit"`
 	LocalExecutionCap   uint64             `mapstructure:"localExecutionCap"`
+	// L1PriceMode is how the L1 fees charged for publishing transactions are derived from the recent L1 blocks.
+	// One of "latest", "window" (average) or "ema" (exponential moving average)
+	L1PriceMode string `mapstructure:"l1PriceMode"`
+	// L1PriceWindow is the number of recent L1 blocks used by the "window" and "ema" modes
+	L1PriceWindow uint64 `mapstructure:"l1PriceWindow"`
+	// L1PricePremium is the percentage added on top of the L1 price
+	L1PricePremium uint64 `mapstructure:"l1PricePremium"`
+	// L1PriceFloor is the minimum L1 price per gas (in wei)
+	L1PriceFloor *big.Int `mapstructure:"l1PriceFloor"`
 }
 
 // L1Config contains config about the L1 network that the Ten network is rolling up to
@@ -0,0 +1,228 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// signedConfigSigner is the address of the key allowed to sign enclave configuration files. It is baked into the enclave
+// build (and so into its measurement) with:
+//
+//	-ldflags "-X github.com/ten-protocol/go-ten/go/config.signedConfigSigner=0x..."
+//
+// When it is empty the signed configuration is not enforced, and the enclave refuses to start with attestation enabled.
+var signedConfigSigner string
+
+// signedConfigNetwork and signedConfigMinVersion are baked into the enclave build like the signer. A signed configuration
+// file is only accepted if it was issued for the network, with at least the minimum version (so the files replaced by a
+// fix can't be replayed).
+var (
+	signedConfigNetwork    string
+	signedConfigMinVersion string
+)
+
+// protectedFields are the config keys (as lowercase viper keys) an enclave only accepts from its embedded defaults or
+// from a signed configuration file. Unsigned overrides of these fields are ignored and reported at startup.
+var protectedFields = []string{
+	"enclave.debug.enabledebugnamespace",
+	"enclave.debug.enableprofiler",
+	"enclave.enableattestation",
+	"network.chainid",
+	"network.gas.paymentaddress",
+	"network.genesis",
+	"network.l1.chainid",
+	"network.l1.contracts.bridge",
+	"network.l1.contracts.management",
+	"network.l1.contracts.messagebus",
+	"network.l1.starthash",
+	"network.sequencer.systemcontractsupgrader",
+}
+
+// SignedConfigScope binds a signed configuration file to a network and a validity period. It is signed with the config.
+type SignedConfigScope struct {
+	ChainID   int64  `yaml:"chainId"`   // the chain ID of the Ten network
+	Network   string `yaml:"network"`   // the name of the Ten network
+	Version   uint64 `yaml:"version"`   // increased with each file issued for the network
+	ExpiresAt int64  `yaml:"expiresAt"` // the unix time after which the file is rejected
+}
+
+// SignedConfig is the format of a signed configuration file. Config is a yaml overlay in the same format as the
+// other config files, Signature is the signature of the keccak256 hash of the scope and the config.
+type SignedConfig struct {
+	SignedConfigScope `yaml:",inline"`
+	Config            string `yaml:"config"`
+	Signature         string `yaml:"signature"`
+}
+
+// hash returns the hash signed by the signer
+func (s *SignedConfig) hash() ([]byte, error) {
+	encoded, err := rlp.EncodeToBytes([]any{uint64(s.ChainID), s.Network, s.Version, uint64(s.ExpiresAt), s.Config}) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("could not encode signed config - %w", err)
+	}
+	return crypto.Keccak256(encoded), nil
+}
+
+// SignConfig produces the content of a signed configuration file for the given yaml overlay
+func SignConfig(overlay []byte, scope SignedConfigScope, key *ecdsa.PrivateKey) ([]byte, error) {
+	// yaml does not preserve all the whitespace of a block string, so the overlay is signed as it will be read back
+	encoded, err := yaml.Marshal(&SignedConfig{SignedConfigScope: scope, Config: string(overlay)})
+	if err != nil {
+		return nil, fmt.Errorf("could not encode config - %w", err)
+	}
+	var signed SignedConfig
+	if err := yaml.Unmarshal(encoded, &signed); err != nil {
+		return nil, fmt.Errorf("could not decode config - %w", err)
+	}
+
+	hash, err := signed.hash()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign config - %w", err)
+	}
+	signed.Signature = hexutil.Encode(sig)
+	return yaml.Marshal(&signed)
+}
+
+// readSignedConfig reads the signed configuration file and returns its overlay and scope, if it was signed by the signer
+// for the network, with at least the minimum version, and it did not expire
+func readSignedConfig(filePath string, signer gethcommon.Address, network string, minVersion uint64, now time.Time) (*viper.Viper, *SignedConfigScope, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read signed config file %s - %w", filePath, err)
+	}
+	var signed SignedConfig
+	if err := yaml.Unmarshal(content, &signed); err != nil {
+		return nil, nil, fmt.Errorf("could not parse signed config file %s - %w", filePath, err)
+	}
+	sig, err := hexutil.Decode(signed.Signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signature in signed config file %s - %w", filePath, err)
+	}
+	hash, err := signed.hash()
+	if err != nil {
+		return nil, nil, err
+	}
+	pubKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signature in signed config file %s - %w", filePath, err)
+	}
+	if recovered := crypto.PubkeyToAddress(*pubKey); recovered != signer {
+		return nil, nil, fmt.Errorf("signed config file %s was signed by %s, expected %s", filePath, recovered, signer)
+	}
+
+	if signed.Network != network {
+		return nil, nil, fmt.Errorf("signed config file %s was issued for network %q, expected %q", filePath, signed.Network, network)
+	}
+	if signed.Version < minVersion {
+		return nil, nil, fmt.Errorf("signed config file %s has version %d, the minimum version is %d", filePath, signed.Version, minVersion)
+	}
+	if expiresAt := time.Unix(signed.ExpiresAt, 0); !now.Before(expiresAt) {
+		return nil, nil, fmt.Errorf("signed config file %s expired at %s", filePath, expiresAt)
+	}
+
+	overlay := viper.New()
+	overlay.SetConfigType("yaml")
+	if err := overlay.ReadConfig(strings.NewReader(signed.Config)); err != nil {
+		return nil, nil, fmt.Errorf("could not parse the config in signed config file %s - %w", filePath, err)
+	}
+	return overlay, &signed.SignedConfigScope, nil
+}
+
+// applySignedConfig is applied last for the enclave processes. Protected fields overridden by anything other than the
+// embedded config files are reset, then the signed configuration overlay (if any) is applied on top of everything.
+// The signed configuration must have been issued for the resulting chain ID.
+// Builds without a signer are only allowed to run without attestation (local, test and debug enclaves), an attested
+// enclave fails to start rather than accepting unsigned values for the protected fields.
+func applySignedConfig(v *viper.Viper, filePaths []string, signerHex string, network string, minVersionStr string, logger gethlog.Logger) error {
+	signedConfigPath := v.GetString("enclave.signedConfigPath")
+	if signerHex == "" {
+		if v.GetBool("enclave.enableAttestation") {
+			return fmt.Errorf("no signed config signer baked into the build, an attested enclave requires one")
+		}
+		logger.Warn("No signed config signer baked into this build, protected config fields are not enforced (attestation is disabled)")
+		if signedConfigPath != "" {
+			logger.Warn("Ignoring signed config file", "path", signedConfigPath)
+		}
+		return nil
+	}
+	if !gethcommon.IsHexAddress(signerHex) {
+		return fmt.Errorf("invalid signed config signer %q baked into the build", signerHex)
+	}
+	signer := gethcommon.HexToAddress(signerHex)
+	if network == "" {
+		return fmt.Errorf("no signed config network baked into the build")
+	}
+	var minVersion uint64
+	if minVersionStr != "" {
+		var err error
+		if minVersion, err = strconv.ParseUint(minVersionStr, 10, 64); err != nil {
+			return fmt.Errorf("invalid signed config minimum version %q baked into the build", minVersionStr)
+		}
+	}
+
+	overlay := viper.New()
+	var scope *SignedConfigScope
+	if signedConfigPath != "" {
+		var err error
+		overlay, scope, err = readSignedConfig(signedConfigPath, signer, network, minVersion, time.Now())
+		if err != nil {
+			return err
+		}
+		logger.Info("Applying signed config file", "path", signedConfigPath, "version", scope.Version)
+	}
+
+	// the values of the embedded files are part of the enclave measurement, unlike the env variables and the other files
+	measured := viper.New()
+	var embeddedPaths []string
+	for _, filePath := range filePaths {
+		if _, err := _baseConfig.ReadFile(filePath); err == nil {
+			embeddedPaths = append(embeddedPaths, filePath)
+		}
+	}
+	if err := readConfigFiles(measured, embeddedPaths); err != nil {
+		return err
+	}
+
+	var ignored []string
+	for _, key := range protectedFields {
+		if overlay.IsSet(key) || configValuesEqual(v.Get(key), measured.Get(key)) {
+			continue
+		}
+		ignored = append(ignored, key)
+		v.Set(key, measured.Get(key))
+	}
+	if len(ignored) > 0 {
+		sort.Strings(ignored)
+		logger.Warn("Ignoring unsigned overrides of protected config fields", "fields", strings.Join(ignored, ", "))
+	}
+
+	for _, key := range overlay.AllKeys() {
+		v.Set(key, overlay.Get(key))
+	}
+	if scope != nil && v.GetInt64("network.chainId") != scope.ChainID {
+		return fmt.Errorf("signed config file %s was issued for chain %d, expected %d", signedConfigPath, scope.ChainID, v.GetInt64("network.chainId"))
+	}
+	return nil
+}
+
+// configValuesEqual compares the values as strings, env variables are always strings while yaml values are typed
+func configValuesEqual(a, b any) bool {
+	return strings.EqualFold(fmt.Sprint(a), fmt.Sprint(b))
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testNetwork = "sepolia-testnet"
+
+const testOverlay = `
+network:
+  l1:
+    contracts:
+      management: "0x1111111111111111111111111111111111111111"
+`
+
+func TestSignedConfigIsAppliedLast(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signedPath := writeSignedConfig(t, testOverlay, testScope(), key)
+
+	t.Setenv("NETWORK_L1_CONTRACTS_MANAGEMENT", "0x2222222222222222222222222222222222222222")
+	t.Setenv("ENCLAVE_DEBUG_ENABLEDEBUGNAMESPACE", "true")
+	t.Setenv("ENCLAVE_SIGNEDCONFIGPATH", signedPath)
+
+	v := loadViper(t)
+	require.NoError(t, applySignedConfig(v, []string{_defaultBaseConfig}, crypto.PubkeyToAddress(key.PublicKey).Hex(), testNetwork, "2", gethlog.New()))
+
+	// the signed value wins over the env variable
+	require.Equal(t, "0x1111111111111111111111111111111111111111", v.GetString("network.l1.contracts.management"))
+	// the unsigned override of a protected field is ignored
+	require.False(t, v.GetBool("enclave.debug.enableDebugNamespace"))
+}
+
+func TestSignedConfigWithWrongSignerIsRejected(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	t.Setenv("ENCLAVE_SIGNEDCONFIGPATH", writeSignedConfig(t, testOverlay, testScope(), key))
+
+	v := loadViper(t)
+	otherSigner := gethcommon.HexToAddress("0x3333333333333333333333333333333333333333")
+	require.Error(t, applySignedConfig(v, []string{_defaultBaseConfig}, otherSigner.Hex(), testNetwork, "", gethlog.New()))
+}
+
+func TestSignedConfigIsBoundToItsScope(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	apply := func(scope SignedConfigScope, minVersion string) error {
+		t.Setenv("ENCLAVE_SIGNEDCONFIGPATH", writeSignedConfig(t, testOverlay, scope, key))
+		return applySignedConfig(loadViper(t), []string{_defaultBaseConfig}, signer, testNetwork, minVersion, gethlog.New())
+	}
+	require.NoError(t, apply(testScope(), "2"))
+
+	// a file issued for another network
+	otherNetwork := testScope()
+	otherNetwork.Network = "other-testnet"
+	require.ErrorContains(t, apply(otherNetwork, ""), "issued for network")
+	otherChain := testScope()
+	otherChain.ChainID = 444
+	require.ErrorContains(t, apply(otherChain, ""), "issued for chain")
+
+	// a file replaced by a newer version
+	require.ErrorContains(t, apply(testScope(), "3"), "minimum version")
+
+	// an expired file
+	expired := testScope()
+	expired.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+	require.ErrorContains(t, apply(expired, ""), "expired")
+
+	// the scope can't be changed without the signer
+	content, err := SignConfig([]byte(testOverlay), testScope(), key)
+	require.NoError(t, err)
+	var signed SignedConfig
+	require.NoError(t, yaml.Unmarshal(content, &signed))
+	signed.ExpiresAt = time.Now().Add(24 * time.Hour).Unix()
+	content, err = yaml.Marshal(&signed)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "signed.yaml")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	t.Setenv("ENCLAVE_SIGNEDCONFIGPATH", path)
+	require.ErrorContains(t, applySignedConfig(loadViper(t), []string{_defaultBaseConfig}, signer, testNetwork, "", gethlog.New()), "was signed by")
+}
+
+func TestUnprotectedFieldsKeepTheirOverrides(t *testing.T) {
+	t.Setenv("ENCLAVE_LOG_LEVEL", "4")
+	t.Setenv("NETWORK_CHAINID", "123")
+
+	v := loadViper(t)
+	require.NoError(t, applySignedConfig(v, []string{_defaultBaseConfig}, "0x3333333333333333333333333333333333333333", testNetwork, "", gethlog.New()))
+
+	require.Equal(t, 4, v.GetInt("enclave.log.level"))
+	require.NotEqual(t, 123, v.GetInt("network.chainId"))
+}
+
+func TestUnsignedOverridesAreRejectedWithoutSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	// an attested enclave built without a signer fails to start, rather than accepting the unsigned overrides
+	t.Setenv("ENCLAVE_ENABLEATTESTATION", "true")
+	t.Setenv("NETWORK_L1_CONTRACTS_MANAGEMENT", "0x2222222222222222222222222222222222222222")
+	require.ErrorContains(t, applySignedConfig(loadViper(t), []string{_defaultBaseConfig}, "", testNetwork, "", gethlog.New()), "no signed config signer")
+	// even with a signed file, as there is no key to verify it
+	t.Setenv("ENCLAVE_SIGNEDCONFIGPATH", writeSignedConfig(t, testOverlay, testScope(), key))
+	require.Error(t, applySignedConfig(loadViper(t), []string{_defaultBaseConfig}, "", testNetwork, "", gethlog.New()))
+
+	// the enclaves running without attestation (local and debug builds) are not enforced
+	t.Setenv("ENCLAVE_ENABLEATTESTATION", "false")
+	v := loadViper(t)
+	require.NoError(t, applySignedConfig(v, []string{_defaultBaseConfig}, "", testNetwork, "", gethlog.New()))
+	require.Equal(t, "0x2222222222222222222222222222222222222222", v.GetString("network.l1.contracts.management"))
+}
+
+func testScope() SignedConfigScope {
+	return SignedConfigScope{ChainID: 443, Network: testNetwork, Version: 2, ExpiresAt: time.Now().Add(time.Hour).Unix()}
+}
+
+func writeSignedConfig(t *testing.T, overlay string, scope SignedConfigScope, key *ecdsa.PrivateKey) string {
+	t.Helper()
+	content, err := SignConfig([]byte(overlay), scope, key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "signed.yaml")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	return path
+}
+
+func loadViper(t *testing.T) *viper.Viper {
+	t.Helper()
+	v := viper.New()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	require.NoError(t, readConfigFiles(v, []string{_defaultBaseConfig}))
+	return v
+}
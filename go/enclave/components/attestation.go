@@ -20,12 +20,13 @@ type IDData struct {
 	EnclaveID   gethcommon.Address
 	PubKey      []byte
 	HostAddress string
+	HostID      gethcommon.Address
 }
 
 // AttestationProvider creates and verifies attestation reports
 type AttestationProvider interface {
 	// CreateAttestationReport returns the verifiable attestation report
-	CreateAttestationReport(ctx context.Context, hostAddress string) (*common.AttestationReport, error)
+	CreateAttestationReport(ctx context.Context, hostAddress string, hostID gethcommon.Address) (*common.AttestationReport, error)
 	// VerifyReport returns the embedded report data
 	VerifyReport(att *common.AttestationReport) ([]byte, error)
 }
@@ -43,8 +44,8 @@ type EgoAttestationProvider struct {
 	logger            gethlog.Logger
 }
 
-func (e *EgoAttestationProvider) CreateAttestationReport(ctx context.Context, hostAddress string) (*common.AttestationReport, error) {
-	idHash, err := getIDHash(e.enclaveKeyService.EnclaveID(), e.enclaveKeyService.PublicKeyBytes(), hostAddress)
+func (e *EgoAttestationProvider) CreateAttestationReport(ctx context.Context, hostAddress string, hostID gethcommon.Address) (*common.AttestationReport, error) {
+	idHash, err := getIDHash(e.enclaveKeyService.EnclaveID(), e.enclaveKeyService.PublicKeyBytes(), hostAddress, hostID)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +59,7 @@ func (e *EgoAttestationProvider) CreateAttestationReport(ctx context.Context, ho
 		PubKey:      e.enclaveKeyService.PublicKeyBytes(),
 		EnclaveID:   e.enclaveKeyService.EnclaveID(),
 		HostAddress: hostAddress,
+		HostID:      hostID,
 	}, nil
 }
 
@@ -74,25 +76,27 @@ type DummyAttestationProvider struct {
 	enclaveKeyService *crypto.EnclaveAttestedKeyService
 }
 
-func (e *DummyAttestationProvider) CreateAttestationReport(ctx context.Context, hostAddress string) (*common.AttestationReport, error) {
+func (e *DummyAttestationProvider) CreateAttestationReport(ctx context.Context, hostAddress string, hostID gethcommon.Address) (*common.AttestationReport, error) {
 	return &common.AttestationReport{
 		Report:      []byte("MOCK REPORT"),
 		PubKey:      e.enclaveKeyService.PublicKeyBytes(),
 		EnclaveID:   e.enclaveKeyService.EnclaveID(),
 		HostAddress: hostAddress,
+		HostID:      hostID,
 	}, nil
 }
 
 func (e *DummyAttestationProvider) VerifyReport(att *common.AttestationReport) ([]byte, error) {
-	return getIDHash(att.EnclaveID, att.PubKey, att.HostAddress)
+	return getIDHash(att.EnclaveID, att.PubKey, att.HostAddress, att.HostID)
 }
 
 // getIDHash provides a hash of identifying data to be included in an attestation report (or verified against the contents of an attestation report)
-func getIDHash(enclaveID gethcommon.Address, pubKey []byte, hostAddress string) ([]byte, error) {
+func getIDHash(enclaveID gethcommon.Address, pubKey []byte, hostAddress string, hostID gethcommon.Address) ([]byte, error) {
 	idData := IDData{
 		EnclaveID:   enclaveID,
 		PubKey:      pubKey,
 		HostAddress: hostAddress,
+		HostID:      hostID,
 	}
 	idJSON, err := json.Marshal(idData)
 	if err != nil {
@@ -103,7 +107,7 @@ func getIDHash(enclaveID gethcommon.Address, pubKey []byte, hostAddress string)
 }
 
 func VerifyIdentity(data []byte, att *common.AttestationReport) error {
-	expectedIDHash, err := getIDHash(att.EnclaveID, att.PubKey, att.HostAddress)
+	expectedIDHash, err := getIDHash(att.EnclaveID, att.PubKey, att.HostAddress, att.HostID)
 	if err != nil {
 		return fmt.Errorf("failed to create ID data to check attestation report with enclaveID: %s. Cause: %w", att.EnclaveID, err)
 	}
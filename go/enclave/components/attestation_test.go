@@ -0,0 +1,36 @@
+package components
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+func TestAttestationBindsTheHost(t *testing.T) {
+	hostID, otherHostID := gethcommon.BigToAddress(big.NewInt(1)), gethcommon.BigToAddress(big.NewInt(2))
+	att := &common.AttestationReport{
+		PubKey:      []byte{1, 2, 3},
+		EnclaveID:   gethcommon.BigToAddress(big.NewInt(11)),
+		HostAddress: "127.0.0.1:10000",
+		HostID:      hostID,
+	}
+	// the data embedded in the report by the enclave
+	data, err := getIDHash(att.EnclaveID, att.PubKey, att.HostAddress, hostID)
+	require.NoError(t, err)
+	require.NoError(t, VerifyIdentity(data, att))
+
+	// a copy of the report can't be published by another host
+	att.HostID = otherHostID
+	require.Error(t, VerifyIdentity(data, att))
+
+	// the host survives the encoding of the report on the L1
+	att.HostID = hostID
+	encoded, err := common.EncodeAttestation(att)
+	require.NoError(t, err)
+	decoded, err := common.DecodeAttestation(encoded)
+	require.NoError(t, err)
+	require.Equal(t, hostID, decoded.HostID)
+}
@@ -86,8 +86,10 @@ func (bp *l1BlockProcessor) Process(ctx context.Context, processed *common.Proce
 		}
 	}
 
-	// todo @siliev - not sure if this is the best way to update the price, will pick up random stale blocks from forks?
-	bp.gasOracle.ProcessL1Block(processed.BlockHeader)
+	// the fees are calculated from the ancestors of the block, so blocks from forks don't affect the canonical chain
+	if err := bp.gasOracle.ProcessL1Block(ctx, processed.BlockHeader); err != nil {
+		return nil, fmt.Errorf("failed to process l1 fees. Cause: %w", err)
+	}
 
 	h := processed.BlockHeader.Hash()
 	bp.currentL1Head = &h
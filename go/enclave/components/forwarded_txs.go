@@ -0,0 +1,104 @@
+package components
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// forwardedTxs - the transactions validated by a mempool in validate only mode, which the host forwards to the sequencer.
+// The validators don't hold transactions, so this is how they know which transactions submitted through them are pending.
+// A transaction is dropped once the nonce of its sender moves past it, or when its lifetime expires (e.g. because the
+// sequencer rejected it).
+type forwardedTxs struct {
+	lifetime time.Duration
+	maxTxs   int
+	mutex    sync.Mutex
+	count    int
+	txs      map[gethcommon.Address]map[uint64]*forwardedTx // keyed by sender and nonce, so a replacement overwrites the tx
+}
+
+type forwardedTx struct {
+	tx        *types.Transaction
+	addedTime time.Time
+}
+
+func newForwardedTxs(lifetime time.Duration, maxTxs int) *forwardedTxs {
+	return &forwardedTxs{
+		lifetime: lifetime,
+		maxTxs:   maxTxs,
+		txs:      make(map[gethcommon.Address]map[uint64]*forwardedTx),
+	}
+}
+
+// add tracks the transaction. When the maximum number of transactions is reached, the transaction is not tracked.
+func (f *forwardedTxs) add(sender gethcommon.Address, tx *types.Transaction) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, replaced := f.txs[sender][tx.Nonce()]; !replaced {
+		if f.count >= f.maxTxs {
+			f.removeExpired()
+		}
+		if f.count >= f.maxTxs {
+			return
+		}
+		f.count++
+	}
+	if _, found := f.txs[sender]; !found {
+		f.txs[sender] = make(map[uint64]*forwardedTx)
+	}
+	f.txs[sender][tx.Nonce()] = &forwardedTx{tx: tx, addedTime: time.Now()}
+}
+
+// content returns the tracked transactions of the sender, ordered by nonce, in the format of the mempool: the pending
+// transactions are the ones which can be executed from the current nonce of the sender, the queued ones follow a gap.
+func (f *forwardedTxs) content(sender gethcommon.Address, stateNonce uint64) ([]*types.Transaction, []*types.Transaction) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	senderTxs := f.txs[sender]
+	for nonce, forwarded := range senderTxs {
+		if nonce < stateNonce || time.Since(forwarded.addedTime) > f.lifetime {
+			f.remove(sender, nonce)
+		}
+	}
+
+	txs := make([]*types.Transaction, 0, len(senderTxs))
+	for _, forwarded := range senderTxs {
+		txs = append(txs, forwarded.tx)
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce() < txs[j].Nonce() })
+
+	pending := make([]*types.Transaction, 0)
+	nextNonce := stateNonce
+	for _, tx := range txs {
+		if tx.Nonce() != nextNonce {
+			break
+		}
+		pending = append(pending, tx)
+		nextNonce++
+	}
+	return pending, txs[len(pending):]
+}
+
+func (f *forwardedTxs) removeExpired() {
+	for sender, senderTxs := range f.txs {
+		for nonce, forwarded := range senderTxs {
+			if time.Since(forwarded.addedTime) > f.lifetime {
+				f.remove(sender, nonce)
+			}
+		}
+	}
+}
+
+func (f *forwardedTxs) remove(sender gethcommon.Address, nonce uint64) {
+	delete(f.txs[sender], nonce)
+	f.count--
+	if len(f.txs[sender]) == 0 {
+		delete(f.txs, sender)
+	}
+}
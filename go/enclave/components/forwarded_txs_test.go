@@ -0,0 +1,67 @@
+package components
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForwardedTxs(t *testing.T) {
+	forwarded := newForwardedTxs(time.Hour, 3)
+	sender, otherSender := gethcommon.Address{1}, gethcommon.Address{2}
+	newTx := func(nonce uint64, gasPrice int64) *types.Transaction {
+		return types.NewTx(&types.LegacyTx{Nonce: nonce, GasPrice: big.NewInt(gasPrice)})
+	}
+
+	forwarded.add(sender, newTx(5, 1))
+	forwarded.add(sender, newTx(7, 1))
+	replacement := newTx(5, 2)
+	forwarded.add(sender, replacement)
+	forwarded.add(otherSender, newTx(0, 1))
+
+	// the transaction after the gap is queued
+	pending, queued := forwarded.content(sender, 5)
+	require.Equal(t, []gethcommon.Hash{replacement.Hash()}, hashes(pending))
+	require.Equal(t, []uint64{7}, nonces(queued))
+
+	// the limit is reached, so the transaction is not tracked
+	forwarded.add(sender, newTx(6, 1))
+	pending, queued = forwarded.content(sender, 5)
+	require.Len(t, pending, 1)
+	require.Len(t, queued, 1)
+
+	// the mined transaction is dropped, which frees a slot
+	pending, queued = forwarded.content(sender, 6)
+	require.Empty(t, pending)
+	require.Equal(t, []uint64{7}, nonces(queued))
+	forwarded.add(sender, newTx(6, 1))
+	pending, queued = forwarded.content(sender, 6)
+	require.Equal(t, []uint64{6, 7}, nonces(pending))
+	require.Empty(t, queued)
+
+	// the expired transactions are dropped
+	forwarded.lifetime = 0
+	pending, queued = forwarded.content(otherSender, 0)
+	require.Empty(t, pending)
+	require.Empty(t, queued)
+}
+
+func hashes(txs []*types.Transaction) []gethcommon.Hash {
+	result := make([]gethcommon.Hash, len(txs))
+	for i, tx := range txs {
+		result[i] = tx.Hash()
+	}
+	return result
+}
+
+func nonces(txs []*types.Transaction) []uint64 {
+	result := make([]uint64, len(txs))
+	for i, tx := range txs {
+		result[i] = tx.Nonce()
+	}
+	return result
+}
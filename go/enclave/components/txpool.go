@@ -56,6 +56,7 @@ type TxPool struct {
 	stateMutex   sync.Mutex
 	logger       gethlog.Logger
 	validateOnly atomic.Bool
+	forwarded    *forwardedTxs // the transactions validated in validate only mode
 }
 
 // NewTxPool returns a new instance of the tx pool
@@ -83,6 +84,7 @@ func NewTxPool(blockchain *EthChainAdapter, gasTip *big.Int, validateOnly bool,
 		gasTip:       gasTip,
 		stateMutex:   sync.Mutex{},
 		validateOnly: atomic.Bool{},
+		forwarded:    newForwardedTxs(txPoolConfig.Lifetime, int(txPoolConfig.GlobalSlots+txPoolConfig.GlobalQueue)),
 		logger:       logger,
 	}
 	txp.validateOnly.Store(validateOnly)
@@ -154,7 +156,16 @@ func (t *TxPool) SubmitTx(transaction *common.L2Tx) error {
 	}
 
 	if t.validateOnly.Load() {
-		return t.validate(transaction)
+		if err := t.validate(transaction); err != nil {
+			return err
+		}
+		// the sender was authenticated by the validation
+		sender, err := types.Sender(types.LatestSigner(t.chainconfig), transaction)
+		if err != nil {
+			return err
+		}
+		t.forwarded.add(sender, transaction)
+		return nil
 	}
 	return t.add(transaction)
 }
@@ -203,6 +214,66 @@ func (t *TxPool) PendingTransactions() map[gethcommon.Address][]*gethtxpool.Lazy
 	})
 }
 
+// GetTx returns the transaction with the given hash if it is in the pool, or nil otherwise
+// In validate only mode the pool does not hold any transactions
+func (t *TxPool) GetTx(hash gethcommon.Hash) *types.Transaction {
+	if !t.running.Load() || t.validateOnly.Load() {
+		return nil
+	}
+	return t.pool.Get(hash)
+}
+
+// GetTxBySenderAndNonce returns the pending or queued transaction of the sender with the given nonce.
+// The second return value is false when the pool can't tell, because it is not running or in validate only mode
+func (t *TxPool) GetTxBySenderAndNonce(sender gethcommon.Address, nonce uint64) (*types.Transaction, bool) {
+	if !t.running.Load() || t.validateOnly.Load() {
+		return nil, false
+	}
+	pending, queued := t.pool.ContentFrom(sender)
+	for _, tx := range append(pending, queued...) {
+		if tx.Nonce() == nonce {
+			return tx, true
+		}
+	}
+	return nil, true
+}
+
+// ContentFrom returns the pending and queued transactions of the sender, ordered by nonce.
+// In validate only mode, these are the transactions of the sender validated by this pool, which were forwarded to the sequencer.
+// The last return value is false when the pool can't tell, because it is not running
+func (t *TxPool) ContentFrom(sender gethcommon.Address) ([]*types.Transaction, []*types.Transaction, bool) {
+	if !t.running.Load() {
+		return nil, nil, false
+	}
+	if t.validateOnly.Load() {
+		stateNonce, err := t.stateNonce(sender)
+		if err != nil {
+			t.logger.Warn("Could not read the nonce of the sender", log.ErrKey, err)
+			return nil, nil, false
+		}
+		pending, queued := t.forwarded.content(sender, stateNonce)
+		return pending, queued, true
+	}
+	pending, queued := t.pool.ContentFrom(sender)
+	return pending, queued, true
+}
+
+// stateNonce returns the nonce of the account at the head of the chain
+func (t *TxPool) stateNonce(address gethcommon.Address) (uint64, error) {
+	head := t.Chain.CurrentBlock()
+	if head == nil {
+		return 0, fmt.Errorf("no head batch")
+	}
+	stateDB, err := t.Chain.StateAt(head.Root)
+	if err != nil {
+		return 0, err
+	}
+	if stateDB == nil { // empty state
+		return 0, nil
+	}
+	return stateDB.GetNonce(address), nil
+}
+
 func (t *TxPool) Close() error {
 	defer func() {
 		if err := recover(); err != nil {
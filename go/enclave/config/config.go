@@ -2,9 +2,11 @@ package config
 
 import (
 	"math/big"
+	"strings"
 	"time"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ten-protocol/go-ten/go/config"
 )
 
@@ -43,6 +45,11 @@ type EnclaveConfig struct {
 	GasPaymentAddress      gethcommon.Address
 	BaseFee                *big.Int
 	GasBatchExecutionLimit uint64
+	// L1 gas oracle settings - see config.GasConfig
+	L1PriceMode    string
+	L1PriceWindow  uint64
+	L1PricePremium uint64
+	L1PriceFloor   *big.Int
 
 	// **Db configs
 	// Whether the enclave should use in-memory or persistent storage
@@ -78,12 +85,16 @@ type EnclaveConfig struct {
 	// This is required to advertise for node discovery, and we include it in the attestation
 	// todo - should we really bind the physical address to the attestation.
 	HostAddress string
+	// The address of the L1 wallet of the host the enclave service is tied to. It is included in the attestation, so
+	// that no other host can register the enclave by publishing a copy of the attestation.
+	HostID gethcommon.Address
 }
 
 func EnclaveConfigFromTenConfig(tenCfg *config.TenConfig) *EnclaveConfig {
 	return &EnclaveConfig{
 		NodeID:                    tenCfg.Node.ID,
 		HostAddress:               tenCfg.Node.HostAddress,
+		HostID:                    hostID(tenCfg.Node.PrivateKeyString),
 		WillAttest:                tenCfg.Enclave.EnableAttestation,
 		StoreExecutedTransactions: tenCfg.Enclave.StoreExecutedTransactions,
 
@@ -111,9 +122,22 @@ func EnclaveConfigFromTenConfig(tenCfg *config.TenConfig) *EnclaveConfig {
 		BaseFee:                  tenCfg.Network.Gas.BaseFee,
 		GasBatchExecutionLimit:   tenCfg.Network.Gas.BatchExecutionLimit,
 		GasLocalExecutionCapFlag: tenCfg.Network.Gas.LocalExecutionCap,
+		L1PriceMode:              tenCfg.Network.Gas.L1PriceMode,
+		L1PriceWindow:            tenCfg.Network.Gas.L1PriceWindow,
+		L1PricePremium:           tenCfg.Network.Gas.L1PricePremium,
+		L1PriceFloor:             tenCfg.Network.Gas.L1PriceFloor,
 
 		TenGenesis:    tenCfg.Network.GenesisJSON,
 		MaxBatchSize:  tenCfg.Network.Batch.MaxSize,
 		MaxRollupSize: tenCfg.Network.Rollup.MaxSize,
 	}
 }
+
+// hostID returns the address of the L1 wallet of the host, or the zero address if the private key is not set
+func hostID(privateKey string) gethcommon.Address {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+	if err != nil {
+		return gethcommon.Address{}
+	}
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
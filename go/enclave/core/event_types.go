@@ -54,6 +54,18 @@ func (stxs *TransactionsWithSender) Add(tx *common.L2PricedTransaction) error {
 	return nil
 }
 
+// TxFee - the price paid for the gas used by a transaction
+type TxFee struct {
+	EffectiveGasPrice *big.Int
+	GasUsed           uint64
+}
+
+// BatchTxFees - the fees paid by the (non-synthetic) transactions of a batch
+type BatchTxFees struct {
+	Header *common.BatchHeader
+	Txs    []TxFee
+}
+
 // InternalReceipt - Equivalent to the geth types.Receipt, but without weird quirks
 type InternalReceipt struct {
 	PostState         []byte
@@ -0,0 +1,223 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ten-protocol/go-ten/go/common/privacy"
+	"github.com/ten-protocol/go-ten/go/common/tracers"
+)
+
+// traceVisibility - the rules deciding which parts of a trace the sender of the transaction is allowed to see. They are
+// the rules of the state reads: the sender can see their own account, the transparent contracts and the whitelisted
+// storage slots.
+// The execution of a contract which is not transparent is hidden. The values it returns (or passes on to the contracts it
+// calls) might be private, so the executions receiving them are hidden from then on as well.
+type traceVisibility struct {
+	sender        gethcommon.Address
+	isTransparent func(gethcommon.Address) bool
+	whitelist     *privacy.Whitelist
+}
+
+// traceFrame - the visibility of a call frame of the traced transaction
+type traceFrame struct {
+	inputVisible bool // whether the caller was visible when it made the call
+	visible      bool // whether the execution is visible, it turns false once a hidden call returns to it
+}
+
+// privateTracer wraps a tracer, to hide the inputs, outputs, logs and steps of the hidden executions (see traceVisibility)
+type privateTracer struct {
+	inner        *tracers.Tracer
+	rules        *traceVisibility
+	structLogger *logger.StructLogger // set when the struct logger is wrapped, its steps are redacted in the result
+	diffMode     bool                 // set when the prestate tracer is wrapped in diff mode
+	isPrestate   bool
+
+	env    *tracing.VMContext
+	frames []*traceFrame
+	steps  []bool // the visibility of the steps recorded by the struct logger, in order
+}
+
+func newPrivateTracer(inner *tracers.Tracer, rules *traceVisibility) *privateTracer {
+	return &privateTracer{inner: inner, rules: rules}
+}
+
+// tracer returns the wrapped tracer, whose hooks track the visibility of the frames
+func (t *privateTracer) tracer() *tracers.Tracer {
+	hooks := *t.inner.Hooks
+	hooks.OnTxStart = t.OnTxStart
+	hooks.OnEnter = t.OnEnter
+	hooks.OnExit = t.OnExit
+	hooks.OnLog = t.OnLog
+	hooks.OnOpcode = t.OnOpcode
+	return &tracers.Tracer{Hooks: &hooks, GetResult: t.GetResult, Stop: t.inner.Stop}
+}
+
+func (t *privateTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from gethcommon.Address) {
+	t.env = env
+	if t.inner.OnTxStart != nil {
+		t.inner.OnTxStart(env, tx, from)
+	}
+}
+
+func (t *privateTracer) OnEnter(depth int, typ byte, from gethcommon.Address, to gethcommon.Address, input []byte, gas uint64, value *big.Int) {
+	// the input of the transaction is the sender's own
+	callerVisible := t.isVisible()
+	visible := callerVisible
+	// the delegate calls execute in the context of the caller, and the creations execute the code supplied by the caller
+	op := vm.OpCode(typ)
+	if (op == vm.CALL || op == vm.STATICCALL) && len(t.env.StateDB.GetCode(to)) > 0 && !t.rules.isTransparent(to) {
+		visible = false
+	}
+	t.frames = append(t.frames, &traceFrame{inputVisible: callerVisible, visible: visible})
+
+	if !callerVisible {
+		input = nil
+	}
+	if t.inner.OnEnter != nil {
+		t.inner.OnEnter(depth, typ, from, to, input, gas, value)
+	}
+}
+
+func (t *privateTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if len(t.frames) > 0 {
+		frame := t.frames[len(t.frames)-1]
+		t.frames = t.frames[:len(t.frames)-1]
+		if !frame.visible {
+			// the values returned to the caller might be private
+			if len(t.frames) > 0 {
+				t.frames[len(t.frames)-1].visible = false
+			}
+			// the output of the transaction is returned to the sender anyway
+			if depth > 0 {
+				output = nil
+			}
+		}
+	}
+	if t.inner.OnExit != nil {
+		t.inner.OnExit(depth, output, gasUsed, err, reverted)
+	}
+}
+
+func (t *privateTracer) OnLog(log *types.Log) {
+	if !t.isVisible() {
+		return
+	}
+	if t.inner.OnLog != nil {
+		t.inner.OnLog(log)
+	}
+}
+
+func (t *privateTracer) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if t.inner.OnOpcode == nil {
+		return
+	}
+	if t.structLogger == nil {
+		t.inner.OnOpcode(pc, opcode, gas, cost, scope, rData, depth, err)
+		return
+	}
+	recorded := len(t.structLogger.StructLogs())
+	t.inner.OnOpcode(pc, opcode, gas, cost, scope, rData, depth, err)
+	if len(t.structLogger.StructLogs()) > recorded {
+		t.steps = append(t.steps, t.isVisible())
+	}
+}
+
+// isVisible returns whether the current execution is visible, the top level call is made by the sender
+func (t *privateTracer) isVisible() bool {
+	return len(t.frames) == 0 || t.frames[len(t.frames)-1].visible
+}
+
+func (t *privateTracer) GetResult() (json.RawMessage, error) {
+	result, err := t.inner.GetResult()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case t.structLogger != nil:
+		return t.redactStructLogs(result)
+	case t.isPrestate:
+		return t.filterPrestate(result)
+	default:
+		return result, nil
+	}
+}
+
+// redactStructLogs removes the stack, memory, return data and storage of the hidden steps
+func (t *privateTracer) redactStructLogs(result json.RawMessage) (json.RawMessage, error) {
+	var execution logger.ExecutionResult
+	if err := json.Unmarshal(result, &execution); err != nil {
+		return nil, fmt.Errorf("could not decode the struct logs. Cause: %w", err)
+	}
+	for i := range execution.StructLogs {
+		// the steps are all hidden if they can't be matched with their visibility
+		if len(t.steps) == len(execution.StructLogs) && t.steps[i] {
+			continue
+		}
+		execution.StructLogs[i].Stack = nil
+		execution.StructLogs[i].Memory = nil
+		execution.StructLogs[i].ReturnData = ""
+		execution.StructLogs[i].Storage = nil
+	}
+	return json.Marshal(execution)
+}
+
+// prestateAccount - the format of the accounts returned by the prestate tracer
+type prestateAccount struct {
+	Balance *hexutil.Big                        `json:"balance,omitempty"`
+	Code    hexutil.Bytes                       `json:"code,omitempty"`
+	Nonce   uint64                              `json:"nonce,omitempty"`
+	Storage map[gethcommon.Hash]gethcommon.Hash `json:"storage,omitempty"`
+}
+
+// filterPrestate keeps the state the sender is allowed to read. The code is public, the balance and nonce are only
+// returned for the sender and the transparent contracts, and the storage for the transparent contracts and the
+// whitelisted slots.
+func (t *privateTracer) filterPrestate(result json.RawMessage) (json.RawMessage, error) {
+	if !t.diffMode {
+		var state map[gethcommon.Address]*prestateAccount
+		if err := json.Unmarshal(result, &state); err != nil {
+			return nil, fmt.Errorf("could not decode the prestate. Cause: %w", err)
+		}
+		return json.Marshal(t.filterAccounts(state))
+	}
+
+	var diff struct {
+		Post map[gethcommon.Address]*prestateAccount `json:"post"`
+		Pre  map[gethcommon.Address]*prestateAccount `json:"pre"`
+	}
+	if err := json.Unmarshal(result, &diff); err != nil {
+		return nil, fmt.Errorf("could not decode the prestate diff. Cause: %w", err)
+	}
+	diff.Post = t.filterAccounts(diff.Post)
+	diff.Pre = t.filterAccounts(diff.Pre)
+	return json.Marshal(diff)
+}
+
+func (t *privateTracer) filterAccounts(state map[gethcommon.Address]*prestateAccount) map[gethcommon.Address]*prestateAccount {
+	filtered := make(map[gethcommon.Address]*prestateAccount, len(state))
+	for address, account := range state {
+		if address == t.rules.sender || t.rules.isTransparent(address) {
+			filtered[address] = account
+			continue
+		}
+		readable := &prestateAccount{Code: account.Code}
+		for key, value := range account.Storage {
+			if t.rules.whitelist.AllowedStorageSlots[hexutil.EncodeBig(key.Big())] {
+				if readable.Storage == nil {
+					readable.Storage = make(map[gethcommon.Hash]gethcommon.Hash)
+				}
+				readable.Storage[key] = value
+			}
+		}
+		filtered[address] = readable
+	}
+	return filtered
+}
@@ -4,145 +4,236 @@ package debugger
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
 
+	gethcore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/gethencoding"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/privacy"
 	"github.com/ten-protocol/go-ten/go/common/tracers"
-	"github.com/ten-protocol/go-ten/go/enclave/l2chain"
+	"github.com/ten-protocol/go-ten/go/enclave/components"
+	enclaveconfig "github.com/ten-protocol/go-ten/go/enclave/config"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/crypto"
+	"github.com/ten-protocol/go-ten/go/enclave/evm"
+	"github.com/ten-protocol/go-ten/go/enclave/gas"
 	"github.com/ten-protocol/go-ten/go/enclave/storage"
+	gethrpc "github.com/ten-protocol/go-ten/lib/gethfork/rpc"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 )
 
 const (
-// defaultTraceTimeout is the amount of time a single transaction can execute
-// by default before being forcefully aborted.
-// defaultTraceTimeout = 5 * time.Second
-
-// defaultTraceReexec is the number of blocks the tracer is willing to go back
-// and reexecute to produce missing historical state necessary to run a specific
-// trace.
-// defaultTraceReexec = uint64(128)
+	// defaultTraceTimeout is the amount of time a single transaction can execute
+	// by default before being forcefully aborted.
+	defaultTraceTimeout = 5 * time.Second
 )
 
+// ErrSyntheticTxNotTraceable - the synthetic transactions are created by the enclave, so they are not exposed to users
+var ErrSyntheticTxNotTraceable = errors.New("synthetic transactions are not traceable")
+
+// supportedTracers - the tracers whose results can be filtered to what the sender is allowed to see (see traceVisibility)
+var supportedTracers = map[string]bool{
+	"callTracer":     true,
+	"prestateTracer": true,
+	"4byteTracer":    true,
+	"noopTracer":     true,
+}
+
+// Debugger - re-executes the transactions of the stored batches with tracing enabled
 type Debugger struct {
-	chain       l2chain.ObscuroChain
-	storage     storage.Storage
-	chainConfig *params.ChainConfig
+	storage             storage.Storage
+	registry            components.BatchRegistry
+	gethEncodingService gethencoding.EncodingService
+	gasOracle           gas.Oracle
+	entropyService      *crypto.EvmEntropyService
+	config              *enclaveconfig.EnclaveConfig
+	chainConfig         *params.ChainConfig
+	logger              gethlog.Logger
 }
 
-func New(chain l2chain.ObscuroChain, storage storage.Storage, config *params.ChainConfig) *Debugger {
+func New(
+	storage storage.Storage,
+	registry components.BatchRegistry,
+	gethEncodingService gethencoding.EncodingService,
+	gasOracle gas.Oracle,
+	entropyService *crypto.EvmEntropyService,
+	config *enclaveconfig.EnclaveConfig,
+	chainConfig *params.ChainConfig,
+	logger gethlog.Logger,
+) *Debugger {
 	return &Debugger{
-		chain:       chain,
-		chainConfig: config,
-		storage:     storage,
+		storage:             storage,
+		registry:            registry,
+		gethEncodingService: gethEncodingService,
+		gasOracle:           gasOracle,
+		entropyService:      entropyService,
+		config:              config,
+		chainConfig:         chainConfig,
+		logger:              logger,
 	}
 }
 
-func (d *Debugger) DebugTraceTransaction(context.Context, gethcommon.Hash, *tracers.TraceConfig) (json.RawMessage, error) {
-	return nil, nil
-	//_, blockHash, blockNumber, index, err := d.storage.GetTransaction(txHash)
-	//if err != nil {
-	//	return nil, err
-	//}
-	//// It shouldn't happen in practice.
-	//if blockNumber == 0 {
-	//	return nil, errors.New("genesis is not traceable")
-	//}
-	//reexec := defaultTraceReexec
-	//if config != nil && config.Reexec != nil {
-	//	reexec = *config.Reexec
-	//}
-	//batch, err := d.storage.FetchBatch(blockHash)
-	//if err != nil {
-	//	return nil, err
-	//}
-
-	//msg, vmctx, statedb, err := d.chain.GetChainStateAtTransaction(batch, int(index), reexec)
-	//if err != nil {
-	//	return nil, err
-	//}
-	//
-	//txctx := &gethtracers.Context{
-	//	BlockHash: blockHash,
-	//	TxIndex:   int(index),
-	//	TxHash:    txHash,
-	//}
-	//return d.traceTx(ctx, msg, txctx, vmctx, statedb, config)
+// DebugTraceTransaction - re-executes the batch containing the transaction up to the transaction, and returns the
+// result of the tracer selected in the config. The default is the struct logger.
+func (d *Debugger) DebugTraceTransaction(ctx context.Context, txHash gethcommon.Hash, config *tracers.TraceConfig) (json.RawMessage, error) {
+	_, batchHash, _, index, sender, err := d.storage.GetTransaction(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve transaction. Cause: %w", err)
+	}
+	batch, err := d.storage.FetchBatch(ctx, batchHash)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve batch %s. Cause: %w", batchHash, err)
+	}
+	// the synthetic transactions are executed after the transactions included in the batch
+	if index >= uint64(len(batch.Transactions)) || batch.Transactions[index].Hash() != txHash {
+		return nil, ErrSyntheticTxNotTraceable
+	}
+
+	txctx := &tracers.Context{
+		BlockHash:   batchHash,
+		BlockNumber: batch.Number(),
+		TxIndex:     int(index),
+		TxHash:      txHash,
+	}
+	rules := &traceVisibility{
+		sender:        sender,
+		isTransparent: d.transparentContracts(ctx),
+		whitelist:     privacy.NewWhitelist(),
+	}
+	return d.traceTx(ctx, batch, txctx, config, rules)
+}
+
+// transparentContracts returns whether the contracts are transparent, looking each of them up once
+func (d *Debugger) transparentContracts(ctx context.Context) func(gethcommon.Address) bool {
+	transparent := make(map[gethcommon.Address]bool)
+	return func(address gethcommon.Address) bool {
+		if isTransparent, found := transparent[address]; found {
+			return isTransparent
+		}
+		contract, err := d.storage.ReadContract(ctx, address)
+		if err != nil && !errors.Is(err, errutil.ErrNotFound) {
+			// the contract is hidden when its visibility can't be read
+			d.logger.Warn("Could not read the contract visibility for the trace", "address", address, log.ErrKey, err)
+		}
+		transparent[address] = contract != nil && contract.IsTransparent()
+		return transparent[address]
+	}
 }
 
 // traceTx configures a new tracer according to the provided configuration, and
-// executes the given message in the provided environment. The return value will
-// be tracer dependent.
-//
-//func (d *Debugger) traceTx(ctx context.Context, message *core.Message, txctx *gethtracers.Context, vmctx vm.BlockContext, statedb *state.StateDB, config *tracers.TraceConfig) (json.RawMessage, error) {
-//	// Assemble the structured logger or the JavaScript tracer
-//	var (
-//		tracer    vm.EVMLogger
-//		err       error
-//		txContext = core.NewEVMTxContext(message)
-//	)
-//	switch {
-//	case config == nil:
-//		tracer = logger.NewStructLogger(nil)
-//	case config.Tracer != nil:
-//		// Define a meaningful timeout of a single transaction trace
-//		timeout := defaultTraceTimeout
-//		if config.Timeout != nil {
-//			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
-//				return nil, err
-//			}
-//		}
-//		//if t, err := tracers.New(*config.Tracer, (*tracers.Context)(txctx)); err != nil {
-//		//	return nil, err
-//		//} else {
-//		//	deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
-//		//	go func() {
-//		//		<-deadlineCtx.Done()
-//		//		if errors.Is(deadlineCtx.Err(), context.DeadlineExceeded) {
-//		//			t.Stop(errors.New("execution timeout"))
-//		//		}
-//		//	}()
-//		//	defer cancel()
-//		//	tracer = t
-//		//}
-//	default:
-//		tracer = logger.NewStructLogger(config.Config)
-//	}
-//	// Run the transaction with tracing enabled.
-//	vmenv := vm.NewEVM(vmctx, txContext, statedb, d.chainConfig, vm.Config{Debug: true, Tracer: tracer, NoBaseFee: true})
-//
-//	// Call Prepare to clear out the statedb access list
-//	statedb.Prepare(txctx.TxHash, txctx.TxIndex)
-//
-//	result, err := core.ApplyMessage(vmenv, message, new(core.GasPool).AddGas(message.Gas()))
-//	if err != nil {
-//		return nil, fmt.Errorf("tracing failed: %w", err)
-//	}
-//
-//	// Depending on the tracer type, format and return the output.
-//	switch tracer := tracer.(type) {
-//	case *logger.StructLogger:
-//		// If the result contains a revert reason, return it.
-//		returnVal := fmt.Sprintf("%x", result.Return())
-//		if len(result.Revert()) > 0 {
-//			returnVal = fmt.Sprintf("%x", result.Revert())
-//		}
-//		exec := &gethapi.ExecutionResult{
-//			Gas:         result.UsedGas,
-//			Failed:      result.Failed(),
-//			ReturnValue: returnVal,
-//			StructLogs:  gethapi.FormatLogs(tracer.StructLogs()),
-//		}
-//		jsonRaw, err := json.Marshal(exec)
-//		if err != nil {
-//			return nil, err
-//		}
-//		return jsonRaw, nil
-//
-//	case tracers.Tracer:
-//		return tracer.GetResult()
-//
-//	default:
-//		panic(fmt.Sprintf("bad tracer type %T", tracer))
-//	}
-//}
+// executes the transaction on top of the state of the parent batch, after the preceding transactions of the batch.
+// The return value will be tracer dependent.
+func (d *Debugger) traceTx(ctx context.Context, batch *core.Batch, txctx *tracers.Context, config *tracers.TraceConfig, rules *traceVisibility) (json.RawMessage, error) {
+	timeout := defaultTraceTimeout
+	if config == nil {
+		config = &tracers.TraceConfig{}
+	}
+	tracer, err := newTracer(txctx, config, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	// Define a meaningful timeout of a single transaction trace
+	if config.Timeout != nil {
+		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+			return nil, err
+		}
+	}
+	deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
+	go func() {
+		<-deadlineCtx.Done()
+		if errors.Is(deadlineCtx.Err(), context.DeadlineExceeded) {
+			tracer.Stop(errors.New("execution timeout"))
+		}
+	}()
+	defer cancel()
+
+	stateDB, err := d.registry.GetBatchState(ctx, gethrpc.BlockNumberOrHash{BlockHash: &batch.Header.ParentHash})
+	if err != nil {
+		return nil, fmt.Errorf("could not create stateDB. Cause: %w", err)
+	}
+	ethHeader, err := d.gethEncodingService.CreateEthHeaderForBatch(ctx, batch.Header)
+	if err != nil {
+		return nil, fmt.Errorf("could not create eth header for batch. Cause: %w", err)
+	}
+	l1Block, err := d.storage.FetchBlock(ctx, batch.Header.L1Proof)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve block %s for batch. Cause: %w", batch.Header.L1Proof, err)
+	}
+	chain := evm.NewTenChainContext(d.storage, d.gethEncodingService, *d.config, d.logger)
+
+	// the transactions are executed with the same conventions as the batch executor
+	usedGas := uint64(0)
+	gp := gethcore.GasPool(d.config.GasBatchExecutionLimit)
+	for i := 0; i <= txctx.TxIndex; i++ {
+		tx := batch.Transactions[i]
+		cost, err := d.gasOracle.EstimateL1StorageGasCost(ctx, tx, l1Block)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get gas cost for tx. Cause: %w", err)
+		}
+		header := *ethHeader
+		header.MixDigest = d.entropyService.TxEntropy(ethHeader.MixDigest.Bytes(), i)
+
+		vmCfg := vm.Config{}
+		if i == txctx.TxIndex {
+			vmCfg.Tracer = tracer.Hooks
+		}
+		pricedTx := &common.L2PricedTransaction{Tx: tx, PublishingCost: cost}
+		result := evm.ExecuteTransaction(pricedTx, stateDB, &header, chain, d.chainConfig, &gp, &usedGas, vmCfg, i, d.logger)
+		if i == txctx.TxIndex && result.Err != nil {
+			return nil, fmt.Errorf("tracing failed: %w", result.Err)
+		}
+	}
+	return tracer.GetResult()
+}
+
+// newTracer returns the tracer selected in the config, wrapped to only return what the sender is allowed to see. The
+// default is the struct logger.
+func newTracer(txctx *tracers.Context, config *tracers.TraceConfig, rules *traceVisibility) (*tracers.Tracer, error) {
+	if config.Tracer != nil {
+		if !supportedTracers[*config.Tracer] {
+			return nil, fmt.Errorf("tracer %s is not supported", *config.Tracer)
+		}
+		tracer, err := tracers.DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig)
+		if err != nil {
+			return nil, err
+		}
+		private := newPrivateTracer(tracer, rules)
+		if *config.Tracer == "prestateTracer" {
+			var prestateConfig struct {
+				DiffMode bool `json:"diffMode"`
+			}
+			if config.TracerConfig != nil {
+				if err := json.Unmarshal(config.TracerConfig, &prestateConfig); err != nil {
+					return nil, err
+				}
+			}
+			private.isPrestate = true
+			private.diffMode = prestateConfig.DiffMode
+		}
+		return private.tracer(), nil
+	}
+
+	loggerConfig := logger.Config{}
+	if config.Config != nil {
+		loggerConfig = *config.Config
+	}
+	loggerConfig.DisableStorage = true
+	structLogger := logger.NewStructLogger(&loggerConfig)
+	private := newPrivateTracer(&tracers.Tracer{
+		Hooks:     structLogger.Hooks(),
+		GetResult: structLogger.GetResult,
+		Stop:      structLogger.Stop,
+	}, rules)
+	private.structLogger = structLogger
+	return private.tracer(), nil
+}
@@ -0,0 +1,224 @@
+package debugger
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/privacy"
+	"github.com/ten-protocol/go-ten/go/common/tracers"
+
+	_ "github.com/ten-protocol/go-ten/go/common/tracers/native" // make sure the tracers are loaded
+)
+
+var (
+	traceSender        = gethcommon.HexToAddress("0x5e")
+	transparentAddress = gethcommon.HexToAddress("0xaa01") // the contract called by the transaction
+	privateAddress     = gethcommon.HexToAddress("0xaa02") // a private contract called by it
+	forwardedAddress   = gethcommon.HexToAddress("0xaa03") // a transparent contract called by the private one
+
+	secretSlot      = gethcommon.BigToHash(big.NewInt(7))
+	secret          = gethcommon.BigToHash(big.NewInt(0x1234567890))
+	whitelistedSlot = gethcommon.BigToHash(new(big.Int).Sub(crypto.Keccak256Hash([]byte("eip1967.proxy.implementation")).Big(), big.NewInt(1)))
+	selector        = []byte{0xaa, 0xbb, 0xcc, 0xdd}
+)
+
+// the secret as it appears in the stack and memory of the struct logs
+const secretHex = "1234567890"
+
+// newTraceState returns the state of the traced transaction:
+// - the transparent contract calls the private contract, then copies and loads the value it returned
+// - the private contract loads the secret (and a whitelisted slot), passes it on to a transparent contract, updates both
+// slots and returns the secret
+// - the other transparent contract loads the secret from its input
+func newTraceState(t *testing.T) *state.StateDB {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+
+	transparentCode := []byte{byte(vm.PUSH4)}
+	transparentCode = append(transparentCode, selector...)
+	transparentCode = append(transparentCode,
+		byte(vm.PUSH1), 0xe0, byte(vm.SHL), byte(vm.PUSH1), 0x00, byte(vm.MSTORE),
+		// call(gas, private, 0, 0, 4, 0, 0)
+		byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x04, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00,
+		byte(vm.PUSH20))
+	transparentCode = append(transparentCode, privateAddress.Bytes()...)
+	transparentCode = append(transparentCode, byte(vm.GAS), byte(vm.CALL), byte(vm.POP),
+		// returndatacopy(0x40, 0, 0x20)
+		byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x40, byte(vm.RETURNDATACOPY),
+		byte(vm.PUSH1), 0x40, byte(vm.MLOAD), byte(vm.POP), byte(vm.STOP))
+
+	privateCode := []byte{byte(vm.PUSH32)}
+	privateCode = append(privateCode, whitelistedSlot.Bytes()...)
+	privateCode = append(privateCode, byte(vm.SLOAD), byte(vm.POP),
+		byte(vm.PUSH1), byte(secretSlot.Big().Uint64()), byte(vm.SLOAD), byte(vm.PUSH1), 0x00, byte(vm.MSTORE),
+		// call(gas, forwarded, 0, 0, 0x20, 0, 0)
+		byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00,
+		byte(vm.PUSH20))
+	privateCode = append(privateCode, forwardedAddress.Bytes()...)
+	privateCode = append(privateCode, byte(vm.GAS), byte(vm.CALL), byte(vm.POP),
+		// both slots are updated, for their values to be part of the diffs
+		byte(vm.PUSH1), 0x99, byte(vm.PUSH1), byte(secretSlot.Big().Uint64()), byte(vm.SSTORE),
+		byte(vm.PUSH1), 0x03, byte(vm.PUSH32))
+	privateCode = append(privateCode, whitelistedSlot.Bytes()...)
+	privateCode = append(privateCode, byte(vm.SSTORE),
+		byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x00, byte(vm.RETURN))
+
+	forwardedCode := []byte{byte(vm.PUSH1), 0x00, byte(vm.CALLDATALOAD), byte(vm.POP), byte(vm.STOP)}
+
+	statedb.SetCode(transparentAddress, transparentCode)
+	statedb.SetState(transparentAddress, gethcommon.BigToHash(big.NewInt(1)), gethcommon.BigToHash(big.NewInt(1)))
+	statedb.SetCode(privateAddress, privateCode)
+	statedb.SetState(privateAddress, secretSlot, secret)
+	statedb.SetState(privateAddress, whitelistedSlot, gethcommon.BigToHash(big.NewInt(2)))
+	statedb.SetNonce(privateAddress, 3)
+	statedb.SetCode(forwardedAddress, forwardedCode)
+	return statedb
+}
+
+// traceCall traces the call to the transparent contract with the tracer selected in the config
+func traceCall(t *testing.T, config *tracers.TraceConfig) json.RawMessage {
+	rules := &traceVisibility{
+		sender: traceSender,
+		isTransparent: func(address gethcommon.Address) bool {
+			return address == transparentAddress || address == forwardedAddress
+		},
+		whitelist: privacy.NewWhitelist(),
+	}
+	tracer, err := newTracer(&tracers.Context{TxHash: gethcommon.Hash{1}}, config, rules)
+	require.NoError(t, err)
+
+	cfg := &runtime.Config{Origin: traceSender, State: newTraceState(t), EVMConfig: vm.Config{Tracer: tracer.Hooks}}
+	_, gasLeft, err := runtime.Call(transparentAddress, nil, cfg)
+	require.NoError(t, err)
+	// the runtime doesn't end the transaction
+	if tracer.OnTxEnd != nil {
+		tracer.OnTxEnd(&types.Receipt{GasUsed: cfg.GasLimit - gasLeft}, nil)
+	}
+	result, err := tracer.GetResult()
+	require.NoError(t, err)
+	return result
+}
+
+func namedTracer(name string, tracerConfig string) *tracers.TraceConfig {
+	config := &tracers.TraceConfig{Tracer: &name}
+	if tracerConfig != "" {
+		config.TracerConfig = json.RawMessage(tracerConfig)
+	}
+	return config
+}
+
+func TestStructLoggerHidesPrivateExecution(t *testing.T) {
+	// the struct logger does not return the storage, even when requested
+	result := traceCall(t, &tracers.TraceConfig{Config: &logger.Config{DisableStorage: false, EnableMemory: true, EnableReturnData: true}})
+	require.NotContains(t, string(result), secretHex)
+
+	var trace logger.ExecutionResult
+	require.NoError(t, json.Unmarshal(result, &trace))
+	require.NotEmpty(t, trace.StructLogs)
+
+	returnedToCaller := false
+	for _, structLog := range trace.StructLogs {
+		require.Nil(t, structLog.Storage)
+		switch {
+		case structLog.Depth == 1 && structLog.Op == vm.CALL.String():
+			// the steps of the transparent contract before the private contract returns are visible
+			require.NotNil(t, structLog.Stack)
+			require.NotNil(t, structLog.Memory)
+		case structLog.Depth > 1 || returnedToCaller || structLog.Op == vm.RETURNDATACOPY.String():
+			// the steps of the private contract, of the contract it calls and of the caller once it received the value
+			// returned by the private contract are hidden
+			returnedToCaller = structLog.Depth == 1 || returnedToCaller
+			require.Nil(t, structLog.Stack)
+			require.Nil(t, structLog.Memory)
+			require.Empty(t, structLog.ReturnData)
+		}
+	}
+	require.True(t, returnedToCaller)
+}
+
+func TestCallTracerHidesInternalInputsAndOutputs(t *testing.T) {
+	result := traceCall(t, namedTracer("callTracer", ""))
+	require.NotContains(t, string(result), secretHex)
+
+	type callFrame struct {
+		To     gethcommon.Address `json:"to"`
+		Input  hexutil.Bytes      `json:"input"`
+		Output hexutil.Bytes      `json:"output"`
+		Calls  []callFrame        `json:"calls"`
+	}
+	var call callFrame
+	require.NoError(t, json.Unmarshal(result, &call))
+	require.Equal(t, transparentAddress, call.To)
+	require.Len(t, call.Calls, 1)
+
+	// the transparent contract's call to the private contract is visible, what it returns is not
+	privateCall := call.Calls[0]
+	require.Equal(t, privateAddress, privateCall.To)
+	require.Equal(t, hexutil.Bytes(selector), privateCall.Input)
+	require.Empty(t, privateCall.Output)
+
+	// the private contract's call is visible, the input it passes on is not
+	require.Len(t, privateCall.Calls, 1)
+	require.Equal(t, forwardedAddress, privateCall.Calls[0].To)
+	require.Empty(t, privateCall.Calls[0].Input)
+}
+
+func Test4ByteTracerHidesPrivateCalls(t *testing.T) {
+	result := traceCall(t, namedTracer("4byteTracer", ""))
+
+	// only the selector called by the transparent contract is returned, not the input passed on by the private one
+	var selectors map[string]int
+	require.NoError(t, json.Unmarshal(result, &selectors))
+	require.Equal(t, map[string]int{"0xaabbccdd-0": 1}, selectors)
+}
+
+func TestPrestateTracerReturnsReadableState(t *testing.T) {
+	for _, tracerConfig := range []string{"", `{"diffMode": true}`} {
+		result := traceCall(t, namedTracer("prestateTracer", tracerConfig))
+		require.NotContains(t, strings.ToLower(string(result)), secretHex)
+
+		var state map[gethcommon.Address]*prestateAccount
+		if tracerConfig == "" {
+			require.NoError(t, json.Unmarshal(result, &state))
+		} else {
+			var diff struct {
+				Pre map[gethcommon.Address]*prestateAccount `json:"pre"`
+			}
+			require.NoError(t, json.Unmarshal(result, &diff))
+			state = diff.Pre
+		}
+
+		// the private contract's code and whitelisted slot are returned, not its other slots, balance or nonce
+		private := state[privateAddress]
+		require.NotNil(t, private)
+		require.Zero(t, private.Nonce)
+		require.Nil(t, private.Balance)
+		require.Equal(t, map[gethcommon.Hash]gethcommon.Hash{whitelistedSlot: gethcommon.BigToHash(big.NewInt(2))}, private.Storage)
+
+		if tracerConfig == "" {
+			// the transparent contract is returned as it is
+			require.NotEmpty(t, private.Code)
+			require.NotNil(t, state[transparentAddress])
+			require.NotEmpty(t, state[transparentAddress].Code)
+			require.NotNil(t, state[traceSender])
+		}
+	}
+}
+
+func TestUnsupportedTracersAreRefused(t *testing.T) {
+	name := "muxTracer"
+	_, err := newTracer(&tracers.Context{}, &tracers.TraceConfig{Tracer: &name}, &traceVisibility{})
+	require.Error(t, err)
+}
@@ -28,7 +28,6 @@ import (
 	"github.com/ten-protocol/go-ten/go/common/gethencoding"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
-	"github.com/ten-protocol/go-ten/go/common/tracers"
 	"github.com/ten-protocol/go-ten/go/enclave/crosschain"
 	"github.com/ten-protocol/go-ten/go/enclave/events"
 
@@ -99,7 +98,7 @@ func NewEnclave(config *enclaveconfig.EnclaveConfig, genesis *genesis.Genesis, m
 		logger.Crit("unable to init eth tx pool", log.ErrKey, err)
 	}
 
-	gasOracle := gas.NewGasOracle()
+	gasOracle := gas.NewGasOracle(storage, config, logger)
 	blockProcessor := components.NewBlockProcessor(storage, crossChainProcessors, gasOracle, logger)
 	dataCompressionService := compression.NewBrotliDataCompressionService()
 	batchExecutor := components.NewBatchExecutor(storage, batchRegistry, *config, gethEncodingService, crossChainProcessors, genesis, gasOracle, chainConfig, scb, evmEntropyService, mempool, dataCompressionService, logger)
@@ -171,10 +170,6 @@ func (e *enclaveImpl) RPCEncryptionKey(ctx context.Context) ([]byte, common.Syst
 	return e.initAPI.RPCEncryptionKey(ctx)
 }
 
-func (e *enclaveImpl) DebugTraceTransaction(ctx context.Context, txHash gethcommon.Hash, config *tracers.TraceConfig) (json.RawMessage, common.SystemError) {
-	return e.rpcAPI.DebugTraceTransaction(ctx, txHash, config)
-}
-
 func (e *enclaveImpl) GetTotalContractCount(ctx context.Context) (*big.Int, common.SystemError) {
 	if systemError := checkStopping(e.stopControl); systemError != nil {
 		return nil, systemError
@@ -182,6 +177,13 @@ func (e *enclaveImpl) GetTotalContractCount(ctx context.Context) (*big.Int, comm
 	return e.rpcAPI.GetTotalContractCount(ctx)
 }
 
+func (e *enclaveImpl) FeeHistoryRewards(ctx context.Context, fromHeight uint64, toHeight uint64, rewardPercentiles []float64) (map[uint64][]*big.Int, common.SystemError) {
+	if systemError := checkStopping(e.stopControl); systemError != nil {
+		return nil, systemError
+	}
+	return e.rpcAPI.FeeHistoryRewards(ctx, fromHeight, toHeight, rewardPercentiles)
+}
+
 func (e *enclaveImpl) EnclavePublicConfig(ctx context.Context) (*common.EnclavePublicConfig, common.SystemError) {
 	if systemError := checkStopping(e.stopControl); systemError != nil {
 		return nil, systemError
@@ -80,7 +80,7 @@ func (e *enclaveInitService) Attestation(ctx context.Context) (*common.Attestati
 	if e.enclaveKeyService.PublicKey() == nil {
 		return nil, responses.ToInternalError(fmt.Errorf("public key not initialized, we can't produce the attestation report"))
 	}
-	report, err := e.attestationProvider.CreateAttestationReport(ctx, e.config.HostAddress)
+	report, err := e.attestationProvider.CreateAttestationReport(ctx, e.config.HostAddress, e.config.HostID)
 	if err != nil {
 		return nil, responses.ToInternalError(fmt.Errorf("could not produce remote report. Cause %w", err))
 	}
@@ -2,8 +2,6 @@ package enclave
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"math/big"
 
@@ -18,8 +16,6 @@ import (
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ten-protocol/go-ten/go/common"
-	"github.com/ten-protocol/go-ten/go/common/syserr"
-	"github.com/ten-protocol/go-ten/go/common/tracers"
 	"github.com/ten-protocol/go-ten/go/enclave/components"
 	enclaveconfig "github.com/ten-protocol/go-ten/go/enclave/config"
 	"github.com/ten-protocol/go-ten/go/enclave/crosschain"
@@ -37,7 +33,6 @@ type enclaveRPCService struct {
 	registry             components.BatchRegistry
 	subscriptionManager  *events.SubscriptionManager
 	config               *enclaveconfig.EnclaveConfig
-	debugger             *debugger.Debugger
 	storage              storage.Storage
 	crossChainProcessors *crosschain.Processors
 	scb                  system.SystemContractCallbacks
@@ -57,16 +52,15 @@ func NewEnclaveRPCAPI(config *enclaveconfig.EnclaveConfig, storage storage.Stora
 		batchRegistry,
 		config.GasLocalExecutionCapFlag,
 	)
-	debug := debugger.New(chain, storage, chainConfig)
+	debug := debugger.New(storage, batchRegistry, gethEncodingService, gasOracle, crypto.NewEvmEntropyService(sharedSecretService, logger), config, chainConfig, logger)
 
-	rpcEncryptionManager := rpc.NewEncryptionManager(storage, cachingService, batchRegistry, mempool, crossChainProcessors, config, gasOracle, storage, blockProcessor, chain, rpcKeyService, logger)
+	rpcEncryptionManager := rpc.NewEncryptionManager(storage, cachingService, batchRegistry, mempool, crossChainProcessors, config, gasOracle, storage, blockProcessor, chain, debug, rpcKeyService, logger)
 
 	return &enclaveRPCService{
 		rpcEncryptionManager: rpcEncryptionManager,
 		registry:             batchRegistry,
 		subscriptionManager:  subscriptionManager,
 		config:               config,
-		debugger:             debug,
 		storage:              storage,
 		crossChainProcessors: crossChainProcessors,
 		scb:                  scb,
@@ -104,26 +98,20 @@ func (e *enclaveRPCService) Unsubscribe(id gethrpc.ID) common.SystemError {
 	return nil
 }
 
-func (e *enclaveRPCService) DebugTraceTransaction(ctx context.Context, txHash gethcommon.Hash, config *tracers.TraceConfig) (json.RawMessage, common.SystemError) {
-	// ensure the debug namespace is enabled
-	if !e.config.DebugNamespaceEnabled {
-		return nil, responses.ToInternalError(fmt.Errorf("debug namespace not enabled"))
-	}
+func (e *enclaveRPCService) GetTotalContractCount(ctx context.Context) (*big.Int, common.SystemError) {
+	return e.storage.GetContractCount(ctx)
+}
 
-	jsonMsg, err := e.debugger.DebugTraceTransaction(ctx, txHash, config)
+func (e *enclaveRPCService) FeeHistoryRewards(ctx context.Context, fromHeight uint64, toHeight uint64, rewardPercentiles []float64) (map[uint64][]*big.Int, common.SystemError) {
+	batches, err := e.storage.FetchCanonicalBatchTxFees(ctx, fromHeight, toHeight)
 	if err != nil {
-		if errors.Is(err, syserr.InternalError{}) {
-			return nil, responses.ToInternalError(err)
-		}
-		// TODO *Pedro* MOVE THIS TO Enclave Response
-		return json.RawMessage(err.Error()), nil
+		return nil, responses.ToInternalError(fmt.Errorf("could not read the fees of the batches. Cause: %w", err))
 	}
-
-	return jsonMsg, nil
-}
-
-func (e *enclaveRPCService) GetTotalContractCount(ctx context.Context) (*big.Int, common.SystemError) {
-	return e.storage.GetContractCount(ctx)
+	rewards := make(map[uint64][]*big.Int, len(batches))
+	for _, batch := range batches {
+		rewards[batch.Header.Number.Uint64()] = gas.RewardPercentiles(batch.Header.BaseFee, batch.Txs, rewardPercentiles)
+	}
+	return rewards, nil
 }
 
 func (e *enclaveRPCService) EnclavePublicConfig(context.Context) (*common.EnclavePublicConfig, common.SystemError) {
@@ -0,0 +1,159 @@
+package events
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common"
+	gethrpc "github.com/ten-protocol/go-ten/lib/gethfork/rpc"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// maxFilterTopics - the event signature plus the 3 indexed topics
+const maxFilterTopics = 4
+
+type subscriptionSet map[gethrpc.ID]*logSubscription
+
+// subscriptionIndex - indexes the subscriptions by the most selective criteria of their filter, so that the candidate
+// subscriptions for a log can be found without iterating through all the subscriptions.
+// Each subscription is indexed exactly once:
+//   - by each of its addresses, if the filter contains addresses
+//   - otherwise, by each value of its first non-empty topic position
+//   - otherwise, as a wildcard subscription which is a candidate for every log
+//
+// A log has a single address and a single value per topic position, so a subscription can't be returned twice.
+// The subscriptions are also indexed by the account that created them, because the private logs are visible only
+// to a few accounts.
+type subscriptionIndex struct {
+	byAddress map[gethcommon.Address]subscriptionSet
+	byTopic   [maxFilterTopics]map[gethcommon.Hash]subscriptionSet
+	wildcard  subscriptionSet
+	byAccount map[gethcommon.Address]subscriptionSet
+}
+
+func newSubscriptionIndex() *subscriptionIndex {
+	idx := &subscriptionIndex{
+		byAddress: map[gethcommon.Address]subscriptionSet{},
+		wildcard:  subscriptionSet{},
+		byAccount: map[gethcommon.Address]subscriptionSet{},
+	}
+	for i := range idx.byTopic {
+		idx.byTopic[i] = map[gethcommon.Hash]subscriptionSet{}
+	}
+	return idx
+}
+
+func (idx *subscriptionIndex) add(id gethrpc.ID, sub *logSubscription) {
+	account := *sub.ViewingKeyEncryptor.AccountAddress
+	if _, found := idx.byAccount[account]; !found {
+		idx.byAccount[account] = subscriptionSet{}
+	}
+	idx.byAccount[account][id] = sub
+
+	idx.visit(sub.Subscription.Filter, func(m map[gethcommon.Hash]subscriptionSet, key gethcommon.Hash) {
+		set, found := m[key]
+		if !found {
+			set = subscriptionSet{}
+			m[key] = set
+		}
+		set[id] = sub
+	}, func(addr gethcommon.Address) {
+		set, found := idx.byAddress[addr]
+		if !found {
+			set = subscriptionSet{}
+			idx.byAddress[addr] = set
+		}
+		set[id] = sub
+	}, func() {
+		idx.wildcard[id] = sub
+	})
+}
+
+func (idx *subscriptionIndex) remove(id gethrpc.ID, sub *logSubscription) {
+	account := *sub.ViewingKeyEncryptor.AccountAddress
+	delete(idx.byAccount[account], id)
+	if len(idx.byAccount[account]) == 0 {
+		delete(idx.byAccount, account)
+	}
+
+	idx.visit(sub.Subscription.Filter, func(m map[gethcommon.Hash]subscriptionSet, key gethcommon.Hash) {
+		delete(m[key], id)
+		if len(m[key]) == 0 {
+			delete(m, key)
+		}
+	}, func(addr gethcommon.Address) {
+		delete(idx.byAddress[addr], id)
+		if len(idx.byAddress[addr]) == 0 {
+			delete(idx.byAddress, addr)
+		}
+	}, func() {
+		delete(idx.wildcard, id)
+	})
+}
+
+// visit calls the function corresponding to the index entries of the filter
+func (idx *subscriptionIndex) visit(filter *common.FilterCriteriaJSON, onTopic func(map[gethcommon.Hash]subscriptionSet, gethcommon.Hash), onAddress func(gethcommon.Address), onWildcard func()) {
+	if filter == nil {
+		onWildcard()
+		return
+	}
+	if len(filter.Addresses) > 0 {
+		for _, addr := range filter.Addresses {
+			onAddress(addr)
+		}
+		return
+	}
+	for i, values := range filter.Topics {
+		if len(values) > 0 {
+			for _, v := range values {
+				onTopic(idx.byTopic[i], v)
+			}
+			return
+		}
+	}
+	onWildcard()
+}
+
+// candidates returns the subscriptions that might match the log, based on the index
+func (idx *subscriptionIndex) candidates(l *types.Log) []subscriptionSet {
+	res := make([]subscriptionSet, 0)
+	if set, found := idx.byAddress[l.Address]; found {
+		res = append(res, set)
+	}
+	for i := 0; i < len(l.Topics) && i < maxFilterTopics; i++ {
+		if set, found := idx.byTopic[i][l.Topics[i]]; found {
+			res = append(res, set)
+		}
+	}
+	if len(idx.wildcard) > 0 {
+		res = append(res, idx.wildcard)
+	}
+	return res
+}
+
+// filterMatches - applies the same address and topic rules as the `FilterLogs` database query
+func filterMatches(filter *common.FilterCriteriaJSON, l *types.Log) bool {
+	if filter == nil {
+		return true
+	}
+	if len(filter.Addresses) > 0 && !contains(filter.Addresses, l.Address) {
+		return false
+	}
+	for i, values := range filter.Topics {
+		if len(values) == 0 {
+			continue
+		}
+		if i >= len(l.Topics) || !contains(values, l.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains[T comparable](values []T, v T) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -3,9 +3,14 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/log"
+
 	"github.com/ten-protocol/go-ten/go/enclave/components"
 
 	"github.com/ten-protocol/go-ten/go/enclave/vkhandler"
@@ -33,8 +38,9 @@ type SubscriptionManager struct {
 	registry components.BatchRegistry
 
 	subscriptions     map[gethrpc.ID]*logSubscription
+	index             *subscriptionIndex // the subscriptions indexed by their filter criteria
 	chainID           int64
-	subscriptionMutex *sync.RWMutex // the mutex guards the subscriptions/lastHead pair
+	subscriptionMutex *sync.RWMutex // the mutex guards the subscriptions/index pair
 
 	logger gethlog.Logger
 }
@@ -45,6 +51,7 @@ func NewSubscriptionManager(storage storage.Storage, registry components.BatchRe
 		registry: registry,
 
 		subscriptions:     map[gethrpc.ID]*logSubscription{},
+		index:             newSubscriptionIndex(),
 		chainID:           chainID,
 		subscriptionMutex: &sync.RWMutex{},
 		logger:            logger,
@@ -58,6 +65,9 @@ func (s *SubscriptionManager) AddSubscription(id gethrpc.ID, encodedSubscription
 	if err := json.Unmarshal(encodedSubscription, subscription); err != nil {
 		return fmt.Errorf("could not decode log subscription. Cause: %w", err)
 	}
+	if subscription.Filter != nil && len(subscription.Filter.Topics) > maxFilterTopics {
+		return fmt.Errorf("invalid filter. Too many topics")
+	}
 
 	// verify the viewing key
 	authenticateViewingKey, err := vkhandler.VerifyViewingKey(subscription.ViewingKey, s.chainID)
@@ -67,20 +77,32 @@ func (s *SubscriptionManager) AddSubscription(id gethrpc.ID, encodedSubscription
 
 	s.subscriptionMutex.Lock()
 	defer s.subscriptionMutex.Unlock()
-	s.subscriptions[id] = &logSubscription{
+	s.addSubscription(id, &logSubscription{
 		Subscription:        subscription,
 		ViewingKeyEncryptor: authenticateViewingKey,
-	}
+	})
 
 	return nil
 }
 
+// the caller must hold the lock
+func (s *SubscriptionManager) addSubscription(id gethrpc.ID, sub *logSubscription) {
+	if existing, found := s.subscriptions[id]; found {
+		s.index.remove(id, existing)
+	}
+	s.subscriptions[id] = sub
+	s.index.add(id, sub)
+}
+
 // RemoveSubscription removes the log subscription with the given ID from the enclave. If there is no subscription with
 // the given ID, nothing is deleted.
 func (s *SubscriptionManager) RemoveSubscription(id gethrpc.ID) {
 	s.subscriptionMutex.Lock()
 	defer s.subscriptionMutex.Unlock()
-	delete(s.subscriptions, id)
+	if existing, found := s.subscriptions[id]; found {
+		s.index.remove(id, existing)
+		delete(s.subscriptions, id)
+	}
 }
 
 // GetSubscribedLogsForBatch - Retrieves and encrypts the logs for the batch in live mode.
@@ -94,27 +116,166 @@ func (s *SubscriptionManager) GetSubscribedLogsForBatch(ctx context.Context, bat
 		return nil, nil
 	}
 
-	h := batch.Hash()
-	relevantLogsPerSubscription := map[gethrpc.ID][]*types.Log{}
-
 	if len(receipts) == 0 {
 		return nil, nil
 	}
 
-	for id, sub := range s.subscriptions {
-		relevantLogsForSub, err := s.storage.FilterLogs(ctx, sub.ViewingKeyEncryptor.AccountAddress, nil, nil, &h, sub.Subscription.Filter.Addresses, sub.Subscription.Filter.Topics)
-		if err != nil {
-			return nil, err
-		}
-		if len(relevantLogsForSub) > 0 {
-			relevantLogsPerSubscription[id] = relevantLogsForSub
-		}
+	relevantLogsPerSubscription, err := s.matchLogs(ctx, batch, receipts)
+	if err != nil {
+		return nil, err
 	}
 
 	// Encrypt the results
 	return s.encryptLogs(relevantLogsPerSubscription)
 }
 
+// matchLogs - iterates the logs of the batch once, and assigns each log to the subscriptions that match it and that
+// are allowed to view it. The visibility rules are evaluated at most once per log.
+// The caller must hold the lock.
+func (s *SubscriptionManager) matchLogs(ctx context.Context, batch *core.Batch, receipts types.Receipts) (map[gethrpc.ID][]*types.Log, error) {
+	relevantLogsPerSubscription := map[gethrpc.ID][]*types.Log{}
+	senders := &txSenders{batch: batch, chainID: s.chainID}
+
+	for _, receipt := range receipts {
+		for _, l := range receipt.Logs {
+			// logs without topics have no event type, so they are never stored or returned
+			if len(l.Topics) == 0 {
+				continue
+			}
+			candidates := s.index.candidates(l)
+			if len(candidates) == 0 {
+				continue
+			}
+			visibility, err := s.logVisibility(ctx, l, senders)
+			if err != nil {
+				return nil, err
+			}
+			// private logs are visible to a few accounts, so only their subscriptions have to be checked
+			if !visibility.public {
+				candidates = candidates[:0]
+				for _, account := range visibility.accounts {
+					if set, found := s.index.byAccount[account]; found {
+						candidates = append(candidates, set)
+					}
+				}
+			}
+			for _, set := range candidates {
+				for id, sub := range set {
+					if filterMatches(sub.Subscription.Filter, l) {
+						relevantLogsPerSubscription[id] = append(relevantLogsPerSubscription[id], l)
+					}
+				}
+			}
+		}
+	}
+	return relevantLogsPerSubscription, nil
+}
+
+// logVisibility - the accounts that can view a log
+type logVisibility struct {
+	public   bool
+	accounts []gethcommon.Address
+}
+
+func (v *logVisibility) addAccount(account gethcommon.Address) {
+	if !contains(v.accounts, account) {
+		v.accounts = append(v.accounts, account)
+	}
+}
+
+// logVisibility applies the same rules as the visibility condition of the `FilterLogs` database query.
+// Note that a topic is only relevant if it is an externally owned account. The accounts of the subscribers are
+// authenticated by signing the viewing key, so they can't be contracts.
+func (s *SubscriptionManager) logVisibility(ctx context.Context, l *types.Log, senders *txSenders) (*logVisibility, error) {
+	eventType, err := s.storage.ReadEventType(ctx, l.Address, l.Topics[0])
+	if errors.Is(err, errutil.ErrNotFound) {
+		// the event types are stored together with the batch, so this should not happen
+		s.logger.Warn("Event type not found for log", "address", l.Address, "event_sig", l.Topics[0], log.TxKey, l.TxHash)
+		return &logVisibility{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read event type. Cause: %w", err)
+	}
+
+	switch {
+	case eventType.IsPublic():
+		return &logVisibility{public: true}, nil
+
+	case eventType.AutoVisibility:
+		if eventType.AutoPublic != nil && *eventType.AutoPublic {
+			return &logVisibility{public: true}, nil
+		}
+		visibility := &logVisibility{}
+		for i := 1; i < len(l.Topics); i++ {
+			if addr := common.ExtractPotentialAddress(l.Topics[i]); addr != nil {
+				visibility.addAccount(*addr)
+			}
+		}
+		// the auto public flag is set when the event is first emitted, so a cached event type might not have it yet
+		if eventType.AutoPublic == nil {
+			visibility.public, err = s.noRelevantAddress(ctx, visibility.accounts)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return visibility, nil
+
+	default:
+		visibility := &logVisibility{}
+		for i := 1; i < len(l.Topics) && i < maxFilterTopics; i++ {
+			if !eventType.IsTopicRelevant(i) {
+				continue
+			}
+			if addr := common.ExtractPotentialAddress(l.Topics[i]); addr != nil {
+				visibility.addAccount(*addr)
+			}
+		}
+		if eventType.SenderCanView != nil && *eventType.SenderCanView {
+			if sender := senders.get(l.TxHash); sender != nil {
+				visibility.addAccount(*sender)
+			}
+		}
+		return visibility, nil
+	}
+}
+
+// noRelevantAddress - returns true when none of the addresses extracted from the topics is an externally owned account
+func (s *SubscriptionManager) noRelevantAddress(ctx context.Context, addresses []gethcommon.Address) (bool, error) {
+	for _, addr := range addresses {
+		_, err := s.storage.ReadContract(ctx, addr)
+		if errors.Is(err, errutil.ErrNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("could not read contract. Cause: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// txSenders - lazily recovers the senders of the batch transactions, which are only needed for the events
+// that are visible to the sender
+type txSenders struct {
+	batch   *core.Batch
+	chainID int64
+	senders map[gethcommon.Hash]*gethcommon.Address
+}
+
+func (ts *txSenders) get(txHash gethcommon.Hash) *gethcommon.Address {
+	if ts.senders == nil {
+		ts.senders = make(map[gethcommon.Hash]*gethcommon.Address, len(ts.batch.Transactions))
+		for _, tx := range ts.batch.Transactions {
+			sender, err := core.GetAuthenticatedSender(ts.chainID, tx)
+			if err != nil {
+				continue
+			}
+			ts.senders[tx.Hash()] = sender
+		}
+	}
+	// the synthetic transactions are not part of the batch, and are not sent by a user account
+	return ts.senders[txHash]
+}
+
 // Encrypts each log with the appropriate viewing key.
 func (s *SubscriptionManager) encryptLogs(logsByID map[gethrpc.ID][]*types.Log) (map[gethrpc.ID][]byte, error) {
 	encryptedLogsByID := map[gethrpc.ID][]byte{}
@@ -0,0 +1,299 @@
+package events
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	enclaveconfig "github.com/ten-protocol/go-ten/go/enclave/config"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+	"github.com/ten-protocol/go-ten/go/enclave/storage/enclavedb"
+	"github.com/ten-protocol/go-ten/go/enclave/storage/init/sqlite"
+	"github.com/ten-protocol/go-ten/go/enclave/vkhandler"
+	gethrpc "github.com/ten-protocol/go-ten/lib/gethfork/rpc"
+)
+
+const testChainID = 443
+
+var (
+	transferSig = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	publicSig   = crypto.Keccak256Hash([]byte("Public(uint256)"))
+	tokenAddr   = gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+)
+
+// eventTypeStorage - serves the event types from memory
+type eventTypeStorage struct {
+	storage.Storage
+	eventTypes map[gethcommon.Hash]*enclavedb.EventType
+}
+
+func (s *eventTypeStorage) ReadEventType(_ context.Context, _ gethcommon.Address, eventSignature gethcommon.Hash) (*enclavedb.EventType, error) {
+	et, found := s.eventTypes[eventSignature]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return et, nil
+}
+
+func (s *eventTypeStorage) ReadContract(_ context.Context, _ gethcommon.Address) (*enclavedb.Contract, error) {
+	return nil, errutil.ErrNotFound
+}
+
+func newTestSubscriptionManager() *SubscriptionManager {
+	t := true
+	contract := &enclavedb.Contract{Address: tokenAddr}
+	st := &eventTypeStorage{eventTypes: map[gethcommon.Hash]*enclavedb.EventType{
+		transferSig: {Contract: contract, EventSignature: transferSig, AutoVisibility: true, AutoPublic: new(bool)},
+		publicSig:   {Contract: contract, EventSignature: publicSig, AutoVisibility: true, AutoPublic: &t},
+	}}
+	return NewSubscriptionManager(st, nil, testChainID, log.New(log.EnclaveCmp, int(gethlog.LevelError), log.SysOut))
+}
+
+func testAccount(i int) gethcommon.Address {
+	return gethcommon.BytesToAddress(crypto.Keccak256(big.NewInt(int64(i)).Bytes()))
+}
+
+func subscribe(sm *SubscriptionManager, id gethrpc.ID, account gethcommon.Address, filter *common.FilterCriteriaJSON) {
+	sm.subscriptionMutex.Lock()
+	defer sm.subscriptionMutex.Unlock()
+	sm.addSubscription(id, &logSubscription{
+		Subscription:        &common.LogSubscription{Filter: filter},
+		ViewingKeyEncryptor: &vkhandler.AuthenticatedViewingKey{AccountAddress: &account},
+	})
+}
+
+func transferLog(from, to gethcommon.Address) *types.Log {
+	return &types.Log{
+		Address: tokenAddr,
+		Topics:  []gethcommon.Hash{transferSig, gethcommon.BytesToHash(from.Bytes()), gethcommon.BytesToHash(to.Bytes())},
+	}
+}
+
+func TestMatchLogs(t *testing.T) {
+	sm := newTestSubscriptionManager()
+	alice, bob, charlie := testAccount(1), testAccount(2), testAccount(3)
+
+	subscribe(sm, "all-alice", alice, &common.FilterCriteriaJSON{})
+	subscribe(sm, "token-bob", bob, &common.FilterCriteriaJSON{Addresses: []gethcommon.Address{tokenAddr}})
+	subscribe(sm, "transfer-charlie", charlie, &common.FilterCriteriaJSON{Topics: [][]gethcommon.Hash{{transferSig}}})
+	subscribe(sm, "public-charlie", charlie, &common.FilterCriteriaJSON{Topics: [][]gethcommon.Hash{{publicSig}}})
+	subscribe(sm, "to-bob", bob, &common.FilterCriteriaJSON{Topics: [][]gethcommon.Hash{nil, nil, {gethcommon.BytesToHash(bob.Bytes())}}})
+	subscribe(sm, "other-contract", alice, &common.FilterCriteriaJSON{Addresses: []gethcommon.Address{testAccount(100)}})
+
+	aliceToBob := transferLog(alice, bob)
+	publicLog := &types.Log{Address: tokenAddr, Topics: []gethcommon.Hash{publicSig}}
+	receipts := types.Receipts{{Logs: []*types.Log{aliceToBob}}, {Logs: []*types.Log{publicLog}}}
+
+	res, err := sm.matchLogs(context.Background(), &core.Batch{}, receipts)
+	require.NoError(t, err)
+	require.Equal(t, map[gethrpc.ID][]*types.Log{
+		"all-alice":      {aliceToBob, publicLog},
+		"token-bob":      {aliceToBob, publicLog},
+		"public-charlie": {publicLog},
+		"to-bob":         {aliceToBob},
+	}, res)
+
+	// once removed, the subscriptions are no longer matched
+	sm.RemoveSubscription("all-alice")
+	sm.RemoveSubscription("token-bob")
+	res, err = sm.matchLogs(context.Background(), &core.Batch{}, receipts)
+	require.NoError(t, err)
+	require.Equal(t, map[gethrpc.ID][]*types.Log{
+		"public-charlie": {publicLog},
+		"to-bob":         {aliceToBob},
+	}, res)
+	require.NotContains(t, sm.index.byAddress, tokenAddr)
+	require.Empty(t, sm.index.wildcard)
+}
+
+func TestSenderCanViewLog(t *testing.T) {
+	sm := newTestSubscriptionManager()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(testChainID)), &types.LegacyTx{Gas: 21_000, GasPrice: big.NewInt(1)})
+	require.NoError(t, err)
+
+	t1 := true
+	sm.storage.(*eventTypeStorage).eventTypes[transferSig] = &enclavedb.EventType{EventSignature: transferSig, Contract: &enclavedb.Contract{}, SenderCanView: &t1}
+	subscribe(sm, "sender", sender, &common.FilterCriteriaJSON{})
+	subscribe(sm, "recipient", testAccount(2), &common.FilterCriteriaJSON{})
+
+	l := transferLog(testAccount(1), testAccount(2))
+	l.TxHash = tx.Hash()
+	res, err := sm.matchLogs(context.Background(), &core.Batch{Transactions: []*common.L2Tx{tx}}, types.Receipts{{Logs: []*types.Log{l}}})
+	require.NoError(t, err)
+	require.Equal(t, map[gethrpc.ID][]*types.Log{"sender": {l}}, res)
+}
+
+// TestMatchLogsParityWithFilterLogs stores a batch with logs of every visibility kind and checks that the live
+// subscriptions of each account receive exactly the logs that the `FilterLogs` database query returns to it
+func TestMatchLogsParityWithFilterLogs(t *testing.T) {
+	ctx := context.Background()
+	backingDB, err := sqlite.CreateTemporarySQLiteDB("", "", enclaveconfig.EnclaveConfig{RPCTimeout: time.Second}, gethlog.New())
+	require.NoError(t, err)
+	st := storage.NewStorage(backingDB, storage.NewCacheService(gethlog.New(), true), &enclaveconfig.EnclaveConfig{StoreExecutedTransactions: true}, nil, gethlog.New())
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		keys[i], err = crypto.GenerateKey()
+		require.NoError(t, err)
+	}
+	alice, bob, charlie := crypto.PubkeyToAddress(keys[0].PublicKey), crypto.PubkeyToAddress(keys[1].PublicKey), crypto.PubkeyToAddress(keys[2].PublicKey)
+	dave := testAccount(4)
+	privateContract, transparentContract, autoContract := testAccount(100), testAccount(101), testAccount(102)
+
+	ownedSig := crypto.Keccak256Hash([]byte("Owned(address)"))
+	sentSig := crypto.Keccak256Hash([]byte("Sent(address)"))
+	undeclaredSig := crypto.Keccak256Hash([]byte("Undeclared(address)"))
+	approvalSig := crypto.Keccak256Hash([]byte("Approval(address,address)"))
+	pingSig := crypto.Keccak256Hash([]byte("Ping(address)"))
+	valueSig := crypto.Keccak256Hash([]byte("Value(uint256)"))
+
+	t1 := true
+	contracts := map[gethcommon.Address]*core.ContractVisibilityConfig{
+		privateContract: {EventConfigs: map[gethcommon.Hash]*core.EventVisibilityConfig{
+			ownedSig:  {Topic1CanView: &t1},
+			sentSig:   {SenderCanView: &t1},
+			publicSig: {Public: true},
+		}},
+		transparentContract: {Transparent: &t1},
+		autoContract:        {AutoConfig: true},
+	}
+
+	signer := types.LatestSignerForChainID(big.NewInt(testChainID))
+	txs := make([]*common.L2Tx, 4)
+	for i, key := range []*ecdsa.PrivateKey{keys[0], keys[1], keys[2], keys[0]} {
+		txs[i], err = types.SignNewTx(key, signer, &types.LegacyTx{Nonce: uint64(i), Gas: 21_000, GasPrice: big.NewInt(1)})
+		require.NoError(t, err)
+	}
+
+	topic := func(addr gethcommon.Address) gethcommon.Hash { return gethcommon.BytesToHash(addr.Bytes()) }
+	newLog := func(tx *common.L2Tx, contract gethcommon.Address, topics ...gethcommon.Hash) *types.Log {
+		return &types.Log{Address: contract, Topics: topics, TxHash: tx.Hash()}
+	}
+	txLogs := [][]*types.Log{
+		// the deployment of the contracts
+		nil,
+		// sent by bob to the private contract
+		{
+			newLog(txs[1], privateContract, ownedSig, topic(charlie)),
+			newLog(txs[1], privateContract, sentSig, topic(alice)),
+			newLog(txs[1], privateContract, publicSig),
+			newLog(txs[1], privateContract, undeclaredSig, topic(alice)),
+		},
+		// sent by charlie to the contract without configuration
+		{
+			newLog(txs[2], autoContract, transferSig, topic(alice), topic(bob)),
+			newLog(txs[2], autoContract, approvalSig, topic(bob), topic(privateContract)),
+			newLog(txs[2], autoContract, pingSig, topic(privateContract)),
+			newLog(txs[2], autoContract, valueSig, gethcommon.BigToHash(big.NewInt(42))),
+		},
+		// sent by alice to the transparent contract
+		{newLog(txs[3], transparentContract, transferSig, topic(bob), topic(charlie))},
+	}
+
+	block := &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(0)}
+	require.NoError(t, st.StoreBlock(ctx, block, nil))
+	batch := &core.Batch{
+		Header:       &common.BatchHeader{Number: big.NewInt(1), SequencerOrderNo: big.NewInt(int64(common.L2GenesisSeqNo)), L1Proof: block.Hash()},
+		Transactions: txs,
+	}
+	require.NoError(t, st.StoreBatch(ctx, batch, gethcommon.Hash{}))
+
+	var index uint
+	receipts := make(types.Receipts, len(txs))
+	results := make(core.TxExecResults, len(txs))
+	for i, tx := range txs {
+		for _, l := range txLogs[i] {
+			l.Index = index
+			index++
+		}
+		receipts[i] = &types.Receipt{TxHash: tx.Hash(), Status: types.ReceiptStatusSuccessful, Logs: txLogs[i]}
+		sender, err := core.GetExternalTxSigner(tx)
+		require.NoError(t, err)
+		results[i] = &core.TxExecResult{Receipt: receipts[i], TxWithSender: &core.TxWithSender{Tx: tx, Sender: &sender}}
+	}
+	results[0].CreatedContracts = contracts
+	require.NoError(t, st.StoreExecutedBatch(ctx, batch, results))
+
+	sm := NewSubscriptionManager(st, nil, testChainID, log.New(log.EnclaveCmp, int(gethlog.LevelError), log.SysOut))
+	accounts := []gethcommon.Address{alice, bob, charlie, dave}
+	for _, account := range accounts {
+		subscribe(sm, gethrpc.ID(account.Hex()), account, &common.FilterCriteriaJSON{})
+	}
+	matched, err := sm.matchLogs(ctx, batch, receipts)
+	require.NoError(t, err)
+
+	indexes := func(logs []*types.Log) []uint {
+		res := make([]uint, 0, len(logs))
+		for _, l := range logs {
+			res = append(res, l.Index)
+		}
+		return res
+	}
+	expected := map[gethcommon.Address][]uint{
+		alice:   {2, 3, 4, 6, 7, 8},
+		bob:     {1, 2, 4, 5, 6, 7, 8},
+		charlie: {0, 2, 6, 7, 8},
+		dave:    {2, 6, 7, 8},
+	}
+	for _, account := range accounts {
+		filtered, err := st.FilterLogs(ctx, &account, nil, nil, nil, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, expected[account], indexes(filtered), "FilterLogs for %s", account)
+		require.Equal(t, expected[account], indexes(matched[gethrpc.ID(account.Hex())]), "matchLogs for %s", account)
+	}
+}
+
+// benchmarks a batch with 100 transfers between 10k accounts that each have a subscription
+func benchmarkMatchLogs(b *testing.B, filterFor func(account gethcommon.Address) *common.FilterCriteriaJSON) {
+	const noOfSubscriptions = 10_000
+	sm := newTestSubscriptionManager()
+	for i := 0; i < noOfSubscriptions; i++ {
+		account := testAccount(i)
+		subscribe(sm, gethrpc.ID(fmt.Sprintf("sub%d", i)), account, filterFor(account))
+	}
+	receipts := make(types.Receipts, 100)
+	for i := range receipts {
+		receipts[i] = &types.Receipt{Logs: []*types.Log{transferLog(testAccount(i), testAccount(i+1))}}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := sm.matchLogs(context.Background(), &core.Batch{}, receipts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMatchLogs10kTopicSubscriptions(b *testing.B) {
+	benchmarkMatchLogs(b, func(account gethcommon.Address) *common.FilterCriteriaJSON {
+		return &common.FilterCriteriaJSON{Topics: [][]gethcommon.Hash{nil, {gethcommon.BytesToHash(account.Bytes())}}}
+	})
+}
+
+func BenchmarkMatchLogs10kAddressSubscriptions(b *testing.B) {
+	benchmarkMatchLogs(b, func(_ gethcommon.Address) *common.FilterCriteriaJSON {
+		return &common.FilterCriteriaJSON{Addresses: []gethcommon.Address{tokenAddr}}
+	})
+}
+
+func BenchmarkMatchLogs10kWildcardSubscriptions(b *testing.B) {
+	benchmarkMatchLogs(b, func(_ gethcommon.Address) *common.FilterCriteriaJSON {
+		return &common.FilterCriteriaJSON{}
+	})
+}
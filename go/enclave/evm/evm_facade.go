@@ -65,18 +65,27 @@ func ExecuteTransaction(
 	snap := s.Snapshot()
 	s.SetTxContext(tx.Tx.Hash(), tCount)
 
-	s.SetLogger(&tracing.Hooks{
-		// called when the code of a contract changes.
-		OnCodeChange: func(addr gethcommon.Address, prevCodeHash gethcommon.Hash, prevCode []byte, codeHash gethcommon.Hash, code []byte) {
-			// only proceed for new deployments.
-			if len(prevCode) > 0 {
-				logger.Debug("OnCodeChange: Skipping contract deployment", "address", addr.Hex())
-				return
-			}
-			createdContracts = append(createdContracts, &addr)
-			logger.Debug("OnCodeChange: Contract deployed", "address", addr.Hex())
-		},
-	})
+	// when the transaction is traced, the state changes are reported to the tracer as well
+	hooks := &tracing.Hooks{}
+	if vmCfg.Tracer != nil {
+		tracerHooks := *vmCfg.Tracer
+		hooks = &tracerHooks
+	}
+	tracerOnCodeChange := hooks.OnCodeChange
+	// called when the code of a contract changes.
+	hooks.OnCodeChange = func(addr gethcommon.Address, prevCodeHash gethcommon.Hash, prevCode []byte, codeHash gethcommon.Hash, code []byte) {
+		if tracerOnCodeChange != nil {
+			tracerOnCodeChange(addr, prevCodeHash, prevCode, codeHash, code)
+		}
+		// only proceed for new deployments.
+		if len(prevCode) > 0 {
+			logger.Debug("OnCodeChange: Skipping contract deployment", "address", addr.Hex())
+			return
+		}
+		createdContracts = append(createdContracts, &addr)
+		logger.Debug("OnCodeChange: Contract deployed", "address", addr.Hex())
+	}
+	s.SetLogger(hooks)
 	defer s.SetLogger(nil)
 
 	var vmenv *vm.EVM
@@ -167,6 +176,10 @@ func ExecuteTransaction(
 		}
 	}
 
+	// the visibility calls are not part of the transaction, so they must not be traced
+	if vmenv != nil {
+		vmenv.Config.Tracer = nil
+	}
 	contractsWithVisibility := make(map[gethcommon.Address]*core.ContractVisibilityConfig)
 	for _, contractAddress := range createdContracts {
 		contractsWithVisibility[*contractAddress] = readVisibilityConfig(vmenv, contractAddress)
@@ -277,6 +290,23 @@ func ExecuteCall(
 	gasEstimationCap uint64,
 	config enclaveconfig.EnclaveConfig,
 	logger gethlog.Logger,
+) (*gethcore.ExecutionResult, error) {
+	return ExecuteCallWithTracer(ctx, msg, s, header, storage, gethEncodingService, chainConfig, gasEstimationCap, config, nil, logger)
+}
+
+// ExecuteCallWithTracer - same as ExecuteCall, but the execution is observed by the tracer
+func ExecuteCallWithTracer(
+	ctx context.Context,
+	msg *gethcore.Message,
+	s *state.StateDB,
+	header *common.BatchHeader,
+	storage storage.Storage,
+	gethEncodingService gethencoding.EncodingService,
+	chainConfig *params.ChainConfig,
+	gasEstimationCap uint64,
+	config enclaveconfig.EnclaveConfig,
+	tracer *tracing.Hooks,
+	logger gethlog.Logger,
 ) (*gethcore.ExecutionResult, error) {
 	noBaseFee := true
 	if header.BaseFee != nil && header.BaseFee.Cmp(gethcommon.Big0) != 0 && msg.GasPrice.Cmp(gethcommon.Big0) != 0 {
@@ -298,6 +328,7 @@ func ExecuteCall(
 	cleanState := createCleanState(s, msg, ethHeader, chainConfig)
 
 	chain, vmCfg := initParams(storage, gethEncodingService, config, noBaseFee, nil)
+	vmCfg.Tracer = tracer
 	blockContext := gethcore.NewEVMBlockContext(ethHeader, chain, nil)
 	// sets TxKey.origin
 	txContext := gethcore.NewEVMTxContext(msg)
@@ -322,6 +353,51 @@ func ExecuteCall(
 	return result, nil
 }
 
+// ExecuteCallsInSequence - executes the messages one after the other, so each call sees the changes made by the previous ones.
+// The changes are written to the state, so the caller must pass in a copy.
+// Unlike ExecuteCall, the base fee is only waived when noBaseFee is set.
+func ExecuteCallsInSequence(
+	ctx context.Context,
+	msgs []*gethcore.Message,
+	s *state.StateDB,
+	header *common.BatchHeader,
+	storage storage.Storage,
+	gethEncodingService gethencoding.EncodingService,
+	chainConfig *params.ChainConfig,
+	gasEstimationCap uint64,
+	config enclaveconfig.EnclaveConfig,
+	noBaseFee bool,
+	logger gethlog.Logger,
+) ([]*gethcore.ExecutionResult, error) {
+	defer core.LogMethodDuration(logger, measure.NewStopwatch(), "evm_facade.go:ExecuteCallsInSequence()")
+	ethHeader, err := gethEncodingService.CreateEthHeaderForBatch(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	gp := gethcore.GasPool(gasEstimationCap)
+	gp.SetGas(gasEstimationCap)
+
+	chain, vmCfg := initParams(storage, gethEncodingService, config, noBaseFee, nil)
+	blockContext := gethcore.NewEVMBlockContext(ethHeader, chain, nil)
+	rules := chainConfig.Rules(ethHeader.Number, true, 0)
+	results := make([]*gethcore.ExecutionResult, len(msgs))
+	for i, msg := range msgs {
+		s.Prepare(rules, msg.From, ethHeader.Coinbase, msg.To, vm.ActivePrecompiles(rules), msg.AccessList)
+		vmenv := vm.NewEVM(blockContext, gethcore.NewEVMTxContext(msg), s, chainConfig, vmCfg)
+		result, err := gethcore.ApplyMessage(vmenv, msg, &gp)
+		if vmerr := s.Error(); vmerr != nil {
+			return nil, vmerr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w (supplied gas %d)", i, err, msg.GasLimit)
+		}
+		s.Finalise(true)
+		results[i] = result
+	}
+	return results, nil
+}
+
 func createCleanState(s *state.StateDB, msg *gethcore.Message, ethHeader *types.Header, chainConfig *params.ChainConfig) *state.StateDB {
 	cleanState := s.Copy()
 	cleanState.Prepare(chainConfig.Rules(ethHeader.Number, true, 0), msg.From, ethHeader.Coinbase, msg.To, nil, msg.AccessList)
@@ -0,0 +1,51 @@
+package gas
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+)
+
+// RewardPercentiles returns the priority fees paid by the transactions of a batch at each of the percentiles, like the
+// rewards of eth_feeHistory. The tip of a transaction is its effective gas price above the base fee of the batch, and
+// the transactions are weighted by the gas they used.
+func RewardPercentiles(baseFee *big.Int, txs []core.TxFee, percentiles []float64) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+	if len(txs) == 0 {
+		// an empty batch has no tips
+		for i := range rewards {
+			rewards[i] = big.NewInt(0)
+		}
+		return rewards
+	}
+
+	type tipAndGas struct {
+		tip     *big.Int
+		gasUsed uint64
+	}
+	tips := make([]tipAndGas, len(txs))
+	totalGasUsed := uint64(0)
+	for i, tx := range txs {
+		tip := new(big.Int).Sub(tx.EffectiveGasPrice, baseFee)
+		if tip.Sign() < 0 {
+			tip.SetInt64(0)
+		}
+		tips[i] = tipAndGas{tip: tip, gasUsed: tx.GasUsed}
+		totalGasUsed += tx.GasUsed
+	}
+	sort.SliceStable(tips, func(i, j int) bool { return tips[i].tip.Cmp(tips[j].tip) < 0 })
+
+	// the reward at a percentile is the tip of the transaction that uses the gas at that percentile of the batch gas
+	txIndex := 0
+	sumGasUsed := tips[0].gasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(totalGasUsed) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(tips)-1 {
+			txIndex++
+			sumGasUsed += tips[txIndex].gasUsed
+		}
+		rewards[i] = tips[txIndex].tip
+	}
+	return rewards
+}
@@ -0,0 +1,41 @@
+package gas
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+)
+
+func txFee(effectiveGasPrice int64, gasUsed uint64) core.TxFee {
+	return core.TxFee{EffectiveGasPrice: big.NewInt(effectiveGasPrice), GasUsed: gasUsed}
+}
+
+func requireRewards(t *testing.T, expected []int64, rewards []*big.Int) {
+	require.Len(t, rewards, len(expected))
+	for i, reward := range rewards {
+		require.Equal(t, expected[i], reward.Int64(), "reward #%d", i)
+	}
+}
+
+func TestRewardPercentilesOfMixedTips(t *testing.T) {
+	baseFee := big.NewInt(100)
+	txs := []core.TxFee{
+		txFee(150, 21_000),  // tip 50
+		txFee(100, 21_000),  // paid only the base fee
+		txFee(110, 100_000), // tip 10, uses most of the gas
+		txFee(400, 21_000),  // tip 300
+	}
+	// the total gas used is 163_000. The 25th percentile (40_750) is reached by the tx paying 10,
+	// the 50th (81_500) also, the 75th (122_250) by the tx paying 50, and the 100th by the one paying 300
+	requireRewards(t, []int64{0, 10, 10, 50, 300}, RewardPercentiles(baseFee, txs, []float64{0, 25, 50, 75, 100}))
+
+	// the order of the transactions in the batch doesn't matter
+	reversed := []core.TxFee{txs[3], txs[2], txs[1], txs[0]}
+	requireRewards(t, []int64{0, 10, 10, 50, 300}, RewardPercentiles(baseFee, reversed, []float64{0, 25, 50, 75, 100}))
+}
+
+func TestRewardPercentilesOfEmptyBatch(t *testing.T) {
+	requireRewards(t, []int64{0, 0}, RewardPercentiles(big.NewInt(100), nil, []float64{10, 90}))
+}
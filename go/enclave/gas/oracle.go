@@ -1,7 +1,19 @@
 package gas
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
+	"sync/atomic"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	enclaveconfig "github.com/ten-protocol/go-ten/go/enclave/config"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
 
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -10,53 +22,148 @@ import (
 	"github.com/ten-protocol/go-ten/go/common/gethapi"
 )
 
+const (
+	// PriceModeLatest - the fees of the L1 block are used as they are
+	PriceModeLatest = "latest"
+	// PriceModeWindow - the average of the fees over the last L1PriceWindow blocks
+	PriceModeWindow = "window"
+	// PriceModeEMA - the exponential moving average of the fees over the last L1PriceWindow blocks
+	PriceModeEMA = "ema"
+)
+
 // Oracle - the interface for the future precompiled gas oracle contract
 // which will expose necessary l1 information.
 type Oracle interface {
-	ProcessL1Block(block *types.Header)
-	EstimateL1StorageGasCost(tx *types.Transaction, block *types.Header) (*big.Int, error)
-	EstimateL1CostForMsg(args *gethapi.TransactionArgs, block *types.Header) (*big.Int, error)
+	// ProcessL1Block - calculates and persists the fees for a new L1 block
+	ProcessL1Block(ctx context.Context, block *types.Header) error
+	// L1Fees - returns the fees for the given L1 block, after smoothing
+	L1Fees(ctx context.Context, block *types.Header) (*L1Fees, error)
+	EstimateL1StorageGasCost(ctx context.Context, tx *types.Transaction, block *types.Header) (*big.Int, error)
+	EstimateL1CostForMsg(ctx context.Context, args *gethapi.TransactionArgs, block *types.Header) (*big.Int, error)
+}
+
+// L1Fees - the fees of the L1 at a given block
+type L1Fees struct {
+	BaseFee     *big.Int `json:"baseFee"`
+	BlobBaseFee *big.Int `json:"blobBaseFee"` // nil when the L1 block does not have excess blob gas
 }
 
+// oracle - the fees of a block are derived only from the block and its ancestors, back to the L1 block of the genesis
+// batch at most. Every enclave of the network processed those blocks, so they all calculate the same values, no matter
+// when they started processing the L1. A missing ancestor is an error, rather than a shorter window.
+// The results are persisted when the L1 block is processed, so they don't have to be recalculated after a restart.
+// The batch execution only reads them.
 type oracle struct {
-	baseFee *big.Int
+	storage storage.Storage
+	mode    string
+	window  uint64
+	premium uint64
+	floor   *big.Int
+	logger  gethlog.Logger
+
+	genesisL1Height atomic.Pointer[uint64] // cached once the genesis batch is known
 }
 
-func NewGasOracle() Oracle {
+func NewGasOracle(storage storage.Storage, config *enclaveconfig.EnclaveConfig, logger gethlog.Logger) Oracle {
+	mode := config.L1PriceMode
+	if mode == "" || config.L1PriceWindow <= 1 {
+		mode = PriceModeLatest
+	}
+	floor := big.NewInt(0)
+	if config.L1PriceFloor != nil {
+		floor = config.L1PriceFloor
+	}
 	return &oracle{
-		baseFee: big.NewInt(1),
+		storage: storage,
+		mode:    mode,
+		window:  config.L1PriceWindow,
+		premium: config.L1PricePremium,
+		floor:   floor,
+		logger:  logger,
 	}
 }
 
-// ProcessL1Block - should be used to update the gas oracle. Currently does not really
-// fit into phase 1 gas mechanics as the information needs to be available per block.
-// would be fixed when this becomes a smart contract using the stateDB
-func (o *oracle) ProcessL1Block(block *types.Header) {
-	blockBaseFee := block.BaseFee
-	if blockBaseFee != nil {
-		o.baseFee = blockBaseFee
+// ProcessL1Block - calculates and persists the fees of the L1 block, when the block is processed.
+// Before the genesis batch is known, the fees are not persisted, as the window they are calculated over is not known yet.
+func (o *oracle) ProcessL1Block(ctx context.Context, block *types.Header) error {
+	if _, err := o.fetchFees(ctx, block); !errors.Is(err, errutil.ErrNotFound) {
+		return err
+	}
+	fees, final, err := o.calculateFees(ctx, block)
+	if err != nil || !final {
+		return err
 	}
+	encoded, err := json.Marshal(fees)
+	if err != nil {
+		return err
+	}
+	if err := o.storage.StoreL1Fees(ctx, block.Hash(), encoded); err != nil {
+		return fmt.Errorf("could not store l1 fees. Cause: %w", err)
+	}
+	return nil
+}
+
+// L1Fees - returns the persisted fees of the block, or calculates them without persisting them
+func (o *oracle) L1Fees(ctx context.Context, block *types.Header) (*L1Fees, error) {
+	fees, err := o.fetchFees(ctx, block)
+	if !errors.Is(err, errutil.ErrNotFound) {
+		return fees, err
+	}
+	fees, _, err = o.calculateFees(ctx, block)
+	return fees, err
+}
+
+func (o *oracle) fetchFees(ctx context.Context, block *types.Header) (*L1Fees, error) {
+	stored, err := o.storage.FetchL1Fees(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	var fees L1Fees
+	if err := json.Unmarshal(stored, &fees); err != nil {
+		return nil, fmt.Errorf("could not decode l1 fees. Cause: %w", err)
+	}
+	return &fees, nil
+}
+
+// windowStart - the height of the L1 block of the genesis batch, the oldest block the fees are calculated from.
+// Returns false if the genesis batch is not known yet.
+func (o *oracle) windowStart(ctx context.Context) (uint64, bool, error) {
+	if start := o.genesisL1Height.Load(); start != nil {
+		return *start, true, nil
+	}
+	genesis, err := o.storage.FetchBatchHeaderBySeqNo(ctx, common.L2GenesisSeqNo)
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	genesisBlock, err := o.storage.FetchBlock(ctx, genesis.L1Proof)
+	if err != nil {
+		return 0, false, fmt.Errorf("could not fetch the l1 block of the genesis batch. Cause: %w", err)
+	}
+	start := genesisBlock.Number.Uint64()
+	o.genesisL1Height.Store(&start)
+	return start, true, nil
 }
 
 // EstimateL1StorageGasCost - Returns the expected l1 gas cost for a transaction at a given l1 block.
-func (o *oracle) EstimateL1StorageGasCost(tx *types.Transaction, block *types.Header) (*big.Int, error) {
+func (o *oracle) EstimateL1StorageGasCost(ctx context.Context, tx *types.Transaction, block *types.Header) (*big.Int, error) {
 	encodedTx, err := rlp.EncodeToBytes(tx)
 	if err != nil {
 		return nil, err
 	}
 
-	// If the block does not have excess blob gas, we can't estimate the cost
-	if block.ExcessBlobGas == nil {
-		return big.NewInt(0), nil
+	price, err := o.l1Price(ctx, block)
+	if err != nil {
+		return nil, err
 	}
 
-	blobFee := eip4844.CalcBlobFee(*block.ExcessBlobGas)
-
 	l1Gas := CalculateL1GasUsed(encodedTx, big.NewInt(0))
-	return big.NewInt(0).Mul(l1Gas, blobFee), nil
+	return big.NewInt(0).Mul(l1Gas, price), nil
 }
 
-func (o *oracle) EstimateL1CostForMsg(args *gethapi.TransactionArgs, block *types.Header) (*big.Int, error) {
+func (o *oracle) EstimateL1CostForMsg(ctx context.Context, args *gethapi.TransactionArgs, block *types.Header) (*big.Int, error) {
 	encoded := make([]byte, 0)
 	if args.Data != nil {
 		encoded = append(encoded, *args.Data...)
@@ -64,13 +171,129 @@ func (o *oracle) EstimateL1CostForMsg(args *gethapi.TransactionArgs, block *type
 
 	// We get the non zero gas cost per byte of calldata, and multiply it by the fixed bytes
 	// of a transaction. Then we take the data of a transaction and calculate the l1 gas used for it.
-	// Both are added together and multiplied by the base fee to give us the final cost for the message.
+	// Both are added together and multiplied by the same price used when the transaction is executed.
 	nonZeroGas := big.NewInt(int64(params.TxDataNonZeroGasEIP2028))
 	overhead := big.NewInt(0).Mul(big.NewInt(150), nonZeroGas)
 	l1Gas := CalculateL1GasUsed(encoded, overhead)
-	baseFee := big.NewInt(0)
+
+	price, err := o.l1Price(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(0).Mul(l1Gas, price), nil
+}
+
+// l1Price - the price per l1 gas of publishing data. The rollups are published as blobs, so it is the blob fee.
+// The premium is added, and the result can't be lower than the floor.
+func (o *oracle) l1Price(ctx context.Context, block *types.Header) (*big.Int, error) {
+	fees, err := o.L1Fees(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	price := big.NewInt(0)
+	if fees.BlobBaseFee != nil {
+		price.Mul(fees.BlobBaseFee, big.NewInt(int64(100+o.premium)))
+		price.Div(price, big.NewInt(100))
+	}
+	if price.Cmp(o.floor) < 0 {
+		price.Set(o.floor)
+	}
+	return price, nil
+}
+
+// calculateFees - returns the fees of the block, and whether they are final. Before the genesis batch is known, the fees
+// of a block are its own fees. The genesis batch is executed with those, and the window starts at its L1 block.
+func (o *oracle) calculateFees(ctx context.Context, block *types.Header) (*L1Fees, bool, error) {
+	start, final, err := o.windowStart(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if !final {
+		start = block.Number.Uint64()
+	}
+	if o.mode == PriceModeLatest {
+		return blockFees(block), final, nil
+	}
+
+	// the blocks in the window, from the oldest to the newest
+	blocks := []*types.Header{block}
+	for uint64(len(blocks)) < o.window && blocks[0].Number.Uint64() > start {
+		parent, err := o.storage.FetchBlock(ctx, blocks[0].ParentHash)
+		if err != nil {
+			return nil, false, fmt.Errorf("could not fetch l1 block %s of the fee window. Cause: %w", blocks[0].ParentHash, err)
+		}
+		blocks = append([]*types.Header{parent}, blocks...)
+	}
+
+	fees := make([]*L1Fees, len(blocks))
+	for i, b := range blocks {
+		fees[i] = blockFees(b)
+	}
+
+	var result *L1Fees
+	switch o.mode {
+	case PriceModeWindow:
+		result = &L1Fees{
+			BaseFee:     average(fees, func(f *L1Fees) *big.Int { return f.BaseFee }),
+			BlobBaseFee: average(fees, func(f *L1Fees) *big.Int { return f.BlobBaseFee }),
+		}
+	case PriceModeEMA:
+		result = &L1Fees{
+			BaseFee:     ema(fees, o.window, func(f *L1Fees) *big.Int { return f.BaseFee }),
+			BlobBaseFee: ema(fees, o.window, func(f *L1Fees) *big.Int { return f.BlobBaseFee }),
+		}
+	default:
+		return nil, false, fmt.Errorf("unknown l1 price mode %s", o.mode)
+	}
+	o.logger.Trace("Calculated l1 fees", log.BlockHashKey, block.Hash(), "baseFee", result.BaseFee, "blobBaseFee", result.BlobBaseFee)
+	return result, final, nil
+}
+
+func blockFees(block *types.Header) *L1Fees {
+	fees := &L1Fees{BaseFee: big.NewInt(0)}
 	if block.BaseFee != nil {
-		baseFee = block.BaseFee
+		fees.BaseFee = block.BaseFee
+	}
+	if block.ExcessBlobGas != nil {
+		fees.BlobBaseFee = eip4844.CalcBlobFee(*block.ExcessBlobGas)
+	}
+	return fees
+}
+
+// average - of the values that are not nil. Returns nil if there is no value
+func average(fees []*L1Fees, value func(*L1Fees) *big.Int) *big.Int {
+	sum := big.NewInt(0)
+	count := int64(0)
+	for _, f := range fees {
+		if v := value(f); v != nil {
+			sum.Add(sum, v)
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	return sum.Div(sum, big.NewInt(count))
+}
+
+// ema - the exponential moving average of the values that are not nil, seeded with the oldest value.
+// The smoothing factor is 2/(window+1), and the calculation uses integers only, so it is deterministic.
+func ema(fees []*L1Fees, window uint64, value func(*L1Fees) *big.Int) *big.Int {
+	var res *big.Int
+	for _, f := range fees {
+		v := value(f)
+		if v == nil {
+			continue
+		}
+		if res == nil {
+			res = new(big.Int).Set(v)
+			continue
+		}
+		// res = (2*v + (window-1)*res) / (window+1)
+		res.Mul(res, new(big.Int).SetUint64(window-1))
+		res.Add(res, new(big.Int).Mul(v, big.NewInt(2)))
+		res.Div(res, new(big.Int).SetUint64(window+1))
 	}
-	return big.NewInt(0).Mul(l1Gas, baseFee), nil
+	return res
 }
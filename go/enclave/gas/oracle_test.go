@@ -0,0 +1,136 @@
+package gas
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	enclaveconfig "github.com/ten-protocol/go-ten/go/enclave/config"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+)
+
+func feesOf(values ...int64) []*L1Fees {
+	res := make([]*L1Fees, len(values))
+	for i, v := range values {
+		res[i] = &L1Fees{BaseFee: big.NewInt(v)}
+		if v > 0 {
+			res[i].BlobBaseFee = big.NewInt(v)
+		}
+	}
+	return res
+}
+
+func TestAverage(t *testing.T) {
+	fees := feesOf(0, 10, 20, 30)
+	if avg := average(fees, func(f *L1Fees) *big.Int { return f.BaseFee }); avg.Int64() != 15 {
+		t.Errorf("expected 15, got %d", avg)
+	}
+	// the blocks without blob gas are ignored
+	if avg := average(fees, func(f *L1Fees) *big.Int { return f.BlobBaseFee }); avg.Int64() != 20 {
+		t.Errorf("expected 20, got %d", avg)
+	}
+	if avg := average(feesOf(0), func(f *L1Fees) *big.Int { return f.BlobBaseFee }); avg != nil {
+		t.Errorf("expected nil, got %d", avg)
+	}
+}
+
+func TestEMA(t *testing.T) {
+	baseFee := func(f *L1Fees) *big.Int { return f.BaseFee }
+	// window 3 => smoothing factor 0.5
+	if res := ema(feesOf(100, 200), 3, baseFee); res.Int64() != 150 {
+		t.Errorf("expected 150, got %d", res)
+	}
+	if res := ema(feesOf(100, 200, 100), 3, baseFee); res.Int64() != 125 {
+		t.Errorf("expected 125, got %d", res)
+	}
+	// a spike is smoothed
+	if res := ema(feesOf(100, 100, 100, 1000), 9, baseFee); res.Int64() != 280 {
+		t.Errorf("expected 280, got %d", res)
+	}
+}
+
+// l1Storage - the L1 blocks, the genesis batch and the persisted fees of an enclave
+type l1Storage struct {
+	storage.Storage
+	blocks  map[gethcommon.Hash]*types.Header
+	genesis *common.BatchHeader
+	fees    map[gethcommon.Hash][]byte
+}
+
+func (s *l1Storage) FetchBlock(_ context.Context, blockHash common.L1BlockHash) (*types.Header, error) {
+	block, found := s.blocks[blockHash]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return block, nil
+}
+
+func (s *l1Storage) FetchBatchHeaderBySeqNo(context.Context, uint64) (*common.BatchHeader, error) {
+	if s.genesis == nil {
+		return nil, errutil.ErrNotFound
+	}
+	return s.genesis, nil
+}
+
+func (s *l1Storage) StoreL1Fees(_ context.Context, blockHash common.L1BlockHash, fees []byte) error {
+	s.fees[blockHash] = fees
+	return nil
+}
+
+func (s *l1Storage) FetchL1Fees(_ context.Context, blockHash common.L1BlockHash) ([]byte, error) {
+	fees, found := s.fees[blockHash]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return fees, nil
+}
+
+// newL1Chain returns a chain of L1 blocks with the given base fees, from height 1
+func newL1Chain(s *l1Storage, baseFees ...int64) []*types.Header {
+	chain := make([]*types.Header, len(baseFees))
+	parent := gethcommon.Hash{}
+	for i, baseFee := range baseFees {
+		chain[i] = &types.Header{ParentHash: parent, Number: big.NewInt(int64(i + 1)), BaseFee: big.NewInt(baseFee)}
+		s.blocks[chain[i].Hash()] = chain[i]
+		parent = chain[i].Hash()
+	}
+	return chain
+}
+
+func TestFeesAreCalculatedFromTheGenesisBatchOnwards(t *testing.T) {
+	ctx := context.Background()
+	s := &l1Storage{blocks: map[gethcommon.Hash]*types.Header{}, fees: map[gethcommon.Hash][]byte{}}
+	chain := newL1Chain(s, 1000, 100, 200, 300)
+	o := NewGasOracle(s, &enclaveconfig.EnclaveConfig{L1PriceMode: PriceModeWindow, L1PriceWindow: 4}, gethlog.New())
+
+	// before the genesis batch, the fees of a block are its own, and they are not persisted
+	fees, err := o.L1Fees(ctx, chain[2])
+	require.NoError(t, err)
+	require.Equal(t, int64(200), fees.BaseFee.Int64())
+	require.NoError(t, o.ProcessL1Block(ctx, chain[2]))
+	require.Empty(t, s.fees)
+
+	// the window doesn't reach before the L1 block of the genesis batch, even if the enclave stored older blocks
+	s.genesis = &common.BatchHeader{L1Proof: chain[1].Hash()}
+	fees, err = o.L1Fees(ctx, chain[3])
+	require.NoError(t, err)
+	require.Equal(t, int64(200), fees.BaseFee.Int64())
+
+	// the fees are only persisted when the block is processed
+	require.Empty(t, s.fees)
+	require.NoError(t, o.ProcessL1Block(ctx, chain[3]))
+	require.Contains(t, s.fees, chain[3].Hash())
+
+	// a missing block of the window is an error, instead of a shorter window
+	delete(s.blocks, chain[2].Hash())
+	delete(s.fees, chain[3].Hash())
+	_, err = o.L1Fees(ctx, chain[3])
+	require.Error(t, err)
+	require.Error(t, o.ProcessL1Block(ctx, chain[3]))
+}
@@ -21,4 +21,10 @@ type ObscuroChain interface {
 
 	// ObsCallAtBlock - Execute eth_call RPC against obscuro for a specific block (batch) number.
 	ObsCallAtBlock(ctx context.Context, apiArgs *gethapi.TransactionArgs, blockNumber *gethrpc.BlockNumber) (*gethcore.ExecutionResult, error)
+
+	// CreateAccessList - returns the storage slots accessed by the call, and the gas it uses when executed with that access list.
+	CreateAccessList(ctx context.Context, apiArgs *gethapi.TransactionArgs, blockNumber *gethrpc.BlockNumber) (*gethapi.AccessListResult, error)
+
+	// Simulate - executes the calls in sequence on top of the state of the given block (batch) number, applying the state overrides.
+	Simulate(ctx context.Context, opts *gethapi.SimOpts, blockNumber *gethrpc.BlockNumber) ([]gethapi.SimBlockResult, error)
 }
@@ -0,0 +1,163 @@
+package l2chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethcore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ten-protocol/go-ten/go/common/gethapi"
+	"github.com/ten-protocol/go-ten/go/enclave/evm"
+	gethrpc "github.com/ten-protocol/go-ten/lib/gethfork/rpc"
+)
+
+const (
+	// maxSimulatedBlocks - the maximum number of blocks of calls accepted by Simulate. Same as geth
+	maxSimulatedBlocks = 256
+
+	// the error codes used by geth for the failed simulated calls
+	errCodeReverted = 3
+	errCodeVMError  = -32015
+)
+
+func (oc *tenChain) CreateAccessList(ctx context.Context, apiArgs *gethapi.TransactionArgs, blockNumber *gethrpc.BlockNumber) (*gethapi.AccessListResult, error) {
+	if apiArgs.From == nil {
+		return nil, fmt.Errorf("no from address provided")
+	}
+	blockState, err := oc.Registry.GetBatchStateAtHeight(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	batch, err := oc.Registry.GetBatchAtHeight(ctx, *blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch batch. Cause: %w", err)
+	}
+
+	// the sender, the recipient and the precompiles are always warm, so they are excluded from the list
+	from := *apiArgs.From
+	var to gethcommon.Address
+	if apiArgs.To != nil {
+		to = *apiArgs.To
+	} else {
+		to = crypto.CreateAddress(from, blockState.GetNonce(from))
+	}
+	precompiles := vm.ActivePrecompiles(oc.chainConfig.Rules(batch.Header.Number, true, 0))
+
+	prevTracer := logger.NewAccessListTracer(nil, from, to, precompiles)
+	if apiArgs.AccessList != nil {
+		prevTracer = logger.NewAccessListTracer(*apiArgs.AccessList, from, to, precompiles)
+	}
+	// Same as geth: execute the call with the current access list until it no longer changes
+	for {
+		accessList := prevTracer.AccessList()
+		args := *apiArgs
+		args.AccessList = &accessList
+		msg, err := args.ToMessage(batch.Header.GasLimit-1, batch.Header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert TransactionArgs to Message - %w", err)
+		}
+
+		tracer := logger.NewAccessListTracer(accessList, from, to, precompiles)
+		res, err := evm.ExecuteCallWithTracer(ctx, msg, blockState, batch.Header, oc.storage, oc.gethEncodingService, oc.chainConfig, oc.gasEstimationCap, oc.config, tracer.Hooks(), oc.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply transaction: %w", err)
+		}
+		if tracer.Equal(prevTracer) {
+			result := &gethapi.AccessListResult{Accesslist: &accessList, GasUsed: hexutil.Uint64(res.UsedGas)}
+			if res.Err != nil {
+				result.Error = res.Err.Error()
+			}
+			return result, nil
+		}
+		prevTracer = tracer
+	}
+}
+
+func (oc *tenChain) Simulate(ctx context.Context, opts *gethapi.SimOpts, blockNumber *gethrpc.BlockNumber) ([]gethapi.SimBlockResult, error) {
+	if err := opts.Validate(maxSimulatedBlocks); err != nil {
+		return nil, err
+	}
+	blockState, err := oc.Registry.GetBatchStateAtHeight(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	batch, err := oc.Registry.GetBatchAtHeight(ctx, *blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch batch. Cause: %w", err)
+	}
+
+	// all the blocks are executed on the same copy, so each block sees the changes of the previous ones
+	simState := blockState.Copy()
+	results := make([]gethapi.SimBlockResult, 0, len(opts.BlockStateCalls))
+	for _, block := range opts.BlockStateCalls {
+		if err := checkOverrides(blockState, block.StateOverrides); err != nil {
+			return nil, err
+		}
+		if err := block.StateOverrides.Apply(simState); err != nil {
+			return nil, err
+		}
+
+		msgs := make([]*gethcore.Message, len(block.Calls))
+		for i := range block.Calls {
+			msgs[i], err = block.Calls[i].ToMessage(batch.Header.GasLimit-1, batch.Header.BaseFee)
+			if err != nil {
+				return nil, fmt.Errorf("unable to convert call %d to Message - %w", i, err)
+			}
+		}
+		execResults, err := evm.ExecuteCallsInSequence(ctx, msgs, simState, batch.Header, oc.storage, oc.gethEncodingService, oc.chainConfig, oc.gasEstimationCap, oc.config, !opts.Validation, oc.logger)
+		if err != nil {
+			return nil, err
+		}
+
+		blockResult := gethapi.SimBlockResult{Calls: make([]gethapi.SimCallResult, len(execResults))}
+		for i, r := range execResults {
+			blockResult.Calls[i] = toSimCallResult(r)
+		}
+		results = append(results, blockResult)
+	}
+	return results, nil
+}
+
+// checkOverrides - the deployed contracts can't be overridden. Replacing the code would allow reading their private
+// state, while seeding their state, balance or nonce would allow deriving it from the results of the calls.
+func checkOverrides(s *state.StateDB, overrides *gethapi.StateOverride) error {
+	if overrides == nil {
+		return nil
+	}
+	for addr := range *overrides {
+		if s.GetCodeSize(addr) > 0 {
+			return fmt.Errorf("the deployed contract %s cannot be overridden", addr.Hex())
+		}
+	}
+	return nil
+}
+
+func toSimCallResult(r *gethcore.ExecutionResult) gethapi.SimCallResult {
+	res := gethapi.SimCallResult{
+		ReturnValue: r.ReturnData,
+		GasUsed:     hexutil.Uint64(r.UsedGas),
+		Status:      hexutil.Uint64(types.ReceiptStatusSuccessful),
+	}
+	if !r.Failed() {
+		return res
+	}
+	res.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+	if errors.Is(r.Err, vm.ErrExecutionReverted) {
+		msg := r.Err.Error()
+		if reason, err := abi.UnpackRevert(r.Revert()); err == nil {
+			msg = fmt.Sprintf("%s: %s", msg, reason)
+		}
+		res.Error = &gethapi.SimCallError{Message: msg, Code: errCodeReverted, Data: hexutil.Encode(r.Revert())}
+		return res
+	}
+	res.Error = &gethapi.SimCallError{Message: r.Err.Error(), Code: errCodeVMError}
+	return res
+}
@@ -0,0 +1,175 @@
+package l2chain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/gethapi"
+	"github.com/ten-protocol/go-ten/go/common/gethencoding"
+	"github.com/ten-protocol/go-ten/go/enclave/components"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/evm/ethchainadapter"
+	gethrpc "github.com/ten-protocol/go-ten/lib/gethfork/rpc"
+)
+
+var (
+	// increments the value of slot 0 and returns it
+	counterCode = hexutil.MustDecode("0x6000546001018060005560005260206000f3")
+	// reverts without a reason
+	revertCode = hexutil.MustDecode("0x60006000fd")
+)
+
+// callerCode calls the contract at the given address without arguments
+func callerCode(callee gethcommon.Address) []byte {
+	code := hexutil.MustDecode("0x60006000600060006000")
+	code = append(code, 0x73)
+	code = append(code, callee.Bytes()...)
+	return append(code, 0x5a, 0xf1, 0x00)
+}
+
+// call returns the arguments of a call with enough gas for the test contracts, as the calls of a simulated block share
+// the gas of the batch
+func call(from, to gethcommon.Address) gethapi.TransactionArgs {
+	gas := hexutil.Uint64(100_000)
+	return gethapi.TransactionArgs{From: &from, To: &to, Gas: &gas}
+}
+
+// batchRegistry - a registry with a single batch, whose state is returned for every height
+type batchRegistry struct {
+	components.BatchRegistry
+	state *state.StateDB
+	batch *core.Batch
+}
+
+func (r *batchRegistry) GetBatchStateAtHeight(context.Context, *gethrpc.BlockNumber) (*state.StateDB, error) {
+	return r.state, nil
+}
+
+func (r *batchRegistry) GetBatchAtHeight(context.Context, gethrpc.BlockNumber) (*core.Batch, error) {
+	return r.batch, nil
+}
+
+// headerEncoding - converts the batch headers without the entropy and the converted parent hash
+type headerEncoding struct {
+	gethencoding.EncodingService
+}
+
+func (e *headerEncoding) CreateEthHeaderForBatch(_ context.Context, h *common.BatchHeader) (*types.Header, error) {
+	return &types.Header{Number: h.Number, Time: h.Time, GasLimit: h.GasLimit, BaseFee: h.BaseFee, Difficulty: big.NewInt(0)}, nil
+}
+
+// newTestChain returns a chain whose state has a funded sender, the counter contract, a contract calling the counter
+// and a reverting contract
+func newTestChain(t *testing.T) (*tenChain, gethcommon.Address, gethcommon.Address, gethcommon.Address, gethcommon.Address) {
+	stateDB, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	sender, counter, caller, reverter := gethcommon.Address{1}, gethcommon.Address{2}, gethcommon.Address{3}, gethcommon.Address{4}
+	stateDB.SetBalance(sender, uint256.NewInt(1e18), tracing.BalanceChangeUnspecified)
+	stateDB.SetCode(counter, counterCode)
+	stateDB.SetCode(caller, callerCode(counter))
+	stateDB.SetCode(reverter, revertCode)
+
+	batch := &core.Batch{Header: &common.BatchHeader{Number: big.NewInt(1), SequencerOrderNo: big.NewInt(1), GasLimit: 30_000_000, BaseFee: big.NewInt(1)}}
+	return &tenChain{
+		chainConfig:         ethchainadapter.ChainParams(big.NewInt(443)),
+		gethEncodingService: &headerEncoding{},
+		logger:              gethlog.New(),
+		Registry:            &batchRegistry{state: stateDB, batch: batch},
+		gasEstimationCap:    30_000_000,
+	}, sender, counter, caller, reverter
+}
+
+func TestSimulate(t *testing.T) {
+	chain, sender, counter, _, reverter := newTestChain(t)
+	blockNumber := gethrpc.BlockNumber(1)
+	fresh := gethcommon.Address{5}
+	code := hexutil.Bytes(counterCode)
+
+	results, err := chain.Simulate(context.Background(), &gethapi.SimOpts{BlockStateCalls: []gethapi.SimBlock{
+		{Calls: []gethapi.TransactionArgs{call(sender, counter), call(sender, counter)}},
+		// the state changes of the previous blocks are kept, and the accounts which are not contracts can be overridden
+		{
+			StateOverrides: &gethapi.StateOverride{fresh: {Code: &code}},
+			Calls:          []gethapi.TransactionArgs{call(sender, counter), call(sender, reverter), call(sender, fresh)},
+		},
+	}}, &blockNumber)
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	require.Len(t, results[0].Calls, 2)
+	require.Len(t, results[1].Calls, 3)
+	for i, expected := range []int64{1, 2} {
+		require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), results[0].Calls[i].Status)
+		require.Equal(t, expected, new(big.Int).SetBytes(results[0].Calls[i].ReturnValue).Int64())
+		require.NotZero(t, results[0].Calls[i].GasUsed)
+	}
+	require.Equal(t, int64(3), new(big.Int).SetBytes(results[1].Calls[0].ReturnValue).Int64())
+
+	reverted := results[1].Calls[1]
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusFailed), reverted.Status)
+	require.NotNil(t, reverted.Error)
+	require.Equal(t, errCodeReverted, reverted.Error.Code)
+
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), results[1].Calls[2].Status)
+	require.Equal(t, int64(1), new(big.Int).SetBytes(results[1].Calls[2].ReturnValue).Int64())
+
+	// the simulation doesn't change the state of the batch
+	state, err := chain.Registry.GetBatchStateAtHeight(context.Background(), &blockNumber)
+	require.NoError(t, err)
+	require.Equal(t, gethcommon.Hash{}, state.GetState(counter, gethcommon.Hash{}))
+}
+
+func TestSimulateRejectsTheOverridesOfDeployedContracts(t *testing.T) {
+	chain, sender, counter, _, _ := newTestChain(t)
+	blockNumber := gethrpc.BlockNumber(1)
+	stateDiff := map[gethcommon.Hash]gethcommon.Hash{{}: gethcommon.BigToHash(big.NewInt(41))}
+
+	_, err := chain.Simulate(context.Background(), &gethapi.SimOpts{BlockStateCalls: []gethapi.SimBlock{
+		{Calls: []gethapi.TransactionArgs{call(sender, counter)}},
+		{StateOverrides: &gethapi.StateOverride{counter: {StateDiff: &stateDiff}}, Calls: []gethapi.TransactionArgs{call(sender, counter)}},
+	}}, &blockNumber)
+	require.ErrorContains(t, err, "cannot be overridden")
+}
+
+func TestCreateAccessList(t *testing.T) {
+	chain, sender, counter, caller, _ := newTestChain(t)
+	blockNumber := gethrpc.BlockNumber(1)
+
+	result, err := chain.CreateAccessList(context.Background(), &gethapi.TransactionArgs{From: &sender, To: &caller}, &blockNumber)
+	require.NoError(t, err)
+	require.Empty(t, result.Error)
+	require.NotZero(t, result.GasUsed)
+	// the sender and the recipient are always warm, so only the contract called by the recipient is listed
+	require.Equal(t, types.AccessList{{Address: counter, StorageKeys: []gethcommon.Hash{{}}}}, *result.Accesslist)
+}
+
+func TestCheckOverrides(t *testing.T) {
+	stateDB, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	contract, account := gethcommon.Address{1}, gethcommon.Address{2}
+	stateDB.SetCode(contract, []byte{1})
+
+	balance := (*hexutil.Big)(hexutil.MustDecodeBig("0x10"))
+	nonce := hexutil.Uint64(1)
+	stateDiff := map[gethcommon.Hash]gethcommon.Hash{{1}: {1}}
+	code := hexutil.Bytes{1}
+
+	// the accounts which are not contracts can be overridden
+	require.NoError(t, checkOverrides(stateDB, &gethapi.StateOverride{account: {Balance: &balance, Nonce: &nonce, Code: &code, StateDiff: &stateDiff}}))
+
+	// any override of a deployed contract is rejected
+	for _, override := range []gethapi.OverrideAccount{{Code: &code}, {Balance: &balance}, {Nonce: &nonce}, {State: &stateDiff}, {StateDiff: &stateDiff}} {
+		require.Error(t, checkOverrides(stateDB, &gethapi.StateOverride{contract: override}))
+	}
+}
@@ -12,7 +12,7 @@ import (
 
 // Runs an Obscuro enclave as a standalone process.
 func main() {
-	tenCfg, err := config.LoadTenConfig()
+	tenCfg, err := config.LoadEnclaveTenConfig()
 	if err != nil {
 		fmt.Println("Error loading ten config:", err)
 		os.Exit(1)
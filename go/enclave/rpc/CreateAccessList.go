@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/gethapi"
+	"github.com/ten-protocol/go-ten/go/common/gethencoding"
+	"github.com/ten-protocol/go-ten/go/common/log"
+)
+
+func CreateAccessListValidate(reqParams []any, builder *CallBuilder[CallParamsWithBlock, gethapi.AccessListResult], _ *EncryptionManager) error {
+	// Parameters are [TransactionArgs, BlockNumber (optional)]
+	if len(reqParams) < 1 || len(reqParams) > 2 {
+		builder.Err = fmt.Errorf("unexpected number of parameters")
+		return nil
+	}
+	apiArgs, err := gethencoding.ExtractEthCall(reqParams[0])
+	if err != nil {
+		builder.Err = fmt.Errorf("unable to decode EthCall Params - %w", err)
+		return nil
+	}
+
+	if apiArgs.From == nil {
+		builder.Err = fmt.Errorf("no from address provided")
+		return nil
+	}
+
+	blkNumber, err := gethencoding.ExtractOptionalBlockNumber(reqParams, 1)
+	if err != nil {
+		builder.Err = fmt.Errorf("unable to extract requested block number - %w", err)
+		return nil
+	}
+	if blkNumber.BlockNumber == nil {
+		builder.Err = fmt.Errorf("only block numbers are supported")
+		return nil
+	}
+
+	builder.From = apiArgs.From
+	builder.Param = &CallParamsWithBlock{apiArgs, blkNumber.BlockNumber}
+	return nil
+}
+
+// CreateAccessListExecute - the access list contains the storage slots touched by the call. The slots of a private
+// contract can be derived from the values of its state, so only the slots readable with eth_getStorageAt are returned.
+// The gas used is the gas of the call executed with the full access list.
+func CreateAccessListExecute(builder *CallBuilder[CallParamsWithBlock, gethapi.AccessListResult], rpc *EncryptionManager) error {
+	err := authenticateFrom(builder.VK, builder.From)
+	if err != nil {
+		builder.Err = err
+		return nil //nolint:nilerr
+	}
+
+	result, err := rpc.chain.CreateAccessList(builder.ctx, builder.Param.callParams, builder.Param.block)
+	if err != nil {
+		rpc.logger.Debug("Failed eth_createAccessList.", log.ErrKey, err)
+		builder.Err = err
+		return nil //nolint:nilerr
+	}
+	if result.Accesslist != nil {
+		accessList, err := filterAccessList(builder.ctx, rpc, *result.Accesslist)
+		if err != nil {
+			return fmt.Errorf("unable to filter the access list - %w", err)
+		}
+		result.Accesslist = &accessList
+	}
+	builder.ReturnValue = result
+	return nil
+}
+
+// filterAccessList removes the storage keys of the non-transparent contracts, except for the whitelisted slots
+func filterAccessList(ctx context.Context, rpc *EncryptionManager, accessList types.AccessList) (types.AccessList, error) {
+	filtered := make(types.AccessList, len(accessList))
+	for i, tuple := range accessList {
+		filtered[i] = types.AccessTuple{Address: tuple.Address, StorageKeys: make([]gethcommon.Hash, 0, len(tuple.StorageKeys))}
+		contract, err := rpc.storage.ReadContract(ctx, tuple.Address)
+		if err != nil && !errors.Is(err, errutil.ErrNotFound) {
+			return nil, err
+		}
+		for _, key := range tuple.StorageKeys {
+			if (contract != nil && contract.IsTransparent()) || rpc.storageSlotWhitelist.AllowedStorageSlots[hexutil.EncodeBig(key.Big())] {
+				filtered[i].StorageKeys = append(filtered[i].StorageKeys, key)
+			}
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/gethapi"
+	"github.com/ten-protocol/go-ten/go/common/privacy"
+	"github.com/ten-protocol/go-ten/go/enclave/l2chain"
+	"github.com/ten-protocol/go-ten/go/enclave/storage/enclavedb"
+	"github.com/ten-protocol/go-ten/go/enclave/vkhandler"
+	"github.com/ten-protocol/go-ten/integration/datagenerator"
+	gethrpc "github.com/ten-protocol/go-ten/lib/gethfork/rpc"
+)
+
+// simulationChain - a chain returning fixed access list and simulation results, and recording the simulated calls
+type simulationChain struct {
+	l2chain.ObscuroChain
+	accessList *gethapi.AccessListResult
+	results    []gethapi.SimBlockResult
+	simulated  *gethapi.SimOpts
+}
+
+func (c *simulationChain) CreateAccessList(context.Context, *gethapi.TransactionArgs, *gethrpc.BlockNumber) (*gethapi.AccessListResult, error) {
+	return c.accessList, nil
+}
+
+func (c *simulationChain) Simulate(_ context.Context, opts *gethapi.SimOpts, _ *gethrpc.BlockNumber) ([]gethapi.SimBlockResult, error) {
+	c.simulated = opts
+	return c.results, nil
+}
+
+func TestFilterAccessList(t *testing.T) {
+	transparentContract, privateContract, account := datagenerator.RandomAddress(), datagenerator.RandomAddress(), datagenerator.RandomAddress()
+	transparent := true
+	rpc := &EncryptionManager{
+		storage: &contractStorage{contracts: map[gethcommon.Address]*enclavedb.Contract{
+			transparentContract: {Address: transparentContract, Transparent: &transparent},
+			privateContract:     {Address: privateContract},
+		}},
+		storageSlotWhitelist: privacy.NewWhitelist(),
+	}
+	// the eip-1967 implementation slot
+	implementationSlot := gethcommon.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	slot := gethcommon.Hash{1}
+
+	filtered, err := filterAccessList(context.Background(), rpc, types.AccessList{
+		{Address: transparentContract, StorageKeys: []gethcommon.Hash{slot}},
+		{Address: privateContract, StorageKeys: []gethcommon.Hash{slot, implementationSlot}},
+		{Address: account, StorageKeys: []gethcommon.Hash{}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.AccessList{
+		{Address: transparentContract, StorageKeys: []gethcommon.Hash{slot}},
+		{Address: privateContract, StorageKeys: []gethcommon.Hash{implementationSlot}},
+		{Address: account, StorageKeys: []gethcommon.Hash{}},
+	}, filtered)
+}
+
+func TestCreateAccessList(t *testing.T) {
+	privateContract, sender := datagenerator.RandomAddress(), datagenerator.RandomAddress()
+	slot := gethcommon.Hash{1}
+	accessList := types.AccessList{{Address: privateContract, StorageKeys: []gethcommon.Hash{slot}}}
+	rpc := &EncryptionManager{
+		storage:              &contractStorage{contracts: map[gethcommon.Address]*enclavedb.Contract{privateContract: {Address: privateContract}}},
+		storageSlotWhitelist: privacy.NewWhitelist(),
+		chain:                &simulationChain{accessList: &gethapi.AccessListResult{Accesslist: &accessList, GasUsed: 21_000}},
+		logger:               gethlog.New(),
+	}
+
+	builder := &CallBuilder[CallParamsWithBlock, gethapi.AccessListResult]{ctx: context.Background(), VK: &vkhandler.AuthenticatedViewingKey{AccountAddress: &sender}}
+	require.NoError(t, CreateAccessListValidate([]any{map[string]any{"from": sender.Hex(), "to": privateContract.Hex()}, "latest"}, builder, rpc))
+	require.NoError(t, builder.Err)
+	require.NoError(t, CreateAccessListExecute(builder, rpc))
+	require.NoError(t, builder.Err)
+
+	// the slots of the private contract touched by the call are not returned
+	require.Equal(t, types.AccessList{{Address: privateContract, StorageKeys: []gethcommon.Hash{}}}, *builder.ReturnValue.Accesslist)
+	require.Equal(t, hexutil.Uint64(21_000), builder.ReturnValue.GasUsed)
+
+	// the access list can only be created for the account of the viewing key
+	other := datagenerator.RandomAddress()
+	builder = &CallBuilder[CallParamsWithBlock, gethapi.AccessListResult]{ctx: context.Background(), VK: &vkhandler.AuthenticatedViewingKey{AccountAddress: &other}}
+	require.NoError(t, CreateAccessListValidate([]any{map[string]any{"from": sender.Hex(), "to": privateContract.Hex()}, "latest"}, builder, rpc))
+	require.NoError(t, CreateAccessListExecute(builder, rpc))
+	require.ErrorContains(t, builder.Err, "failed authentication")
+	require.Nil(t, builder.ReturnValue)
+}
@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/tracers"
+)
+
+type traceTransactionParams struct {
+	txHash gethcommon.Hash
+	config *tracers.TraceConfig
+}
+
+func DebugTraceTransactionValidate(reqParams []any, builder *CallBuilder[traceTransactionParams, json.RawMessage], rpc *EncryptionManager) error {
+	if !rpc.config.DebugNamespaceEnabled {
+		builder.Err = fmt.Errorf("debug namespace not enabled")
+		return nil
+	}
+	if !storeTxEnabled(rpc, builder) {
+		return nil
+	}
+	// Parameters are [Hash, TraceConfig?]
+	if len(reqParams) < 1 || len(reqParams) > 2 {
+		builder.Err = fmt.Errorf("wrong parameters")
+		return nil
+	}
+	txHashStr, ok := reqParams[0].(string)
+	if !ok {
+		builder.Err = fmt.Errorf("unexpected tx hash parameter")
+		return nil
+	}
+
+	var config *tracers.TraceConfig
+	if len(reqParams) == 2 && reqParams[1] != nil {
+		serialised, err := json.Marshal(reqParams[1])
+		if err != nil {
+			builder.Err = fmt.Errorf("invalid trace config: %w", err)
+			return nil
+		}
+		config = &tracers.TraceConfig{}
+		if err := json.Unmarshal(serialised, config); err != nil {
+			builder.Err = fmt.Errorf("invalid trace config: %w", err)
+			return nil
+		}
+	}
+
+	builder.Param = &traceTransactionParams{txHash: gethcommon.HexToHash(txHashStr), config: config}
+	return nil
+}
+
+func DebugTraceTransactionExecute(builder *CallBuilder[traceTransactionParams, json.RawMessage], rpc *EncryptionManager) error {
+	txHash := builder.Param.txHash
+
+	_, _, _, _, sender, err := rpc.storage.GetTransaction(builder.ctx, txHash)
+	if err != nil && errors.Is(err, errutil.ErrNotFound) {
+		builder.Status = NotFound
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// authorise - only the signer can trace the transaction, because the trace exposes the private state it touched
+	if sender != *builder.VK.AccountAddress {
+		builder.Status = NotAuthorised
+		return nil
+	}
+
+	trace, err := rpc.debugger.DebugTraceTransaction(builder.ctx, txHash, builder.Param.config)
+	if err != nil {
+		builder.Err = err
+		return nil
+	}
+	builder.ReturnValue = &trace
+	return nil
+}
@@ -70,7 +70,7 @@ func EstimateGasExecute(builder *CallBuilder[CallParamsWithBlock, hexutil.Uint64
 
 	// The message is run through the l1 publishing cost estimation for the current
 	// known head BlockHeader.
-	l1Cost, err := rpc.gasOracle.EstimateL1CostForMsg(txArgs, block)
+	l1Cost, err := rpc.gasOracle.EstimateL1CostForMsg(builder.ctx, txArgs, block)
 	if err != nil {
 		return err
 	}
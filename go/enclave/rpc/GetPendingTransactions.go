@@ -0,0 +1,68 @@
+package rpc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common/gethutil"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	PendingTxsKey = "pending"
+	QueuedTxsKey  = "queued"
+)
+
+// PendingTransactions - the transactions of a sender waiting in the mempool, in the format of geth's `txpool_contentFrom`:
+// the "pending" and "queued" transactions, keyed by nonce
+type PendingTransactions map[string]map[string]*RpcTransaction
+
+// GetPendingTransactionsValidate - the parameter is the address of the sender whose pending transactions are returned
+func GetPendingTransactionsValidate(reqParams []any, builder *CallBuilder[gethcommon.Address, PendingTransactions], _ *EncryptionManager) error {
+	if len(reqParams) != 1 {
+		builder.Err = fmt.Errorf("unexpected number of parameters")
+		return nil
+	}
+	addressStr, ok := reqParams[0].(string)
+	if !ok || !gethcommon.IsHexAddress(addressStr) {
+		builder.Err = fmt.Errorf("unexpected address parameter")
+		return nil
+	}
+
+	address := gethcommon.HexToAddress(addressStr)
+	builder.From = &address
+	builder.Param = &address
+	return nil
+}
+
+func GetPendingTransactionsExecute(builder *CallBuilder[gethcommon.Address, PendingTransactions], rpc *EncryptionManager) error {
+	// only the sender can see their own pending transactions
+	err := authenticateFrom(builder.VK, builder.From)
+	if err != nil {
+		builder.Err = err
+		return nil //nolint:nilerr
+	}
+
+	pending, queued, known := rpc.mempool.ContentFrom(*builder.From)
+	if !known {
+		builder.Err = fmt.Errorf("the mempool of this node is not available")
+		return nil
+	}
+
+	result := PendingTransactions{
+		PendingTxsKey: toRPCTransactionsByNonce(pending, *builder.From, rpc),
+		QueuedTxsKey:  toRPCTransactionsByNonce(queued, *builder.From, rpc),
+	}
+	builder.ReturnValue = &result
+	return nil
+}
+
+func toRPCTransactionsByNonce(txs []*types.Transaction, from gethcommon.Address, rpc *EncryptionManager) map[string]*RpcTransaction {
+	result := make(map[string]*RpcTransaction, len(txs))
+	for _, tx := range txs {
+		result[strconv.FormatUint(tx.Nonce(), 10)] = newRPCTransaction(tx, gethutil.EmptyHash, 0, 0, rpc.config.BaseFee, from)
+	}
+	return result
+}
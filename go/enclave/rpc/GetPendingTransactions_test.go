@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	enclaveconfig "github.com/ten-protocol/go-ten/go/enclave/config"
+	"github.com/ten-protocol/go-ten/go/enclave/vkhandler"
+	"github.com/ten-protocol/go-ten/integration/datagenerator"
+)
+
+// senderTxPool - a mempool holding the transactions of a single sender
+type senderTxPool struct {
+	sender  gethcommon.Address
+	pending []*types.Transaction
+	queued  []*types.Transaction
+	known   bool
+}
+
+func (p *senderTxPool) SubmitTx(*common.L2Tx) error { return nil }
+
+func (p *senderTxPool) GetTx(gethcommon.Hash) *types.Transaction { return nil }
+
+func (p *senderTxPool) GetTxBySenderAndNonce(gethcommon.Address, uint64) (*types.Transaction, bool) {
+	return nil, p.known
+}
+
+func (p *senderTxPool) ContentFrom(sender gethcommon.Address) ([]*types.Transaction, []*types.Transaction, bool) {
+	if sender != p.sender {
+		return nil, nil, p.known
+	}
+	return p.pending, p.queued, p.known
+}
+
+func TestGetPendingTransactions(t *testing.T) {
+	sender, otherAccount := datagenerator.RandomAddress(), datagenerator.RandomAddress()
+	pendingTx := types.NewTx(&types.LegacyTx{Nonce: 3, GasPrice: big.NewInt(1)})
+	queuedTx := types.NewTx(&types.LegacyTx{Nonce: 5, GasPrice: big.NewInt(1)})
+	mempool := &senderTxPool{sender: sender, pending: []*types.Transaction{pendingTx}, queued: []*types.Transaction{queuedTx}, known: true}
+	rpc := &EncryptionManager{mempool: mempool, config: &enclaveconfig.EnclaveConfig{BaseFee: big.NewInt(1)}}
+
+	execute := func(requester gethcommon.Address, address gethcommon.Address) *CallBuilder[gethcommon.Address, PendingTransactions] {
+		builder := &CallBuilder[gethcommon.Address, PendingTransactions]{ctx: context.Background(), VK: &vkhandler.AuthenticatedViewingKey{AccountAddress: &requester}}
+		require.NoError(t, GetPendingTransactionsValidate([]any{address.Hex()}, builder, rpc))
+		require.NoError(t, builder.Err)
+		require.NoError(t, GetPendingTransactionsExecute(builder, rpc))
+		return builder
+	}
+
+	// the sender sees their pending and queued transactions, keyed by nonce
+	builder := execute(sender, sender)
+	require.NoError(t, builder.Err)
+	result := *builder.ReturnValue
+	require.Len(t, result[PendingTxsKey], 1)
+	require.Equal(t, pendingTx.Hash(), result[PendingTxsKey]["3"].Hash)
+	require.Equal(t, sender, result[PendingTxsKey]["3"].From)
+	require.Len(t, result[QueuedTxsKey], 1)
+	require.Equal(t, queuedTx.Hash(), result[QueuedTxsKey]["5"].Hash)
+
+	// the transactions of another account are not returned
+	builder = execute(otherAccount, sender)
+	require.Error(t, builder.Err)
+	require.Nil(t, builder.ReturnValue)
+
+	// the request fails when the mempool can't tell which transactions are pending
+	mempool.known = false
+	builder = execute(sender, sender)
+	require.Error(t, builder.Err)
+}
@@ -0,0 +1,255 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/gethencoding"
+	gethrpc "github.com/ten-protocol/go-ten/lib/gethfork/rpc"
+)
+
+type proofRequest struct {
+	address       *gethcommon.Address
+	storageKeys   []gethcommon.Hash
+	block         *gethrpc.BlockNumberOrHash
+	isContract    bool
+	isTransparent bool
+}
+
+// AccountResult - the result of eth_getProof, lifted from Geth's internal `ethapi` package.
+// The account proof is empty when the proof is requested for a contract, and the storage hash is empty for the
+// contracts which are not transparent.
+type AccountResult struct {
+	Address      gethcommon.Address `json:"address"`
+	AccountProof []string           `json:"accountProof"`
+	Balance      *hexutil.Big       `json:"balance"`
+	CodeHash     gethcommon.Hash    `json:"codeHash"`
+	Nonce        hexutil.Uint64     `json:"nonce"`
+	StorageHash  gethcommon.Hash    `json:"storageHash"`
+	StorageProof []StorageResult    `json:"storageProof"`
+}
+
+type StorageResult struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []string     `json:"proof"`
+}
+
+// GetProofValidate - the parameters are [Address, StorageKeys, BlockNumberOrHash].
+// The account proof is only returned to the owner of the account. The storage keys follow the rules of
+// eth_getStorageAt: all the slots of the transparent contracts, and only the whitelisted slots of the other contracts.
+// A storage proof contains the trie nodes along the path of the key, which hold the values of other slots (the short
+// nodes are inlined, and the proof of absence ends in a neighbouring leaf), so the whitelisted slots of the private
+// contracts are returned with their value only.
+func GetProofValidate(reqParams []any, builder *CallBuilder[proofRequest, AccountResult], rpc *EncryptionManager) error {
+	if len(reqParams) < 2 || len(reqParams) > 3 {
+		builder.Err = fmt.Errorf("unexpected number of parameters")
+		return nil
+	}
+	address, err := gethencoding.ExtractAddress(reqParams[0])
+	if err != nil {
+		builder.Err = fmt.Errorf("error extracting address - %w", err)
+		return nil
+	}
+	keyParams, ok := reqParams[1].([]any)
+	if !ok && reqParams[1] != nil {
+		builder.Err = fmt.Errorf("unexpected storage keys parameter")
+		return nil
+	}
+	keys := make([]gethcommon.Hash, len(keyParams))
+	for i, keyParam := range keyParams {
+		keyStr, ok := keyParam.(string)
+		if !ok {
+			builder.Err = fmt.Errorf("unexpected storage key parameter")
+			return nil
+		}
+		// deserialize all the keys to prevent state access on invalid input
+		keys[i], err = decodeStorageKey(keyStr)
+		if err != nil {
+			builder.Err = fmt.Errorf("invalid storage key %s - %w", keyStr, err)
+			return nil
+		}
+	}
+	var block *gethrpc.BlockNumberOrHash
+	if len(reqParams) == 3 {
+		block, err = gethencoding.ExtractBlockNumber(reqParams[2])
+	} else {
+		block, err = gethencoding.ExtractBlockNumber(nil)
+	}
+	if err != nil {
+		builder.Err = fmt.Errorf("unable to extract requested block number - %w", err)
+		return nil
+	}
+
+	req := &proofRequest{address: address, storageKeys: keys, block: block}
+	contract, err := rpc.storage.ReadContract(builder.ctx, *address)
+	switch {
+	case err == nil && contract != nil:
+		if !contract.IsTransparent() {
+			for _, key := range keys {
+				if !rpc.storageSlotWhitelist.AllowedStorageSlots[hexutil.EncodeBig(key.Big())] {
+					builder.Err = fmt.Errorf("eth_getProof is not supported for the storage of this contract")
+					return nil
+				}
+			}
+		}
+		req.isContract = true
+		req.isTransparent = contract.IsTransparent()
+	case errors.Is(err, errutil.ErrNotFound):
+		// not a contract, so only the owner of the account can request its proof
+		builder.From = address
+	default:
+		return fmt.Errorf("unable to read contract - %w", err)
+	}
+	builder.Param = req
+	return nil
+}
+
+func GetProofExecute(builder *CallBuilder[proofRequest, AccountResult], rpc *EncryptionManager) error {
+	req := builder.Param
+	if !req.isContract {
+		err := authenticateFrom(builder.VK, builder.From)
+		if err != nil {
+			builder.Err = err
+			return nil //nolint:nilerr
+		}
+	}
+
+	header, err := fetchBatchHeader(builder, rpc, req.block)
+	if err != nil {
+		builder.Err = fmt.Errorf("unable to read block - %w", err)
+		return nil //nolint:nilerr
+	}
+	stateDB, err := rpc.storage.CreateStateDB(builder.ctx, header.Hash())
+	if err != nil {
+		return fmt.Errorf("unable to create state - %w", err)
+	}
+
+	result, err := accountResult(stateDB, rpc, header.Root, req)
+	if err != nil {
+		return err
+	}
+	builder.ReturnValue = result
+	return nil
+}
+
+// accountResult builds the proofs of the account at the state root
+func accountResult(stateDB *state.StateDB, rpc *EncryptionManager, stateRoot gethcommon.Hash, req *proofRequest) (*AccountResult, error) {
+	address := *req.address
+	storageRoot := stateDB.GetStorageRoot(address)
+	withProofs := !req.isContract || req.isTransparent
+	storageProof, err := storageProofs(stateDB, rpc, stateRoot, address, storageRoot, req.storageKeys, withProofs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to prove storage - %w", err)
+	}
+
+	accountProof := make(proofList, 0)
+	if !req.isContract {
+		accountTrie, err := trie.NewStateTrie(trie.StateTrieID(stateRoot), rpc.storage.TrieDB())
+		if err != nil {
+			return nil, fmt.Errorf("unable to open state trie - %w", err)
+		}
+		if err := accountTrie.Prove(crypto.Keccak256(address.Bytes()), &accountProof); err != nil {
+			return nil, fmt.Errorf("unable to prove account - %w", err)
+		}
+	}
+	// the storage hash would allow to verify guesses of the private storage
+	if req.isContract && !req.isTransparent {
+		storageRoot = gethcommon.Hash{}
+	}
+
+	return &AccountResult{
+		Address:      address,
+		AccountProof: accountProof,
+		Balance:      (*hexutil.Big)(stateDB.GetBalance(address).ToBig()),
+		CodeHash:     stateDB.GetCodeHash(address),
+		Nonce:        hexutil.Uint64(stateDB.GetNonce(address)),
+		StorageHash:  storageRoot,
+		StorageProof: storageProof,
+	}, stateDB.Error()
+}
+
+func fetchBatchHeader(builder *CallBuilder[proofRequest, AccountResult], rpc *EncryptionManager, block *gethrpc.BlockNumberOrHash) (*common.BatchHeader, error) {
+	if block.BlockHash != nil {
+		return rpc.storage.FetchBatchHeader(builder.ctx, *block.BlockHash)
+	}
+	if block.BlockNumber == nil {
+		return nil, fmt.Errorf("block number or block hash does not exist")
+	}
+	batch, err := rpc.registry.GetBatchAtHeight(builder.ctx, *block.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return batch.Header, nil
+}
+
+// storageProofs returns the values of the keys, along with their proofs unless withProofs is false
+func storageProofs(stateDB *state.StateDB, rpc *EncryptionManager, stateRoot gethcommon.Hash, address gethcommon.Address, storageRoot gethcommon.Hash, keys []gethcommon.Hash, withProofs bool) ([]StorageResult, error) {
+	result := make([]StorageResult, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+	var storageTrie *trie.StateTrie
+	if storageRoot != types.EmptyRootHash && storageRoot != (gethcommon.Hash{}) {
+		id := trie.StorageTrieID(stateRoot, crypto.Keccak256Hash(address.Bytes()), storageRoot)
+		st, err := trie.NewStateTrie(id, rpc.storage.TrieDB())
+		if err != nil {
+			return nil, err
+		}
+		storageTrie = st
+	}
+	for i, key := range keys {
+		outputKey := hexutil.Encode(key[:])
+		if storageTrie == nil {
+			result[i] = StorageResult{outputKey, &hexutil.Big{}, []string{}}
+			continue
+		}
+		if !withProofs {
+			result[i] = StorageResult{outputKey, (*hexutil.Big)(stateDB.GetState(address, key).Big()), []string{}}
+			continue
+		}
+		proof := make(proofList, 0)
+		if err := storageTrie.Prove(crypto.Keccak256(key.Bytes()), &proof); err != nil {
+			return nil, err
+		}
+		result[i] = StorageResult{outputKey, (*hexutil.Big)(stateDB.GetState(address, key).Big()), proof}
+	}
+	return result, nil
+}
+
+// decodeStorageKey parses a hex-encoded storage key of up to 32 bytes
+func decodeStorageKey(s string) (gethcommon.Hash, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	b, err := hexutil.Decode("0x" + s)
+	if err != nil {
+		return gethcommon.Hash{}, err
+	}
+	if len(b) > gethcommon.HashLength {
+		return gethcommon.Hash{}, fmt.Errorf("hex string too long, want at most 32 bytes")
+	}
+	return gethcommon.BytesToHash(b), nil
+}
+
+// proofList collects the proof nodes as hex strings, lifted from Geth's internal `ethapi` package
+type proofList []string
+
+func (n *proofList) Put(_ []byte, value []byte) error {
+	*n = append(*n, hexutil.Encode(value))
+	return nil
+}
+
+func (n *proofList) Delete(_ []byte) error {
+	panic("not supported")
+}
@@ -0,0 +1,131 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/privacy"
+	enclaveconfig "github.com/ten-protocol/go-ten/go/enclave/config"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+	"github.com/ten-protocol/go-ten/go/enclave/storage/enclavedb"
+	"github.com/ten-protocol/go-ten/go/enclave/storage/init/sqlite"
+	"github.com/ten-protocol/go-ten/integration/datagenerator"
+)
+
+// contractStorage - the enclave storage with a fixed list of contracts
+type contractStorage struct {
+	storage.Storage
+	contracts map[gethcommon.Address]*enclavedb.Contract
+}
+
+func (s *contractStorage) ReadContract(_ context.Context, address gethcommon.Address) (*enclavedb.Contract, error) {
+	contract, found := s.contracts[address]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return contract, nil
+}
+
+func TestGetProof(t *testing.T) {
+	backingDB, err := sqlite.CreateTemporarySQLiteDB("", "", enclaveconfig.EnclaveConfig{RPCTimeout: time.Second}, gethlog.New())
+	require.NoError(t, err)
+	storageDB := storage.NewStorage(backingDB, storage.NewCacheService(gethlog.New(), true), nil, nil, gethlog.New())
+
+	transparentContract, privateContract, account := datagenerator.RandomAddress(), datagenerator.RandomAddress(), datagenerator.RandomAddress()
+	slot, otherSlot := gethcommon.Hash{1}, gethcommon.Hash{2}
+	// the eip-1967 implementation slot
+	whitelistedSlot := gethcommon.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	value := gethcommon.BigToHash(uint256.NewInt(1234).ToBig())
+	stateDB, err := storageDB.EmptyStateDB()
+	require.NoError(t, err)
+	for _, contract := range []gethcommon.Address{transparentContract, privateContract} {
+		stateDB.SetCode(contract, []byte{1})
+		stateDB.SetState(contract, slot, value)
+		stateDB.SetState(contract, otherSlot, value)
+		stateDB.SetState(contract, whitelistedSlot, value)
+	}
+	stateDB.SetBalance(account, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	root, err := stateDB.Commit(0, true)
+	require.NoError(t, err)
+	stateDB, err = state.New(root, storageDB.StateDB(), nil)
+	require.NoError(t, err)
+
+	transparent := true
+	rpc := &EncryptionManager{
+		storage: &contractStorage{Storage: storageDB, contracts: map[gethcommon.Address]*enclavedb.Contract{
+			transparentContract: {Address: transparentContract, Transparent: &transparent},
+			privateContract:     {Address: privateContract},
+		}},
+		storageSlotWhitelist: privacy.NewWhitelist(),
+	}
+	validate := func(address gethcommon.Address, keys ...gethcommon.Hash) *CallBuilder[proofRequest, AccountResult] {
+		keyParams := make([]any, len(keys))
+		for i, key := range keys {
+			keyParams[i] = key.Hex()
+		}
+		builder := &CallBuilder[proofRequest, AccountResult]{ctx: context.Background()}
+		require.NoError(t, GetProofValidate([]any{address.Hex(), keyParams, "latest"}, builder, rpc))
+		return builder
+	}
+
+	// the storage of a transparent contract can be proven
+	builder := validate(transparentContract, slot)
+	require.NoError(t, builder.Err)
+	result, err := accountResult(stateDB, rpc, root, builder.Param)
+	require.NoError(t, err)
+	require.Empty(t, result.AccountProof)
+	require.Equal(t, value.Big(), result.StorageProof[0].Value.ToInt())
+	proofDB := memorydb.New()
+	for _, node := range result.StorageProof[0].Proof {
+		encodedNode := gethcommon.FromHex(node)
+		require.NoError(t, proofDB.Put(crypto.Keccak256(encodedNode), encodedNode))
+	}
+	provenValue, err := trie.VerifyProof(result.StorageHash, crypto.Keccak256(slot.Bytes()), proofDB)
+	require.NoError(t, err)
+	expectedValue, err := rlp.EncodeToBytes(gethcommon.TrimLeftZeroes(value.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, expectedValue, provenValue)
+
+	// the proofs of the storage of a private contract would reveal the values of other slots
+	builder = validate(privateContract, slot)
+	require.Error(t, builder.Err)
+	builder = validate(privateContract)
+	require.NoError(t, builder.Err)
+	result, err = accountResult(stateDB, rpc, root, builder.Param)
+	require.NoError(t, err)
+	require.Empty(t, result.AccountProof)
+	require.Empty(t, result.StorageProof)
+	require.Equal(t, gethcommon.Hash{}, result.StorageHash)
+	builder = validate(privateContract, whitelistedSlot, slot)
+	require.Error(t, builder.Err)
+
+	// the whitelisted slots of a private contract are readable, but without the proof
+	builder = validate(privateContract, whitelistedSlot)
+	require.NoError(t, builder.Err)
+	result, err = accountResult(stateDB, rpc, root, builder.Param)
+	require.NoError(t, err)
+	require.Len(t, result.StorageProof, 1)
+	require.Equal(t, value.Big(), result.StorageProof[0].Value.ToInt())
+	require.Empty(t, result.StorageProof[0].Proof)
+	require.Equal(t, gethcommon.Hash{}, result.StorageHash)
+
+	// the account proof is only returned to the owner of the account
+	builder = validate(account)
+	require.NoError(t, builder.Err)
+	require.Equal(t, account, *builder.From)
+	result, err = accountResult(stateDB, rpc, root, builder.Param)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.AccountProof)
+}
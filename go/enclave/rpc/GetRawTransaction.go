@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+)
+
+func GetRawTransactionValidate(reqParams []any, builder *CallBuilder[gethcommon.Hash, hexutil.Bytes], rpc *EncryptionManager) error {
+	// Parameters are [Hash]
+	if len(reqParams) != 1 {
+		builder.Err = fmt.Errorf("wrong parameters")
+		return nil
+	}
+	txHashStr, ok := reqParams[0].(string)
+	if !ok {
+		builder.Err = fmt.Errorf("unexpected tx hash parameter")
+		return nil
+	}
+	txHash := gethcommon.HexToHash(txHashStr)
+	builder.Param = &txHash
+	return nil
+}
+
+// GetRawTransactionExecute - returns the RLP-encoded transaction. Pending transactions are looked up in the mempool.
+// Only the sender of the transaction is authorised to retrieve it
+func GetRawTransactionExecute(builder *CallBuilder[gethcommon.Hash, hexutil.Bytes], rpc *EncryptionManager) error {
+	txHash := *builder.Param
+	requester := builder.VK.AccountAddress
+
+	tx := rpc.mempool.GetTx(txHash)
+	var sender gethcommon.Address
+	if tx != nil {
+		from, err := core.GetAuthenticatedSender(rpc.config.TenChainID, tx)
+		if err != nil {
+			return fmt.Errorf("could not recover the sender of pooled tx %s. Cause: %w", txHash, err)
+		}
+		sender = *from
+	} else {
+		if !storeTxEnabled(rpc, builder) {
+			return nil
+		}
+		var err error
+		tx, _, _, _, sender, err = rpc.storage.GetTransaction(builder.ctx, txHash)
+		if err != nil && errors.Is(err, errutil.ErrNotFound) {
+			builder.Status = NotFound
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// authorise - only the signer can request the transaction
+	if sender != *requester {
+		builder.Status = NotAuthorised
+		return nil
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("could not encode tx %s. Cause: %w", txHash, err)
+	}
+	res := hexutil.Bytes(raw)
+	builder.ReturnValue = &res
+	return nil
+}
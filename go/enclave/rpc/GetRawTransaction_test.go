@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	enclaveconfig "github.com/ten-protocol/go-ten/go/enclave/config"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+	"github.com/ten-protocol/go-ten/go/enclave/vkhandler"
+	"github.com/ten-protocol/go-ten/integration/datagenerator"
+)
+
+// txStorage - the enclave storage with a fixed list of executed transactions
+type txStorage struct {
+	storage.Storage
+	txs     map[gethcommon.Hash]*types.Transaction
+	senders map[gethcommon.Hash]gethcommon.Address
+}
+
+func (s *txStorage) GetTransaction(_ context.Context, txHash common.L2TxHash) (*types.Transaction, common.L2BatchHash, uint64, uint64, gethcommon.Address, error) {
+	tx, found := s.txs[txHash]
+	if !found {
+		return nil, gethcommon.Hash{}, 0, 0, gethcommon.Address{}, errutil.ErrNotFound
+	}
+	return tx, gethcommon.Hash{}, 0, 0, s.senders[txHash], nil
+}
+
+func TestGetRawTransaction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender, otherAccount := crypto.PubkeyToAddress(key.PublicKey), datagenerator.RandomAddress()
+
+	pendingTx := signedLegacyTx(t, key, 1, 100)
+	executedTx := signedLegacyTx(t, key, 0, 100)
+	mempool := newReplacementTxPool()
+	require.NoError(t, mempool.SubmitTx(pendingTx))
+	rpc := &EncryptionManager{
+		mempool: mempool,
+		storage: &txStorage{
+			txs:     map[gethcommon.Hash]*types.Transaction{executedTx.Hash(): executedTx},
+			senders: map[gethcommon.Hash]gethcommon.Address{executedTx.Hash(): sender},
+		},
+		config: &enclaveconfig.EnclaveConfig{TenChainID: resendChainID, StoreExecutedTransactions: true},
+	}
+
+	execute := func(requester gethcommon.Address, txHash gethcommon.Hash) *CallBuilder[gethcommon.Hash, hexutil.Bytes] {
+		builder := &CallBuilder[gethcommon.Hash, hexutil.Bytes]{ctx: context.Background(), VK: &vkhandler.AuthenticatedViewingKey{AccountAddress: &requester}}
+		require.NoError(t, GetRawTransactionValidate([]any{txHash.Hex()}, builder, rpc))
+		require.NoError(t, builder.Err)
+		require.NoError(t, GetRawTransactionExecute(builder, rpc))
+		return builder
+	}
+	requireRaw := func(builder *CallBuilder[gethcommon.Hash, hexutil.Bytes], tx *types.Transaction) {
+		require.NoError(t, builder.Err)
+		raw, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, hexutil.Bytes(raw), *builder.ReturnValue)
+	}
+
+	// the sender gets their pending and executed transactions
+	requireRaw(execute(sender, pendingTx.Hash()), pendingTx)
+	requireRaw(execute(sender, executedTx.Hash()), executedTx)
+
+	// other accounts don't
+	for _, txHash := range []gethcommon.Hash{pendingTx.Hash(), executedTx.Hash()} {
+		builder := execute(otherAccount, txHash)
+		require.Equal(t, NotAuthorised, builder.Status)
+		require.Nil(t, builder.ReturnValue)
+	}
+
+	// an unknown transaction is not found
+	builder := execute(sender, gethcommon.Hash{1})
+	require.Equal(t, NotFound, builder.Status)
+	require.Nil(t, builder.ReturnValue)
+}
@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+)
+
+// ResendValidate - the parameter is a signed transaction that replaces a pending transaction with the same nonce.
+// The gateway (or any client) is responsible for creating and signing the replacement with the new gas parameters.
+func ResendValidate(reqParams []any, builder *CallBuilder[common.L2Tx, gethcommon.Hash], rpc *EncryptionManager) error {
+	if len(reqParams) != 1 {
+		builder.Err = fmt.Errorf("wrong parameters")
+		return nil
+	}
+	txStr, ok := reqParams[0].(string)
+	if !ok {
+		return errors.New("unsupported format")
+	}
+	l2Tx, err := ExtractTx(txStr)
+	if err != nil {
+		builder.Err = fmt.Errorf("could not extract transaction. Cause: %w", err)
+		return nil
+	}
+	sender, err := core.GetAuthenticatedSender(rpc.config.TenChainID, l2Tx)
+	if err != nil {
+		builder.Err = fmt.Errorf("could not recover the sender of the transaction. Cause: %w", err)
+		return nil
+	}
+	builder.From = sender
+	builder.Param = l2Tx
+	return nil
+}
+
+func ResendExecute(builder *CallBuilder[common.L2Tx, gethcommon.Hash], rpc *EncryptionManager) error {
+	// only the sender can replace their own transactions
+	err := authenticateFrom(builder.VK, builder.From)
+	if err != nil {
+		builder.Err = err
+		return nil //nolint:nilerr
+	}
+
+	replacement := builder.Param
+	existing, known := rpc.mempool.GetTxBySenderAndNonce(*builder.From, replacement.Nonce())
+	if known && existing == nil {
+		builder.Err = fmt.Errorf("no pending transaction with nonce %d", replacement.Nonce())
+		return nil
+	}
+	if existing != nil && existing.Hash() == replacement.Hash() {
+		builder.Err = fmt.Errorf("the replacement is identical to the pending transaction %s", existing.Hash())
+		return nil
+	}
+
+	// the mempool only accepts the replacement if the gas price was bumped enough
+	if err := rpc.mempool.SubmitTx(replacement); err != nil {
+		rpc.logger.Debug("Could not resend transaction", log.TxKey, replacement.Hash(), log.ErrKey, err)
+		builder.Err = err
+		return nil
+	}
+	h := replacement.Hash()
+	builder.ReturnValue = &h
+	return nil
+}
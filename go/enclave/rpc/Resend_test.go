@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	enclaveconfig "github.com/ten-protocol/go-ten/go/enclave/config"
+	"github.com/ten-protocol/go-ten/go/enclave/vkhandler"
+	"github.com/ten-protocol/go-ten/integration/datagenerator"
+)
+
+const resendChainID = 443
+
+// replacementTxPool - a mempool holding transactions by sender and nonce. Like the real mempool, it only accepts a
+// replacement if its gas price is bumped by at least 10%.
+type replacementTxPool struct {
+	txs map[gethcommon.Address]map[uint64]*types.Transaction
+}
+
+func newReplacementTxPool() *replacementTxPool {
+	return &replacementTxPool{txs: map[gethcommon.Address]map[uint64]*types.Transaction{}}
+}
+
+func (p *replacementTxPool) SubmitTx(tx *common.L2Tx) error {
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return err
+	}
+	if p.txs[sender] == nil {
+		p.txs[sender] = map[uint64]*types.Transaction{}
+	}
+	if existing := p.txs[sender][tx.Nonce()]; existing != nil {
+		minPrice := new(big.Int).Div(new(big.Int).Mul(existing.GasPrice(), big.NewInt(110)), big.NewInt(100))
+		if tx.GasPrice().Cmp(minPrice) < 0 {
+			return txpool.ErrReplaceUnderpriced
+		}
+	}
+	p.txs[sender][tx.Nonce()] = tx
+	return nil
+}
+
+func (p *replacementTxPool) GetTx(hash gethcommon.Hash) *types.Transaction {
+	for _, txs := range p.txs {
+		for _, tx := range txs {
+			if tx.Hash() == hash {
+				return tx
+			}
+		}
+	}
+	return nil
+}
+
+func (p *replacementTxPool) GetTxBySenderAndNonce(sender gethcommon.Address, nonce uint64) (*types.Transaction, bool) {
+	return p.txs[sender][nonce], true
+}
+
+func (p *replacementTxPool) ContentFrom(gethcommon.Address) ([]*types.Transaction, []*types.Transaction, bool) {
+	return nil, nil, true
+}
+
+func signedLegacyTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, gasPrice int64) *types.Transaction {
+	to := datagenerator.RandomAddress()
+	tx, err := types.SignNewTx(key, types.NewCancunSigner(big.NewInt(resendChainID)), &types.LegacyTx{Nonce: nonce, GasPrice: big.NewInt(gasPrice), Gas: 21_000, To: &to})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestResend(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender, otherAccount := crypto.PubkeyToAddress(key.PublicKey), datagenerator.RandomAddress()
+	mempool := newReplacementTxPool()
+	rpc := &EncryptionManager{mempool: mempool, config: &enclaveconfig.EnclaveConfig{TenChainID: resendChainID}, logger: gethlog.New()}
+
+	original := signedLegacyTx(t, key, 0, 100)
+	require.NoError(t, mempool.SubmitTx(original))
+
+	resend := func(requester gethcommon.Address, replacement *types.Transaction) *CallBuilder[common.L2Tx, gethcommon.Hash] {
+		raw, err := replacement.MarshalBinary()
+		require.NoError(t, err)
+		builder := &CallBuilder[common.L2Tx, gethcommon.Hash]{ctx: context.Background(), VK: &vkhandler.AuthenticatedViewingKey{AccountAddress: &requester}}
+		require.NoError(t, ResendValidate([]any{hexutil.Encode(raw)}, builder, rpc))
+		require.NoError(t, builder.Err)
+		require.NoError(t, ResendExecute(builder, rpc))
+		return builder
+	}
+
+	// only the sender can replace their transactions
+	builder := resend(otherAccount, signedLegacyTx(t, key, 0, 200))
+	require.Error(t, builder.Err)
+	require.Equal(t, original.Hash(), mempool.txs[sender][0].Hash())
+
+	// there is nothing to replace without a pending transaction with the same nonce
+	builder = resend(sender, signedLegacyTx(t, key, 1, 200))
+	require.ErrorContains(t, builder.Err, "no pending transaction with nonce 1")
+
+	// the replacement must differ from the pending transaction, and bump its fees enough
+	builder = resend(sender, original)
+	require.ErrorContains(t, builder.Err, "identical")
+	builder = resend(sender, signedLegacyTx(t, key, 0, 105))
+	require.True(t, errors.Is(builder.Err, txpool.ErrReplaceUnderpriced))
+	require.Equal(t, original.Hash(), mempool.txs[sender][0].Hash())
+
+	replacement := signedLegacyTx(t, key, 0, 110)
+	builder = resend(sender, replacement)
+	require.NoError(t, builder.Err)
+	require.Equal(t, replacement.Hash(), *builder.ReturnValue)
+	require.Equal(t, replacement.Hash(), mempool.txs[sender][0].Hash())
+}
@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ten-protocol/go-ten/go/common/gethapi"
+	"github.com/ten-protocol/go-ten/go/common/gethencoding"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	gethrpc "github.com/ten-protocol/go-ten/lib/gethfork/rpc"
+)
+
+type SimulateParams struct {
+	opts  *gethapi.SimOpts
+	block *gethrpc.BlockNumber
+}
+
+func SimulateValidate(reqParams []any, builder *CallBuilder[SimulateParams, []gethapi.SimBlockResult], _ *EncryptionManager) error {
+	// Parameters are [SimOpts, BlockNumber (optional)]
+	if len(reqParams) < 1 || len(reqParams) > 2 {
+		builder.Err = fmt.Errorf("unexpected number of parameters")
+		return nil
+	}
+
+	serialised, err := json.Marshal(reqParams[0])
+	if err != nil {
+		builder.Err = fmt.Errorf("invalid parameter %w", err)
+		return nil
+	}
+	var opts gethapi.SimOpts
+	if err := json.Unmarshal(serialised, &opts); err != nil {
+		builder.Err = fmt.Errorf("invalid parameter %w", err)
+		return nil
+	}
+
+	// all the calls must be made by the same account, which is authenticated by the viewing key
+	for _, block := range opts.BlockStateCalls {
+		for _, call := range block.Calls {
+			if call.From == nil {
+				builder.Err = fmt.Errorf("no from address provided")
+				return nil
+			}
+			if builder.From == nil {
+				builder.From = call.From
+			}
+			if *call.From != *builder.From {
+				builder.Err = fmt.Errorf("all the simulated calls must have the same from address")
+				return nil
+			}
+		}
+	}
+	if builder.From == nil {
+		builder.Err = fmt.Errorf("no calls provided")
+		return nil
+	}
+
+	blkNumber, err := gethencoding.ExtractOptionalBlockNumber(reqParams, 1)
+	if err != nil {
+		builder.Err = fmt.Errorf("unable to extract requested block number - %w", err)
+		return nil
+	}
+	if blkNumber.BlockNumber == nil {
+		builder.Err = fmt.Errorf("only block numbers are supported")
+		return nil
+	}
+
+	builder.Param = &SimulateParams{&opts, blkNumber.BlockNumber}
+	return nil
+}
+
+func SimulateExecute(builder *CallBuilder[SimulateParams, []gethapi.SimBlockResult], rpc *EncryptionManager) error {
+	err := authenticateFrom(builder.VK, builder.From)
+	if err != nil {
+		builder.Err = err
+		return nil //nolint:nilerr
+	}
+
+	results, err := rpc.chain.Simulate(builder.ctx, builder.Param.opts, builder.Param.block)
+	if err != nil {
+		rpc.logger.Debug("Failed eth_simulateV1.", log.ErrKey, err)
+		builder.Err = err
+		return nil //nolint:nilerr
+	}
+	builder.ReturnValue = &results
+	return nil
+}
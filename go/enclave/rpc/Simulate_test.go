@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/gethapi"
+	"github.com/ten-protocol/go-ten/go/enclave/vkhandler"
+	"github.com/ten-protocol/go-ten/integration/datagenerator"
+)
+
+func TestSimulate(t *testing.T) {
+	sender, contract := datagenerator.RandomAddress(), datagenerator.RandomAddress()
+	results := []gethapi.SimBlockResult{
+		{Calls: []gethapi.SimCallResult{{ReturnValue: hexutil.Bytes{1}, GasUsed: 21_000, Status: 1}, {ReturnValue: hexutil.Bytes{2}, GasUsed: 22_000, Status: 1}}},
+		{Calls: []gethapi.SimCallResult{{Status: 0, Error: &gethapi.SimCallError{Message: "execution reverted", Code: 3}}}},
+	}
+	chain := &simulationChain{results: results}
+	rpc := &EncryptionManager{chain: chain, logger: gethlog.New()}
+	call := map[string]any{"from": sender.Hex(), "to": contract.Hex()}
+	opts := map[string]any{"blockStateCalls": []any{
+		map[string]any{"calls": []any{call, call}},
+		map[string]any{"calls": []any{call}},
+	}}
+
+	builder := &CallBuilder[SimulateParams, []gethapi.SimBlockResult]{ctx: context.Background(), VK: &vkhandler.AuthenticatedViewingKey{AccountAddress: &sender}}
+	require.NoError(t, SimulateValidate([]any{opts, "latest"}, builder, rpc))
+	require.NoError(t, builder.Err)
+	require.Equal(t, sender, *builder.From)
+	require.NoError(t, SimulateExecute(builder, rpc))
+	require.NoError(t, builder.Err)
+
+	// all the blocks and calls are simulated, and their results are returned in order
+	require.Len(t, chain.simulated.BlockStateCalls, 2)
+	require.Len(t, chain.simulated.BlockStateCalls[0].Calls, 2)
+	require.Len(t, chain.simulated.BlockStateCalls[1].Calls, 1)
+	require.Equal(t, results, *builder.ReturnValue)
+
+	// the simulation can only be run for the account of the viewing key
+	other := datagenerator.RandomAddress()
+	builder = &CallBuilder[SimulateParams, []gethapi.SimBlockResult]{ctx: context.Background(), VK: &vkhandler.AuthenticatedViewingKey{AccountAddress: &other}}
+	require.NoError(t, SimulateValidate([]any{opts, "latest"}, builder, rpc))
+	require.NoError(t, SimulateExecute(builder, rpc))
+	require.ErrorContains(t, builder.Err, "failed authentication")
+	require.Nil(t, builder.ReturnValue)
+}
+
+func TestSimulateValidate(t *testing.T) {
+	sender, other, contract := datagenerator.RandomAddress(), datagenerator.RandomAddress(), datagenerator.RandomAddress()
+	callFrom := func(from gethcommon.Address) map[string]any {
+		return map[string]any{"from": from.Hex(), "to": contract.Hex()}
+	}
+	blocks := func(calls ...[]any) map[string]any {
+		blockStateCalls := make([]any, len(calls))
+		for i, c := range calls {
+			blockStateCalls[i] = map[string]any{"calls": c}
+		}
+		return map[string]any{"blockStateCalls": blockStateCalls}
+	}
+
+	for name, params := range map[string][]any{
+		"no calls":                          {blocks()},
+		"no from":                           {blocks([]any{map[string]any{"to": contract.Hex()}})},
+		"another from in the same block":    {blocks([]any{callFrom(sender), callFrom(other)})},
+		"another from in a following block": {blocks([]any{callFrom(sender)}, []any{callFrom(other)})},
+		"a block hash":                      {blocks([]any{callFrom(sender)}), gethcommon.Hash{1}.Hex()},
+		"too many parameters":               {blocks([]any{callFrom(sender)}), "latest", "latest"},
+	} {
+		builder := &CallBuilder[SimulateParams, []gethapi.SimBlockResult]{ctx: context.Background()}
+		require.NoError(t, SimulateValidate(params, builder, nil), name)
+		require.Error(t, builder.Err, name)
+	}
+}
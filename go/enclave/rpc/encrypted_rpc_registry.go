@@ -0,0 +1,103 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/rpc"
+	"github.com/ten-protocol/go-ten/go/enclave/vkhandler"
+	"github.com/ten-protocol/go-ten/go/responses"
+)
+
+// MethodNotFoundCode - the standard JSON-RPC error code for unknown methods
+const MethodNotFoundCode = -32601
+
+// encryptedRPCHandler - the type-erased validate/execute pair of an encrypted method, called with the viewing keys of
+// all the accounts of the request
+type encryptedRPCHandler func(ctx context.Context, encManager *EncryptionManager, decodedRequest rpc.RequestWithVk, vks []*vkhandler.AuthenticatedViewingKey) (*responses.EnclaveResponse, common.SystemError)
+
+// EncryptedRPCRegistry - maps the names of the encrypted methods to their handlers.
+// Methods can be registered while the enclave is serving requests.
+type EncryptedRPCRegistry struct {
+	handlers     map[string]encryptedRPCHandler
+	handlersLock *sync.RWMutex
+}
+
+func NewEncryptedRPCRegistry() *EncryptedRPCRegistry {
+	return &EncryptedRPCRegistry{handlers: make(map[string]encryptedRPCHandler), handlersLock: &sync.RWMutex{}}
+}
+
+// NewDefaultEncryptedRPCRegistry - returns a registry with all the encrypted methods supported by the enclave
+func NewDefaultEncryptedRPCRegistry() *EncryptedRPCRegistry {
+	r := NewEncryptedRPCRegistry()
+	Register(r, rpc.ERPCCall, TenCallValidate, TenCallExecute)
+	Register(r, rpc.ERPCGetBalance, GetBalanceValidate, GetBalanceExecute)
+	Register(r, rpc.ERPCGetTransactionByHash, GetTransactionValidate, GetTransactionExecute)
+	Register(r, rpc.ERPCGetRawTransactionByHash, GetRawTransactionValidate, GetRawTransactionExecute)
+	Register(r, rpc.ERPCGetTransactionCount, GetTransactionCountValidate, GetTransactionCountExecute)
+	Register(r, rpc.ERPCGetTransactionReceipt, GetTransactionReceiptValidate, GetTransactionReceiptExecute)
+	Register(r, rpc.ERPCSendRawTransaction, SubmitTxValidate, SubmitTxExecute)
+	Register(r, rpc.ERPCResend, ResendValidate, ResendExecute)
+	Register(r, rpc.ERPCEstimateGas, EstimateGasValidate, EstimateGasExecute)
+	Register(r, rpc.ERPCCreateAccessList, CreateAccessListValidate, CreateAccessListExecute)
+	Register(r, rpc.ERPCSimulate, SimulateValidate, SimulateExecute)
+	RegisterMultiAccount(r, rpc.ERPCGetLogs, GetLogsValidate, GetLogsExecute)
+	Register(r, rpc.ERPCGetStorageAt, TenStorageReadValidate, TenStorageReadExecute)
+	Register(r, rpc.ERPCDebugLogs, DebugLogsValidate, DebugLogsExecute)
+	Register(r, rpc.ERPCDebugTraceTransaction, DebugTraceTransactionValidate, DebugTraceTransactionExecute)
+	Register(r, rpc.ERPCGetPersonalTransactions, GetPersonalTransactionsValidate, GetPersonalTransactionsExecute)
+	Register(r, rpc.ERPCGetPendingTransactions, GetPendingTransactionsValidate, GetPendingTransactionsExecute)
+	Register(r, rpc.ERPCGetProof, GetProofValidate, GetProofExecute)
+	return r
+}
+
+// Register - adds the validate/execute pair of an encrypted method. It panics if the method is already registered,
+// because that is a programming error.
+// The requests carrying the viewing keys of several accounts are rejected.
+func Register[P any, R any](r *EncryptedRPCRegistry, method string, validate ValidateFunc[P, R], execute ExecuteFunc[P, R]) {
+	register(r, method, false, validate, execute)
+}
+
+// RegisterMultiAccount - adds an encrypted method which can be executed for several accounts in a single request.
+// The results of the accounts are returned separately, and the caller merges them, so this is only meant for reads
+// whose results can be merged, like the logs.
+func RegisterMultiAccount[P any, R any](r *EncryptedRPCRegistry, method string, validate ValidateFunc[P, R], execute ExecuteFunc[P, R]) {
+	register(r, method, true, validate, execute)
+}
+
+func register[P any, R any](r *EncryptedRPCRegistry, method string, multiAccount bool, validate ValidateFunc[P, R], execute ExecuteFunc[P, R]) {
+	r.handlersLock.Lock()
+	defer r.handlersLock.Unlock()
+	if _, found := r.handlers[method]; found {
+		panic(fmt.Sprintf("encrypted method %s already registered", method))
+	}
+	r.handlers[method] = func(ctx context.Context, encManager *EncryptionManager, decodedRequest rpc.RequestWithVk, vks []*vkhandler.AuthenticatedViewingKey) (*responses.EnclaveResponse, common.SystemError) {
+		if len(vks) > 1 && !multiAccount {
+			return responses.AsPlaintextError(fmt.Errorf("invalid request. %s does not accept multiple viewing keys", method)), nil
+		}
+		return withVKEncryption(ctx, encManager, decodedRequest, vks, validate, execute)
+	}
+}
+
+// RegisterVersion - adds a new version of an encrypted method. Version 1 is the method without a version suffix
+func RegisterVersion[P any, R any](r *EncryptedRPCRegistry, method string, version uint, validate ValidateFunc[P, R], execute ExecuteFunc[P, R]) {
+	Register(r, rpc.VersionedMethod(method, version), validate, execute)
+}
+
+func (r *EncryptedRPCRegistry) lookup(method string) (encryptedRPCHandler, bool) {
+	r.handlersLock.RLock()
+	defer r.handlersLock.RUnlock()
+	h, found := r.handlers[method]
+	return h, found
+}
+
+// methodNotFoundError - the standard JSON-RPC error for unknown methods
+func methodNotFoundError(method string) error {
+	return &errutil.DataError{
+		Code: MethodNotFoundCode,
+		Err:  fmt.Sprintf("the method %s does not exist/is not available", method),
+	}
+}
@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/rpc"
+	"github.com/ten-protocol/go-ten/go/enclave/vkhandler"
+)
+
+func TestDefaultRegistryHasAllEncryptedMethods(t *testing.T) {
+	r := NewDefaultEncryptedRPCRegistry()
+	for _, m := range []string{rpc.ERPCCall, rpc.ERPCGetBalance, rpc.ERPCSendRawTransaction, rpc.ERPCGetLogs, rpc.ERPCGetStorageAt, rpc.ERPCDebugTraceTransaction} {
+		if _, found := r.lookup(m); !found {
+			t.Errorf("method %s not registered", m)
+		}
+	}
+	if _, found := r.lookup("ten_unknownMethod"); found {
+		t.Error("unknown method should not be found")
+	}
+}
+
+func TestRegisterVersion(t *testing.T) {
+	r := NewDefaultEncryptedRPCRegistry()
+	RegisterVersion(r, rpc.ERPCCall, 2, TenCallValidate, TenCallExecute)
+	if _, found := r.lookup(rpc.VersionedMethod(rpc.ERPCCall, 2)); !found {
+		t.Error("versioned method not registered")
+	}
+	if !rpc.IsEncryptedMethod(rpc.VersionedMethod(rpc.ERPCCall, 2)) {
+		t.Error("versioned method should be encrypted")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a method twice should panic")
+		}
+	}()
+	Register(r, rpc.ERPCCall, TenCallValidate, TenCallExecute)
+}
+
+func TestRegisterWhileServing(t *testing.T) {
+	r := NewDefaultEncryptedRPCRegistry()
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			r.lookup(rpc.ERPCCall)
+		}
+	}()
+	for v := uint(2); v < 100; v++ {
+		RegisterVersion(r, rpc.ERPCCall, v, TenCallValidate, TenCallExecute)
+	}
+	wg.Wait()
+	if _, found := r.lookup(rpc.VersionedMethod(rpc.ERPCCall, 99)); !found {
+		t.Error("versioned method not registered")
+	}
+}
+
+func TestMethodNotFoundError(t *testing.T) {
+	var dataErr *errutil.DataError
+	if !errors.As(methodNotFoundError("ten_unknownMethod"), &dataErr) || dataErr.ErrorCode() != MethodNotFoundCode {
+		t.Error("expected a method not found error")
+	}
+}
+
+func TestOnlyLogsAcceptMultipleViewingKeys(t *testing.T) {
+	r := NewDefaultEncryptedRPCRegistry()
+	vks := []*vkhandler.AuthenticatedViewingKey{{}, {}}
+	for _, m := range []string{rpc.ERPCSendRawTransaction, rpc.ERPCResend, rpc.ERPCCall, rpc.ERPCGetTransactionReceipt, rpc.ERPCGetBalance} {
+		handler, found := r.lookup(m)
+		if !found {
+			t.Fatalf("method %s not registered", m)
+		}
+		// the results of these methods are not merged, and a transaction must not be submitted once per account
+		req := rpc.RequestWithVk{Method: m, Params: []any{"0x00"}}
+		resp, sysErr := handler(context.Background(), nil, req, vks)
+		if sysErr != nil {
+			t.Fatalf("unexpected system error %s", sysErr)
+		}
+		// the error is in plaintext, so the host does not forward the transaction to the sequencer
+		if resp.Error() == nil {
+			t.Errorf("method %s should reject multiple viewing keys", m)
+		}
+	}
+}
@@ -3,6 +3,10 @@ package rpc
 import (
 	"fmt"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common"
+
 	"github.com/ten-protocol/go-ten/go/enclave/crypto"
 
 	"github.com/ten-protocol/go-ten/go/common/privacy"
@@ -12,6 +16,7 @@ import (
 	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/ten-protocol/go-ten/go/enclave/components"
 	"github.com/ten-protocol/go-ten/go/enclave/crosschain"
+	"github.com/ten-protocol/go-ten/go/enclave/debugger"
 	"github.com/ten-protocol/go-ten/go/enclave/l2chain"
 	"github.com/ten-protocol/go-ten/go/enclave/storage"
 )
@@ -19,27 +24,38 @@ import (
 // EncryptionManager manages the decryption and encryption of enclave comms.
 type EncryptionManager struct {
 	chain                l2chain.ObscuroChain
+	debugger             *debugger.Debugger
 	rpcKeyService        *crypto.RPCKeyService
 	storage              storage.Storage
 	cacheService         *storage.CacheService
 	registry             components.BatchRegistry
 	processors           *crosschain.Processors
-	mempool              *components.TxPool
+	mempool              txPool
 	gasOracle            gas.Oracle
 	blockResolver        storage.BlockResolver
 	l1BlockProcessor     components.L1BlockProcessor
 	config               *enclaveconfig.EnclaveConfig
 	logger               gethlog.Logger
 	storageSlotWhitelist *privacy.Whitelist
+	methods              *EncryptedRPCRegistry
+}
+
+// txPool - the mempool operations used by the encrypted methods
+type txPool interface {
+	SubmitTx(transaction *common.L2Tx) error
+	GetTx(hash gethcommon.Hash) *types.Transaction
+	GetTxBySenderAndNonce(sender gethcommon.Address, nonce uint64) (*types.Transaction, bool)
+	ContentFrom(sender gethcommon.Address) ([]*types.Transaction, []*types.Transaction, bool)
 }
 
-func NewEncryptionManager(storage storage.Storage, cacheService *storage.CacheService, registry components.BatchRegistry, mempool *components.TxPool, processors *crosschain.Processors, config *enclaveconfig.EnclaveConfig, oracle gas.Oracle, blockResolver storage.BlockResolver, l1BlockProcessor components.L1BlockProcessor, chain l2chain.ObscuroChain, rpcKeyService *crypto.RPCKeyService, logger gethlog.Logger) *EncryptionManager {
+func NewEncryptionManager(storage storage.Storage, cacheService *storage.CacheService, registry components.BatchRegistry, mempool *components.TxPool, processors *crosschain.Processors, config *enclaveconfig.EnclaveConfig, oracle gas.Oracle, blockResolver storage.BlockResolver, l1BlockProcessor components.L1BlockProcessor, chain l2chain.ObscuroChain, debugger *debugger.Debugger, rpcKeyService *crypto.RPCKeyService, logger gethlog.Logger) *EncryptionManager {
 	return &EncryptionManager{
 		storage:              storage,
 		cacheService:         cacheService,
 		registry:             registry,
 		processors:           processors,
 		chain:                chain,
+		debugger:             debugger,
 		config:               config,
 		blockResolver:        blockResolver,
 		l1BlockProcessor:     l1BlockProcessor,
@@ -48,9 +64,15 @@ func NewEncryptionManager(storage storage.Storage, cacheService *storage.CacheSe
 		rpcKeyService:        rpcKeyService,
 		storageSlotWhitelist: privacy.NewWhitelist(),
 		mempool:              mempool,
+		methods:              NewDefaultEncryptedRPCRegistry(),
 	}
 }
 
+// Methods returns the registry of the encrypted methods, which can be used to add new methods, also while serving requests
+func (rpc *EncryptionManager) Methods() *EncryptedRPCRegistry {
+	return rpc.methods
+}
+
 // DecryptBytes decrypts the bytes with the enclave's private key.
 func (rpc *EncryptionManager) DecryptBytes(encryptedBytes []byte) ([]byte, error) {
 	bytes, err := rpc.rpcKeyService.DecryptRPCRequest(encryptedBytes)
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -72,85 +73,119 @@ func HandleEncryptedRPC(ctx context.Context,
 	if err != nil {
 		return responses.AsPlaintextError(fmt.Errorf("invalid viewing key - %w", err)), nil
 	}
+	vks, err := verifyExtraViewingKeys(decodedRequest, vk, encManager.config.TenChainID)
+	if err != nil {
+		return responses.AsPlaintextError(err), nil
+	}
 
 	// 4. Call the function that knows how to validate the request
-	switch decodedRequest.Method {
-	case rpc.ERPCCall:
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, TenCallValidate, TenCallExecute)
-	case rpc.ERPCGetBalance:
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, GetBalanceValidate, GetBalanceExecute)
-	case rpc.ERPCGetTransactionByHash:
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, GetTransactionValidate, GetTransactionExecute)
-	case rpc.ERPCGetRawTransactionByHash:
-		// todo - implement?
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, GetTransactionValidate, GetTransactionExecute)
-	case rpc.ERPCGetTransactionCount:
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, GetTransactionCountValidate, GetTransactionCountExecute)
-	case rpc.ERPCGetTransactionReceipt:
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, GetTransactionReceiptValidate, GetTransactionReceiptExecute)
-	case rpc.ERPCSendRawTransaction:
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, SubmitTxValidate, SubmitTxExecute)
-	case rpc.ERPCResend:
-		// todo - implement
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, SubmitTxValidate, SubmitTxExecute)
-	case rpc.ERPCEstimateGas:
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, EstimateGasValidate, EstimateGasExecute)
-	case rpc.ERPCGetLogs:
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, GetLogsValidate, GetLogsExecute)
-	case rpc.ERPCGetStorageAt:
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, TenStorageReadValidate, TenStorageReadExecute)
-	case rpc.ERPCDebugLogs:
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, DebugLogsValidate, DebugLogsExecute)
-	case rpc.ERPCGetPersonalTransactions:
-		return withVKEncryption(ctx, encManager, decodedRequest, vk, GetPersonalTransactionsValidate, GetPersonalTransactionsExecute)
-	default:
-		panic(fmt.Sprintf("unsupported method %s", decodedRequest.Method))
+	handler, found := encManager.methods.lookup(decodedRequest.Method)
+	if !found {
+		return responses.AsEncryptedError(methodNotFoundError(decodedRequest.Method), vk), nil
+	}
+	return handler(ctx, encManager, decodedRequest, vks)
+}
+
+// verifyExtraViewingKeys returns the viewing keys of all the accounts of the request, starting with the main one
+func verifyExtraViewingKeys(decodedRequest rpc.RequestWithVk, vk *vkhandler.AuthenticatedViewingKey, chainID int64) ([]*vkhandler.AuthenticatedViewingKey, error) {
+	if len(decodedRequest.ExtraVKs)+1 > rpc.MaxViewingKeysPerRequest {
+		return nil, fmt.Errorf("invalid request. too many viewing keys, the maximum is %d", rpc.MaxViewingKeysPerRequest)
+	}
+	vks := []*vkhandler.AuthenticatedViewingKey{vk}
+	for _, rpcVK := range decodedRequest.ExtraVKs {
+		if rpcVK == nil {
+			return nil, fmt.Errorf("invalid request. viewing key is missing")
+		}
+		extraVK, err := vkhandler.VerifyViewingKey(rpcVK, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid viewing key - %w", err)
+		}
+		// the response is encrypted with the main viewing key, so it must be readable by the owner of every account
+		if !bytes.Equal(extraVK.UserID, vk.UserID) {
+			return nil, fmt.Errorf("invalid request. the viewing keys must share the same key pair")
+		}
+		vks = append(vks, extraVK)
 	}
+	return vks, nil
 }
 
 // withVKEncryption
 // P - the type of the temporary parameter calculated after phase 1
 // R - the type of the result
+// When the request has several viewing keys, the call is executed for each account and the results are returned
+// together as responses.AccountResponses, encrypted with the main viewing key.
 func withVKEncryption[P any, R any](
 	ctx context.Context,
 	encManager *EncryptionManager,
 	decodedRequest rpc.RequestWithVk,
-	vk *vkhandler.AuthenticatedViewingKey,
+	vks []*vkhandler.AuthenticatedViewingKey,
 	validate ValidateFunc[P, R],
 	execute ExecuteFunc[P, R],
 ) (*responses.EnclaveResponse, common.SystemError) {
+	vk := vks[0]
+	if len(vks) == 1 {
+		builder, err := executeWithVK(ctx, encManager, decodedRequest, vk, validate, execute)
+		if err != nil {
+			return responses.AsPlaintextError(errInt), responses.ToInternalError(err)
+		}
+		if builder.Err != nil {
+			return responses.AsEncryptedError(builder.Err, vk), nil //nolint:nilerr
+		}
+		if builder.Status == NotFound {
+			// if the requested resource was not found, return an empty response
+			// todo - this must be encrypted - but we have some logic that expects it unencrypted, which is a bug
+			// return responses.AsEncryptedEmptyResponse(vk), nil
+			return responses.AsEmptyResponse(), nil
+		}
+		return responses.AsEncryptedResponse[R](builder.ReturnValue, vk), nil
+	}
+
+	accountResponses := make([]responses.AccountResponse, 0, len(vks))
+	for _, accountVK := range vks {
+		builder, err := executeWithVK(ctx, encManager, decodedRequest, accountVK, validate, execute)
+		if err != nil {
+			return responses.AsPlaintextError(errInt), responses.ToInternalError(err)
+		}
+		result := builder.ReturnValue
+		if builder.Status == NotFound {
+			result = nil
+		}
+		accountResponses = append(accountResponses, responses.AsAccountResponse(*accountVK.AccountAddress, result, builder.Err))
+	}
+	return responses.AsEncryptedResponse(&responses.AccountResponses{Accounts: accountResponses}, vk), nil
+}
+
+// executeWithVK validates and executes the call for the account of the viewing key. The returned error is a system
+// error, while the errors to be returned to the user are set on the builder.
+func executeWithVK[P any, R any](
+	ctx context.Context,
+	encManager *EncryptionManager,
+	decodedRequest rpc.RequestWithVk,
+	vk *vkhandler.AuthenticatedViewingKey,
+	validate ValidateFunc[P, R],
+	execute ExecuteFunc[P, R],
+) (*CallBuilder[P, R], error) {
 	// 4. Call the function that knows how to validate the request
 	builder := &CallBuilder[P, R]{Status: NotSet, VK: vk, ctx: ctx}
 
 	err := validate(decodedRequest.Params, builder, encManager)
 	if err != nil {
-		return responses.AsPlaintextError(errInt), responses.ToInternalError(err)
+		return nil, err
 	}
 	if builder.Err != nil {
-		return responses.AsEncryptedError(builder.Err, vk), nil //nolint:nilerr
+		return builder, nil
 	}
 
 	// 5. Execute the authorisation and call
 	// Note - it is the responsibility of this function to check that the authenticated address is authorised to view the data
 	err = execute(builder, encManager)
 	if err != nil {
-		return responses.AsPlaintextError(errInt), responses.ToInternalError(err)
-	}
-	if builder.Err != nil {
-		return responses.AsEncryptedError(builder.Err, vk), nil //nolint:nilerr
+		return nil, err
 	}
-	if builder.Status == NotFound {
-		// if the requested resource was not found, return an empty response
-		// todo - this must be encrypted - but we have some logic that expects it unencrypted, which is a bug
-		// return responses.AsEncryptedEmptyResponse(vk), nil
-		return responses.AsEmptyResponse(), nil
+	if builder.Err == nil && builder.Status == NotAuthorised {
+		builder.Err = errors.New("not authorised")
 	}
-	if builder.Status == NotAuthorised {
-		// if the requested resource was not found, return an empty response
-		return responses.AsEncryptedError(errors.New("not authorised"), vk), nil
-	}
-
-	return responses.AsEncryptedResponse[R](builder.ReturnValue, vk), nil
+	return builder, nil
 }
 
 func authenticateFrom(vk *vkhandler.AuthenticatedViewingKey, from *gethcommon.Address) error {
@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/rpc"
+	"github.com/ten-protocol/go-ten/go/common/viewingkey"
+	"github.com/ten-protocol/go-ten/go/enclave/vkhandler"
+)
+
+const testChainID = 443
+
+// signViewingKey returns the viewing key signed by a new account
+func signViewingKey(t *testing.T, vkPrivKey *ecdsa.PrivateKey) *viewingkey.RPCSignedViewingKey {
+	accountKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	vkPubKey := crypto.CompressPubkey(&vkPrivKey.PublicKey)
+	msg, err := viewingkey.GenerateMessage(viewingkey.CalculateUserID(vkPubKey), testChainID, viewingkey.PersonalSignVersion, viewingkey.PersonalSign)
+	require.NoError(t, err)
+	msgHash, err := viewingkey.GetMessageHash(msg, viewingkey.PersonalSign)
+	require.NoError(t, err)
+	signature, err := crypto.Sign(msgHash, accountKey)
+	require.NoError(t, err)
+	return &viewingkey.RPCSignedViewingKey{PublicKey: vkPubKey, SignatureWithAccountKey: signature, SignatureType: viewingkey.PersonalSign}
+}
+
+func TestVerifyExtraViewingKeys(t *testing.T) {
+	vkPrivKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	mainVK := signViewingKey(t, vkPrivKey)
+	vk, err := vkhandler.VerifyViewingKey(mainVK, testChainID)
+	require.NoError(t, err)
+
+	// the viewing key signed by other accounts of the same user
+	req := rpc.RequestWithVk{VK: mainVK, ExtraVKs: []*viewingkey.RPCSignedViewingKey{signViewingKey(t, vkPrivKey), signViewingKey(t, vkPrivKey)}}
+	vks, err := verifyExtraViewingKeys(req, vk, testChainID)
+	require.NoError(t, err)
+	require.Len(t, vks, 3)
+	require.Equal(t, vk, vks[0])
+	require.NotEqual(t, *vks[1].AccountAddress, *vks[2].AccountAddress)
+
+	// a viewing key of another user can't be added, because it could not read the response
+	otherVKPrivKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	req.ExtraVKs = append(req.ExtraVKs, signViewingKey(t, otherVKPrivKey))
+	_, err = verifyExtraViewingKeys(req, vk, testChainID)
+	require.Error(t, err)
+
+	req.ExtraVKs = make([]*viewingkey.RPCSignedViewingKey, rpc.MaxViewingKeysPerRequest)
+	_, err = verifyExtraViewingKeys(req, vk, testChainID)
+	require.ErrorContains(t, err, "too many viewing keys")
+}
@@ -14,7 +14,6 @@ import (
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/common/rpc"
 	"github.com/ten-protocol/go-ten/go/common/rpc/generated"
-	"github.com/ten-protocol/go-ten/go/common/tracers"
 	"google.golang.org/grpc"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -247,23 +246,6 @@ func (s *RPCServer) CreateBatch(ctx context.Context, r *generated.CreateBatchReq
 	return &generated.CreateBatchResponse{}, sysError
 }
 
-func (s *RPCServer) DebugTraceTransaction(ctx context.Context, req *generated.DebugTraceTransactionRequest) (*generated.DebugTraceTransactionResponse, error) {
-	txHash := gethcommon.BytesToHash(req.TxHash)
-	var config tracers.TraceConfig
-
-	sysError := json.Unmarshal(req.Config, &config)
-	if sysError != nil {
-		s.logger.Error("Error calling debug tx", log.ErrKey, sysError)
-
-		return &generated.DebugTraceTransactionResponse{
-			SystemError: toRPCError(fmt.Errorf("unable to unmarshall config - %w", sysError)),
-		}, nil
-	}
-
-	traceTx, sysError := s.enclave.DebugTraceTransaction(ctx, txHash, &config)
-	return &generated.DebugTraceTransactionResponse{Msg: string(traceTx), SystemError: toRPCError(sysError)}, nil
-}
-
 func (s *RPCServer) GetBatch(ctx context.Context, request *generated.GetBatchRequest) (*generated.GetBatchResponse, error) {
 	batch, err := s.enclave.GetBatch(ctx, gethcommon.BytesToHash(request.KnownHead))
 	if err != nil {
@@ -383,6 +365,24 @@ func (s *RPCServer) GetTotalContractCount(ctx context.Context, _ *generated.GetT
 	}, nil
 }
 
+func (s *RPCServer) FeeHistoryRewards(ctx context.Context, req *generated.FeeHistoryRewardsRequest) (*generated.FeeHistoryRewardsResponse, error) {
+	rewards, sysError := s.enclave.FeeHistoryRewards(ctx, req.FromHeight, req.ToHeight, req.RewardPercentiles)
+	if sysError != nil {
+		s.logger.Error("Error FeeHistoryRewards", log.ErrKey, sysError)
+		return &generated.FeeHistoryRewardsResponse{SystemError: toRPCError(sysError)}, nil
+	}
+
+	batchRewards := make([]*generated.BatchRewards, 0, len(rewards))
+	for height, batchReward := range rewards {
+		encoded := make([][]byte, len(batchReward))
+		for i, reward := range batchReward {
+			encoded[i] = reward.Bytes()
+		}
+		batchRewards = append(batchRewards, &generated.BatchRewards{Height: height, Rewards: encoded})
+	}
+	return &generated.FeeHistoryRewardsResponse{BatchRewards: batchRewards}, nil
+}
+
 func (s *RPCServer) EncryptedRPC(ctx context.Context, req *generated.EncCallRequest) (*generated.EncCallResponse, error) {
 	enclaveResp, sysError := s.enclave.EncryptedRPC(ctx, req.EncryptedParams)
 	if sysError != nil {
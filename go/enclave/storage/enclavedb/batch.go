@@ -347,6 +347,46 @@ func ReadBatchTransactions(ctx context.Context, db *sql.DB, height uint64) ([]*c
 	return txs, nil
 }
 
+// ReadCanonicalBatchTxFees returns the canonical batches between the heights, each with the fees paid by its transactions
+func ReadCanonicalBatchTxFees(ctx context.Context, db *sql.DB, fromHeight uint64, toHeight uint64) ([]*core.BatchTxFees, error) {
+	headers, err := fetchBatches(ctx, db, " where b.height>=? and b.height<=? and b.is_canonical=true order by b.height", fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*core.BatchTxFees, 0, len(headers))
+	byHeight := make(map[uint64]*core.BatchTxFees, len(headers))
+	for _, h := range headers {
+		batchFees := &core.BatchTxFees{Header: h}
+		result = append(result, batchFees)
+		byHeight[h.Number.Uint64()] = batchFees
+	}
+
+	rows, err := db.QueryContext(ctx,
+		"select b.height, rec.effective_gas_price, rec.cumulative_gas_used "+
+			"from receipt rec "+
+			"join batch b on rec.batch=b.sequence "+
+			"join tx on rec.tx=tx.id "+
+			"where b.height>=? and b.height<=? and b.is_canonical=true and tx.is_synthetic=false",
+		fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var height, gasUsed uint64
+		var effectiveGasPrice sql.NullInt64
+		if err := rows.Scan(&height, &effectiveGasPrice, &gasUsed); err != nil {
+			return nil, err
+		}
+		batchFees, found := byHeight[height]
+		if !found {
+			continue
+		}
+		batchFees.Txs = append(batchFees.Txs, core.TxFee{EffectiveGasPrice: big.NewInt(effectiveGasPrice.Int64), GasUsed: gasUsed})
+	}
+	return result, rows.Err()
+}
+
 func ReadContractCreationCount(ctx context.Context, db *sql.DB) (*big.Int, error) {
 	row := db.QueryRowContext(ctx, "select count(id) from contract")
 
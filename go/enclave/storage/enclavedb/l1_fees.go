@@ -0,0 +1,23 @@
+package enclavedb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+const (
+	// `replace` works for both sqlite and edgeless db
+	l1FeesInsert = "replace into l1_fees (block_hash, fees) values (?,?)"
+	l1FeesSelect = "select fees from l1_fees where block_hash=?"
+)
+
+func WriteL1Fees(ctx context.Context, dbtx *sql.Tx, blockHash common.L1BlockHash, fees []byte) error {
+	_, err := dbtx.ExecContext(ctx, l1FeesInsert, blockHash.Bytes(), fees)
+	return err
+}
+
+func FetchL1Fees(ctx context.Context, db *sql.DB, blockHash common.L1BlockHash) ([]byte, error) {
+	return readSingleRow(ctx, db, l1FeesSelect, blockHash.Bytes())
+}
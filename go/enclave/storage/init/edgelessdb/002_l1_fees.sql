@@ -0,0 +1,6 @@
+create table if not exists tendb.l1_fees
+(
+    block_hash binary(32),
+    fees       blob NOT NULL,
+    primary key (block_hash)
+);
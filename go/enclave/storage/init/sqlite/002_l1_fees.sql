@@ -0,0 +1,5 @@
+create table if not exists l1_fees
+(
+    block_hash binary(32) primary key,
+    fees       blob       NOT NULL
+);
@@ -118,6 +118,12 @@ type EnclaveKeyStorage interface {
 	GetEnclaveKey(ctx context.Context) ([]byte, error)
 }
 
+// L1FeesStorage - persists the L1 fees calculated by the gas oracle for each L1 block
+type L1FeesStorage interface {
+	StoreL1Fees(ctx context.Context, blockHash common.L1BlockHash, fees []byte) error
+	FetchL1Fees(ctx context.Context, blockHash common.L1BlockHash) ([]byte, error)
+}
+
 type SystemContractAddressesStorage interface {
 	StoreSystemContractAddresses(ctx context.Context, addresses common.SystemContractAddresses) error
 	GetSystemContractAddresses(ctx context.Context) (common.SystemContractAddresses, error)
@@ -135,6 +141,7 @@ type Storage interface {
 	EnclaveKeyStorage
 	ScanStorage
 	SystemContractAddressesStorage
+	L1FeesStorage
 	io.Closer
 
 	// HealthCheck returns whether the storage is deemed healthy or not
@@ -160,6 +167,8 @@ type Storage interface {
 
 type ScanStorage interface {
 	GetContractCount(ctx context.Context) (*big.Int, error)
+	// FetchCanonicalBatchTxFees returns the canonical batches between the heights, with the fees paid by their transactions
+	FetchCanonicalBatchTxFees(ctx context.Context, fromHeight uint64, toHeight uint64) ([]*core.BatchTxFees, error)
 	GetTransactionsPerAddress(ctx context.Context, address *gethcommon.Address, pagination *common.QueryPagination) ([]*core.InternalReceipt, error)
 
 	CountTransactionsPerAddress(ctx context.Context, addr *gethcommon.Address) (uint64, error)
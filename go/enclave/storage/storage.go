@@ -763,6 +763,24 @@ func (s *storageImpl) GetEnclaveKey(ctx context.Context) ([]byte, error) {
 	return enclavedb.FetchConfig(ctx, s.db.GetSQLDB(), enclaveKeyCfg)
 }
 
+func (s *storageImpl) StoreL1Fees(ctx context.Context, blockHash common.L1BlockHash, fees []byte) error {
+	defer s.logDuration("StoreL1Fees", measure.NewStopwatch())
+	dbTx, err := s.db.NewDBTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("could not create DB transaction - %w", err)
+	}
+	defer dbTx.Rollback()
+	if err := enclavedb.WriteL1Fees(ctx, dbTx, blockHash, fees); err != nil {
+		return fmt.Errorf("could not write l1 fees. Cause: %w", err)
+	}
+	return dbTx.Commit()
+}
+
+func (s *storageImpl) FetchL1Fees(ctx context.Context, blockHash common.L1BlockHash) ([]byte, error) {
+	defer s.logDuration("FetchL1Fees", measure.NewStopwatch())
+	return enclavedb.FetchL1Fees(ctx, s.db.GetSQLDB(), blockHash)
+}
+
 func (s *storageImpl) StoreRollup(ctx context.Context, rollup *common.ExtRollup, internalHeader *common.CalldataRollupHeader) error {
 	defer s.logDuration("StoreRollup", measure.NewStopwatch())
 
@@ -841,6 +859,11 @@ func (s *storageImpl) BatchWasExecuted(ctx context.Context, hash common.L2BatchH
 	return enclavedb.BatchWasExecuted(ctx, s.db.GetSQLDB(), hash)
 }
 
+func (s *storageImpl) FetchCanonicalBatchTxFees(ctx context.Context, fromHeight uint64, toHeight uint64) ([]*core.BatchTxFees, error) {
+	defer s.logDuration("FetchCanonicalBatchTxFees", measure.NewStopwatch())
+	return enclavedb.ReadCanonicalBatchTxFees(ctx, s.db.GetSQLDB(), fromHeight, toHeight)
+}
+
 func (s *storageImpl) GetTransactionsPerAddress(ctx context.Context, requester *gethcommon.Address, pagination *common.QueryPagination) ([]*core.InternalReceipt, error) {
 	defer s.logDuration("GetTransactionsPerAddress", measure.NewStopwatch())
 	return enclavedb.GetTransactionsPerAddress(ctx, s.db.GetSQLDB(), requester, pagination)
@@ -0,0 +1,260 @@
+package ethadapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+)
+
+const (
+	blobArchiveSchemeFile = "file"
+	blobArchiveSchemeS3   = "s3"
+	blobArchiveSchemeMem  = "mem"
+
+	defaultS3Region = "us-east-1"
+)
+
+// BlobArchiveStore - a store for the blobs published by the network, so they remain available after the beacon nodes
+// prune them. The blobs are addressed by their versioned hash, so storing the same blob twice is a no-op.
+type BlobArchiveStore interface {
+	StoreBlob(ctx context.Context, hash gethcommon.Hash, blob *kzg4844.Blob) error
+	// FetchBlob returns errutil.ErrNotFound if the blob is not in the archive
+	FetchBlob(ctx context.Context, hash gethcommon.Hash) (*kzg4844.Blob, error)
+}
+
+// NewBlobArchiveStore creates the archive store configured by the url. The supported formats are:
+//   - file:///path/to/dir - the blobs are files in the directory
+//   - s3://host:port/bucket/prefix?region=us-east-1&insecure=true - an S3 compatible object store, using path-style
+//     addressing. The credentials come from the AWS default credential chain, never from the url. The `insecure` flag
+//     uses http instead of https (e.g. for a local MinIO)
+//   - mem:// - an in-memory stand-in, for testing and local networks
+//
+// Returns nil if the url is empty.
+func NewBlobArchiveStore(archiveURL string) (BlobArchiveStore, error) {
+	if archiveURL == "" {
+		return nil, nil //nolint:nilnil
+	}
+	u, err := url.Parse(archiveURL)
+	if err != nil {
+		// the parse error quotes the url, which may contain a secret
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("invalid blob archive url. Cause: %w", err)
+	}
+	switch u.Scheme {
+	case blobArchiveSchemeFile:
+		return NewFileBlobArchiveStore(u.Path)
+	case blobArchiveSchemeS3:
+		return newS3BlobArchiveStore(u)
+	case blobArchiveSchemeMem:
+		return NewInMemBlobArchiveStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported blob archive scheme '%s'", u.Scheme)
+	}
+}
+
+type fileBlobArchiveStore struct {
+	dir string
+}
+
+func NewFileBlobArchiveStore(dir string) (BlobArchiveStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("blob archive directory not set")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("could not create blob archive directory. Cause: %w", err)
+	}
+	return &fileBlobArchiveStore{dir: dir}, nil
+}
+
+func (s *fileBlobArchiveStore) StoreBlob(_ context.Context, hash gethcommon.Hash, blob *kzg4844.Blob) error {
+	target := filepath.Join(s.dir, hash.Hex())
+	if _, err := os.Stat(target); err == nil {
+		return nil
+	}
+	// write to a temporary file first, so a partially written blob is never served
+	tmp, err := os.CreateTemp(s.dir, hash.Hex()+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create blob file. Cause: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(blob[:]); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not write blob file. Cause: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write blob file. Cause: %w", err)
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+func (s *fileBlobArchiveStore) FetchBlob(_ context.Context, hash gethcommon.Hash) (*kzg4844.Blob, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, hash.Hex()))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errutil.ErrNotFound
+		}
+		return nil, fmt.Errorf("could not read blob file. Cause: %w", err)
+	}
+	return toBlob(data)
+}
+
+type inMemBlobArchiveStore struct {
+	blobs map[gethcommon.Hash]kzg4844.Blob
+	mu    sync.RWMutex
+}
+
+func NewInMemBlobArchiveStore() BlobArchiveStore {
+	return &inMemBlobArchiveStore{blobs: make(map[gethcommon.Hash]kzg4844.Blob)}
+}
+
+func (s *inMemBlobArchiveStore) StoreBlob(_ context.Context, hash gethcommon.Hash, blob *kzg4844.Blob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[hash] = *blob
+	return nil
+}
+
+func (s *inMemBlobArchiveStore) FetchBlob(_ context.Context, hash gethcommon.Hash) (*kzg4844.Blob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	blob, found := s.blobs[hash]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return &blob, nil
+}
+
+// s3BlobArchiveStore - stores the blobs as objects in an S3 compatible bucket. The requests are signed with the AWS
+// signature version 4, using the credentials of the AWS default credential chain.
+type s3BlobArchiveStore struct {
+	client      *http.Client
+	endpoint    string // scheme://host
+	basePath    string // /bucket/prefix
+	region      string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+}
+
+func newS3BlobArchiveStore(u *url.URL) (BlobArchiveStore, error) {
+	// the url is part of the config, which is logged, so it can't carry the secret
+	if u.User != nil {
+		return nil, fmt.Errorf("the s3 blob archive url must not contain credentials, they are loaded from the AWS credential chain")
+	}
+	basePath := strings.TrimSuffix(u.Path, "/")
+	if u.Host == "" || basePath == "" {
+		return nil, fmt.Errorf("the s3 blob archive url must contain the host and the bucket")
+	}
+	scheme := "https"
+	if u.Query().Get("insecure") == "true" {
+		scheme = "http"
+	}
+
+	// the credentials are resolved when signing the first request, e.g. from the AWS_ACCESS_KEY_ID and
+	// AWS_SECRET_ACCESS_KEY environment variables, the shared credentials file or the role of the instance
+	var opts []func(*awsconfig.LoadOptions) error
+	if region := u.Query().Get("region"); region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the AWS config. Cause: %w", err)
+	}
+	region := awsCfg.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+	return &s3BlobArchiveStore{
+		client:      &http.Client{Timeout: 30 * time.Second},
+		endpoint:    scheme + "://" + u.Host,
+		basePath:    basePath,
+		region:      region,
+		credentials: awsCfg.Credentials,
+		signer:      v4.NewSigner(),
+	}, nil
+}
+
+func (s *s3BlobArchiveStore) StoreBlob(ctx context.Context, hash gethcommon.Hash, blob *kzg4844.Blob) error {
+	resp, err := s.do(ctx, http.MethodPut, hash, blob[:])
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not store blob %s. Status: %s", hash.Hex(), resp.Status)
+	}
+	return nil
+}
+
+func (s *s3BlobArchiveStore) FetchBlob(ctx context.Context, hash gethcommon.Hash) (*kzg4844.Blob, error) {
+	resp, err := s.do(ctx, http.MethodGet, hash, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errutil.ErrNotFound
+	default:
+		return nil, fmt.Errorf("could not fetch blob %s. Status: %s", hash.Hex(), resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxBlobBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not read blob %s. Cause: %w", hash.Hex(), err)
+	}
+	return toBlob(data)
+}
+
+func (s *s3BlobArchiveStore) do(ctx context.Context, method string, hash gethcommon.Hash, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.endpoint+path.Join(s.basePath, hash.Hex()), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	creds, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve the AWS credentials. Cause: %w", err)
+	}
+	payloadHash := sha256.Sum256(body)
+	payloadHashHex := hex.EncodeToString(payloadHash[:])
+	// S3 requires the hash of the payload as a header as well
+	req.Header.Set("x-amz-content-sha256", payloadHashHex)
+	if err := s.signer.SignHTTP(ctx, creds, req, payloadHashHex, "s3", s.region, time.Now().UTC()); err != nil {
+		return nil, fmt.Errorf("could not sign the blob archive request. Cause: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("blob archive request failed. Cause: %w", err)
+	}
+	return resp, nil
+}
+
+func toBlob(data []byte) (*kzg4844.Blob, error) {
+	var blob kzg4844.Blob
+	if len(data) != len(blob) {
+		return nil, fmt.Errorf("invalid archived blob size %d", len(data))
+	}
+	copy(blob[:], data)
+	return &blob, nil
+}
@@ -0,0 +1,156 @@
+package ethadapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+)
+
+func TestFileBlobArchiveStore(t *testing.T) {
+	store, err := NewBlobArchiveStore("file://" + t.TempDir())
+	require.NoError(t, err)
+	testBlobArchiveStore(t, store)
+}
+
+func TestInMemBlobArchiveStore(t *testing.T) {
+	store, err := NewBlobArchiveStore("mem://")
+	require.NoError(t, err)
+	testBlobArchiveStore(t, store)
+}
+
+// setAWSCredentials - the credentials of the environment, without the shared files or the instance role of the machine
+func setAWSCredentials(t *testing.T) aws.Credentials {
+	t.Setenv("AWS_ACCESS_KEY_ID", "key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	return aws.Credentials{AccessKeyID: "key", SecretAccessKey: "secret"}
+}
+
+// validSignature - whether the request is signed with the credentials, checked by signing it again at the same time
+func validSignature(r *http.Request, creds aws.Credentials, region string) bool {
+	signedAt, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return false
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	payloadHash := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(payloadHash[:]) {
+		return false
+	}
+
+	resigned, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.Path, nil)
+	if err != nil {
+		return false
+	}
+	resigned.ContentLength = r.ContentLength
+	resigned.Header.Set("X-Amz-Content-Sha256", r.Header.Get("X-Amz-Content-Sha256"))
+	err = v4.NewSigner().SignHTTP(context.Background(), creds, resigned, hex.EncodeToString(payloadHash[:]), "s3", region, signedAt)
+	return err == nil && resigned.Header.Get("Authorization") == r.Header.Get("Authorization")
+}
+
+func TestS3BlobArchiveStore(t *testing.T) {
+	creds := setAWSCredentials(t)
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !validSignature(r, creds, "eu-west-2") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = data
+		case http.MethodGet:
+			data, found := objects[r.URL.Path]
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	}))
+	defer server.Close()
+
+	store, err := NewBlobArchiveStore("s3://" + strings.TrimPrefix(server.URL, "http://") + "/bucket/blobs?region=eu-west-2&insecure=true")
+	require.NoError(t, err)
+	testBlobArchiveStore(t, store)
+
+	// the requests signed with other credentials are rejected
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "other")
+	store, err = NewBlobArchiveStore("s3://" + strings.TrimPrefix(server.URL, "http://") + "/bucket/blobs?region=eu-west-2&insecure=true")
+	require.NoError(t, err)
+	_, err = store.FetchBlob(context.Background(), gethcommon.HexToHash("0x01ab"))
+	require.ErrorContains(t, err, "403")
+
+	mu.Lock()
+	defer mu.Unlock()
+	for p := range objects {
+		require.True(t, strings.HasPrefix(p, "/bucket/blobs/0x"))
+	}
+}
+
+func TestInvalidBlobArchiveStore(t *testing.T) {
+	store, err := NewBlobArchiveStore("")
+	require.NoError(t, err)
+	require.Nil(t, store)
+
+	_, err = NewBlobArchiveStore("ftp://host/dir")
+	require.Error(t, err)
+
+	setAWSCredentials(t)
+	_, err = NewBlobArchiveStore("s3://host")
+	require.Error(t, err)
+
+	// the credentials can't be part of the url, and the errors don't leak them
+	for _, archiveURL := range []string{"s3://key:secret@host/bucket", "s3://key:secret@host:port/bucket", "s3://key:secret@host/bucket\x7f"} {
+		_, err = NewBlobArchiveStore(archiveURL)
+		require.Error(t, err, archiveURL)
+		require.NotContains(t, err.Error(), "secret", archiveURL)
+	}
+}
+
+func testBlobArchiveStore(t *testing.T, store BlobArchiveStore) {
+	ctx := context.Background()
+	hash := gethcommon.HexToHash("0x01ab")
+	var blob kzg4844.Blob
+	blob[0] = 1
+	blob[len(blob)-1] = 2
+
+	_, err := store.FetchBlob(ctx, hash)
+	require.ErrorIs(t, err, errutil.ErrNotFound)
+
+	require.NoError(t, store.StoreBlob(ctx, hash, &blob))
+	// storing the same blob again is a no-op
+	require.NoError(t, store.StoreBlob(ctx, hash, &blob))
+
+	fetched, err := store.FetchBlob(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, blob, *fetched)
+}
@@ -3,14 +3,16 @@ package mgmtcontractlib
 import "github.com/ten-protocol/go-ten/contracts/generated/ManagementContract"
 
 const (
-	AddRollupMethod                = "AddRollup"
-	RespondSecretMethod            = "RespondNetworkSecret"
-	RequestSecretMethod            = "RequestNetworkSecret"
-	InitializeSecretMethod         = "InitializeNetworkSecret" //#nosec
-	GetHostAddressesMethod         = "GetHostAddresses"
-	GetImportantContractKeysMethod = "GetImportantContractKeys"
-	SetImportantContractsMethod    = "SetImportantContractAddress"
-	GetImportantAddressMethod      = "importantContractAddresses"
+	AddRollupMethod                 = "AddRollup"
+	RespondSecretMethod             = "RespondNetworkSecret"
+	RequestSecretMethod             = "RequestNetworkSecret"
+	InitializeSecretMethod          = "InitializeNetworkSecret" //#nosec
+	GetHostAddressesMethod          = "GetHostAddresses"
+	GetImportantContractKeysMethod  = "GetImportantContractKeys"
+	SetImportantContractsMethod     = "SetImportantContractAddress"
+	GetImportantAddressMethod       = "importantContractAddresses"
+	AddCrossChainMessagesRootMethod = "addCrossChainMessagesRoot"
+	IsBundleAvailableMethod         = "isBundleAvailable"
 )
 
 var MgmtContractABI = ManagementContract.ManagementContractMetaData.ABI
@@ -29,6 +29,9 @@ type MgmtContractLib interface {
 	CreateRequestSecret(tx *common.L1RequestSecretTx) types.TxData
 	CreateRespondSecret(tx *common.L1RespondSecretTx, verifyAttester bool) types.TxData
 	CreateInitializeSecret(tx *common.L1InitializeSecretTx) types.TxData
+	// CreateCrossChainBundle creates the tx publishing the cross chain roots of the bundle. rollupNum and forkID are
+	// the last batch sequence number and the hash of the rollup the bundle belongs to.
+	CreateCrossChainBundle(bundle *common.ExtCrossChainBundle, rollupNum *big.Int, forkID gethcommon.Hash) (types.TxData, error)
 
 	// DecodeTx receives a *types.Transaction and converts it to a common.L1Transaction
 	DecodeTx(tx *types.Transaction) common.L1TenTransaction
@@ -46,6 +49,9 @@ type MgmtContractLib interface {
 
 	GetImportantAddressCallMsg(key string) (ethereum.CallMsg, error)
 	DecodeImportantAddressResponse(callResponse []byte) (gethcommon.Address, error)
+
+	IsBundleAvailableMsg(bundle *common.ExtCrossChainBundle) (ethereum.CallMsg, error)
+	DecodeIsBundleAvailableResponse(callResponse []byte) (bool, error)
 }
 
 type contractLibImpl struct {
@@ -209,6 +215,26 @@ func (c *contractLibImpl) CreateInitializeSecret(tx *common.L1InitializeSecretTx
 	}
 }
 
+func (c *contractLibImpl) CreateCrossChainBundle(bundle *common.ExtCrossChainBundle, rollupNum *big.Int, forkID gethcommon.Hash) (types.TxData, error) {
+	data, err := c.contractABI.Pack(
+		AddCrossChainMessagesRootMethod,
+		bundle.LastBatchHash,
+		bundle.L1BlockHash,
+		bundle.L1BlockNum,
+		[][]byte(bundle.CrossChainRootHashes),
+		bundle.Signature,
+		rollupNum,
+		forkID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not pack the cross chain bundle. Cause: %w", err)
+	}
+	return &types.LegacyTx{
+		To:   c.addr,
+		Data: data,
+	}, nil
+}
+
 func (c *contractLibImpl) GetHostAddressesMsg() (ethereum.CallMsg, error) {
 	data, err := c.contractABI.Pack(GetHostAddressesMethod)
 	if err != nil {
@@ -321,6 +347,31 @@ func (c *contractLibImpl) DecodeImportantAddressResponse(callResponse []byte) (g
 	return address, nil
 }
 
+func (c *contractLibImpl) IsBundleAvailableMsg(bundle *common.ExtCrossChainBundle) (ethereum.CallMsg, error) {
+	data, err := c.contractABI.Pack(IsBundleAvailableMethod, [][]byte(bundle.CrossChainRootHashes))
+	if err != nil {
+		return ethereum.CallMsg{}, fmt.Errorf("could not pack the call data. Cause: %w", err)
+	}
+	return ethereum.CallMsg{To: c.addr, Data: data}, nil
+}
+
+func (c *contractLibImpl) DecodeIsBundleAvailableResponse(callResponse []byte) (bool, error) {
+	unpackedResponse, err := c.contractABI.Unpack(IsBundleAvailableMethod, callResponse)
+	if err != nil {
+		return false, fmt.Errorf("could not unpack call response. Cause: %w", err)
+	}
+
+	if len(unpackedResponse) != 1 {
+		return false, fmt.Errorf("unexpected number of results (%d) returned from call, response: %s", len(unpackedResponse), unpackedResponse)
+	}
+	available, ok := unpackedResponse[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("could not convert element in call response to bool")
+	}
+
+	return available, nil
+}
+
 func (c *contractLibImpl) unpackInitSecretTx(tx *types.Transaction, method *abi.Method, contractCallData map[string]interface{}) *common.L1InitializeSecretTx {
 	err := method.Inputs.UnpackIntoMap(contractCallData, tx.Data()[methodBytesLen:])
 	if err != nil {
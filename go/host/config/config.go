@@ -1,6 +1,7 @@
 package config
 
 import (
+	"math/big"
 	"time"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -41,6 +42,8 @@ type HostConfig struct {
 	L1BlockTime time.Duration
 	// CrossChainInterval - The interval at which the host will check for new cross chain data to submit
 	CrossChainInterval time.Duration
+	// MinGasPrice - the minimum priority fee accepted by the sequencer's mempool
+	MinGasPrice *big.Int
 
 	/////
 	// NODE CONFIG
@@ -92,12 +95,19 @@ type HostConfig struct {
 	L1BeaconUrl string
 	// L1BlobArchiveUrl of the blob archive to fetch expired blob data
 	L1BlobArchiveUrl string
+	// L1BlobArchiveStore where the published rollup blobs are archived. Disabled when empty
+	L1BlobArchiveStore string
 	// Timeout duration for RPC requests to the enclave service
 	EnclaveRPCTimeout time.Duration
 	// Timeout duration for connecting to, and communicating with, the L1 node
 	L1RPCTimeout time.Duration
 	// Timeout duration for messaging between hosts.
 	P2PConnectionTimeout time.Duration
+	// P2PCompressionEnabled compresses the large messages sent to other hosts
+	P2PCompressionEnabled bool
+	// P2PExemptLoopback exempts the inbound P2P connections from the loopback interface from the per-IP limit, for the
+	// local networks running all the hosts on one machine
+	P2PExemptLoopback bool
 	// ProfilerEnabled starts a profiler instance
 	ProfilerEnabled bool
 	// MetricsEnabled defines whether the metrics are enabled or not
@@ -131,6 +141,7 @@ func HostConfigFromTenConfig(tenCfg *config.TenConfig) *HostConfig {
 		RollupInterval:     tenCfg.Network.Rollup.Interval,
 		MaxRollupSize:      tenCfg.Network.Rollup.MaxSize,
 		CrossChainInterval: tenCfg.Network.CrossChain.Interval,
+		MinGasPrice:        tenCfg.Network.Gas.MinGasPrice,
 
 		LogLevel: tenCfg.Host.Log.Level,
 		LogPath:  tenCfg.Host.Log.Path,
@@ -148,15 +159,18 @@ func HostConfigFromTenConfig(tenCfg *config.TenConfig) *HostConfig {
 		EnclaveRPCAddresses: tenCfg.Host.Enclave.RPCAddresses,
 		EnclaveRPCTimeout:   tenCfg.Host.Enclave.RPCTimeout,
 
-		IsInboundP2PDisabled: tenCfg.Host.P2P.IsDisabled,
-		P2PBindAddress:       tenCfg.Host.P2P.BindAddress,
-		P2PConnectionTimeout: tenCfg.Host.P2P.Timeout,
-		P2PPublicAddress:     tenCfg.Node.HostAddress,
-
-		L1WebsocketURL:   tenCfg.Host.L1.WebsocketURL,
-		L1BeaconUrl:      tenCfg.Host.L1.L1BeaconUrl,
-		L1BlobArchiveUrl: tenCfg.Host.L1.L1BlobArchiveUrl,
-		L1RPCTimeout:     tenCfg.Host.L1.RPCTimeout,
+		IsInboundP2PDisabled:  tenCfg.Host.P2P.IsDisabled,
+		P2PBindAddress:        tenCfg.Host.P2P.BindAddress,
+		P2PConnectionTimeout:  tenCfg.Host.P2P.Timeout,
+		P2PCompressionEnabled: tenCfg.Host.P2P.IsCompressionEnabled,
+		P2PExemptLoopback:     tenCfg.Host.P2P.IsLoopbackExempt,
+		P2PPublicAddress:      tenCfg.Node.HostAddress,
+
+		L1WebsocketURL:     tenCfg.Host.L1.WebsocketURL,
+		L1BeaconUrl:        tenCfg.Host.L1.L1BeaconUrl,
+		L1BlobArchiveUrl:   tenCfg.Host.L1.L1BlobArchiveUrl,
+		L1BlobArchiveStore: tenCfg.Host.L1.L1BlobArchiveStore,
+		L1RPCTimeout:       tenCfg.Host.L1.RPCTimeout,
 
 		ProfilerEnabled:       tenCfg.Host.Debug.EnableProfiler,
 		MetricsEnabled:        tenCfg.Host.Debug.EnableMetrics,
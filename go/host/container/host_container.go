@@ -28,11 +28,10 @@ import (
 )
 
 const (
-	APIVersion1       = "1.0"
-	APINamespaceTen   = "ten"
-	APINamespaceScan  = "scan"
-	APINamespaceTest  = "test"
-	APINamespaceDebug = "debug"
+	APIVersion1      = "1.0"
+	APINamespaceTen  = "ten"
+	APINamespaceScan = "scan"
+	APINamespaceTest = "test"
 )
 
 type HostContainer struct {
@@ -132,7 +131,7 @@ func NewHostContainerFromConfig(cfg *hostconfig.HostConfig, logger gethlog.Logge
 	p2pLogger := logger.New(log.CmpKey, log.P2PCmp)
 	metricsService := metrics.New(cfg.MetricsEnabled, cfg.MetricsHTTPPort, logger)
 
-	aggP2P := p2p.NewSocketP2PLayer(cfg, services, p2pLogger, metricsService.Registry())
+	aggP2P := p2p.NewSocketP2PLayer(cfg, services, ethWallet, p2pLogger, metricsService.Registry())
 	rpcServer := node.NewServer(&node.RPCConfig{
 		EnableHTTP: cfg.HasClientRPCHTTP,
 		HTTPPort:   int(cfg.ClientRPCPortHTTP),
@@ -145,7 +144,11 @@ func NewHostContainerFromConfig(cfg *hostconfig.HostConfig, logger gethlog.Logge
 	beaconClient := ethadapter.NewBeaconHTTPClient(new(http.Client), cfg.L1BeaconUrl)
 	// we can add more fallback clients as they become available
 	beaconFallback := ethadapter.NewBeaconHTTPClient(new(http.Client), cfg.L1BlobArchiveUrl)
-	blobResolver := l1.NewBlobResolver(ethadapter.NewL1BeaconClient(beaconClient, beaconFallback))
+	blobArchive, err := ethadapter.NewBlobArchiveStore(cfg.L1BlobArchiveStore)
+	if err != nil {
+		logger.Crit("could not create the blob archive store.", log.ErrKey, err)
+	}
+	blobResolver := l1.NewBlobResolver(ethadapter.NewL1BeaconClient(beaconClient, beaconFallback), blobArchive, logger)
 	contractAddresses := map[l1.ContractType][]gethcommon.Address{
 		l1.MgmtContract: {cfg.ManagementContractAddress},
 		l1.MsgBus:       {cfg.MessageBusAddress},
@@ -193,14 +196,6 @@ func NewHostContainer(cfg *hostconfig.HostConfig, services *host.ServicesRegistr
 			},
 		})
 
-		if cfg.DebugNamespaceEnabled {
-			rpcServer.RegisterAPIs([]rpc.API{
-				{
-					Namespace: APINamespaceDebug,
-					Service:   clientapi.NewNetworkDebug(h),
-				},
-			})
-		}
 		services.RegisterService(hostcommon.FilterAPIServiceName, filterAPI.NewHeadsService)
 	}
 	return hostContainer
@@ -40,6 +40,9 @@ const (
 
 	// when we have submitted request to L1 for the secret, how long do we wait for an answer before we retry
 	_maxWaitForSecretResponse = 2 * time.Minute
+
+	// how many times we try to publish the cross chain bundle of a rollup before giving up on it
+	_maxCrossChainBundleAttempts = 10
 )
 
 // This private interface enforces the services that the guardian depends on
@@ -83,6 +86,19 @@ type Guardian struct {
 	enclaveID        *common.EnclaveID
 
 	cleanupFuncs []func()
+
+	// the rolled up batch ranges whose cross chain bundles were not published yet, in the order of the rollups
+	pendingBundles     []*pendingBundle
+	pendingBundlesLock sync.Mutex
+}
+
+// pendingBundle - the range of batches of a published rollup. The cross chain bundle is bound to the rollup, so it is
+// rejected by the management contract if the rollup is not on the canonical L1 chain
+type pendingBundle struct {
+	fromSeqNo  uint64
+	toSeqNo    uint64
+	rollupHash gethcommon.Hash
+	attempts   int
 }
 
 func NewGuardian(cfg *hostconfig.HostConfig, hostData host.Identity, serviceLocator guardianServiceLocator, enclaveClient common.Enclave, storage storage.Storage, interrupter *stopcontrol.StopControl, logger gethlog.Logger) *Guardian {
@@ -677,8 +693,17 @@ func (g *Guardian) periodicRollupProduction() {
 					continue
 				}
 				// this method waits until the receipt is received
-				g.sl.L1Publisher().PublishRollup(producedRollup)
+				err = g.sl.L1Publisher().PublishRollup(producedRollup)
+				if err != nil {
+					// the rollup is produced again on the next tick
+					continue
+				}
 				lastSuccessfulRollup = time.Now()
+				g.addPendingBundle(&pendingBundle{
+					fromSeqNo:  fromBatch,
+					toSeqNo:    producedRollup.Header.LastBatchSeqNo,
+					rollupHash: producedRollup.Hash(),
+				})
 			}
 
 		case <-g.hostInterrupter.Done():
@@ -689,6 +714,117 @@ func (g *Guardian) periodicRollupProduction() {
 	}
 }
 
+func (g *Guardian) periodicBundleSubmission() {
+	defer g.logger.Info("Stopping bundle submission")
+
+	// the bundles of the rollups published before a restart
+	g.loadPendingBundles()
+
+	interval := g.crossChainInterval
+	if interval == 0 {
+		interval = g.blockTime
+	}
+	bundleSubmissionTicker := time.NewTicker(interval)
+
+	for {
+		select {
+		case <-bundleSubmissionTicker.C:
+			if !g.state.IsUpToDate() {
+				g.logger.Debug("Skipping bundle submission because L1 is not up to date", "state", g.state)
+				continue
+			}
+			g.publishPendingBundles()
+
+		case <-g.hostInterrupter.Done():
+			// interrupted - end periodic process
+			bundleSubmissionTicker.Stop()
+			return
+		}
+	}
+}
+
+func (g *Guardian) loadPendingBundles() {
+	storedBundles, err := g.storage.FetchPendingBundles()
+	if err != nil {
+		g.logger.Error("Could not read the pending cross chain bundles", log.ErrKey, err)
+		return
+	}
+	for _, stored := range storedBundles {
+		g.addPendingBundle(&pendingBundle{fromSeqNo: stored.FromSeqNo, toSeqNo: stored.ToSeqNo, rollupHash: stored.RollupHash})
+	}
+}
+
+// addPendingBundle - stores the bundle, so that it is published after a restart
+func (g *Guardian) addPendingBundle(bundle *pendingBundle) {
+	err := g.storage.StorePendingBundle(&common.PendingCrossChainBundle{RollupHash: bundle.rollupHash, FromSeqNo: bundle.fromSeqNo, ToSeqNo: bundle.toSeqNo})
+	if err != nil {
+		g.logger.Error("Could not store the pending cross chain bundle", log.RollupHashKey, bundle.rollupHash, log.ErrKey, err)
+	}
+
+	g.pendingBundlesLock.Lock()
+	defer g.pendingBundlesLock.Unlock()
+	for _, pending := range g.pendingBundles {
+		if pending.rollupHash == bundle.rollupHash {
+			return
+		}
+	}
+	g.pendingBundles = append(g.pendingBundles, bundle)
+}
+
+// publishPendingBundles - exports the cross chain bundles of the published rollups and submits them to the management
+// contract, in order. A bundle that fails is retried on the next tick, until it reaches the maximum number of attempts.
+// The lock is not held while waiting for the L1 receipt, so new rollups can add their bundles meanwhile.
+func (g *Guardian) publishPendingBundles() {
+	for {
+		pending := g.nextPendingBundle()
+		if pending == nil {
+			return
+		}
+		err := g.publishBundle(pending)
+		if err != nil {
+			pending.attempts++
+			if pending.attempts < _maxCrossChainBundleAttempts {
+				g.logger.Warn("Could not publish cross chain bundle. Will retry", log.RollupHashKey, pending.rollupHash,
+					"attempts", pending.attempts, log.ErrKey, err)
+				return
+			}
+			g.logger.Error("Could not publish cross chain bundle. Giving up", log.RollupHashKey, pending.rollupHash,
+				"fromSeqNo", pending.fromSeqNo, "toSeqNo", pending.toSeqNo, log.ErrKey, err)
+		}
+		g.removePendingBundle(pending)
+	}
+}
+
+func (g *Guardian) nextPendingBundle() *pendingBundle {
+	g.pendingBundlesLock.Lock()
+	defer g.pendingBundlesLock.Unlock()
+	if len(g.pendingBundles) == 0 {
+		return nil
+	}
+	return g.pendingBundles[0]
+}
+
+// removePendingBundle - removes the bundle at the head of the queue. Only the bundle submission removes bundles, so the
+// head is still the published bundle.
+func (g *Guardian) removePendingBundle(bundle *pendingBundle) {
+	g.pendingBundlesLock.Lock()
+	g.pendingBundles = g.pendingBundles[1:]
+	g.pendingBundlesLock.Unlock()
+
+	if err := g.storage.DeletePendingBundle(bundle.rollupHash); err != nil {
+		g.logger.Error("Could not delete the pending cross chain bundle", log.RollupHashKey, bundle.rollupHash, log.ErrKey, err)
+	}
+}
+
+func (g *Guardian) publishBundle(pending *pendingBundle) error {
+	// the bundle is exported on every attempt, so the L1 block it is bound to is recent
+	bundle, err := g.enclaveClient.ExportCrossChainData(context.Background(), pending.fromSeqNo, pending.toSeqNo)
+	if err != nil {
+		return fmt.Errorf("could not export cross chain data. Cause: %w", err)
+	}
+	return g.sl.L1Publisher().PublishCrossChainBundle(bundle, big.NewInt(int64(pending.toSeqNo)), pending.rollupHash)
+}
+
 func (g *Guardian) streamEnclaveData() {
 	defer g.logger.Info("Stopping enclave data stream")
 	g.logger.Info("Starting L2 update stream from enclave")
@@ -783,6 +919,7 @@ func (g *Guardian) getLatestBatchNo() (uint64, error) {
 func (g *Guardian) startSequencerProcesses() {
 	go g.periodicBatchProduction()
 	go g.periodicRollupProduction()
+	go g.periodicBundleSubmission()
 }
 
 // evictEnclaveFromHAPool evicts a failing enclave from the HA pool if appropriate
@@ -0,0 +1,161 @@
+package enclave
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/host/storage"
+	"github.com/ten-protocol/go-ten/go/host/storage/hostdb"
+	"github.com/ten-protocol/go-ten/go/host/storage/init/sqlite"
+)
+
+// bundleEnclave - exports an empty cross chain bundle for any range of batches
+type bundleEnclave struct {
+	common.Enclave
+}
+
+func (e *bundleEnclave) ExportCrossChainData(_ context.Context, fromSeqNo uint64, toSeqNo uint64) (*common.ExtCrossChainBundle, common.SystemError) {
+	return &common.ExtCrossChainBundle{}, nil
+}
+
+// bundlePublisher - records the rollups whose bundles were published, and fails while failures is positive
+type bundlePublisher struct {
+	host.L1Publisher
+	failures  int
+	published []gethcommon.Hash
+}
+
+func (p *bundlePublisher) PublishCrossChainBundle(_ *common.ExtCrossChainBundle, _ *big.Int, rollupHash gethcommon.Hash) error {
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("the bundle tx failed")
+	}
+	p.published = append(p.published, rollupHash)
+	return nil
+}
+
+type bundleServices struct {
+	guardianServiceLocator
+	publisher *bundlePublisher
+}
+
+func (s *bundleServices) L1Publisher() host.L1Publisher {
+	return s.publisher
+}
+
+func newBundleStorage(t *testing.T) storage.Storage {
+	sqlDB, err := sqlite.CreateTemporarySQLiteHostDB("", "mode=memory")
+	require.NoError(t, err)
+	db, err := hostdb.NewHostDB(sqlDB, hostdb.SQLiteSQLStatements())
+	require.NoError(t, err)
+	s := storage.NewStorage(db, gethlog.New())
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func newBundleGuardian(publisher *bundlePublisher, s storage.Storage) *Guardian {
+	return &Guardian{
+		sl:            &bundleServices{publisher: publisher},
+		enclaveClient: &bundleEnclave{},
+		storage:       s,
+		logger:        gethlog.New(),
+	}
+}
+
+func rollupHashes(t *testing.T, s storage.Storage) []gethcommon.Hash {
+	stored, err := s.FetchPendingBundles()
+	require.NoError(t, err)
+	hashes := make([]gethcommon.Hash, 0, len(stored))
+	for _, bundle := range stored {
+		hashes = append(hashes, bundle.RollupHash)
+	}
+	return hashes
+}
+
+func TestPendingBundlesArePublishedInOrder(t *testing.T) {
+	publisher := &bundlePublisher{}
+	s := newBundleStorage(t)
+	g := newBundleGuardian(publisher, s)
+
+	rollup1, rollup2 := gethcommon.Hash{1}, gethcommon.Hash{2}
+	g.addPendingBundle(&pendingBundle{fromSeqNo: 1, toSeqNo: 10, rollupHash: rollup1})
+	g.addPendingBundle(&pendingBundle{fromSeqNo: 11, toSeqNo: 20, rollupHash: rollup2})
+	// the bundle of a rollup is only queued once
+	g.addPendingBundle(&pendingBundle{fromSeqNo: 1, toSeqNo: 10, rollupHash: rollup1})
+	require.Len(t, g.pendingBundles, 2)
+	require.Equal(t, []gethcommon.Hash{rollup1, rollup2}, rollupHashes(t, s))
+
+	g.publishPendingBundles()
+	require.Equal(t, []gethcommon.Hash{rollup1, rollup2}, publisher.published)
+	require.Empty(t, g.pendingBundles)
+	require.Empty(t, rollupHashes(t, s))
+}
+
+func TestFailedBundlesAreRetried(t *testing.T) {
+	publisher := &bundlePublisher{failures: 1}
+	s := newBundleStorage(t)
+	g := newBundleGuardian(publisher, s)
+
+	rollup1, rollup2 := gethcommon.Hash{1}, gethcommon.Hash{2}
+	g.addPendingBundle(&pendingBundle{fromSeqNo: 1, toSeqNo: 10, rollupHash: rollup1})
+	g.addPendingBundle(&pendingBundle{fromSeqNo: 11, toSeqNo: 20, rollupHash: rollup2})
+
+	// the failed bundle stays at the head of the queue, the later bundles wait for it
+	g.publishPendingBundles()
+	require.Empty(t, publisher.published)
+	require.Len(t, g.pendingBundles, 2)
+	require.Equal(t, 1, g.pendingBundles[0].attempts)
+	require.Equal(t, []gethcommon.Hash{rollup1, rollup2}, rollupHashes(t, s))
+
+	g.publishPendingBundles()
+	require.Equal(t, []gethcommon.Hash{rollup1, rollup2}, publisher.published)
+	require.Empty(t, rollupHashes(t, s))
+}
+
+func TestBundlesAreDroppedAfterMaxAttempts(t *testing.T) {
+	publisher := &bundlePublisher{failures: _maxCrossChainBundleAttempts}
+	s := newBundleStorage(t)
+	g := newBundleGuardian(publisher, s)
+
+	rollup1, rollup2 := gethcommon.Hash{1}, gethcommon.Hash{2}
+	g.addPendingBundle(&pendingBundle{fromSeqNo: 1, toSeqNo: 10, rollupHash: rollup1})
+	g.addPendingBundle(&pendingBundle{fromSeqNo: 11, toSeqNo: 20, rollupHash: rollup2})
+
+	for i := 0; i < _maxCrossChainBundleAttempts-1; i++ {
+		g.publishPendingBundles()
+		require.Len(t, g.pendingBundles, 2)
+	}
+	// the last attempt gives up on the first bundle, and moves on to the next one
+	g.publishPendingBundles()
+	require.Equal(t, []gethcommon.Hash{rollup2}, publisher.published)
+	require.Empty(t, g.pendingBundles)
+	require.Empty(t, rollupHashes(t, s))
+}
+
+func TestPendingBundlesAreLoadedAfterRestart(t *testing.T) {
+	s := newBundleStorage(t)
+	rollup1, rollup2 := gethcommon.Hash{1}, gethcommon.Hash{2}
+	g := newBundleGuardian(&bundlePublisher{failures: 1}, s)
+	g.addPendingBundle(&pendingBundle{fromSeqNo: 11, toSeqNo: 20, rollupHash: rollup2})
+	g.addPendingBundle(&pendingBundle{fromSeqNo: 1, toSeqNo: 10, rollupHash: rollup1})
+	g.publishPendingBundles()
+
+	// the restarted guardian publishes the stored bundles, in the order of the rollups
+	publisher := &bundlePublisher{}
+	restarted := newBundleGuardian(publisher, s)
+	restarted.loadPendingBundles()
+	require.Len(t, restarted.pendingBundles, 2)
+	require.Equal(t, uint64(1), restarted.pendingBundles[0].fromSeqNo)
+	require.Equal(t, uint64(10), restarted.pendingBundles[0].toSeqNo)
+
+	restarted.publishPendingBundles()
+	require.Equal(t, []gethcommon.Hash{rollup1, rollup2}, publisher.published)
+	require.Empty(t, rollupHashes(t, s))
+}
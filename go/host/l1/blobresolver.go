@@ -2,13 +2,16 @@ package l1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 
+	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/ethadapter"
 )
 
@@ -16,26 +19,80 @@ import (
 type BlobResolver interface {
 	// FetchBlobs Fetches the blob data using beacon chain APIs
 	FetchBlobs(ctx context.Context, b *types.Header, hashes []gethcommon.Hash) ([]*kzg4844.Blob, error)
-	// StoreBlobs is used to store blobs for the in-memory testing nodes
-	StoreBlobs(slot uint64, blobs []*kzg4844.Blob) error
+	// StoreBlobs keeps a copy of the published blobs, so they remain available after the beacon nodes prune them
+	StoreBlobs(ctx context.Context, blobs []*kzg4844.Blob) error
 }
 
+// beaconBlobResolver fetches the blobs from the beacon chain, and falls back to the archive store when the beacon
+// clients don't return them. The archive store is optional.
 type beaconBlobResolver struct {
 	beaconClient *ethadapter.L1BeaconClient
+	archive      ethadapter.BlobArchiveStore
+	logger       gethlog.Logger
 }
 
-func NewBlobResolver(beaconClient *ethadapter.L1BeaconClient) BlobResolver {
-	return &beaconBlobResolver{beaconClient: beaconClient}
+func NewBlobResolver(beaconClient *ethadapter.L1BeaconClient, archive ethadapter.BlobArchiveStore, logger gethlog.Logger) BlobResolver {
+	return &beaconBlobResolver{beaconClient: beaconClient, archive: archive, logger: logger}
 }
 
 func (r *beaconBlobResolver) FetchBlobs(ctx context.Context, b *types.Header, hashes []gethcommon.Hash) ([]*kzg4844.Blob, error) {
 	blobs, err := r.beaconClient.FetchBlobs(ctx, b, hashes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch blobs from beacon client: %w", err)
+	if err == nil {
+		return blobs, nil
+	}
+	err = fmt.Errorf("failed to fetch blobs from beacon client: %w", err)
+	if r.archive == nil || len(hashes) == 0 {
+		return nil, err
+	}
+
+	r.logger.Debug("Blobs not available from the beacon client, fetching them from the archive", log.BlockHashKey, b.Hash(), log.ErrKey, err)
+	blobs, archiveErr := r.fetchArchivedBlobs(ctx, hashes)
+	if archiveErr != nil {
+		return nil, errors.Join(err, archiveErr)
+	}
+	return blobs, nil
+}
+
+func (r *beaconBlobResolver) StoreBlobs(ctx context.Context, blobs []*kzg4844.Blob) error {
+	if r.archive == nil {
+		return nil
+	}
+	for _, blob := range blobs {
+		hash, err := versionedHash(blob)
+		if err != nil {
+			return err
+		}
+		if err := r.archive.StoreBlob(ctx, hash, blob); err != nil {
+			return fmt.Errorf("failed to archive blob %s: %w", hash.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// fetchArchivedBlobs - the archive is not trusted, so every blob is checked against its versioned hash
+func (r *beaconBlobResolver) fetchArchivedBlobs(ctx context.Context, hashes []gethcommon.Hash) ([]*kzg4844.Blob, error) {
+	blobs := make([]*kzg4844.Blob, len(hashes))
+	for i, hash := range hashes {
+		blob, err := r.archive.FetchBlob(ctx, hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch blob %s from the archive: %w", hash.Hex(), err)
+		}
+		archivedHash, err := versionedHash(blob)
+		if err != nil {
+			return nil, err
+		}
+		if archivedHash != hash {
+			return nil, fmt.Errorf("archived blob %s does not match its hash", hash.Hex())
+		}
+		blobs[i] = blob
 	}
 	return blobs, nil
 }
 
-func (r *beaconBlobResolver) StoreBlobs(_ uint64, _ []*kzg4844.Blob) error {
-	panic("provided by the ethereum consensus layer")
+func versionedHash(blob *kzg4844.Blob) (gethcommon.Hash, error) {
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	if err != nil {
+		return gethcommon.Hash{}, fmt.Errorf("cannot compute KZG commitment of blob: %w", err)
+	}
+	return ethadapter.KZGToVersionedHash(commitment), nil
 }
@@ -2,6 +2,7 @@ package l1
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"math/big"
@@ -224,6 +225,128 @@ func (r *DataService) GetTenRelevantTransactions(block *types.Header) (*common.P
 	return processed, nil
 }
 
+// GetNodeSetEvents returns the events that changed the set of network nodes between the two heights (inclusive), in order.
+// The host that registered an enclave is the sender of the transaction that published its attestation.
+func (r *DataService) GetNodeSetEvents(fromBlock, toBlock *big.Int) ([]*host.NodeSetEvent, error) {
+	logs, err := r.ethClient.GetLogs(ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: r.contractAddresses[MgmtContract],
+		Topics: [][]gethcommon.Hash{{
+			crosschain.SequencerEnclaveGrantedEventID,
+			crosschain.SequencerEnclaveRevokedEventID,
+			crosschain.NetworkSecretRequestedID,
+			crosschain.NetworkSecretRespondedID,
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch node set logs - %w", err)
+	}
+
+	events := make([]*host.NodeSetEvent, 0)
+	for _, l := range logs {
+		logEvents := len(events)
+		switch l.Topics[0] {
+		case crosschain.SequencerEnclaveGrantedEventID:
+			enclaveID, err := getEnclaveIdFromLog(l)
+			if err != nil {
+				r.logger.Warn("Could not read the sequencer enclave ID", "txHash", l.TxHash, log.ErrKey, err)
+				continue
+			}
+			// the enclave which initialises the network secret is implicitly attested and a sequencer
+			initEvents, err := r.initialiseSecretEvents(l.TxHash)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, initEvents...)
+			events = append(events, &host.NodeSetEvent{Type: host.SequencerGranted, EnclaveID: enclaveID})
+		case crosschain.SequencerEnclaveRevokedEventID:
+			enclaveID, err := getEnclaveIdFromLog(l)
+			if err != nil {
+				r.logger.Warn("Could not read the sequencer enclave ID", "txHash", l.TxHash, log.ErrKey, err)
+				continue
+			}
+			events = append(events, &host.NodeSetEvent{Type: host.SequencerRevoked, EnclaveID: enclaveID})
+		case crosschain.NetworkSecretRequestedID:
+			// anyone can request the secret, so invalid requests are skipped
+			att, err := decodeSecretRequestLog(l)
+			if err != nil {
+				r.logger.Debug("Could not decode the secret request", "txHash", l.TxHash, log.ErrKey, err)
+				continue
+			}
+			// a copy of the attestation published by another host is ignored, as the attestation binds the host
+			requester := gethcommon.BytesToAddress(l.Topics[1].Bytes())
+			if att.HostID != requester {
+				r.logger.Debug("Secret request not published by the attested host", "txHash", l.TxHash, "requester", requester, "hostID", att.HostID)
+				continue
+			}
+			events = append(events, &host.NodeSetEvent{
+				Type:       host.HostRegistered,
+				EnclaveID:  att.EnclaveID,
+				HostID:     requester,
+				P2PAddress: att.HostAddress,
+			})
+		case crosschain.NetworkSecretRespondedID:
+			if len(l.Topics) < 3 {
+				continue
+			}
+			events = append(events, &host.NodeSetEvent{Type: host.EnclaveAttested, EnclaveID: gethcommon.BytesToAddress(l.Topics[2].Bytes())})
+		}
+		for _, e := range events[logEvents:] {
+			e.BlockNumber, e.BlockHash = l.BlockNumber, l.BlockHash
+		}
+	}
+	return events, nil
+}
+
+// initialiseSecretEvents returns the registration of the genesis host, if the transaction initialised the network secret
+func (r *DataService) initialiseSecretEvents(txHash gethcommon.Hash) ([]*host.NodeSetEvent, error) {
+	tx, _, err := r.ethClient.TransactionByHash(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching transaction: %w", err)
+	}
+	initTx, ok := r.mgmtContractLib.DecodeTx(tx).(*common.L1InitializeSecretTx)
+	if !ok {
+		return nil, nil
+	}
+	att, err := common.DecodeAttestation(initTx.Attestation)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode the genesis attestation - %w", err)
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover the sender of the genesis transaction - %w", err)
+	}
+	if att.HostID != sender {
+		r.logger.Warn("Genesis attestation not published by the attested host", "txHash", txHash, "sender", sender, "hostID", att.HostID)
+		return nil, nil
+	}
+	return []*host.NodeSetEvent{
+		{Type: host.HostRegistered, EnclaveID: att.EnclaveID, HostID: sender, P2PAddress: att.HostAddress},
+		{Type: host.EnclaveAttested, EnclaveID: att.EnclaveID},
+	}, nil
+}
+
+// decodeSecretRequestLog extracts the attestation report of the enclave that requested the network secret
+func decodeSecretRequestLog(l types.Log) (*common.AttestationReport, error) {
+	if len(l.Topics) < 2 {
+		return nil, errors.New("log has no requester topic")
+	}
+	values, err := crosschain.MgmtContractABI.Unpack("NetworkSecretRequested", l.Data)
+	if err != nil {
+		return nil, err
+	}
+	report, ok := values[0].(string)
+	if !ok {
+		return nil, errors.New("unexpected request report type")
+	}
+	encoded, err := base64.StdEncoding.DecodeString(report)
+	if err != nil {
+		return nil, err
+	}
+	return common.DecodeAttestation(encoded)
+}
+
 // fetchMessageBusMgmtContractLogs retrieves all logs from management contract and message bus addresses
 func (r *DataService) fetchMessageBusMgmtContractLogs(block *types.Header) ([]types.Log, error) {
 	blkHash := block.Hash()
@@ -15,12 +15,12 @@ import (
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/pkg/errors"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/host"
 	"github.com/ten-protocol/go-ten/go/common/log"
-	"github.com/ten-protocol/go-ten/go/common/retry"
 	"github.com/ten-protocol/go-ten/go/ethadapter"
 	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
 	"github.com/ten-protocol/go-ten/go/wallet"
@@ -44,12 +44,10 @@ type Publisher struct {
 
 	hostStopper *stopcontrol.StopControl
 
-	maxWaitForL1Receipt       time.Duration
-	retryIntervalForL1Receipt time.Duration
+	// tracks the nonces of the host wallet, so several txs can be in flight at a time
+	txManager *TxManager
 
-	// we only allow one transaction in-flight at a time to avoid nonce conflicts
-	// We also have a context to cancel the tx if host stops
-	sendingLock      sync.Mutex
+	// a context to stop waiting for the txs if the host stops
 	sendingContext   context.Context
 	sendingCtxCancel context.CancelFunc
 }
@@ -69,28 +67,29 @@ func NewL1Publisher(
 ) *Publisher {
 	sendingCtx, cancelSendingCtx := context.WithCancel(context.Background())
 	return &Publisher{
-		hostData:                  hostData,
-		hostWallet:                hostWallet,
-		ethClient:                 client,
-		mgmtContractLib:           mgmtContract,
-		repository:                repository,
-		blobResolver:              blobResolver,
-		hostStopper:               hostStopper,
-		logger:                    logger,
-		maxWaitForL1Receipt:       maxWaitForL1Receipt,
-		retryIntervalForL1Receipt: retryIntervalForL1Receipt,
-		storage:                   storage,
+		hostData:        hostData,
+		hostWallet:      hostWallet,
+		ethClient:       client,
+		mgmtContractLib: mgmtContract,
+		repository:      repository,
+		blobResolver:    blobResolver,
+		hostStopper:     hostStopper,
+		logger:          logger,
+		storage:         storage,
+		txManager:       NewTxManager(hostWallet, client, storage, hostStopper, logger, maxWaitForL1Receipt, retryIntervalForL1Receipt),
 
 		importantContractAddresses: map[string]gethcommon.Address{},
 		importantAddressesMutex:    sync.RWMutex{},
 
-		sendingLock:      sync.Mutex{},
 		sendingContext:   sendingCtx,
 		sendingCtxCancel: cancelSendingCtx,
 	}
 }
 
 func (p *Publisher) Start() error {
+	if err := p.txManager.Start(); err != nil {
+		return err
+	}
 	go func() {
 		// Do an initial read of important contract addresses when service starts up
 		err := p.ResyncImportantContracts()
@@ -103,7 +102,7 @@ func (p *Publisher) Start() error {
 
 func (p *Publisher) Stop() error {
 	p.sendingCtxCancel()
-	return nil
+	return p.txManager.Stop()
 }
 
 func (p *Publisher) HealthStatus(context.Context) host.HealthStatus {
@@ -116,6 +115,8 @@ func (p *Publisher) HealthStatus(context.Context) host.HealthStatus {
 }
 
 func (p *Publisher) InitializeSecret(attestation *common.AttestationReport, encSecret common.EncryptedSharedEnclaveSecret) error {
+	// the enclave attests the host it is tied to, which must be the host publishing the attestation
+	attestation.HostID = p.hostWallet.Address()
 	encodedAttestation, err := common.EncodeAttestation(attestation)
 	if err != nil {
 		return errors.Wrap(err, "could not encode attestation")
@@ -131,6 +132,8 @@ func (p *Publisher) InitializeSecret(attestation *common.AttestationReport, encS
 }
 
 func (p *Publisher) RequestSecret(attestation *common.AttestationReport) (gethcommon.Hash, error) {
+	// the enclave attests the host it is tied to, which must be the host publishing the attestation
+	attestation.HostID = p.hostWallet.Address()
 	encodedAttestation, err := common.EncodeAttestation(attestation)
 	if err != nil {
 		return gethutil.EmptyHash, errors.Wrap(err, "could not encode attestation")
@@ -202,7 +205,7 @@ func (p *Publisher) FetchLatestSeqNo() (*big.Int, error) {
 	return p.ethClient.FetchLastBatchSeqNo(*p.mgmtContractLib.GetContractAddr())
 }
 
-func (p *Publisher) PublishRollup(producedRollup *common.ExtRollup) {
+func (p *Publisher) PublishRollup(producedRollup *common.ExtRollup) error {
 	encRollup, err := common.EncodeRollup(producedRollup)
 	if err != nil {
 		p.logger.Crit("could not encode rollup.", log.ErrKey, err)
@@ -227,21 +230,71 @@ func (p *Publisher) PublishRollup(producedRollup *common.ExtRollup) {
 	rollupBlobTx, err := p.mgmtContractLib.CreateBlobRollup(tx)
 	if err != nil {
 		p.logger.Error("Could not create rollup blobs", log.RollupHashKey, producedRollup.Hash(), log.ErrKey, err)
+		return fmt.Errorf("could not create rollup blobs. Cause: %w", err)
 	}
 
 	err = p.publishTransaction(rollupBlobTx)
 	if err != nil {
 		p.logger.Error("Could not issue rollup tx", log.RollupHashKey, producedRollup.Hash(), log.ErrKey, err)
-	} else {
-		p.logger.Info("Rollup included in L1", log.RollupHashKey, producedRollup.Hash())
+		return fmt.Errorf("could not issue rollup tx. Cause: %w", err)
+	}
+	p.logger.Info("Rollup included in L1", log.RollupHashKey, producedRollup.Hash())
+
+	// the beacon nodes prune the blobs after a few weeks, so they are archived to remain available to new nodes
+	if blobTx, ok := rollupBlobTx.(*types.BlobTx); ok && blobTx.Sidecar != nil {
+		if err := p.blobResolver.StoreBlobs(p.sendingContext, blobPointers(blobTx.Sidecar.Blobs)); err != nil {
+			p.logger.Error("Could not archive rollup blobs", log.RollupHashKey, producedRollup.Hash(), log.ErrKey, err)
+		}
 	}
-	// TODO publish rollup to archive service if not already done
+	return nil
 }
 
 func (p *Publisher) PublishCrossChainBundle(bundle *common.ExtCrossChainBundle, rollupNum *big.Int, forkID gethcommon.Hash) error {
+	if !hasCrossChainMessages(bundle) {
+		p.logger.Debug("No cross chain roots to publish", log.RollupHashKey, forkID)
+		return nil
+	}
+
+	// the bundle may have been published already, e.g. when the receipt was not received before a restart
+	available, err := p.isBundleAvailable(bundle)
+	if err != nil {
+		return err
+	}
+	if available {
+		p.logger.Info("Cross chain bundle already published", log.RollupHashKey, forkID, "roots", bundle.CrossChainRootHashes.ToHexString())
+		return nil
+	}
+
+	bundleTx, err := p.mgmtContractLib.CreateCrossChainBundle(bundle, rollupNum, forkID)
+	if err != nil {
+		return fmt.Errorf("could not create cross chain bundle tx. Cause: %w", err)
+	}
+
+	p.logger.Info("Publishing cross chain bundle", log.RollupHashKey, forkID, "rollupNum", rollupNum, "roots", bundle.CrossChainRootHashes.ToHexString())
+	err = p.publishTransaction(bundleTx)
+	if err != nil {
+		return fmt.Errorf("could not issue cross chain bundle tx. Cause: %w", err)
+	}
+	p.logger.Info("Cross chain bundle included in L1", log.RollupHashKey, forkID)
 	return nil
 }
 
+func (p *Publisher) isBundleAvailable(bundle *common.ExtCrossChainBundle) (bool, error) {
+	callMsg, err := p.mgmtContractLib.IsBundleAvailableMsg(bundle)
+	if err != nil {
+		return false, fmt.Errorf("could not build callMsg for bundle availability: %w", err)
+	}
+	resp, err := p.ethClient.CallContract(callMsg)
+	if err != nil {
+		return false, fmt.Errorf("could not fetch bundle availability: %w", err)
+	}
+	available, err := p.mgmtContractLib.DecodeIsBundleAvailableResponse(resp)
+	if err != nil {
+		return false, fmt.Errorf("could not decode bundle availability resp: %w", err)
+	}
+	return available, nil
+}
+
 func (p *Publisher) GetImportantContracts() map[string]gethcommon.Address {
 	p.importantAddressesMutex.RLock()
 	defer p.importantAddressesMutex.RUnlock()
@@ -291,72 +344,35 @@ func (p *Publisher) ResyncImportantContracts() error {
 	return nil
 }
 
-// publishTransaction will keep trying unless the L1 seems to be unavailable or the tx is otherwise rejected
-// this method is guarded by a lock to ensure that only one transaction is attempted at a time to avoid nonce conflicts
+// publishTransaction submits the tx to the tx manager and blocks until it is included or abandoned
 // todo (@matt) this method should take a context so we can try to cancel if the tx is no longer required
 func (p *Publisher) publishTransaction(tx types.TxData) error {
-	// this log message seems superfluous but is useful to debug deadlock issues, we expect 'Host issuing l1 tx' soon
-	// after unless we're stuck blocking.
-	p.logger.Info("Host preparing to issue L1 tx")
-
-	p.sendingLock.Lock()
-	defer p.sendingLock.Unlock()
-
-	retries := -1
-
-	nonce, err := p.ethClient.Nonce(p.hostWallet.Address())
+	result, err := p.txManager.SubmitTx(p.sendingContext, tx)
 	if err != nil {
-		return fmt.Errorf("could not get nonce for L1 tx: %w", err)
+		return err
 	}
+	select {
+	case res := <-result:
+		return res.Err
+	case <-p.sendingContext.Done():
+		return errors.New("host is stopping or context canceled")
+	}
+}
 
-	// while the publisher service is still alive we keep trying to get the transaction into the L1
-	for !p.hostStopper.IsStopping() {
-		retries++ // count each attempt so we can increase gas price
-
-		// update the tx gas price before each attempt
-		tx, err := ethadapter.SetTxGasPrice(p.sendingContext, p.ethClient, tx, p.hostWallet.Address(), nonce, retries)
-		if err != nil {
-			return errors.Wrap(err, "could not estimate gas/gas price for L1 tx")
-		}
-
-		signedTx, err := p.hostWallet.SignTransaction(tx)
-		if err != nil {
-			return errors.Wrap(err, "could not sign L1 tx")
-		}
-		p.logger.Info("Host issuing L1 tx", log.TxKey, signedTx.Hash(), "size", signedTx.Size()/1024, "retries", retries)
-		err = p.ethClient.SendTransaction(signedTx)
-		if err != nil {
-			return errors.Wrap(err, "could not broadcast L1 tx")
-		}
-		p.logger.Info("Successfully submitted tx to L1", "txHash", signedTx.Hash())
-
-		var receipt *types.Receipt
-		// retry until receipt is found or context is canceled
-		err = retry.Do(
-			func() error {
-				if p.hostStopper.IsStopping() {
-					return retry.FailFast(errors.New("host is stopping or context canceled"))
-				}
-				receipt, err = p.ethClient.TransactionReceipt(signedTx.Hash())
-				if err != nil {
-					return fmt.Errorf("could not get receipt publishing tx for L1 tx=%s: %w", signedTx.Hash(), err)
-				}
-				return err
-			},
-			retry.NewTimeoutStrategy(p.maxWaitForL1Receipt, p.retryIntervalForL1Receipt),
-		)
-		if err != nil {
-			p.logger.Info("Receipt not found for transaction, we will re-attempt", log.ErrKey, err)
-			continue // try again with updated gas price
-		}
-
-		if receipt.Status != types.ReceiptStatusSuccessful {
-			return fmt.Errorf("unsuccessful receipt found for published L1 transaction, status=%d", receipt.Status)
+// hasCrossChainMessages - the batches without cross chain messages have the max hash as their cross chain root
+func hasCrossChainMessages(bundle *common.ExtCrossChainBundle) bool {
+	for _, root := range bundle.CrossChainRootHashes {
+		if gethcommon.BytesToHash(root) != gethcommon.MaxHash {
+			return true
 		}
+	}
+	return false
+}
 
-		p.logger.Debug("L1 transaction successful receipt found.", log.TxKey, signedTx.Hash(),
-			log.BlockHeightKey, receipt.BlockNumber, log.BlockHashKey, receipt.BlockHash)
-		break
+func blobPointers(blobs []kzg4844.Blob) []*kzg4844.Blob {
+	res := make([]*kzg4844.Blob, len(blobs))
+	for i := range blobs {
+		res[i] = &blobs[i]
 	}
-	return nil
+	return res
 }
@@ -0,0 +1,383 @@
+package l1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/retry"
+	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/host/storage"
+	"github.com/ten-protocol/go-ten/go/wallet"
+)
+
+const (
+	// the maximum number of L1 txs which are sent but not included yet
+	_maxInFlightL1Txs = 16
+	// the mempool rejects a replacement unless all its fees are bumped by at least 10% (100% for blob txs)
+	_replacementPriceBumpPercent     = 10
+	_blobReplacementPriceBumpPercent = 100
+)
+
+var errTxManagerStopping = errors.New("L1 tx manager is stopping")
+
+// L1TxResult is the outcome of an L1 tx submitted to the TxManager
+type L1TxResult struct {
+	Receipt *types.Receipt // the receipt of the version of the tx that was included, nil if none was
+	Err     error
+}
+
+// TxManager sends the L1 txs issued by the host. It assigns the nonces locally, so several txs can be in flight at the
+// same time, and replaces the txs which are not included in time with versions paying higher fees.
+// The pending txs are persisted, so they are tracked again after a restart.
+type TxManager struct {
+	wallet    wallet.Wallet
+	ethClient ethadapter.EthClient
+	storage   storage.PendingL1TxResolver
+	logger    gethlog.Logger
+
+	hostStopper *stopcontrol.StopControl
+
+	maxWaitForL1Receipt       time.Duration
+	retryIntervalForL1Receipt time.Duration
+
+	// guards the nonce assignment, which must happen in the same order as the broadcasting
+	nonceLock   sync.Mutex
+	nextNonce   uint64
+	nonceSynced bool
+
+	inFlight chan struct{} // a semaphore bounding the number of txs in flight
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func NewTxManager(
+	hostWallet wallet.Wallet,
+	client ethadapter.EthClient,
+	storage storage.PendingL1TxResolver,
+	hostStopper *stopcontrol.StopControl,
+	logger gethlog.Logger,
+	maxWaitForL1Receipt time.Duration,
+	retryIntervalForL1Receipt time.Duration,
+) *TxManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &TxManager{
+		wallet:                    hostWallet,
+		ethClient:                 client,
+		storage:                   storage,
+		logger:                    logger,
+		hostStopper:               hostStopper,
+		maxWaitForL1Receipt:       maxWaitForL1Receipt,
+		retryIntervalForL1Receipt: retryIntervalForL1Receipt,
+		inFlight:                  make(chan struct{}, _maxInFlightL1Txs),
+		ctx:                       ctx,
+		ctxCancel:                 cancel,
+	}
+}
+
+// Start resumes tracking the txs which were still pending when the host stopped
+func (m *TxManager) Start() error {
+	pendingTxs, err := m.storage.FetchPendingL1Txs()
+	if err != nil {
+		return fmt.Errorf("could not load pending L1 txs. Cause: %w", err)
+	}
+
+	m.nonceLock.Lock()
+	for _, tx := range pendingTxs {
+		if tx.Nonce() >= m.nextNonce {
+			m.nextNonce = tx.Nonce() + 1
+		}
+	}
+	m.nonceLock.Unlock()
+
+	for _, tx := range pendingTxs {
+		// any of the versions sent before the restart may be the one that is included
+		hashes, err := m.storage.FetchPendingL1TxHashes(tx.Nonce())
+		if err != nil {
+			return fmt.Errorf("could not load the hashes of pending L1 tx=%s. Cause: %w", tx.Hash(), err)
+		}
+		if !slices.Contains(hashes, tx.Hash()) {
+			hashes = append(hashes, tx.Hash())
+		}
+
+		m.logger.Info("Resuming tracking of pending L1 tx", log.TxKey, tx.Hash(), "nonce", tx.Nonce(), "versions", len(hashes))
+		// the tx may have been dropped from the mempool in the meantime
+		if err := m.ethClient.SendTransaction(tx); err != nil && !isKnownTxErr(err) {
+			m.logger.Debug("Could not re-broadcast pending L1 tx", log.TxKey, tx.Hash(), log.ErrKey, err)
+		}
+		go func(tx *types.Transaction, hashes []gethcommon.Hash) {
+			m.inFlight <- struct{}{}
+			result := make(chan *L1TxResult, 1)
+			m.track(tx, hashes, result)
+			if res := <-result; res.Err != nil {
+				m.logger.Warn("Resumed L1 tx was not included", log.TxKey, tx.Hash(), log.ErrKey, res.Err)
+			}
+		}(tx, hashes)
+	}
+	return nil
+}
+
+func (m *TxManager) Stop() error {
+	m.ctxCancel()
+	return nil
+}
+
+// SubmitTx prices, signs and broadcasts the tx using the next nonce. The outcome is delivered on the returned channel
+// once a version of the tx is included, or once the tx manager gives up on it.
+func (m *TxManager) SubmitTx(ctx context.Context, txData types.TxData) (<-chan *L1TxResult, error) {
+	select {
+	case m.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-m.ctx.Done():
+		return nil, errTxManagerStopping
+	}
+
+	signedTx, err := m.signAndSend(txData)
+	if err != nil {
+		<-m.inFlight
+		return nil, err
+	}
+
+	result := make(chan *L1TxResult, 1)
+	go m.track(signedTx, []gethcommon.Hash{signedTx.Hash()}, result)
+	return result, nil
+}
+
+// signAndSend assigns the next nonce to the tx and broadcasts it. The nonce is only consumed if the broadcast succeeds,
+// so a failed tx does not leave a gap that would block the txs sent after it.
+func (m *TxManager) signAndSend(txData types.TxData) (*types.Transaction, error) {
+	m.nonceLock.Lock()
+	defer m.nonceLock.Unlock()
+
+	if !m.nonceSynced {
+		nonce, err := m.ethClient.Nonce(m.wallet.Address())
+		if err != nil {
+			return nil, fmt.Errorf("could not get nonce for L1 tx: %w", err)
+		}
+		if nonce > m.nextNonce {
+			m.nextNonce = nonce
+		}
+		m.nonceSynced = true
+	}
+
+	pricedTx, err := ethadapter.SetTxGasPrice(m.ctx, m.ethClient, txData, m.wallet.Address(), m.nextNonce, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not estimate gas/gas price for L1 tx: %w", err)
+	}
+	signedTx, err := m.wallet.SignTransaction(pricedTx)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign L1 tx: %w", err)
+	}
+	// the tx is persisted before it is broadcast, so it is not lost if the host stops in between
+	if err := m.storage.StorePendingL1Tx(signedTx); err != nil {
+		return nil, err
+	}
+
+	m.logger.Info("Host issuing L1 tx", log.TxKey, signedTx.Hash(), "nonce", signedTx.Nonce(), "size", signedTx.Size()/1024)
+	if err := m.ethClient.SendTransaction(signedTx); err != nil {
+		if dbErr := m.storage.DeletePendingL1Tx(signedTx.Nonce()); dbErr != nil {
+			m.logger.Error("Could not delete L1 tx that failed to broadcast", log.TxKey, signedTx.Hash(), log.ErrKey, dbErr)
+		}
+		// the node may know about txs we are not aware of, so the nonce is fetched again for the next tx
+		m.nonceSynced = false
+		return nil, fmt.Errorf("could not broadcast L1 tx: %w", err)
+	}
+	m.nextNonce++
+	return signedTx, nil
+}
+
+// track waits for one of the versions of the tx to be included, replacing it with a higher priced version every time
+// the wait times out. The hashes are those of all the versions sent so far, as any of them may be the one that gets
+// included. The outcome is written to the result channel.
+func (m *TxManager) track(tx *types.Transaction, hashes []gethcommon.Hash, result chan<- *L1TxResult) {
+	defer func() { <-m.inFlight }()
+
+	for {
+		receipt, err := m.waitForReceipt(hashes)
+		if err == nil {
+			m.forget(tx)
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				result <- &L1TxResult{Receipt: receipt, Err: fmt.Errorf("unsuccessful receipt found for published L1 transaction, status=%d", receipt.Status)}
+				return
+			}
+			m.logger.Debug("L1 transaction successful receipt found.", log.TxKey, receipt.TxHash,
+				log.BlockHeightKey, receipt.BlockNumber, log.BlockHashKey, receipt.BlockHash)
+			result <- &L1TxResult{Receipt: receipt}
+			return
+		}
+		if m.isStopping() {
+			// the tx remains persisted, so it is tracked again after a restart
+			result <- &L1TxResult{Err: errTxManagerStopping}
+			return
+		}
+
+		m.logger.Info("Receipt not found for L1 tx, replacing it with higher fees", log.TxKey, tx.Hash(), "nonce", tx.Nonce(), log.ErrKey, err)
+		replacement, err := m.replace(tx)
+		if err != nil {
+			if errors.Is(err, core.ErrNonceTooLow) {
+				// the nonce was consumed, so one of the versions may have been included since the last check
+				if receipt, rErr := m.fetchReceipt(hashes); rErr == nil {
+					m.forget(tx)
+					result <- &L1TxResult{Receipt: receipt}
+					return
+				}
+				m.forget(tx)
+				result <- &L1TxResult{Err: fmt.Errorf("nonce %d of L1 tx=%s was used by another tx", tx.Nonce(), tx.Hash())}
+				return
+			}
+			// the current version stays in the mempool, so it can still be included while we retry
+			m.logger.Warn("Could not replace L1 tx", log.TxKey, tx.Hash(), log.ErrKey, err)
+			continue
+		}
+		tx = replacement
+		hashes = append(hashes, tx.Hash())
+	}
+}
+
+// replace signs and broadcasts a new version of the tx with bumped fees
+func (m *TxManager) replace(tx *types.Transaction) (*types.Transaction, error) {
+	txData, err := m.replacementTxData(tx)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := m.wallet.SignTransaction(txData)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign L1 tx: %w", err)
+	}
+	if err := m.storage.StorePendingL1Tx(signedTx); err != nil {
+		return nil, err
+	}
+	m.logger.Info("Host issuing replacement L1 tx", log.TxKey, signedTx.Hash(), "nonce", signedTx.Nonce(), "replaced", tx.Hash())
+	if err := m.ethClient.SendTransaction(signedTx); err != nil {
+		// the previous version remains the one that is expected to be included
+		if dbErr := m.storage.StorePendingL1Tx(tx); dbErr != nil {
+			m.logger.Error("Could not restore replaced L1 tx", log.TxKey, tx.Hash(), log.ErrKey, dbErr)
+		}
+		if strings.Contains(err.Error(), core.ErrNonceTooLow.Error()) {
+			return nil, core.ErrNonceTooLow
+		}
+		return nil, fmt.Errorf("could not broadcast L1 tx: %w", err)
+	}
+	return signedTx, nil
+}
+
+// replacementTxData returns a copy of the tx whose fees are the higher of the current suggestions and the minimum bump
+// the mempool accepts for a replacement
+func (m *TxManager) replacementTxData(tx *types.Transaction) (types.TxData, error) {
+	suggestedTip, err := m.ethClient.SuggestGasTipCap(m.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not suggest gas price - %w", err)
+	}
+	head, err := m.ethClient.HeaderByNumber(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the latest block header: %w", err)
+	}
+
+	bumpPercent := int64(_replacementPriceBumpPercent)
+	if tx.Type() == types.BlobTxType {
+		bumpPercent = _blobReplacementPriceBumpPercent
+	}
+	gasTipCap := maxBig(suggestedTip, bumpPrice(tx.GasTipCap(), bumpPercent))
+	gasFeeCap := maxBig(new(big.Int).Add(head.BaseFee, gasTipCap), bumpPrice(tx.GasFeeCap(), bumpPercent))
+
+	if tx.Type() != types.BlobTxType {
+		return &types.DynamicFeeTx{
+			Nonce:     tx.Nonce(),
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       tx.Gas(),
+			To:        tx.To(),
+			Value:     tx.Value(),
+			Data:      tx.Data(),
+		}, nil
+	}
+
+	if head.ExcessBlobGas == nil {
+		return nil, fmt.Errorf("should not happen. missing blob base fee")
+	}
+	blobFeeCap := maxBig(eip4844.CalcBlobFee(*head.ExcessBlobGas), bumpPrice(tx.BlobGasFeeCap(), bumpPercent))
+	return &types.BlobTx{
+		Nonce:      tx.Nonce(),
+		GasTipCap:  uint256.MustFromBig(gasTipCap),
+		GasFeeCap:  uint256.MustFromBig(gasFeeCap),
+		Gas:        tx.Gas(),
+		To:         *tx.To(),
+		Value:      uint256.MustFromBig(tx.Value()),
+		Data:       tx.Data(),
+		BlobFeeCap: uint256.MustFromBig(blobFeeCap),
+		BlobHashes: tx.BlobHashes(),
+		Sidecar:    tx.BlobTxSidecar(),
+	}, nil
+}
+
+// waitForReceipt polls for the receipt of any of the hashes until it is found or the wait times out
+func (m *TxManager) waitForReceipt(hashes []gethcommon.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := retry.Do(
+		func() error {
+			if m.isStopping() {
+				return retry.FailFast(errTxManagerStopping)
+			}
+			var err error
+			receipt, err = m.fetchReceipt(hashes)
+			return err
+		},
+		retry.NewTimeoutStrategy(m.maxWaitForL1Receipt, m.retryIntervalForL1Receipt),
+	)
+	return receipt, err
+}
+
+func (m *TxManager) fetchReceipt(hashes []gethcommon.Hash) (*types.Receipt, error) {
+	for _, hash := range hashes {
+		receipt, err := m.ethClient.TransactionReceipt(hash)
+		if err == nil && receipt != nil {
+			return receipt, nil
+		}
+	}
+	return nil, fmt.Errorf("no receipt found for L1 tx=%s", hashes[len(hashes)-1])
+}
+
+// forget removes the tx from the persisted pending txs once its outcome is known
+func (m *TxManager) forget(tx *types.Transaction) {
+	if err := m.storage.DeletePendingL1Tx(tx.Nonce()); err != nil {
+		m.logger.Error("Could not delete pending L1 tx", log.TxKey, tx.Hash(), log.ErrKey, err)
+	}
+}
+
+func (m *TxManager) isStopping() bool {
+	return m.hostStopper.IsStopping() || m.ctx.Err() != nil
+}
+
+func isKnownTxErr(err error) bool {
+	return strings.Contains(err.Error(), txpool.ErrAlreadyKnown.Error()) || strings.Contains(err.Error(), core.ErrNonceTooLow.Error())
+}
+
+// bumpPrice returns the price increased by the given percentage, rounded up
+func bumpPrice(price *big.Int, percent int64) *big.Int {
+	bumped := new(big.Int).Mul(price, big.NewInt(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) > 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,225 @@
+package l1
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/wallet"
+)
+
+// embedded under an alias, as the field named after the interface would clash with its EthClient method
+type ethClient = ethadapter.EthClient
+
+// fakeL1 - records the sent txs and only returns receipts for the txs marked as included
+type fakeL1 struct {
+	ethClient
+	mu       sync.Mutex
+	sent     []*types.Transaction
+	included map[gethcommon.Hash]bool
+}
+
+func (f *fakeL1) Nonce(gethcommon.Address) (uint64, error) { return 0, nil }
+
+func (f *fakeL1) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) { return 21_000, nil }
+
+func (f *fakeL1) SuggestGasTipCap(context.Context) (*big.Int, error) { return big.NewInt(100), nil }
+
+func (f *fakeL1) HeaderByNumber(*big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: big.NewInt(1000)}, nil
+}
+
+func (f *fakeL1) SendTransaction(tx *types.Transaction) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, tx)
+	return nil
+}
+
+func (f *fakeL1) TransactionReceipt(hash gethcommon.Hash) (*types.Receipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.included[hash] {
+		return nil, ethereum.NotFound
+	}
+	return &types.Receipt{TxHash: hash, Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1)}, nil
+}
+
+func (f *fakeL1) include(tx *types.Transaction) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.included[tx.Hash()] = true
+}
+
+func (f *fakeL1) sentTxs() []*types.Transaction {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*types.Transaction{}, f.sent...)
+}
+
+type inMemoryPendingTxs struct {
+	mu     sync.Mutex
+	txs    map[uint64]*types.Transaction
+	hashes map[uint64][]gethcommon.Hash
+}
+
+func newInMemoryPendingTxs() *inMemoryPendingTxs {
+	return &inMemoryPendingTxs{txs: map[uint64]*types.Transaction{}, hashes: map[uint64][]gethcommon.Hash{}}
+}
+
+func (s *inMemoryPendingTxs) StorePendingL1Tx(tx *types.Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.txs[tx.Nonce()] = tx
+	if !slices.Contains(s.hashes[tx.Nonce()], tx.Hash()) {
+		s.hashes[tx.Nonce()] = append(s.hashes[tx.Nonce()], tx.Hash())
+	}
+	return nil
+}
+
+func (s *inMemoryPendingTxs) DeletePendingL1Tx(nonce uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.txs, nonce)
+	delete(s.hashes, nonce)
+	return nil
+}
+
+func (s *inMemoryPendingTxs) FetchPendingL1TxHashes(nonce uint64) ([]gethcommon.Hash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]gethcommon.Hash{}, s.hashes[nonce]...), nil
+}
+
+func (s *inMemoryPendingTxs) FetchPendingL1Txs() ([]*types.Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txs := make([]*types.Transaction, 0, len(s.txs))
+	for _, tx := range s.txs {
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+func (s *inMemoryPendingTxs) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.txs)
+}
+
+func newTestTxManager(t *testing.T, client *fakeL1, pending *inMemoryPendingTxs, maxWait time.Duration) *TxManager {
+	logger := log.New(log.HostCmp, int(gethlog.LevelError), log.SysOut)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	hostWallet := wallet.NewInMemoryWalletFromPK(big.NewInt(1337), key, logger)
+	txManager := NewTxManager(hostWallet, client, pending, stopcontrol.New(), logger, maxWait, 10*time.Millisecond)
+	t.Cleanup(func() { _ = txManager.Stop() })
+	return txManager
+}
+
+func TestTxManagerKeepsSeveralTxsInFlight(t *testing.T) {
+	client := &fakeL1{included: map[gethcommon.Hash]bool{}}
+	pending := newInMemoryPendingTxs()
+	txManager := newTestTxManager(t, client, pending, time.Minute)
+	to := gethcommon.HexToAddress("0x01")
+
+	results := make([]<-chan *L1TxResult, 3)
+	for i := range results {
+		res, err := txManager.SubmitTx(context.Background(), &types.DynamicFeeTx{To: &to})
+		require.NoError(t, err)
+		results[i] = res
+	}
+	sent := client.sentTxs()
+	require.Len(t, sent, 3)
+	for i, tx := range sent {
+		require.Equal(t, uint64(i), tx.Nonce())
+	}
+	require.Equal(t, 3, pending.count())
+
+	// the outcome of a tx does not depend on the txs sent before it
+	client.include(sent[2])
+	select {
+	case res := <-results[2]:
+		require.NoError(t, res.Err)
+		require.Equal(t, sent[2].Hash(), res.Receipt.TxHash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no result for the included tx")
+	}
+	require.Eventually(t, func() bool { return pending.count() == 2 }, time.Second, 10*time.Millisecond)
+
+	// after a restart the pending txs are broadcast again, and the new txs use the nonces after them, even when the
+	// L1 node does not know about the pending txs
+	restartedClient := &fakeL1{included: map[gethcommon.Hash]bool{}}
+	restarted := newTestTxManager(t, restartedClient, pending, time.Minute)
+	require.NoError(t, restarted.Start())
+	_, err := restarted.SubmitTx(context.Background(), &types.DynamicFeeTx{To: &to})
+	require.NoError(t, err)
+	sent = restartedClient.sentTxs()
+	require.Len(t, sent, 3)
+	require.Equal(t, uint64(2), sent[2].Nonce())
+}
+
+func TestTxManagerReplacesStuckTxWithHigherFees(t *testing.T) {
+	client := &fakeL1{included: map[gethcommon.Hash]bool{}}
+	pending := newInMemoryPendingTxs()
+	txManager := newTestTxManager(t, client, pending, 50*time.Millisecond)
+	to := gethcommon.HexToAddress("0x01")
+
+	res, err := txManager.SubmitTx(context.Background(), &types.DynamicFeeTx{To: &to})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(client.sentTxs()) >= 2 }, 5*time.Second, 10*time.Millisecond)
+
+	sent := client.sentTxs()
+	original, replacement := sent[0], sent[1]
+	require.Equal(t, original.Nonce(), replacement.Nonce())
+	require.GreaterOrEqual(t, replacement.GasTipCap().Cmp(bumpPrice(original.GasTipCap(), _replacementPriceBumpPercent)), 0)
+	require.GreaterOrEqual(t, replacement.GasFeeCap().Cmp(bumpPrice(original.GasFeeCap(), _replacementPriceBumpPercent)), 0)
+
+	// any of the versions may be included
+	client.include(original)
+	select {
+	case r := <-res:
+		require.NoError(t, r.Err)
+		require.Equal(t, original.Hash(), r.Receipt.TxHash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no result for the included tx")
+	}
+	require.Equal(t, 0, pending.count())
+}
+
+func TestTxManagerFindsTheReplacedVersionsAfterRestart(t *testing.T) {
+	client := &fakeL1{included: map[gethcommon.Hash]bool{}}
+	pending := newInMemoryPendingTxs()
+	txManager := newTestTxManager(t, client, pending, 50*time.Millisecond)
+	to := gethcommon.HexToAddress("0x01")
+
+	_, err := txManager.SubmitTx(context.Background(), &types.DynamicFeeTx{To: &to})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(client.sentTxs()) >= 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, txManager.Stop())
+	original := client.sentTxs()[0]
+
+	// the original version is included while the host is down, after it was replaced
+	restartedClient := &fakeL1{included: map[gethcommon.Hash]bool{original.Hash(): true}}
+	restarted := newTestTxManager(t, restartedClient, pending, time.Minute)
+	require.NoError(t, restarted.Start())
+	require.Eventually(t, func() bool { return pending.count() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestBumpPriceRoundsUp(t *testing.T) {
+	require.Equal(t, big.NewInt(11), bumpPrice(big.NewInt(10), _replacementPriceBumpPercent))
+	require.Equal(t, big.NewInt(2), bumpPrice(big.NewInt(1), _replacementPriceBumpPercent))
+	require.Equal(t, big.NewInt(20), bumpPrice(big.NewInt(10), _blobReplacementPriceBumpPercent))
+}
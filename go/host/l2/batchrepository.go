@@ -25,8 +25,23 @@ const (
 	// (recipient will request the next ones as required, and they should be catching up from roll-ups first)
 	_maxBatchesInP2PResponse      = 50
 	_timeoutWaitingForP2PResponse = 30 * time.Second
+	// the missing batches are requested in ranges of _maxBatchesInP2PResponse, from several peers in parallel
+	_maxParallelP2PRequests = 8
 )
 
+// p2pRequest is a range of batches requested from a peer, which is in flight until the first batch of the range arrives
+type p2pRequest struct {
+	fromSeqNo     uint64 // the first batch requested, which is not aligned when the batches before it are stored
+	requestedAt   time.Time
+	fromSequencer bool // the sequencer is only asked when the request to a validator peer failed or timed out
+}
+
+// batchRange is the range of batches [from, to] requested from a peer
+type batchRange struct {
+	from uint64
+	to   uint64
+}
+
 // This private interface enforces the services that the guardian depends on
 type batchRepoServiceLocator interface {
 	P2P() host.P2P
@@ -53,10 +68,10 @@ type Repository struct {
 
 	// The repository requests batches from peers asynchronously, we don't want to repeatedly spam out requests if we
 	// haven't received a response yet, but we also don't want to wait forever if there's no response.
-	// So we keep track of the last request time and what was requested, using a mutex to avoid concurrent access errors on them
-	p2pReqMutex          sync.Mutex
-	p2pInFlightRequested *big.Int
-	p2pInFlightReqTime   *time.Time
+	// So we keep track of the in-flight requests by the aligned start of their range (see alignedRangeStart), using a mutex
+	// to avoid concurrent access errors
+	p2pReqMutex    sync.Mutex
+	p2pInFlightReq map[uint64]*p2pRequest
 
 	running atomic.Bool
 	logger  gethlog.Logger
@@ -71,6 +86,7 @@ func NewBatchRepository(cfg *hostconfig.HostConfig, hostService batchRepoService
 		isSequencer:               cfg.NodeType == common.ActiveSequencer,
 		latestBatchSeqNo:          big.NewInt(0),
 		latestValidatedSeqNo:      big.NewInt(0),
+		p2pInFlightReq:            map[uint64]*p2pRequest{},
 		running:                   atomic.Bool{},
 		logger:                    logger,
 	}
@@ -103,14 +119,16 @@ func (r *Repository) HealthStatus(context.Context) host.HealthStatus {
 // HandleBatches receives new batches from the p2p network, it also handles batches that are requested from peers
 // If the batch is the new head of the L2 then it notifies subscribers to this service that a new batch has arrived
 func (r *Repository) HandleBatches(batches []*common.ExtBatch, isLive bool) {
-	// if these batches resolve the in-flight request we made then clear the in-flight request (see type def for details)
-	r.p2pReqMutex.Lock()
-	if !isLive && len(batches) > 0 && r.p2pInFlightRequested != nil && batches[0].Header.SequencerOrderNo.Cmp(r.p2pInFlightRequested) == 0 {
-		// the first bach in the response is the one we requested, so clear the in-flight request
-		r.p2pInFlightRequested = nil
-		r.p2pInFlightReqTime = nil
+	// if these batches resolve an in-flight request we made then clear the in-flight request (see type def for details)
+	if !isLive && len(batches) > 0 {
+		// the first batch in the response is the first one of the range we requested
+		firstSeqNo := batches[0].Header.SequencerOrderNo.Uint64()
+		r.p2pReqMutex.Lock()
+		if req, found := r.p2pInFlightReq[alignedRangeStart(firstSeqNo)]; found && req.fromSeqNo == firstSeqNo {
+			delete(r.p2pInFlightReq, alignedRangeStart(firstSeqNo))
+		}
+		r.p2pReqMutex.Unlock()
 	}
-	r.p2pReqMutex.Unlock()
 
 	// try to add all the batches to the db, and notify subscribers if they are new and live
 	for _, batch := range batches {
@@ -126,11 +144,15 @@ func (r *Repository) HandleBatches(batches []*common.ExtBatch, isLive bool) {
 }
 
 // HandleBatchRequest handles a request for a batch from a peer, sending batches to the requester asynchronously
+// Both the sequencer and the validators serve the batches stored in their host DB.
 // todo (#1625) - only allow requests for batches since last rollup, to avoid DoS attacks.
-func (r *Repository) HandleBatchRequest(requesterID string, fromSeqNo *big.Int) {
+func (r *Repository) HandleBatchRequest(requesterID string, fromSeqNo *big.Int, toSeqNo *big.Int) {
 	batches := make([]*common.ExtBatch, 0)
-	nextSeqNum := fromSeqNo
+	nextSeqNum := new(big.Int).Set(fromSeqNo)
 	for len(batches) <= _maxBatchesInP2PResponse {
+		if toSeqNo != nil && toSeqNo.Sign() > 0 && nextSeqNum.Cmp(toSeqNo) > 0 {
+			break // the end of the requested range
+		}
 		batch, err := r.storage.FetchBatchBySeqNo(nextSeqNum.Uint64())
 		if err != nil {
 			if !errors.Is(err, errutil.ErrNotFound) {
@@ -231,23 +253,73 @@ func (r *Repository) fetchBatchFallbackToEnclave(ctx context.Context, seqNo *big
 	return b, nil
 }
 
-// RequestMissingBatches requests batches from peers from the specified sequence number.
+// requestMissingBatchesFromPeers requests the batches from the specified sequence number up to the latest one seen.
+// The range is split in ranges of _maxBatchesInP2PResponse which are requested from the validator peers in parallel,
+// a range is only requested from the sequencer when the request to a validator failed or timed out.
 // It is an asynchronous request and the repository does not expect to be notified of the result.
 func (r *Repository) requestMissingBatchesFromPeers(fromSeqNo *big.Int) {
+	r.latestSeqNoMutex.Lock()
+	latestSeqNo := r.latestBatchSeqNo.Uint64()
+	r.latestSeqNoMutex.Unlock()
+
 	r.p2pReqMutex.Lock()
 	defer r.p2pReqMutex.Unlock()
-	if r.p2pInFlightReqTime != nil && time.Since(*r.p2pInFlightReqTime) < _timeoutWaitingForP2PResponse {
-		// don't send request if we have sent one too recently
-		r.logger.Trace("not requesting missing batches from sequencer - too soon since last request", "fromSeqNo", fromSeqNo, "lastReq", r.p2pInFlightReqTime)
-		return
+
+	// forget the requests which were never answered, in case their range is not requested again
+	for seqNo, req := range r.p2pInFlightReq {
+		if time.Since(req.requestedAt) > 2*_timeoutWaitingForP2PResponse {
+			delete(r.p2pInFlightReq, seqNo)
+		}
+	}
+
+	for _, rng := range missingBatchRanges(fromSeqNo.Uint64(), latestSeqNo) {
+		key := alignedRangeStart(rng.from)
+		req, inFlight := r.p2pInFlightReq[key]
+		timedOut := inFlight && time.Since(req.requestedAt) >= _timeoutWaitingForP2PResponse
+		// a range is requested again from an earlier batch when the request in flight doesn't cover it
+		if inFlight && !timedOut && req.fromSeqNo <= rng.from {
+			// don't send request if we have sent one too recently
+			r.logger.Trace("not requesting missing batches - too soon since last request", "fromSeqNo", rng.from, "lastReq", req.requestedAt)
+			continue
+		}
+		// a range which timed out with a validator is requested from the sequencer, and the other way around
+		r.p2pInFlightReq[key] = r.requestBatchRange(rng, timedOut && !req.fromSequencer)
+	}
+}
+
+// missingBatchRanges splits the batches from fromSeqNo up to the latest one seen (which is stored) in at most
+// _maxParallelP2PRequests ranges. The ranges are aligned to multiples of _maxBatchesInP2PResponse, so a range is tracked
+// under the same key whichever of its batches is found missing first.
+func missingBatchRanges(fromSeqNo uint64, latestSeqNo uint64) []batchRange {
+	ranges := make([]batchRange, 0)
+	for from := fromSeqNo; from < latestSeqNo && len(ranges) < _maxParallelP2PRequests; {
+		to := min(alignedRangeStart(from)+_maxBatchesInP2PResponse-1, latestSeqNo-1)
+		ranges = append(ranges, batchRange{from: from, to: to})
+		from = to + 1
+	}
+	return ranges
+}
+
+func alignedRangeStart(seqNo uint64) uint64 {
+	return seqNo - seqNo%_maxBatchesInP2PResponse
+}
+
+func (r *Repository) requestBatchRange(rng batchRange, fromSequencer bool) *p2pRequest {
+	fromSeqNo, toSeqNo := new(big.Int).SetUint64(rng.from), new(big.Int).SetUint64(rng.to)
+	if !fromSequencer {
+		r.logger.Debug("requesting missing batches from peer", "fromSeqNo", rng.from, "toSeqNo", rng.to)
+		err := r.sl.P2P().RequestBatchesFromPeer(fromSeqNo, toSeqNo)
+		if err == nil {
+			return &p2pRequest{fromSeqNo: rng.from, requestedAt: time.Now()}
+		}
+		r.logger.Debug("unable to request missing batches from peer, falling back to the sequencer", "fromSeqNo", rng.from, log.ErrKey, err)
 	}
 
-	r.logger.Debug("requesting missing batches from sequencer", "fromSeqNo", fromSeqNo)
-	err := r.sl.P2P().RequestBatchesFromSequencer(fromSeqNo)
+	r.logger.Debug("requesting missing batches from sequencer", "fromSeqNo", rng.from, "toSeqNo", rng.to)
+	err := r.sl.P2P().RequestBatchesFromSequencer(fromSeqNo, toSeqNo)
 	if err != nil {
-		r.logger.Warn("unable to request missing batches from sequencer", "fromSeqNo", fromSeqNo, log.ErrKey, err)
-		return
+		r.logger.Warn("unable to request missing batches from sequencer", "fromSeqNo", rng.from, log.ErrKey, err)
 	}
-	now := time.Now()
-	r.p2pInFlightReqTime = &now
+	// a failed request is retried once it times out, to avoid spamming the peers
+	return &p2pRequest{fromSeqNo: rng.from, requestedAt: time.Now(), fromSequencer: true}
 }
@@ -0,0 +1,127 @@
+package l2
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	hostconfig "github.com/ten-protocol/go-ten/go/host/config"
+	"github.com/ten-protocol/go-ten/go/host/storage"
+)
+
+// batchRequestP2P - records the batch ranges requested from the validator peers and from the sequencer
+type batchRequestP2P struct {
+	host.P2P
+	peerErr         error
+	peerRanges      []batchRange
+	sequencerRanges []batchRange
+}
+
+func (p *batchRequestP2P) RequestBatchesFromPeer(fromSeqNo *big.Int, toSeqNo *big.Int) error {
+	if p.peerErr != nil {
+		return p.peerErr
+	}
+	p.peerRanges = append(p.peerRanges, batchRange{from: fromSeqNo.Uint64(), to: toSeqNo.Uint64()})
+	return nil
+}
+
+func (p *batchRequestP2P) RequestBatchesFromSequencer(fromSeqNo *big.Int, toSeqNo *big.Int) error {
+	p.sequencerRanges = append(p.sequencerRanges, batchRange{from: fromSeqNo.Uint64(), to: toSeqNo.Uint64()})
+	return nil
+}
+
+type batchRepoServices struct {
+	p2p *batchRequestP2P
+}
+
+func (s *batchRepoServices) P2P() host.P2P {
+	return s.p2p
+}
+
+func (s *batchRepoServices) Enclaves() host.EnclaveService {
+	return nil
+}
+
+// batchStorage - a host DB which stores any batch
+type batchStorage struct {
+	storage.Storage
+}
+
+func (s *batchStorage) AddBatch(*common.ExtBatch) error {
+	return nil
+}
+
+func newTestRepository(p2p *batchRequestP2P, latestSeqNo int64) *Repository {
+	r := NewBatchRepository(&hostconfig.HostConfig{NodeType: common.Validator}, &batchRepoServices{p2p: p2p}, &batchStorage{}, gethlog.New())
+	r.latestBatchSeqNo = big.NewInt(latestSeqNo)
+	return r
+}
+
+func TestMissingBatchRanges(t *testing.T) {
+	// the first range ends at the next aligned range, the last one before the latest batch, which is stored
+	require.Equal(t, []batchRange{{from: 73, to: 99}, {from: 100, to: 149}, {from: 150, to: 179}}, missingBatchRanges(73, 180))
+	require.Equal(t, []batchRange{{from: 50, to: 99}}, missingBatchRanges(50, 100))
+	require.Empty(t, missingBatchRanges(180, 180))
+
+	// the number of ranges requested in parallel is capped
+	ranges := missingBatchRanges(0, 1_000_000)
+	require.Len(t, ranges, _maxParallelP2PRequests)
+	require.Equal(t, batchRange{from: (_maxParallelP2PRequests - 1) * _maxBatchesInP2PResponse, to: _maxParallelP2PRequests*_maxBatchesInP2PResponse - 1}, ranges[len(ranges)-1])
+}
+
+func TestRequestMissingBatchesByAlignedRange(t *testing.T) {
+	p2p := &batchRequestP2P{}
+	r := newTestRepository(p2p, 180)
+
+	r.requestMissingBatchesFromPeers(big.NewInt(73))
+	require.Equal(t, []batchRange{{from: 73, to: 99}, {from: 100, to: 149}, {from: 150, to: 179}}, p2p.peerRanges)
+	require.Empty(t, p2p.sequencerRanges)
+
+	// a later batch of a range in flight is not requested again
+	p2p.peerRanges = nil
+	r.requestMissingBatchesFromPeers(big.NewInt(80))
+	require.Empty(t, p2p.peerRanges)
+
+	// an earlier batch of a range in flight is requested, as the request in flight doesn't cover it
+	r.requestMissingBatchesFromPeers(big.NewInt(60))
+	require.Equal(t, []batchRange{{from: 60, to: 99}}, p2p.peerRanges)
+
+	// the response to the replaced request doesn't resolve the range, the response to the new one does
+	batchWithSeqNo := func(seqNo int64) []*common.ExtBatch {
+		return []*common.ExtBatch{{Header: &common.BatchHeader{Number: big.NewInt(seqNo), SequencerOrderNo: big.NewInt(seqNo)}}}
+	}
+	r.HandleBatches(batchWithSeqNo(73), false)
+	require.Contains(t, r.p2pInFlightReq, uint64(50))
+	r.HandleBatches(batchWithSeqNo(60), false)
+	require.NotContains(t, r.p2pInFlightReq, uint64(50))
+	require.Contains(t, r.p2pInFlightReq, uint64(100))
+}
+
+func TestRequestMissingBatchesFallsBackToSequencer(t *testing.T) {
+	p2p := &batchRequestP2P{peerErr: errors.New("no validator peers")}
+	r := newTestRepository(p2p, 100)
+
+	// the range is requested from the sequencer when it can't be requested from a validator
+	r.requestMissingBatchesFromPeers(big.NewInt(60))
+	require.Empty(t, p2p.peerRanges)
+	require.Equal(t, []batchRange{{from: 60, to: 99}}, p2p.sequencerRanges)
+
+	// once the sequencer times out, the range is requested from a validator again
+	p2p.peerErr = nil
+	p2p.sequencerRanges = nil
+	r.p2pInFlightReq[50].requestedAt = time.Now().Add(-_timeoutWaitingForP2PResponse)
+	r.requestMissingBatchesFromPeers(big.NewInt(60))
+	require.Equal(t, []batchRange{{from: 60, to: 99}}, p2p.peerRanges)
+	require.Empty(t, p2p.sequencerRanges)
+
+	// and a range which times out with a validator is requested from the sequencer
+	r.p2pInFlightReq[50].requestedAt = time.Now().Add(-_timeoutWaitingForP2PResponse)
+	r.requestMissingBatchesFromPeers(big.NewInt(60))
+	require.Equal(t, []batchRange{{from: 60, to: 99}}, p2p.sequencerRanges)
+	require.Len(t, p2p.peerRanges, 1)
+}
@@ -0,0 +1,202 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/signature"
+)
+
+// HandleBlock - the node set is updated with the management contract events of every new L1 block
+func (p *Service) HandleBlock(block *types.Header) {
+	// if a sync is in progress, the block is skipped. The next block catches up with it.
+	if !p.nodeSetSyncMutex.TryLock() {
+		return
+	}
+	defer p.nodeSetSyncMutex.Unlock()
+	if err := p.syncNodeSet(block); err != nil {
+		p.logger.Warn("Failed to sync the node set", log.ErrKey, err)
+	}
+}
+
+// syncNodeSet applies the node set events from the last synced L1 height up to the given block, or up to the L1 head
+// if the block is nil. The first sync starts from the L1 start block, where the management contract was deployed.
+// The caller must hold the nodeSetSyncMutex.
+func (p *Service) syncNodeSet(block *types.Header) error {
+	if block == nil {
+		head, err := p.sl.L1Data().FetchBlockByHeight(nil)
+		if err != nil {
+			return fmt.Errorf("could not fetch the L1 head. Cause: %w", err)
+		}
+		block = head
+	}
+
+	if err := p.rollbackReorgedNodeSetEvents(block); err != nil {
+		return err
+	}
+
+	from := p.nodes.nextHeight()
+	if from == nil {
+		var err error
+		if from, err = p.l1StartHeight(); err != nil {
+			return err
+		}
+	}
+	for from.Cmp(block.Number) <= 0 {
+		if p.stopControl.IsStopping() {
+			return nil
+		}
+		to := new(big.Int).Add(from, big.NewInt(_nodeSetSyncRange-1))
+		if to.Cmp(block.Number) > 0 {
+			to = block.Number
+		}
+		events, err := p.sl.L1Data().GetNodeSetEvents(from, to)
+		if err != nil {
+			return err
+		}
+		toBlock := block
+		if to.Cmp(block.Number) < 0 {
+			if toBlock, err = p.sl.L1Data().FetchBlockByHeight(to); err != nil {
+				return fmt.Errorf("could not fetch the L1 block at height %d. Cause: %w", to, err)
+			}
+		}
+		p.nodes.apply(events, toBlock)
+		from = new(big.Int).Add(to, big.NewInt(1))
+	}
+	return nil
+}
+
+// rollbackReorgedNodeSetEvents rolls the node set back to the last canonical L1 block with node set events, when the
+// last synced block is no longer canonical. The blocks older than _nodeSetReorgDepth are final, so the node set is
+// rolled back at most to that depth.
+func (p *Service) rollbackReorgedNodeSetEvents(block *types.Header) error {
+	synced := p.nodes.syncedBlock()
+	if synced == nil {
+		return nil
+	}
+	// the usual case, the block is the child of the last synced block
+	if block.ParentHash == synced.Hash() {
+		return nil
+	}
+	isCanonical := func(height uint64, hash gethcommon.Hash) (bool, error) {
+		canonical, err := p.sl.L1Data().FetchBlockByHeight(new(big.Int).SetUint64(height))
+		if err != nil {
+			return false, fmt.Errorf("could not fetch the L1 block at height %d. Cause: %w", height, err)
+		}
+		return canonical.Hash() == hash, nil
+	}
+	if canonical, err := isCanonical(synced.Number.Uint64(), synced.Hash()); err != nil || canonical {
+		return err
+	}
+
+	ancestorHeight := uint64(0)
+	if synced.Number.Uint64() > _nodeSetReorgDepth {
+		ancestorHeight = synced.Number.Uint64() - _nodeSetReorgDepth
+	}
+	numbers, hashes := p.nodes.eventBlocks(ancestorHeight)
+	for i := range numbers {
+		canonical, err := isCanonical(numbers[i], hashes[i])
+		if err != nil {
+			return err
+		}
+		if canonical {
+			ancestorHeight = numbers[i]
+			break
+		}
+	}
+	ancestor, err := p.sl.L1Data().FetchBlockByHeight(new(big.Int).SetUint64(ancestorHeight))
+	if err != nil {
+		return fmt.Errorf("could not fetch the L1 block at height %d. Cause: %w", ancestorHeight, err)
+	}
+	p.logger.Info("Rolling back the node set after an L1 reorg", "from", synced.Number, "to", ancestorHeight)
+	p.nodes.rollback(ancestor)
+	return nil
+}
+
+// l1StartHeight returns the height of the L1 start block, or 0 if it is not configured
+func (p *Service) l1StartHeight() (*big.Int, error) {
+	if p.l1StartHash == (gethcommon.Hash{}) {
+		return big.NewInt(0), nil
+	}
+	startBlock, err := p.sl.L1Data().FetchBlock(context.Background(), p.l1StartHash)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the L1 start block %s. Cause: %w", p.l1StartHash, err)
+	}
+	return startBlock.Number, nil
+}
+
+// signAndEncode timestamps and signs the message with the key of the host wallet, and encodes it
+func (p *Service) signAndEncode(msg message) ([]byte, error) {
+	msg.Timestamp = uint64(time.Now().Unix())
+	hash, err := messageHash(msg)
+	if err != nil {
+		return nil, err
+	}
+	msg.Signature, err = signature.Sign(hash.Bytes(), p.hostWallet.PrivateKey())
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(msg)
+}
+
+// recoverSigner returns the address of the L1 wallet of the host that signed the message
+func recoverSigner(msg message) (gethcommon.Address, error) {
+	if len(msg.Signature) == 0 {
+		return gethcommon.Address{}, errors.New("message is not signed")
+	}
+	hash, err := messageHash(msg)
+	if err != nil {
+		return gethcommon.Address{}, err
+	}
+	signer, err := signature.RecoverAddress(hash.Bytes(), msg.Signature)
+	if err != nil {
+		return gethcommon.Address{}, err
+	}
+	return *signer, nil
+}
+
+// checkMessageAge rejects the messages signed more than _maxMessageAge from now, which could be replayed
+func checkMessageAge(msg message, now time.Time) error {
+	signedAt := time.Unix(int64(msg.Timestamp), 0) //nolint:gosec
+	if signedAt.Before(now.Add(-_maxMessageAge)) || signedAt.After(now.Add(_maxMessageAge)) {
+		return fmt.Errorf("message signed at %s is stale", signedAt)
+	}
+	return nil
+}
+
+func messageHash(msg message) (gethcommon.Hash, error) {
+	encoded, err := rlp.EncodeToBytes([]any{msg.Sender, msg.Type, msg.Contents, msg.Timestamp})
+	if err != nil {
+		return gethcommon.Hash{}, fmt.Errorf("could not encode message. Cause: %w", err)
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// verifyBatchSignatures checks that each batch was signed by a sequencer enclave
+func (p *Service) verifyBatchSignatures(batches []*common.ExtBatch) error {
+	for _, batch := range batches {
+		if batch.Header == nil || len(batch.Header.Signature) == 0 {
+			return fmt.Errorf("batch %s is not signed", batch.Hash())
+		}
+		// the recovery modifies the signature in place, so it works on a copy
+		sig := make([]byte, len(batch.Header.Signature))
+		copy(sig, batch.Header.Signature)
+		enclaveID, err := signature.RecoverAddress(batch.Hash().Bytes(), sig)
+		if err != nil {
+			return fmt.Errorf("invalid signature on batch %s. Cause: %w", batch.Hash(), err)
+		}
+		if !p.nodes.isSequencerEnclave(*enclaveID) {
+			return fmt.Errorf("batch %s was not signed by a sequencer enclave", batch.Hash())
+		}
+	}
+	return nil
+}
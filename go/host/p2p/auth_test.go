@@ -0,0 +1,250 @@
+package p2p
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/common/signature"
+	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
+	"github.com/ten-protocol/go-ten/go/wallet"
+)
+
+func TestNodeSet(t *testing.T) {
+	sequencerHost, validatorHost, attacker := gethcommon.BigToAddress(big.NewInt(1)), gethcommon.BigToAddress(big.NewInt(2)), gethcommon.BigToAddress(big.NewInt(3))
+	sequencerEnclave, validatorEnclave := gethcommon.BigToAddress(big.NewInt(11)), gethcommon.BigToAddress(big.NewInt(12))
+
+	ns := newNodeSet()
+	require.Nil(t, ns.nextHeight())
+
+	ns.apply([]*host.NodeSetEvent{
+		{Type: host.HostRegistered, HostID: sequencerHost, EnclaveID: sequencerEnclave, P2PAddress: "seq:10000"},
+		{Type: host.EnclaveAttested, EnclaveID: sequencerEnclave},
+		{Type: host.SequencerGranted, EnclaveID: sequencerEnclave},
+		{Type: host.HostRegistered, HostID: validatorHost, EnclaveID: validatorEnclave, P2PAddress: "val:10001"},
+		// a copy of the attestation published by someone else does not change the host of the enclave
+		{Type: host.HostRegistered, HostID: attacker, EnclaveID: validatorEnclave, P2PAddress: "attacker:10002"},
+	}, &types.Header{Number: big.NewInt(10)})
+	require.Equal(t, int64(11), ns.nextHeight().Int64())
+
+	require.True(t, ns.isSequencerHost(sequencerHost))
+	require.True(t, ns.isSequencerEnclave(sequencerEnclave))
+	require.Equal(t, "seq:10000", ns.p2pAddress(sequencerHost))
+	// the validator enclave did not receive the secret yet
+	require.False(t, ns.isKnownHost(validatorHost))
+
+	ns.apply([]*host.NodeSetEvent{{Type: host.EnclaveAttested, EnclaveID: validatorEnclave}}, &types.Header{Number: big.NewInt(11)})
+	require.True(t, ns.isKnownHost(validatorHost))
+	require.False(t, ns.isSequencerHost(validatorHost))
+	require.False(t, ns.isKnownHost(attacker))
+	require.Equal(t, "val:10001", ns.p2pAddress(validatorHost))
+	// only the validators serve the batch requests, the sequencer is the fallback
+	require.Equal(t, []string{"val:10001"}, ns.validatorAddresses())
+
+	ns.apply([]*host.NodeSetEvent{{Type: host.SequencerRevoked, EnclaveID: sequencerEnclave}}, &types.Header{Number: big.NewInt(12)})
+	require.False(t, ns.isSequencerHost(sequencerHost))
+	require.False(t, ns.isSequencerEnclave(sequencerEnclave))
+}
+
+func TestMessageSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	p := &Service{hostWallet: wallet.NewInMemoryWalletFromPK(big.NewInt(1), key, gethlog.New())}
+
+	encoded, err := p.signAndEncode(message{Sender: "127.0.0.1:10000", Type: msgTypeTx, Contents: []byte{1, 2, 3}})
+	require.NoError(t, err)
+	msg := message{}
+	require.NoError(t, rlp.DecodeBytes(encoded, &msg))
+	signer, err := recoverSigner(msg)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	// changing the sender changes the signer
+	msg.Sender = "127.0.0.1:10001"
+	signer, err = recoverSigner(msg)
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	// the timestamp is signed, and the stale messages are rejected
+	msg.Sender = "127.0.0.1:10000"
+	require.NoError(t, checkMessageAge(msg, time.Now()))
+	require.Error(t, checkMessageAge(msg, time.Now().Add(2*_maxMessageAge)))
+	require.Error(t, checkMessageAge(msg, time.Now().Add(-2*_maxMessageAge)))
+	msg.Timestamp++
+	signer, err = recoverSigner(msg)
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	msg.Signature = nil
+	_, err = recoverSigner(msg)
+	require.Error(t, err)
+}
+
+func TestVerifyBatchSignatures(t *testing.T) {
+	sequencerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	p := &Service{nodes: newNodeSet()}
+	sequencerEnclave := crypto.PubkeyToAddress(sequencerKey.PublicKey)
+	p.nodes.apply([]*host.NodeSetEvent{
+		{Type: host.EnclaveAttested, EnclaveID: sequencerEnclave},
+		{Type: host.SequencerGranted, EnclaveID: sequencerEnclave},
+	}, &types.Header{Number: big.NewInt(0)})
+
+	signedBatch := func(key *ecdsa.PrivateKey) *common.ExtBatch {
+		batch := &common.ExtBatch{Header: &common.BatchHeader{Number: big.NewInt(1), SequencerOrderNo: big.NewInt(1)}}
+		if key != nil {
+			batch.Header.Signature, err = signature.Sign(batch.Hash().Bytes(), key)
+			require.NoError(t, err)
+		}
+		return batch
+	}
+
+	valid := signedBatch(sequencerKey)
+	sig := append([]byte{}, valid.Header.Signature...)
+	require.NoError(t, p.verifyBatchSignatures([]*common.ExtBatch{valid}))
+	// the signature of the batch is not modified by the verification
+	require.Equal(t, sig, valid.Header.Signature)
+
+	require.Error(t, p.verifyBatchSignatures([]*common.ExtBatch{valid, signedBatch(otherKey)}))
+	require.Error(t, p.verifyBatchSignatures([]*common.ExtBatch{signedBatch(nil)}))
+}
+
+// nodeSetL1Data - the L1 data of a chain whose management contract was deployed at height 100
+type nodeSetL1Data struct {
+	host.L1DataService
+	head            int64
+	forkedBlocks    map[int64]*types.Header                  // the canonical blocks that replaced the original block of their height
+	events          map[gethcommon.Hash][]*host.NodeSetEvent // the events of each block
+	requestedRanges [][2]int64
+}
+
+func newNodeSetL1Data(head int64) *nodeSetL1Data {
+	return &nodeSetL1Data{head: head, forkedBlocks: map[int64]*types.Header{}, events: map[gethcommon.Hash][]*host.NodeSetEvent{}}
+}
+
+// block returns the canonical block at the given height
+func (d *nodeSetL1Data) block(height int64) *types.Header {
+	if b, found := d.forkedBlocks[height]; found {
+		return b
+	}
+	return &types.Header{Number: big.NewInt(height)}
+}
+
+// fork replaces the blocks from the given height, and returns the new blocks by height
+func (d *nodeSetL1Data) fork(fromHeight int64, head int64) map[int64]*types.Header {
+	for h := fromHeight; h <= head; h++ {
+		d.forkedBlocks[h] = &types.Header{Number: big.NewInt(h), Extra: []byte("fork")}
+	}
+	d.head = head
+	return d.forkedBlocks
+}
+
+func (d *nodeSetL1Data) FetchBlockByHeight(height *big.Int) (*types.Header, error) {
+	if height == nil {
+		return d.block(d.head), nil
+	}
+	return d.block(height.Int64()), nil
+}
+
+func (d *nodeSetL1Data) FetchBlock(context.Context, common.L1BlockHash) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(100)}, nil
+}
+
+func (d *nodeSetL1Data) GetNodeSetEvents(fromBlock, toBlock *big.Int) ([]*host.NodeSetEvent, error) {
+	d.requestedRanges = append(d.requestedRanges, [2]int64{fromBlock.Int64(), toBlock.Int64()})
+	var events []*host.NodeSetEvent
+	for h := fromBlock.Int64(); h <= toBlock.Int64(); h++ {
+		events = append(events, d.events[d.block(h).Hash()]...)
+	}
+	return events, nil
+}
+
+// registerHost adds the events of an attested host to the block
+func (d *nodeSetL1Data) registerHost(block *types.Header, hostID gethcommon.Address) {
+	enclaveID := gethcommon.BytesToAddress(crypto.Keccak256(hostID.Bytes()))
+	d.events[block.Hash()] = append(d.events[block.Hash()],
+		&host.NodeSetEvent{Type: host.HostRegistered, HostID: hostID, EnclaveID: enclaveID, BlockNumber: block.Number.Uint64(), BlockHash: block.Hash()},
+		&host.NodeSetEvent{Type: host.EnclaveAttested, EnclaveID: enclaveID, BlockNumber: block.Number.Uint64(), BlockHash: block.Hash()},
+	)
+}
+
+type nodeSetServiceLocator struct {
+	p2pServiceLocator
+	l1Data *nodeSetL1Data
+}
+
+func (l *nodeSetServiceLocator) L1Data() host.L1DataService {
+	return l.l1Data
+}
+
+func TestSyncNodeSetFromL1StartBlock(t *testing.T) {
+	l1Data := newNodeSetL1Data(25_000)
+	p := &Service{
+		sl:          &nodeSetServiceLocator{l1Data: l1Data},
+		nodes:       newNodeSet(),
+		l1StartHash: gethcommon.Hash{1},
+		stopControl: stopcontrol.New(),
+	}
+
+	// the first sync starts from the deployment of the management contract, up to the L1 head
+	require.NoError(t, p.syncNodeSet(nil))
+	require.Equal(t, [][2]int64{{100, 10_099}, {10_100, 20_099}, {20_100, 25_000}}, l1Data.requestedRanges)
+
+	// the next blocks continue from there
+	l1Data.requestedRanges = nil
+	require.NoError(t, p.syncNodeSet(l1Data.block(25_001)))
+	require.Equal(t, [][2]int64{{25_001, 25_001}}, l1Data.requestedRanges)
+
+	// a new block is skipped while a sync is in progress
+	l1Data.requestedRanges = nil
+	p.nodeSetSyncMutex.Lock()
+	p.HandleBlock(l1Data.block(25_002))
+	require.Empty(t, l1Data.requestedRanges)
+	p.nodeSetSyncMutex.Unlock()
+	p.HandleBlock(l1Data.block(25_002))
+	require.Equal(t, [][2]int64{{25_002, 25_002}}, l1Data.requestedRanges)
+}
+
+func TestSyncNodeSetRollsBackReorgedBlocks(t *testing.T) {
+	l1Data := newNodeSetL1Data(110)
+	p := &Service{
+		sl:          &nodeSetServiceLocator{l1Data: l1Data},
+		nodes:       newNodeSet(),
+		l1StartHash: gethcommon.Hash{1},
+		stopControl: stopcontrol.New(),
+		logger:      gethlog.New(),
+	}
+	finalHost, reorgedHost, forkHost := gethcommon.BigToAddress(big.NewInt(1)), gethcommon.BigToAddress(big.NewInt(2)), gethcommon.BigToAddress(big.NewInt(3))
+	l1Data.registerHost(l1Data.block(102), finalHost)
+	l1Data.registerHost(l1Data.block(105), reorgedHost)
+
+	require.NoError(t, p.syncNodeSet(nil))
+	require.True(t, p.nodes.isKnownHost(finalHost))
+	require.True(t, p.nodes.isKnownHost(reorgedHost))
+
+	// the blocks from 104 are replaced, and the host is registered by another block of the new fork
+	forkedBlocks := l1Data.fork(104, 111)
+	l1Data.registerHost(forkedBlocks[107], forkHost)
+	l1Data.requestedRanges = nil
+	p.HandleBlock(forkedBlocks[111])
+
+	// the node set is rolled back to the last canonical block with events, and synced again from there
+	require.Equal(t, [][2]int64{{103, 111}}, l1Data.requestedRanges)
+	require.True(t, p.nodes.isKnownHost(finalHost))
+	require.False(t, p.nodes.isKnownHost(reorgedHost))
+	require.True(t, p.nodes.isKnownHost(forkHost))
+	require.Equal(t, forkedBlocks[111], p.nodes.syncedBlock())
+}
@@ -0,0 +1,181 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/ten-protocol/go-ten/go/common/log"
+)
+
+var (
+	_maxPendingPerPeer = 32 // messages waiting to be written to a peer, further messages are dropped until it catches up
+
+	errPeerBusy    = errors.New("too many pending messages for peer")
+	errPoolClosed  = errors.New("p2p connection pool is closed")
+	errPeerRemoved = errors.New("peer was removed from the p2p connection pool")
+)
+
+// connPool - the long-lived outbound connections, one per peer address. The connection to a peer is dialed when the
+// first message is sent to it, and re-dialed after it fails.
+type connPool struct {
+	timeout time.Duration // for dialing a peer and writing a frame to it
+	peers   map[string]*peerConn
+	closed  bool
+	mutex   sync.Mutex
+
+	metrics *p2pMetrics
+	logger  gethlog.Logger
+}
+
+// peerConn - the connection to a single peer. The writes are serialised, and the number of messages waiting for the
+// connection is bounded, so a slow peer applies backpressure instead of accumulating an unbounded backlog.
+type peerConn struct {
+	address string
+	pending chan struct{} // semaphore for the messages waiting to be written
+	conn    net.Conn      // nil until dialed, or after a failure
+	removed bool          // the peer was removed from the pool, so it must not be dialed again
+	mutex   sync.Mutex
+
+	pendingGauge gethmetrics.Gauge
+	dropped      gethmetrics.Meter
+}
+
+func newConnPool(timeout time.Duration, metrics *p2pMetrics, logger gethlog.Logger) *connPool {
+	return &connPool{
+		timeout: timeout,
+		peers:   map[string]*peerConn{},
+		metrics: metrics,
+		logger:  logger,
+	}
+}
+
+// send writes the frame to the peer, dialing it if there is no open connection
+func (cp *connPool) send(address string, frame []byte) error {
+	pc, err := cp.peer(address)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case pc.pending <- struct{}{}:
+	default:
+		pc.dropped.Mark(1)
+		cp.metrics.dropped.Mark(1)
+		return errPeerBusy
+	}
+	pc.pendingGauge.Inc(1)
+	defer func() {
+		<-pc.pending
+		pc.pendingGauge.Dec(1)
+	}()
+
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+
+	if pc.removed {
+		return errPeerRemoved
+	}
+	if pc.conn == nil {
+		conn, err := net.DialTimeout(tcp, address, cp.timeout)
+		if err != nil {
+			return fmt.Errorf("could not connect to peer. Cause: %w", err)
+		}
+		pc.conn = conn
+		cp.metrics.dials.Mark(1)
+		cp.metrics.connections.Inc(1)
+		go cp.watch(pc, conn)
+	}
+
+	if err := pc.conn.SetWriteDeadline(time.Now().Add(cp.timeout)); err != nil {
+		pc.closeConn(cp.metrics)
+		return err
+	}
+	if _, err := pc.conn.Write(frame); err != nil {
+		// a partial frame corrupts the stream, so the connection can't be reused
+		pc.closeConn(cp.metrics)
+		return fmt.Errorf("could not send message to peer. Cause: %w", err)
+	}
+	cp.metrics.sentMessages.Mark(1)
+	cp.metrics.sentBytes.Mark(int64(len(frame)))
+	return nil
+}
+
+// remove closes the connection to a peer that is no longer used
+func (cp *connPool) remove(address string) {
+	cp.mutex.Lock()
+	pc, found := cp.peers[address]
+	delete(cp.peers, address)
+	cp.mutex.Unlock()
+
+	if found {
+		pc.close(cp.metrics)
+	}
+}
+
+// close closes all the connections, and rejects any further message
+func (cp *connPool) close() {
+	cp.mutex.Lock()
+	peers := cp.peers
+	cp.peers = map[string]*peerConn{}
+	cp.closed = true
+	cp.mutex.Unlock()
+
+	for _, pc := range peers {
+		pc.close(cp.metrics)
+	}
+}
+
+func (cp *connPool) peer(address string) (*peerConn, error) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	if cp.closed {
+		return nil, errPoolClosed
+	}
+	pc, found := cp.peers[address]
+	if !found {
+		pc = &peerConn{
+			address:      address,
+			pending:      make(chan struct{}, _maxPendingPerPeer),
+			pendingGauge: gethmetrics.GetOrRegisterGauge(peerMetricName(address, "pending"), cp.metrics.registry),
+			dropped:      gethmetrics.GetOrRegisterMeter(peerMetricName(address, "dropped"), cp.metrics.registry),
+		}
+		cp.peers[address] = pc
+	}
+	return pc, nil
+}
+
+// watch - nothing is expected from the peer on an outbound connection, so the read only returns when the connection
+// is closed. Noticing it straight away means the next message is sent on a new connection instead of being lost.
+func (cp *connPool) watch(pc *peerConn, conn net.Conn) {
+	_, err := io.Copy(io.Discard, conn)
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+	if pc.conn == conn {
+		cp.logger.Debug("Connection closed by peer", "peer", pc.address, log.ErrKey, err)
+		pc.closeConn(cp.metrics)
+	}
+}
+
+func (pc *peerConn) close(metrics *p2pMetrics) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+	pc.closeConn(metrics)
+	pc.removed = true
+	metrics.unregisterPeer(pc.address)
+}
+
+// the caller must hold the lock
+func (pc *peerConn) closeConn(metrics *p2pMetrics) {
+	if pc.conn == nil {
+		return
+	}
+	_ = pc.conn.Close()
+	pc.conn = nil
+	metrics.connections.Dec(1)
+}
@@ -0,0 +1,247 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestFrameRoundTrip(t *testing.T) {
+	small := []byte{1, 2, 3}
+	large := bytes.Repeat([]byte("batch"), 10_000)
+
+	for _, compress := range []bool{false, true} {
+		var stream bytes.Buffer
+		for _, payload := range [][]byte{small, large} {
+			frame, err := encodeFrame(payload, compress)
+			require.NoError(t, err)
+			stream.Write(frame)
+		}
+
+		for _, payload := range [][]byte{small, large} {
+			decoded, err := readFrame(&stream)
+			require.NoError(t, err)
+			require.Equal(t, payload, decoded)
+		}
+	}
+
+	// the small payloads are never compressed, the large ones only when requested
+	frame, err := encodeFrame(small, true)
+	require.NoError(t, err)
+	require.Equal(t, byte(0), frame[4])
+	frame, err = encodeFrame(large, true)
+	require.NoError(t, err)
+	require.Equal(t, _flagCompressed, frame[4])
+	require.Less(t, len(frame), len(large))
+}
+
+func TestFrameSizeIsCapped(t *testing.T) {
+	defaultMax := _maxFrameSize
+	_maxFrameSize = 1024
+	defer func() { _maxFrameSize = defaultMax }()
+
+	// the header is rejected before the payload is read
+	header := make([]byte, _frameHeaderSize)
+	binary.BigEndian.PutUint32(header, _maxFrameSize+1)
+	_, err := readFrame(bytes.NewReader(header))
+	require.ErrorIs(t, err, errFrameTooLarge)
+
+	_, err = encodeFrame(make([]byte, _maxFrameSize+1), false)
+	require.ErrorIs(t, err, errFrameTooLarge)
+
+	// a small compressed frame can't expand above the limit either
+	compressed, err := compressPayload(make([]byte, 10*_maxFrameSize))
+	require.NoError(t, err)
+	frame := make([]byte, _frameHeaderSize+len(compressed))
+	binary.BigEndian.PutUint32(frame, uint32(len(compressed)))
+	frame[4] = _flagCompressed
+	copy(frame[_frameHeaderSize:], compressed)
+	_, err = readFrame(bytes.NewReader(frame))
+	require.ErrorIs(t, err, errFrameTooLarge)
+}
+
+func TestConnPoolReusesConnection(t *testing.T) {
+	listener, err := net.Listen(tcp, "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	pool := newConnPool(time.Second, newP2PMetrics(nil), gethlog.New())
+	defer pool.close()
+	address := listener.Addr().String()
+
+	for i := byte(0); i < 3; i++ {
+		frame, err := encodeFrame([]byte{i}, false)
+		require.NoError(t, err)
+		require.NoError(t, pool.send(address, frame))
+	}
+
+	conn := <-accepted
+	for i := byte(0); i < 3; i++ {
+		payload, err := readFrame(conn)
+		require.NoError(t, err)
+		require.Equal(t, []byte{i}, payload)
+	}
+
+	// once the peer closes the connection, the next message is sent on a new one
+	require.NoError(t, conn.Close())
+	require.Eventually(t, func() bool {
+		pc, err := pool.peer(address)
+		require.NoError(t, err)
+		pc.mutex.Lock()
+		defer pc.mutex.Unlock()
+		return pc.conn == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	frame, err := encodeFrame([]byte{4}, false)
+	require.NoError(t, err)
+	require.NoError(t, pool.send(address, frame))
+	conn = <-accepted
+	defer conn.Close()
+	payload, err := readFrame(conn)
+	require.NoError(t, err)
+	require.Equal(t, []byte{4}, payload)
+
+	// removing the peer closes its connection
+	pool.remove(address)
+	_, err = readFrame(conn)
+	require.ErrorIs(t, err, io.EOF)
+
+	pool.close()
+	require.ErrorIs(t, pool.send(address, frame), errPoolClosed)
+}
+
+func TestConnPoolBackpressure(t *testing.T) {
+	pool := newConnPool(time.Second, newP2PMetrics(nil), gethlog.New())
+	defer pool.close()
+
+	pc, err := pool.peer("127.0.0.1:1")
+	require.NoError(t, err)
+	// the peer has the maximum number of messages waiting to be written
+	for i := 0; i < _maxPendingPerPeer; i++ {
+		pc.pending <- struct{}{}
+	}
+	require.ErrorIs(t, pool.send("127.0.0.1:1", []byte{1}), errPeerBusy)
+}
+
+// remoteConn - a connection from the given remote address
+type remoteConn struct {
+	net.Conn
+	remoteAddr net.Addr
+}
+
+func (c *remoteConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+func TestInboundConnectionLimits(t *testing.T) {
+	p := &Service{
+		stopControl:  stopcontrol.New(),
+		inbound:      map[net.Conn]struct{}{},
+		inboundPerIP: map[string]int{},
+		metrics:      newP2PMetrics(nil),
+		logger:       gethlog.New(),
+	}
+	connFrom := func(ip string) net.Conn {
+		return &remoteConn{remoteAddr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 10000}}
+	}
+
+	// the connections from a single IP are limited
+	conns := make([]net.Conn, 0)
+	for i := 0; i < _maxInboundConnectionsPerIP; i++ {
+		conn := connFrom("10.0.0.1")
+		require.True(t, p.trackInbound(conn))
+		conns = append(conns, conn)
+	}
+	require.False(t, p.trackInbound(connFrom("10.0.0.1")))
+	p.untrackInbound(conns[0])
+	require.True(t, p.trackInbound(connFrom("10.0.0.1")))
+
+	// the loopback connections are limited per IP as well, unless they are exempt for a local network
+	for i := 0; i < _maxInboundConnectionsPerIP; i++ {
+		require.True(t, p.trackInbound(connFrom("127.0.0.1")))
+	}
+	require.False(t, p.trackInbound(connFrom("127.0.0.1")))
+	p.exemptLoopback = true
+	require.True(t, p.trackInbound(connFrom("127.0.0.1")))
+
+	// the total is limited as well, including the exempt loopback connections
+	for i := len(p.inbound); i < _maxInboundConnections; i++ {
+		require.True(t, p.trackInbound(connFrom("127.0.0.1")))
+	}
+	require.False(t, p.trackInbound(connFrom("10.0.0.2")))
+	require.False(t, p.trackInbound(connFrom("127.0.0.1")))
+}
+
+func TestPartialFrameTimesOut(t *testing.T) {
+	defer func(timeout time.Duration) { _frameReadTimeout = timeout }(_frameReadTimeout)
+	_frameReadTimeout = 100 * time.Millisecond
+
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	// the peer only sends the header and part of the payload
+	frame, err := encodeFrame(make([]byte, 1024), false)
+	require.NoError(t, err)
+	go func() { _, _ = client.Write(frame[:_frameHeaderSize+10]) }()
+
+	start := time.Now()
+	_, _, err = readInboundFrame(server, semaphore.NewWeighted(_maxInboundFrameMemory))
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	require.True(t, netErr.Timeout())
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestInboundFramesShareTheMemory(t *testing.T) {
+	defer func(timeout time.Duration) { _frameReadTimeout = timeout }(_frameReadTimeout)
+	_frameReadTimeout = 100 * time.Millisecond
+	memory := semaphore.NewWeighted(1500)
+
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	frame, err := encodeFrame(make([]byte, 1024), false)
+	require.NoError(t, err)
+	go func() {
+		for i := 0; i < 2; i++ {
+			if _, err := client.Write(frame); err != nil {
+				return
+			}
+		}
+	}()
+
+	payload, release, err := readInboundFrame(server, memory)
+	require.NoError(t, err)
+	require.Len(t, payload, 1024)
+
+	// the next frame can't be received until the memory of the first one is released
+	_, _, err = readInboundFrame(server, memory)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	release()
+	require.True(t, memory.TryAcquire(1500))
+	memory.Release(1500)
+
+	// a compressed frame reserves the memory of its decompressed payload as well
+	require.Equal(t, int64(1024)+int64(_maxFrameSize), frameMemory(1024, _flagCompressed))
+}
@@ -0,0 +1,126 @@
+package p2p
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Messages are sent over long-lived connections as a sequence of frames. Each frame is made of a 4 bytes big-endian
+// payload length, a flags byte and the payload, which is the RLP-encoded `message`, optionally compressed.
+const (
+	_frameHeaderSize      = 5
+	_flagCompressed  byte = 1 << 0
+)
+
+var (
+	_maxFrameSize          = uint32(16 * 1024 * 1024) // frames (and decompressed payloads) above this size are rejected, well above a response of full batches
+	_compressionThreshold  = 4 * 1024                 // smaller payloads are not worth compressing
+	_maxInboundFrameMemory = int64(256 * 1024 * 1024) // the frames received from all the peers at once can't hold more memory
+
+	errFrameTooLarge = errors.New("p2p frame exceeds the maximum size")
+)
+
+// encodeFrame - returns the frame containing the payload. The payload is compressed if requested and if it's large
+// enough. The frame is encoded once, so the same bytes can be written to all the peers of a broadcast.
+func encodeFrame(payload []byte, compress bool) ([]byte, error) {
+	var flags byte
+	if compress && len(payload) >= _compressionThreshold {
+		compressed, err := compressPayload(payload)
+		if err != nil {
+			return nil, fmt.Errorf("could not compress message. Cause: %w", err)
+		}
+		// only use the compressed payload if it's actually smaller
+		if len(compressed) < len(payload) {
+			payload = compressed
+			flags |= _flagCompressed
+		}
+	}
+	if uint64(len(payload)) > uint64(_maxFrameSize) {
+		return nil, errFrameTooLarge
+	}
+
+	frame := make([]byte, _frameHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(frame, uint32(len(payload)))
+	frame[4] = flags
+	copy(frame[_frameHeaderSize:], payload)
+	return frame, nil
+}
+
+// readFrame - reads the next frame from the reader and returns its decompressed payload.
+// Any error leaves the stream in an unknown position, so the connection must be closed.
+func readFrame(r io.Reader) ([]byte, error) {
+	size, flags, err := readFrameHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	return readFramePayload(r, size, flags)
+}
+
+// readFrameHeader - reads the header of the next frame and returns the size of its payload and its flags
+func readFrameHeader(r io.Reader) (uint32, byte, error) {
+	var header [_frameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, 0, err
+	}
+	size := binary.BigEndian.Uint32(header[:4])
+	if size > _maxFrameSize {
+		return 0, 0, errFrameTooLarge
+	}
+	flags := header[4]
+	if flags&^_flagCompressed != 0 {
+		return 0, 0, fmt.Errorf("unknown p2p frame flags %#x", flags)
+	}
+	return size, flags, nil
+}
+
+// frameMemory - the memory held while a frame is received, including its decompressed payload
+func frameMemory(size uint32, flags byte) int64 {
+	if flags&_flagCompressed != 0 {
+		return int64(size) + int64(_maxFrameSize)
+	}
+	return int64(size)
+}
+
+// readFramePayload - reads the payload of the frame whose header was read, and decompresses it
+func readFramePayload(r io.Reader, size uint32, flags byte) ([]byte, error) {
+	// the buffer grows as the payload arrives, so a peer can't reserve the maximum size with a header alone
+	payload, err := io.ReadAll(io.LimitReader(r, int64(size)))
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) != int(size) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if flags&_flagCompressed == 0 {
+		return payload, nil
+	}
+	return decompressPayload(payload)
+}
+
+func compressPayload(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := brotli.NewWriterLevel(&buf, brotli.DefaultCompression)
+	_, err := writer.Write(payload)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	return buf.Bytes(), err
+}
+
+// decompressPayload - the decompressed size is capped as well, so a small frame can't expand into a huge message
+func decompressPayload(payload []byte) ([]byte, error) {
+	r := io.LimitReader(brotli.NewReader(bytes.NewReader(payload)), int64(_maxFrameSize)+1)
+	decompressed, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress message. Cause: %w", err)
+	}
+	if uint64(len(decompressed)) > uint64(_maxFrameSize) {
+		return nil, errFrameTooLarge
+	}
+	return decompressed, nil
+}
@@ -0,0 +1,50 @@
+package p2p
+
+import (
+	"fmt"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+// p2pMetrics - the traffic of the P2P service. The registry may be nil, in which case the default registry is used.
+type p2pMetrics struct {
+	registry gethmetrics.Registry
+
+	sentMessages     gethmetrics.Meter
+	sentBytes        gethmetrics.Meter
+	receivedMessages gethmetrics.Meter
+	receivedBytes    gethmetrics.Meter
+	dropped          gethmetrics.Meter // messages not sent because the peer had too many pending messages
+	rejected         gethmetrics.Meter // inbound frames or messages that were invalid or not authorised
+	dials            gethmetrics.Meter
+	connections      gethmetrics.Gauge // open outbound connections
+	inbound          gethmetrics.Gauge // open inbound connections
+}
+
+func newP2PMetrics(registry gethmetrics.Registry) *p2pMetrics {
+	return &p2pMetrics{
+		registry:         registry,
+		sentMessages:     gethmetrics.GetOrRegisterMeter("p2p/messages/sent", registry),
+		sentBytes:        gethmetrics.GetOrRegisterMeter("p2p/bytes/sent", registry),
+		receivedMessages: gethmetrics.GetOrRegisterMeter("p2p/messages/received", registry),
+		receivedBytes:    gethmetrics.GetOrRegisterMeter("p2p/bytes/received", registry),
+		dropped:          gethmetrics.GetOrRegisterMeter("p2p/messages/dropped", registry),
+		rejected:         gethmetrics.GetOrRegisterMeter("p2p/messages/rejected", registry),
+		dials:            gethmetrics.GetOrRegisterMeter("p2p/connections/dials", registry),
+		connections:      gethmetrics.GetOrRegisterGauge("p2p/connections/outbound", registry),
+		inbound:          gethmetrics.GetOrRegisterGauge("p2p/connections/inbound", registry),
+	}
+}
+
+func (m *p2pMetrics) unregisterPeer(address string) {
+	r := m.registry
+	if r == nil {
+		r = gethmetrics.DefaultRegistry
+	}
+	r.Unregister(peerMetricName(address, "pending"))
+	r.Unregister(peerMetricName(address, "dropped"))
+}
+
+func peerMetricName(address string, name string) string {
+	return fmt.Sprintf("p2p/peers/%s/%s", address, name)
+}
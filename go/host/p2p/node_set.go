@@ -0,0 +1,203 @@
+package p2p
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common/host"
+)
+
+// knownHost - a host that registered at least one enclave in the management contract
+type knownHost struct {
+	enclaveIDs map[gethcommon.Address]struct{}
+	p2pAddress string
+}
+
+// nodeSet - the hosts that are allowed to send P2P messages, as recorded by the management contract.
+// A host is identified by the address of its L1 wallet, which signs its P2P messages. A host is known once one of the
+// enclaves it registered was attested, and it is a sequencer host when that enclave is permissioned as a sequencer.
+//
+// The attestation of an enclave binds the host it is tied to, so the registrations published by other hosts (e.g. with
+// a copy of the attestation) are dropped by the L1 data service.
+//
+// The applied events are kept, so the events of the L1 blocks which are no longer canonical after a reorg can be rolled
+// back by replaying the others. There are only a few of them, as they are published when the nodes join the network.
+type nodeSet struct {
+	hosts             map[gethcommon.Address]*knownHost
+	enclaveHosts      map[gethcommon.Address]gethcommon.Address // enclave ID => host ID
+	attested          map[gethcommon.Address]struct{}
+	sequencerEnclaves map[gethcommon.Address]struct{}
+	events            []*host.NodeSetEvent
+	syncedTo          *types.Header // the last L1 block that was applied, nil if no events were applied yet
+
+	mutex sync.RWMutex
+}
+
+func newNodeSet() *nodeSet {
+	ns := &nodeSet{}
+	ns.reset()
+	return ns
+}
+
+// the caller must hold the lock
+func (ns *nodeSet) reset() {
+	ns.hosts = map[gethcommon.Address]*knownHost{}
+	ns.enclaveHosts = map[gethcommon.Address]gethcommon.Address{}
+	ns.attested = map[gethcommon.Address]struct{}{}
+	ns.sequencerEnclaves = map[gethcommon.Address]struct{}{}
+	ns.events = nil
+}
+
+// nextHeight returns the first L1 height that was not applied yet, or nil if no events were applied yet
+func (ns *nodeSet) nextHeight() *big.Int {
+	ns.mutex.RLock()
+	defer ns.mutex.RUnlock()
+	if ns.syncedTo == nil {
+		return nil
+	}
+	return new(big.Int).Add(ns.syncedTo.Number, big.NewInt(1))
+}
+
+// syncedBlock returns the last L1 block that was applied, or nil if no events were applied yet
+func (ns *nodeSet) syncedBlock() *types.Header {
+	ns.mutex.RLock()
+	defer ns.mutex.RUnlock()
+	return ns.syncedTo
+}
+
+// eventBlocks returns the number and hash of the L1 blocks containing the applied events above the given height, the
+// most recent first
+func (ns *nodeSet) eventBlocks(aboveHeight uint64) ([]uint64, []gethcommon.Hash) {
+	ns.mutex.RLock()
+	defer ns.mutex.RUnlock()
+	var numbers []uint64
+	var hashes []gethcommon.Hash
+	for i := len(ns.events) - 1; i >= 0; i-- {
+		e := ns.events[i]
+		if e.BlockNumber <= aboveHeight {
+			break
+		}
+		if len(hashes) == 0 || hashes[len(hashes)-1] != e.BlockHash {
+			numbers = append(numbers, e.BlockNumber)
+			hashes = append(hashes, e.BlockHash)
+		}
+	}
+	return numbers, hashes
+}
+
+// apply updates the node set with the events found up to the given L1 block
+func (ns *nodeSet) apply(events []*host.NodeSetEvent, to *types.Header) {
+	ns.mutex.Lock()
+	defer ns.mutex.Unlock()
+	for _, e := range events {
+		ns.applyEvent(e)
+	}
+	ns.syncedTo = to
+}
+
+// rollback removes the events found after the given L1 block, which is the last block of the node set
+func (ns *nodeSet) rollback(to *types.Header) {
+	ns.mutex.Lock()
+	defer ns.mutex.Unlock()
+	events := ns.events
+	ns.reset()
+	for _, e := range events {
+		if e.BlockNumber <= to.Number.Uint64() {
+			ns.applyEvent(e)
+		}
+	}
+	ns.syncedTo = to
+}
+
+// the caller must hold the lock
+func (ns *nodeSet) applyEvent(e *host.NodeSetEvent) {
+	ns.events = append(ns.events, e)
+	switch e.Type {
+	case host.HostRegistered:
+		if _, found := ns.enclaveHosts[e.EnclaveID]; found {
+			return
+		}
+		ns.enclaveHosts[e.EnclaveID] = e.HostID
+		h, found := ns.hosts[e.HostID]
+		if !found {
+			h = &knownHost{enclaveIDs: map[gethcommon.Address]struct{}{}}
+			ns.hosts[e.HostID] = h
+		}
+		h.enclaveIDs[e.EnclaveID] = struct{}{}
+		h.p2pAddress = e.P2PAddress
+	case host.EnclaveAttested:
+		ns.attested[e.EnclaveID] = struct{}{}
+	case host.SequencerGranted:
+		ns.sequencerEnclaves[e.EnclaveID] = struct{}{}
+	case host.SequencerRevoked:
+		delete(ns.sequencerEnclaves, e.EnclaveID)
+	}
+}
+
+// isKnownHost returns true if the host registered an enclave that was attested
+func (ns *nodeSet) isKnownHost(hostID gethcommon.Address) bool {
+	ns.mutex.RLock()
+	defer ns.mutex.RUnlock()
+	return ns.hostHasEnclave(hostID, false)
+}
+
+// isSequencerHost returns true if the host registered an attested enclave which is permissioned as a sequencer
+func (ns *nodeSet) isSequencerHost(hostID gethcommon.Address) bool {
+	ns.mutex.RLock()
+	defer ns.mutex.RUnlock()
+	return ns.hostHasEnclave(hostID, true)
+}
+
+// isSequencerEnclave returns true if the enclave is attested and permissioned as a sequencer
+func (ns *nodeSet) isSequencerEnclave(enclaveID gethcommon.Address) bool {
+	ns.mutex.RLock()
+	defer ns.mutex.RUnlock()
+	_, attested := ns.attested[enclaveID]
+	_, isSequencer := ns.sequencerEnclaves[enclaveID]
+	return attested && isSequencer
+}
+
+// p2pAddress returns the P2P address published in the attestation of the host
+func (ns *nodeSet) p2pAddress(hostID gethcommon.Address) string {
+	ns.mutex.RLock()
+	defer ns.mutex.RUnlock()
+	if h, found := ns.hosts[hostID]; found {
+		return h.p2pAddress
+	}
+	return ""
+}
+
+// validatorAddresses returns the P2P addresses of the known hosts which are not sequencer hosts, sorted so the peers
+// take turns in a stable order
+func (ns *nodeSet) validatorAddresses() []string {
+	ns.mutex.RLock()
+	defer ns.mutex.RUnlock()
+	addresses := make([]string, 0, len(ns.hosts))
+	for hostID, h := range ns.hosts {
+		if h.p2pAddress == "" || !ns.hostHasEnclave(hostID, false) || ns.hostHasEnclave(hostID, true) {
+			continue
+		}
+		addresses = append(addresses, h.p2pAddress)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// the caller must hold the lock
+func (ns *nodeSet) hostHasEnclave(hostID gethcommon.Address, sequencer bool) bool {
+	h, found := ns.hosts[hostID]
+	if !found {
+		return false
+	}
+	for enclaveID := range h.enclaveIDs {
+		_, attested := ns.attested[enclaveID]
+		_, isSequencer := ns.sequencerEnclaves[enclaveID]
+		if attested && (isSequencer || !sequencer) {
+			return true
+		}
+	}
+	return false
+}
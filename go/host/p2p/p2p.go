@@ -7,8 +7,10 @@ import (
 	"math/big"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/ten-protocol/go-ten/go/common/measure"
 	"github.com/ten-protocol/go-ten/go/common/retry"
@@ -23,6 +25,8 @@ import (
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/host"
 	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/wallet"
+	"golang.org/x/sync/semaphore"
 )
 
 const (
@@ -38,9 +42,19 @@ const (
 )
 
 var (
-	_alertPeriod             = 5 * time.Minute
-	_maxPeerFailures         = 3               // peer removed from broadcast pool after this many failures
-	_maxWaitWithoutBroadcast = 2 * time.Minute // validators will re-register for broadcasts after this period of silence
+	_alertPeriod                = 5 * time.Minute
+	_maxPeerFailures            = 3                // peer removed from broadcast pool after this many failures
+	_maxWaitWithoutBroadcast    = 2 * time.Minute  // validators will re-register for broadcasts after this period of silence
+	_registrationRetryInterval  = 10 * time.Second // validators retry the registration at this interval until the first broadcast
+	_nodeSetSyncRange           = int64(10_000)    // the maximum number of L1 blocks searched for node set events in one request
+	_nodeSetReorgDepth          = uint64(64)       // the L1 blocks older than this are final, so the node set is never rolled back further
+	_maxMessageAge              = time.Minute      // the messages signed longer ago (or later, to allow for clock drift) are rejected
+	_maxInboundConnections      = 128              // further inbound connections are closed straight away
+	_maxInboundConnectionsPerIP = 8                // the peers keep a single connection open, so a few are enough for restarts
+	_inboundIdleTimeout         = 10 * time.Minute // the inbound connections that don't receive a frame for this long are closed
+	_frameReadTimeout           = time.Minute      // the time allowed to receive a frame once its header arrived
+
+	errNoValidatorPeers = errors.New("no validator peers to request batches from")
 )
 
 // A P2P message's type.
@@ -48,18 +62,22 @@ type msgType uint8
 
 // Associates an encoded message to its type.
 type message struct {
-	Sender   string // todo (#1619) - this needs to be authed in the future
-	Type     msgType
-	Contents []byte
+	Sender    string // the P2P address of the sending host
+	Type      msgType
+	Contents  []byte
+	Timestamp uint64 // the unix time at which the message was signed, so old messages can't be replayed
+	Signature []byte // signature over the other fields with the key of the L1 wallet of the sending host
 }
 
 type p2pServiceLocator interface {
+	L1Data() host.L1DataService
 	L1Publisher() host.L1Publisher
 	L2Repo() host.L2BatchRepository
 }
 
 // NewSocketP2PLayer - returns the Socket implementation of the P2P
-func NewSocketP2PLayer(config *hostconfig.HostConfig, serviceLocator p2pServiceLocator, logger gethlog.Logger, metricReg gethmetrics.Registry) *Service {
+func NewSocketP2PLayer(config *hostconfig.HostConfig, serviceLocator p2pServiceLocator, hostWallet wallet.Wallet, logger gethlog.Logger, metricReg gethmetrics.Registry) *Service {
+	metrics := newP2PMetrics(metricReg)
 	return &Service{
 		batchSubscribers: subscription.NewManager[host.P2PBatchHandler](),
 		txSubscribers:    subscription.NewManager[host.P2PTxHandler](),
@@ -67,6 +85,9 @@ func NewSocketP2PLayer(config *hostconfig.HostConfig, serviceLocator p2pServiceL
 
 		stopControl: stopcontrol.New(),
 		sl:          serviceLocator,
+		hostWallet:  hostWallet,
+		nodes:       newNodeSet(),
+		l1StartHash: config.L1StartHash,
 
 		isSequencer:      config.NodeType == common.ActiveSequencer,
 		ourBindAddress:   config.P2PBindAddress,
@@ -74,13 +95,19 @@ func NewSocketP2PLayer(config *hostconfig.HostConfig, serviceLocator p2pServiceL
 		sequencerAddress: config.SequencerP2PAddress,
 		peerAddresses:    make(map[string]int),
 		p2pTimeout:       config.P2PConnectionTimeout,
+		compress:         config.P2PCompressionEnabled,
+		pool:             newConnPool(config.P2PConnectionTimeout, metrics, logger),
+		inbound:          map[net.Conn]struct{}{},
+		inboundPerIP:     map[string]int{},
+		inboundMemory:    semaphore.NewWeighted(_maxInboundFrameMemory),
+		exemptLoopback:   config.P2PExemptLoopback,
 
 		peerAddressesMutex: sync.RWMutex{},
 
 		// monitoring
-		peerTracker:     newPeerTracker(),
-		metricsRegistry: metricReg,
-		logger:          logger,
+		peerTracker: newPeerTracker(),
+		metrics:     metrics,
+		logger:      logger,
 
 		isIncomingP2PDisabled: config.IsInboundP2PDisabled,
 	}
@@ -96,14 +123,28 @@ type Service struct {
 
 	sl p2pServiceLocator
 
+	hostWallet        wallet.Wallet   // signs the outgoing messages
+	nodes             *nodeSet        // the hosts that are allowed to send messages
+	nodeSetSyncMutex  sync.Mutex      // held while the node set is synced, the new blocks are skipped meanwhile
+	l1StartHash       gethcommon.Hash // the management contract deployment block, where the node set sync starts
+	unsubscribeBlocks func()
+
 	isSequencer      bool
 	ourBindAddress   string
 	ourPublicAddress string
 	peerAddresses    map[string]int // map of peer addresses to the number of times they have failed to send a message
+	nextBatchPeer    atomic.Uint64  // the validator peers take turns serving the batch requests
 	p2pTimeout       time.Duration
+	compress         bool                  // whether the large outgoing messages are compressed
+	pool             *connPool             // the outbound connections
+	inbound          map[net.Conn]struct{} // the inbound connections, closed when the service stops
+	inboundPerIP     map[string]int        // the number of inbound connections from each IP
+	inboundMutex     sync.Mutex
+	inboundMemory    *semaphore.Weighted // the memory held by the frames being received and handled
+	exemptLoopback   bool                // whether the loopback connections are only subject to the total limit
 
 	peerTracker           *peerTracker
-	metricsRegistry       gethmetrics.Registry
+	metrics               *p2pMetrics
 	logger                gethlog.Logger
 	peerAddressesMutex    sync.RWMutex
 	isIncomingP2PDisabled bool
@@ -122,6 +163,18 @@ func (p *Service) Start() error {
 
 	p.listener = listener
 
+	// the node set is kept in sync with the management contract, so the senders of the messages can be authenticated.
+	// The initial sync can take a while, so the messages of the hosts not found yet are rejected until it completes.
+	p.nodeSetSyncMutex.Lock()
+	go func() {
+		defer p.nodeSetSyncMutex.Unlock()
+		if err := p.syncNodeSet(nil); err != nil {
+			// just log the error, the sync is retried with every new L1 block
+			p.logger.Error("Failed to sync the node set", log.ErrKey, err)
+		}
+	}()
+	p.unsubscribeBlocks = p.sl.L1Data().Subscribe(p)
+
 	go p.handleConnections()
 
 	if !p.isSequencer {
@@ -140,11 +193,22 @@ func (p *Service) Start() error {
 func (p *Service) Stop() error {
 	p.logger.Info("Shutting down P2P.")
 	p.stopControl.Stop()
+	if p.unsubscribeBlocks != nil {
+		p.unsubscribeBlocks()
+	}
 	if p.listener != nil {
 		// todo immediately shutting down the listener seems to impact other hosts shutdown process
 		time.Sleep(time.Second)
-		return p.listener.Close()
+		if err := p.listener.Close(); err != nil {
+			return err
+		}
 	}
+	p.pool.close()
+	p.inboundMutex.Lock()
+	for conn := range p.inbound {
+		_ = conn.Close()
+	}
+	p.inboundMutex.Unlock()
 	return nil
 }
 
@@ -205,18 +269,37 @@ func (p *Service) BroadcastBatches(batches []*common.ExtBatch) error {
 	return p.broadcast(msg)
 }
 
-func (p *Service) RequestBatchesFromSequencer(fromSeqNo *big.Int) error {
+func (p *Service) RequestBatchesFromSequencer(fromSeqNo *big.Int, toSeqNo *big.Int) error {
+	return p.requestBatches(fromSeqNo, toSeqNo, p.getSequencer())
+}
+
+func (p *Service) RequestBatchesFromPeer(fromSeqNo *big.Int, toSeqNo *big.Int) error {
+	var peers []string
+	for _, address := range p.nodes.validatorAddresses() {
+		if address != p.ourPublicAddress {
+			peers = append(peers, address)
+		}
+	}
+	if len(peers) == 0 {
+		return errNoValidatorPeers
+	}
+	peer := peers[(p.nextBatchPeer.Add(1)-1)%uint64(len(peers))]
+	return p.requestBatches(fromSeqNo, toSeqNo, peer)
+}
+
+func (p *Service) requestBatches(fromSeqNo *big.Int, toSeqNo *big.Int, peer string) error {
 	if p.isIncomingP2PDisabled {
 		return nil
 	}
 	if p.isSequencer {
-		return errors.New("sequencer cannot request batches from itself")
+		return errors.New("sequencer cannot request batches from peers")
 	}
 	batchRequest := &common.BatchRequest{
 		Requester: p.ourPublicAddress,
 		FromSeqNo: fromSeqNo,
+		ToSeqNo:   toSeqNo,
 	}
-	defer core.LogMethodDuration(p.logger, measure.NewStopwatch(), "Requested batches from sequencer", "fromSeqNo", batchRequest.FromSeqNo)
+	defer core.LogMethodDuration(p.logger, measure.NewStopwatch(), "Requested batches from peer", "peer", peer, "fromSeqNo", fromSeqNo, "toSeqNo", toSeqNo)
 
 	encodedBatchRequest, err := rlp.EncodeToBytes(batchRequest)
 	if err != nil {
@@ -224,17 +307,14 @@ func (p *Service) RequestBatchesFromSequencer(fromSeqNo *big.Int) error {
 	}
 
 	msg := message{Sender: p.ourPublicAddress, Type: msgTypeBatchRequest, Contents: encodedBatchRequest}
-	// todo (#718) - allow missing batches to be requested from peers other than sequencer?
-	return p.send(msg, p.getSequencer())
+	return p.send(msg, peer)
 }
 
+// RespondToBatchRequest - both the sequencer and the validators serve the batches stored by their host
 func (p *Service) RespondToBatchRequest(requestID string, batches []*common.ExtBatch) error {
 	if p.isIncomingP2PDisabled {
 		return nil
 	}
-	if !p.isSequencer {
-		return errors.New("only sequencer can respond to batch requests")
-	}
 	batchMsg := &host.BatchMsg{
 		Batches: batches,
 		IsLive:  false,
@@ -305,30 +385,101 @@ func (p *Service) handleConnections() {
 	}
 }
 
-// Receives and decodes a P2P message, and pushes it to the correct channel.
+// Receives the framed P2P messages sent over the connection until it's closed by the peer. The connection is closed
+// as soon as it carries an invalid or unauthorised message.
 func (p *Service) handle(conn net.Conn) {
-	if conn != nil {
-		defer conn.Close()
+	if conn == nil {
+		return
 	}
+	defer conn.Close()
+	if !p.trackInbound(conn) {
+		return
+	}
+	defer p.untrackInbound(conn)
 
-	encodedMsg, err := io.ReadAll(conn)
+	for !p.stopControl.IsStopping() {
+		encodedMsg, release, err := readInboundFrame(conn, p.inboundMemory)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !p.stopControl.IsStopping() {
+				p.metrics.rejected.Mark(1)
+				p.logger.Debug("Failed to read message from peer", "remoteAddr", conn.RemoteAddr(), log.ErrKey, err)
+			}
+			return
+		}
+		p.metrics.receivedMessages.Mark(1)
+		p.metrics.receivedBytes.Mark(int64(_frameHeaderSize + len(encodedMsg)))
+
+		err = p.handleMessage(encodedMsg)
+		release()
+		if err != nil {
+			p.metrics.rejected.Mark(1)
+			p.logger.Debug("Closing connection after invalid message", "remoteAddr", conn.RemoteAddr(), log.ErrKey, err)
+			return
+		}
+	}
+}
+
+// readInboundFrame - reads the next frame from an inbound connection. An idle connection is closed after
+// _inboundIdleTimeout, and a frame must be received in full within _frameReadTimeout of its header, so a peer can't
+// hold a connection and its buffer with a partial frame.
+// The memory of the frame is reserved from the memory shared by all the inbound connections, waiting for the other
+// frames if needed, and must be released once the frame is handled.
+func readInboundFrame(conn net.Conn, memory *semaphore.Weighted) ([]byte, func(), error) {
+	if err := conn.SetReadDeadline(time.Now().Add(_inboundIdleTimeout)); err != nil {
+		return nil, nil, err
+	}
+	size, flags, err := readFrameHeader(conn)
 	if err != nil {
-		p.logger.Debug("Failed to read message from peer", log.ErrKey, err)
-		return
+		return nil, nil, err
+	}
+
+	reserved := frameMemory(size, flags)
+	ctx, cancel := context.WithTimeout(context.Background(), _frameReadTimeout)
+	defer cancel()
+	if err := memory.Acquire(ctx, reserved); err != nil {
+		return nil, nil, fmt.Errorf("timed out waiting for the memory to receive a frame of %d bytes. Cause: %w", size, err)
 	}
+	release := func() { memory.Release(reserved) }
 
+	if err := conn.SetReadDeadline(time.Now().Add(_frameReadTimeout)); err != nil {
+		release()
+		return nil, nil, err
+	}
+	payload, err := readFramePayload(conn, size, flags)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	return payload, release, nil
+}
+
+// Decodes a P2P message, and pushes it to the correct channel.
+// Returns an error if the message is invalid or was not sent by an authorised host.
+func (p *Service) handleMessage(encodedMsg []byte) error {
 	msg := message{}
-	err = rlp.DecodeBytes(encodedMsg, &msg)
+	err := rlp.DecodeBytes(encodedMsg, &msg)
 	if err != nil {
-		p.logger.Debug("Failed to decode message received from peer: ", log.ErrKey, err)
-		return
+		return fmt.Errorf("could not decode message. Cause: %w", err)
+	}
+
+	signer, err := recoverSigner(msg)
+	if err != nil {
+		return fmt.Errorf("could not authenticate message from %s. Cause: %w", msg.Sender, err)
+	}
+	if err := checkMessageAge(msg, time.Now()); err != nil {
+		return fmt.Errorf("rejected message from %s. Cause: %w", msg.Sender, err)
+	}
+	// the live batches are only accepted from the sequencer (checked below), everything else from any node in the network
+	if !p.nodes.isKnownHost(signer) {
+		p.logger.Warn("Rejected message from unknown host", "peer", msg.Sender, "hostID", signer, "type", msg.Type)
+		return fmt.Errorf("host %s is not authorised to send messages of type %d", signer, msg.Type)
 	}
 
 	switch msg.Type {
 	case msgTypeTx:
 		if !p.isSequencer {
 			p.logger.Error("Received transaction from peer, but not a sequencer node")
-			return
+			return nil
 		}
 		// The transaction is encrypted, so we cannot check that it's correctly formed.
 		for _, txSubs := range p.txSubscribers.Subscribers() {
@@ -337,7 +488,7 @@ func (p *Service) handle(conn net.Conn) {
 	case msgTypeBatches:
 		if p.isSequencer {
 			p.logger.Error("received batch from peer, but this is a sequencer node")
-			return
+			return nil
 		}
 		var batchMsg *host.BatchMsg
 		err := rlp.DecodeBytes(msg.Contents, &batchMsg)
@@ -346,22 +497,38 @@ func (p *Service) handle(conn net.Conn) {
 			// nothing to send to subscribers
 			break
 		}
-		// todo - check the batch signature
-		p.lastReceivedBroadcast = time.Now()
+		if batchMsg.IsLive && !p.nodes.isSequencerHost(signer) {
+			p.logger.Warn("Rejected live batches received from a peer which is not the sequencer", "peer", msg.Sender)
+			return fmt.Errorf("host %s is not authorised to broadcast batches", signer)
+		}
+		// the batches requested from the validators are only used once their sequencer signatures are verified
+		if err := p.verifyBatchSignatures(batchMsg.Batches); err != nil {
+			p.logger.Warn("Rejected batches received from peer", "peer", msg.Sender, log.ErrKey, err)
+			return err
+		}
+		if batchMsg.IsLive {
+			p.lastReceivedBroadcast = time.Now()
+		}
 		for _, batchSubs := range p.batchSubscribers.Subscribers() {
 			go batchSubs.HandleBatches(batchMsg.Batches, batchMsg.IsLive)
 		}
 	case msgTypeBatchRequest:
-		if !p.isSequencer {
-			p.logger.Error("received batch request from peer, but not a sequencer node")
-			return
+		// the response is sent to the P2P address of the requester, which must be the one published in its attestation
+		if registered := p.nodes.p2pAddress(signer); registered != msg.Sender {
+			p.logger.Warn("Rejected batch request", "peer", msg.Sender, "registeredAddress", registered)
+			return nil
 		}
 		// this is an incoming request, p2p service is responsible for finding the response and returning it
-		go p.handleBatchRequest(msg.Contents)
+		go p.handleBatchRequest(msg.Sender, msg.Contents)
 	case msgTypeRegisterForBroadcasts:
 		if !p.isSequencer {
 			p.logger.Error("received register for broadcasts from peer, but not a sequencer node")
-			return
+			return nil
+		}
+		// hosts can only register the address published in the attestation of their enclave
+		if registered := p.nodes.p2pAddress(signer); registered != msg.Sender {
+			p.logger.Warn("Rejected registration for broadcasts", "peer", msg.Sender, "registeredAddress", registered)
+			return nil
 		}
 		// add the peer to the list of peers
 		p.peerAddressesMutex.Lock()
@@ -369,18 +536,20 @@ func (p *Service) handle(conn net.Conn) {
 		p.peerAddressesMutex.Unlock()
 	}
 	p.peerTracker.receivedPeerMsg(msg.Sender)
+	return nil
 }
 
 // Broadcasts a message to all peers.
 func (p *Service) broadcast(msg message) error {
-	msgEncoded, err := rlp.EncodeToBytes(msg)
+	// the frame is encoded once and written to all the peers
+	msgEncoded, err := p.encodeFrame(msg)
 	if err != nil {
 		return fmt.Errorf("could not encode message to send to peers. Cause: %w", err)
 	}
 
 	// clone currently known addresses
 	p.peerAddressesMutex.RLock()
-	currentAddresses := make([]string, len(p.peerAddresses))
+	currentAddresses := make([]string, 0, len(p.peerAddresses))
 	for address := range p.peerAddresses {
 		currentAddresses = append(currentAddresses, address)
 	}
@@ -401,6 +570,7 @@ func (p *Service) broadcast(msg message) error {
 					// if address has failed too many times, remove it
 					if p.peerAddresses[closureAddr] > _maxPeerFailures {
 						delete(p.peerAddresses, closureAddr)
+						p.pool.remove(closureAddr)
 					}
 				}
 				p.peerAddressesMutex.Unlock()
@@ -429,7 +599,7 @@ func (p *Service) send(msg message, to string) error {
 		p.logger.Error(fmt.Sprintf("Sending message with empty contents: %v", msg))
 	}
 
-	msgEncoded, err := rlp.EncodeToBytes(msg)
+	msgEncoded, err := p.encodeFrame(msg)
 	if err != nil {
 		return fmt.Errorf("could not encode message to send to sequencer. Cause: %w", err)
 	}
@@ -440,50 +610,105 @@ func (p *Service) send(msg message, to string) error {
 	return nil
 }
 
-// Sends the bytes to the provided address.
+// Sends the frame to the provided address.
 // Until introducing libp2p (or equivalent), we have a simple retry
-func (p *Service) sendBytesWithRetry(address string, msgEncoded []byte) error {
+func (p *Service) sendBytesWithRetry(address string, frame []byte) error {
 	// retry for about 2 seconds
 	err := retry.Do(func() error {
-		return p.sendBytes(address, msgEncoded)
+		return p.sendBytes(address, frame)
 	}, retry.NewDoublingBackoffStrategy(100*time.Millisecond, 5))
 	return err
 }
 
-// Sends the bytes to the provided address.
-func (p *Service) sendBytes(address string, tx []byte) error {
-	conn, err := net.DialTimeout(tcp, address, p.p2pTimeout)
-	if conn != nil {
-		defer conn.Close()
-	}
+// Sends the frame to the provided address, over the pooled connection to the peer.
+func (p *Service) sendBytes(address string, frame []byte) error {
+	err := p.pool.send(address, frame)
 	if err != nil {
-		p.logger.Debug(fmt.Sprintf("could not connect to peer on address %s", address), log.ErrKey, err)
+		p.logger.Debug(fmt.Sprintf("could not send message to peer on address %s", address), log.ErrKey, err)
+		// retrying can't help when the peer is not keeping up or the service is stopping
+		if errors.Is(err, errPeerBusy) || errors.Is(err, errPeerRemoved) || errors.Is(err, errPoolClosed) {
+			return retry.FailFast(err)
+		}
 		return err
 	}
+	return nil
+}
 
-	_, err = conn.Write(tx)
+// Signs the message, and returns the frame to send it.
+func (p *Service) encodeFrame(msg message) ([]byte, error) {
+	msgEncoded, err := p.signAndEncode(msg)
 	if err != nil {
-		p.logger.Debug(fmt.Sprintf("could not send message to peer on address %s", address), log.ErrKey, err)
-		return err
+		return nil, err
 	}
-	return nil
+	return encodeFrame(msgEncoded, p.compress)
+}
+
+// trackInbound returns false if the connection must be closed straight away, because the service is stopping or
+// because the total or per-IP limit of inbound connections is reached
+func (p *Service) trackInbound(conn net.Conn) bool {
+	p.inboundMutex.Lock()
+	defer p.inboundMutex.Unlock()
+	if p.stopControl.IsStopping() {
+		return false
+	}
+	// the local networks can run all the hosts on the loopback interface, in which case it is only subject to the total limit
+	ip := remoteIP(conn)
+	overIPLimit := p.inboundPerIP[ip] >= _maxInboundConnectionsPerIP && !(p.exemptLoopback && isLoopback(conn))
+	if len(p.inbound) >= _maxInboundConnections || overIPLimit {
+		p.metrics.rejected.Mark(1)
+		p.logger.Debug("Rejected inbound connection over the limit", "remoteAddr", conn.RemoteAddr(), "total", len(p.inbound), "fromIP", p.inboundPerIP[ip])
+		return false
+	}
+	p.inbound[conn] = struct{}{}
+	p.inboundPerIP[ip]++
+	p.metrics.inbound.Inc(1)
+	return true
+}
+
+func (p *Service) untrackInbound(conn net.Conn) {
+	p.inboundMutex.Lock()
+	defer p.inboundMutex.Unlock()
+	delete(p.inbound, conn)
+	ip := remoteIP(conn)
+	if p.inboundPerIP[ip]--; p.inboundPerIP[ip] <= 0 {
+		delete(p.inboundPerIP, ip)
+	}
+	p.metrics.inbound.Dec(1)
+}
+
+func isLoopback(conn net.Conn) bool {
+	addr, ok := conn.RemoteAddr().(*net.TCPAddr)
+	return ok && addr.IP.IsLoopback()
+}
+
+// remoteIP returns the IP of the peer, or its full address if it has no IP
+func remoteIP(conn net.Conn) string {
+	if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+		return addr.IP.String()
+	}
+	return conn.RemoteAddr().String()
 }
 
 func (p *Service) getSequencer() string {
 	return p.sequencerAddress
 }
 
-func (p *Service) handleBatchRequest(encodedBatchRequest common.EncodedBatchRequest) {
+func (p *Service) handleBatchRequest(sender string, encodedBatchRequest common.EncodedBatchRequest) {
 	var batchRequest *common.BatchRequest
 	err := rlp.DecodeBytes(encodedBatchRequest, &batchRequest)
 	if err != nil {
 		p.logger.Warn("unable to decode batch request received from peer using RLP", log.ErrKey, err)
 		return
 	}
+	// the response is sent to the requester, so it must be the authenticated sender
+	if batchRequest.Requester != sender {
+		p.logger.Warn("Rejected batch request on behalf of another peer", "peer", sender, "requester", batchRequest.Requester)
+		return
+	}
 
 	// todo (@matt) should this response be synchronous?
 	for _, requestHandler := range p.batchReqHandlers.Subscribers() {
-		go requestHandler.HandleBatchRequest(batchRequest.Requester, batchRequest.FromSeqNo)
+		go requestHandler.HandleBatchRequest(batchRequest.Requester, batchRequest.FromSeqNo, batchRequest.ToSeqNo)
 	}
 }
 
@@ -492,10 +717,15 @@ func (p *Service) handleBatchRequest(encodedBatchRequest common.EncodedBatchRequ
 func (p *Service) EnsureSubscribedToSequencer() {
 	// this continues to run until the host is stopped
 	for {
+		// until the first broadcast is received, the sequencer might not know about this host yet, so retry sooner
+		waitTime := _maxWaitWithoutBroadcast / 2
+		if p.lastReceivedBroadcast.IsZero() {
+			waitTime = _registrationRetryInterval
+		}
 		select {
 		case <-p.stopControl.Done():
 			return // host is stopping
-		case <-time.After(_maxWaitWithoutBroadcast / 2):
+		case <-time.After(waitTime):
 			if time.Since(p.lastReceivedBroadcast) > _maxWaitWithoutBroadcast {
 				p.logger.Info("No broadcast received from sequencer, re-registering.")
 				err := p.RegisterForBroadcasts()
@@ -0,0 +1,130 @@
+package p2p
+
+import (
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/wallet"
+)
+
+// listenForBatchRequests returns the address of a peer which passes on the batch requests it receives
+func listenForBatchRequests(t *testing.T) (string, chan *common.BatchRequest) {
+	listener, err := net.Listen(tcp, "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	requests := make(chan *common.BatchRequest, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				for {
+					payload, err := readFrame(conn)
+					if err != nil {
+						return
+					}
+					var msg message
+					var request common.BatchRequest
+					if rlp.DecodeBytes(payload, &msg) != nil || msg.Type != msgTypeBatchRequest || rlp.DecodeBytes(msg.Contents, &request) != nil {
+						return
+					}
+					requests <- &request
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String(), requests
+}
+
+func receiveBatchRequest(t *testing.T, requests chan *common.BatchRequest) *common.BatchRequest {
+	select {
+	case request := <-requests:
+		return request
+	case <-time.After(5 * time.Second):
+		t.Fatal("the batch request was not received")
+		return nil
+	}
+}
+
+func TestRequestBatchesFromPeers(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	pool := newConnPool(time.Second, newP2PMetrics(nil), gethlog.New())
+	defer pool.close()
+
+	sequencerAddress, sequencerRequests := listenForBatchRequests(t)
+	validator1Address, validator1Requests := listenForBatchRequests(t)
+	validator2Address, validator2Requests := listenForBatchRequests(t)
+	p := &Service{
+		hostWallet:       wallet.NewInMemoryWalletFromPK(big.NewInt(1), key, gethlog.New()),
+		nodes:            newNodeSet(),
+		pool:             pool,
+		ourPublicAddress: "127.0.0.1:1",
+		sequencerAddress: sequencerAddress,
+		metrics:          newP2PMetrics(nil),
+		logger:           gethlog.New(),
+	}
+
+	// without validator peers, the batches can only be requested from the sequencer
+	require.ErrorIs(t, p.RequestBatchesFromPeer(big.NewInt(1), big.NewInt(50)), errNoValidatorPeers)
+
+	// our own host is not one of the peers
+	var events []*host.NodeSetEvent
+	for i, address := range []string{validator1Address, validator2Address, p.ourPublicAddress} {
+		hostID, enclaveID := gethcommon.BigToAddress(big.NewInt(int64(i+1))), gethcommon.BigToAddress(big.NewInt(int64(i+11)))
+		events = append(events,
+			&host.NodeSetEvent{Type: host.HostRegistered, HostID: hostID, EnclaveID: enclaveID, P2PAddress: address},
+			&host.NodeSetEvent{Type: host.EnclaveAttested, EnclaveID: enclaveID},
+		)
+	}
+	p.nodes.apply(events, &types.Header{Number: big.NewInt(0)})
+	peers := p.nodes.validatorAddresses()
+	require.Len(t, peers, 3)
+
+	// the validators take turns serving the ranges requested one after the other
+	received := map[string][]int64{}
+	for from := int64(0); from < 200; from += 50 {
+		require.NoError(t, p.RequestBatchesFromPeer(big.NewInt(from), big.NewInt(from+49)))
+	}
+	for i := 0; i < 4; i++ {
+		select {
+		case request := <-validator1Requests:
+			require.Equal(t, request.FromSeqNo.Int64()+49, request.ToSeqNo.Int64())
+			received[validator1Address] = append(received[validator1Address], request.FromSeqNo.Int64())
+		case request := <-validator2Requests:
+			require.Equal(t, request.FromSeqNo.Int64()+49, request.ToSeqNo.Int64())
+			received[validator2Address] = append(received[validator2Address], request.FromSeqNo.Int64())
+		case <-time.After(5 * time.Second):
+			t.Fatal("the batch requests were not received")
+		}
+	}
+	require.Len(t, received[validator1Address], 2)
+	require.Len(t, received[validator2Address], 2)
+	require.Equal(t, received[validator1Address][0]+100, received[validator1Address][1])
+	require.Equal(t, received[validator2Address][0]+100, received[validator2Address][1])
+
+	// the range is passed on to the sequencer as well, and the open-ended requests stay open-ended
+	require.NoError(t, p.RequestBatchesFromSequencer(big.NewInt(60), big.NewInt(99)))
+	request := receiveBatchRequest(t, sequencerRequests)
+	require.Equal(t, p.ourPublicAddress, request.Requester)
+	require.Equal(t, int64(60), request.FromSeqNo.Int64())
+	require.Equal(t, int64(99), request.ToSeqNo.Int64())
+	require.NoError(t, p.RequestBatchesFromSequencer(big.NewInt(100), nil))
+	request = receiveBatchRequest(t, sequencerRequests)
+	require.Equal(t, int64(100), request.FromSeqNo.Int64())
+	require.Nil(t, request.ToSeqNo)
+}
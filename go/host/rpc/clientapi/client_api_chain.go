@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/host"
@@ -17,6 +18,12 @@ import (
 	gethlog "github.com/ethereum/go-ethereum/log"
 )
 
+const (
+	// the limits used by geth
+	maxFeeHistory            = 1024
+	maxFeeHistoryPercentiles = 100
+)
+
 // ChainAPI exposes public chain data
 type ChainAPI struct {
 	host   host.Host
@@ -102,32 +109,90 @@ func (api *ChainAPI) MaxPriorityFeePerGas(_ context.Context) (*hexutil.Big, erro
 	return (*hexutil.Big)(header.BaseFee), err
 }
 
-// FeeHistory is a placeholder for an RPC method required by MetaMask/Remix.
-// rpc.DecimalOrHex -> []byte
-func (api *ChainAPI) FeeHistory(context.Context, string, rpc.BlockNumber, []float64) (*FeeHistoryResult, error) {
-	// todo (#1621) - return a non-dummy fee history
-	header, err := api.host.Storage().FetchHeadBatchHeader()
+// FeeHistory returns the base fees, the gas used ratios and the priority fees of the batches ending with newestBlock.
+// The transactions of the batches are encrypted, so the percentiles of the tips paid in each batch are computed by the enclave.
+func (api *ChainAPI) FeeHistory(ctx context.Context, blockCount math.HexOrDecimal64, newestBlock rpc.BlockNumber, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	if blockCount < 1 {
+		return &FeeHistoryResult{OldestBlock: (*hexutil.Big)(big.NewInt(0))}, nil
+	}
+	if blockCount > maxFeeHistory {
+		blockCount = maxFeeHistory
+	}
+	if len(rewardPercentiles) > maxFeeHistoryPercentiles {
+		return nil, fmt.Errorf("too many reward percentiles. Maximum: %d", maxFeeHistoryPercentiles)
+	}
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid reward percentile: %f", p)
+		}
+		if i > 0 && p <= rewardPercentiles[i-1] {
+			return nil, fmt.Errorf("invalid reward percentile: #%d:%f >= #%d:%f", i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+
+	head, err := api.host.Storage().FetchHeadBatchHeader()
 	if err != nil {
 		api.logger.Error("Unable to retrieve header for fee history.", log.ErrKey, err)
 		return nil, fmt.Errorf("unable to retrieve fee history")
 	}
+	newest := head.Number.Uint64()
+	if newestBlock >= 0 && uint64(newestBlock) < newest {
+		newest = uint64(newestBlock)
+	}
+	oldest := uint64(0)
+	if newest+1 > uint64(blockCount) {
+		oldest = newest + 1 - uint64(blockCount)
+	}
+
+	batches, err := api.host.Storage().FetchBatchFees(oldest, newest)
+	if err != nil {
+		api.logger.Error("Unable to retrieve batches for fee history.", log.ErrKey, err)
+		return nil, fmt.Errorf("unable to retrieve fee history")
+	}
+	if len(batches) == 0 {
+		return nil, fmt.Errorf("no batches found between %d and %d", oldest, newest)
+	}
 
-	batches := make([]*common.BatchHeader, 0)
-	batches = append(batches, header)
+	var rewards map[uint64][]*big.Int
+	if len(rewardPercentiles) > 0 {
+		rewards, err = api.host.EnclaveClient().FeeHistoryRewards(ctx, batches[0].Height, batches[len(batches)-1].Height, rewardPercentiles)
+		if err != nil {
+			api.logger.Error("Unable to retrieve rewards for fee history.", log.ErrKey, err)
+			return nil, fmt.Errorf("unable to retrieve fee history")
+		}
+	}
 
 	feeHist := &FeeHistoryResult{
-		OldestBlock:  (*hexutil.Big)(header.Number),
-		Reward:       [][]*hexutil.Big{},
-		BaseFee:      []*hexutil.Big{},
-		GasUsedRatio: []float64{},
+		OldestBlock:  (*hexutil.Big)(new(big.Int).SetUint64(batches[0].Height)),
+		BaseFee:      make([]*hexutil.Big, 0, len(batches)+1),
+		GasUsedRatio: make([]float64, 0, len(batches)),
 	}
-
-	for _, header := range batches {
-		// 0.9 - This number represents how full the block is. As we dont have a dynamic base fee, we tell whomever is requesting that
-		// we expect the baseFee to increase, rather than decrease in order to avoid underpriced transactions.
-		feeHist.GasUsedRatio = append(feeHist.GasUsedRatio, 0.9)
-		feeHist.BaseFee = append(feeHist.BaseFee, (*hexutil.Big)(header.BaseFee))
+	if len(rewardPercentiles) > 0 {
+		feeHist.Reward = make([][]*hexutil.Big, 0, len(batches))
+	}
+	for _, batch := range batches {
+		feeHist.BaseFee = append(feeHist.BaseFee, (*hexutil.Big)(batch.BaseFee))
+		ratio := float64(0)
+		if batch.GasLimit > 0 {
+			ratio = float64(batch.GasUsed) / float64(batch.GasLimit)
+		}
+		feeHist.GasUsedRatio = append(feeHist.GasUsedRatio, ratio)
+
+		if len(rewardPercentiles) > 0 {
+			batchRewards := make([]*hexutil.Big, len(rewardPercentiles))
+			for i := range batchRewards {
+				// the enclave may not have executed the batch yet
+				reward := big.NewInt(0)
+				if rewards[batch.Height] != nil {
+					reward = rewards[batch.Height][i]
+				}
+				batchRewards[i] = (*hexutil.Big)(reward)
+			}
+			feeHist.Reward = append(feeHist.Reward, batchRewards)
+		}
 	}
+	// the base fee of the next batch. The base fee is not dynamic, so it is the same as the last one
+	feeHist.BaseFee = append(feeHist.BaseFee, (*hexutil.Big)(batches[len(batches)-1].BaseFee))
 	return feeHist, nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/host"
@@ -103,6 +104,11 @@ func (s *ScanAPI) GetBlockListing(pagination *common.QueryPagination) (*common.B
 	return s.host.Storage().FetchBlockListing(pagination)
 }
 
+// GetL1BlockByHash returns the header of an L1 block containing rollups given its hash
+func (s *ScanAPI) GetL1BlockByHash(blockHash gethcommon.Hash) (*types.Header, error) {
+	return s.host.Storage().ReadBlock(&blockHash)
+}
+
 // GetRollupByHash returns the public rollup data given its hash
 func (s *ScanAPI) GetRollupByHash(rollupHash gethcommon.Hash) (*common.PublicRollup, error) {
 	return s.host.Storage().FetchRollupByHash(rollupHash)
@@ -18,7 +18,6 @@ import (
 	"github.com/ten-protocol/go-ten/go/common/rpc"
 	"github.com/ten-protocol/go-ten/go/common/rpc/generated"
 	"github.com/ten-protocol/go-ten/go/common/syserr"
-	"github.com/ten-protocol/go-ten/go/common/tracers"
 	"github.com/ten-protocol/go-ten/go/responses"
 
 	"google.golang.org/grpc"
@@ -360,29 +359,6 @@ func (c *Client) CreateRollup(ctx context.Context, fromSeqNo uint64) (*common.Ex
 	return rpc.FromExtRollupMsg(response.Msg), nil
 }
 
-func (c *Client) DebugTraceTransaction(ctx context.Context, hash gethcommon.Hash, config *tracers.TraceConfig) (json.RawMessage, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(ctx, c.enclaveRPCTimeout)
-	defer cancel()
-
-	confBytes, err := json.Marshal(config)
-	if err != nil {
-		return nil, err
-	}
-
-	response, err := c.protoClient.DebugTraceTransaction(timeoutCtx, &generated.DebugTraceTransactionRequest{
-		TxHash: hash.Bytes(),
-		Config: confBytes,
-	})
-	if err != nil {
-		return nil, syserr.NewRPCError(err)
-	}
-	if response != nil && response.SystemError != nil {
-		return nil, syserr.NewInternalError(fmt.Errorf("%s", response.SystemError.ErrorString))
-	}
-
-	return json.RawMessage(response.Msg), nil
-}
-
 func (c *Client) GetBatch(ctx context.Context, hash common.L2BatchHash) (*common.ExtBatch, common.SystemError) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, c.enclaveRPCTimeout)
 	defer cancel()
@@ -478,6 +454,32 @@ func (c *Client) GetTotalContractCount(ctx context.Context) (*big.Int, common.Sy
 	return big.NewInt(response.Count), nil
 }
 
+func (c *Client) FeeHistoryRewards(ctx context.Context, fromHeight uint64, toHeight uint64, rewardPercentiles []float64) (map[uint64][]*big.Int, common.SystemError) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, c.enclaveRPCTimeout)
+	defer cancel()
+
+	response, err := c.protoClient.FeeHistoryRewards(timeoutCtx, &generated.FeeHistoryRewardsRequest{
+		FromHeight:        fromHeight,
+		ToHeight:          toHeight,
+		RewardPercentiles: rewardPercentiles,
+	})
+	if err != nil {
+		return nil, syserr.NewRPCError(err)
+	}
+	if response != nil && response.SystemError != nil {
+		return nil, syserr.NewInternalError(fmt.Errorf("%s", response.SystemError.ErrorString))
+	}
+	rewards := make(map[uint64][]*big.Int, len(response.BatchRewards))
+	for _, batchRewards := range response.BatchRewards {
+		decoded := make([]*big.Int, len(batchRewards.Rewards))
+		for i, reward := range batchRewards.Rewards {
+			decoded[i] = new(big.Int).SetBytes(reward)
+		}
+		rewards[batchRewards.Height] = decoded
+	}
+	return rewards, nil
+}
+
 func (c *Client) EnclavePublicConfig(ctx context.Context) (*common.EnclavePublicConfig, common.SystemError) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, c.enclaveRPCTimeout)
 	defer cancel()
@@ -21,6 +21,7 @@ const (
 	selectBatchSeqByTx = "SELECT b_sequence FROM transaction_host WHERE hash = "
 	selectTxBySeq      = "SELECT hash FROM transaction_host WHERE b_sequence = "
 	selectBatchTxs     = "SELECT t.hash, b.sequence, b.height, b.ext_batch FROM transaction_host t JOIN batch_host b ON t.b_sequence = b.sequence"
+	selectBatchFees    = "SELECT sequence, height, base_fee, gas_used, gas_limit FROM batch_host WHERE height >= %s AND height <= %s ORDER BY height, sequence"
 )
 
 // AddBatch adds a batch and its header to the DB
@@ -35,6 +36,9 @@ func AddBatch(dbtx *dbTransaction, statements *SQLStatements, batch *common.ExtB
 		batch.Hash(),                 // full hash
 		batch.Header.Number.Uint64(), // height
 		extBatch,                     // ext_batch
+		baseFeeBytes(batch.Header),   // base_fee
+		batch.Header.GasUsed,         // gas_used
+		batch.Header.GasLimit,        // gas_limit
 	)
 	if err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "unique") {
@@ -72,6 +76,70 @@ func AddBatch(dbtx *dbTransaction, statements *SQLStatements, batch *common.ExtB
 	return nil
 }
 
+func baseFeeBytes(header *common.BatchHeader) []byte {
+	if header.BaseFee == nil {
+		return []byte{}
+	}
+	return header.BaseFee.Bytes()
+}
+
+// GetBatchFees returns the gas data of the batches with heights in the [from, to] range, ordered by height.
+// When there are multiple batches with the same height, the most recent one is returned.
+func GetBatchFees(db HostDB, from uint64, to uint64) ([]*common.BatchFees, error) {
+	query := fmt.Sprintf(selectBatchFees, db.GetSQLStatement().GetPlaceHolder(1), db.GetSQLStatement().GetPlaceHolder(2))
+	rows, err := db.GetSQLDB().Query(query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query batch fees - %w", err)
+	}
+	defer rows.Close()
+
+	fees := make([]*common.BatchFees, 0)
+	for rows.Next() {
+		var seqNo, height uint64
+		var baseFee []byte
+		var gasUsed, gasLimit sql.NullInt64
+		if err := rows.Scan(&seqNo, &height, &baseFee, &gasUsed, &gasLimit); err != nil {
+			return nil, fmt.Errorf("failed to scan batch fees - %w", err)
+		}
+		batchFees := &common.BatchFees{
+			Height:   height,
+			BaseFee:  new(big.Int).SetBytes(baseFee),
+			GasUsed:  uint64(gasUsed.Int64),
+			GasLimit: uint64(gasLimit.Int64),
+		}
+		// batches stored before the gas columns were added
+		if baseFee == nil || !gasUsed.Valid || !gasLimit.Valid {
+			batchFees, err = batchFeesFromHeader(db, seqNo)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(fees) > 0 && fees[len(fees)-1].Height == height {
+			fees[len(fees)-1] = batchFees
+			continue
+		}
+		fees = append(fees, batchFees)
+	}
+	return fees, rows.Err()
+}
+
+func batchFeesFromHeader(db HostDB, seqNo uint64) (*common.BatchFees, error) {
+	batch, err := GetBatchBySequenceNumber(db, seqNo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch batch %d - %w", seqNo, err)
+	}
+	baseFee := big.NewInt(0)
+	if batch.Header.BaseFee != nil {
+		baseFee = batch.Header.BaseFee
+	}
+	return &common.BatchFees{
+		Height:   batch.Header.Number.Uint64(),
+		BaseFee:  baseFee,
+		GasUsed:  batch.Header.GasUsed,
+		GasLimit: batch.Header.GasLimit,
+	}, nil
+}
+
 // GetBatchListing returns latest batches given a pagination.
 // For example, page 0, size 10 will return the latest 10 batches.
 func GetBatchListing(db HostDB, pagination *common.QueryPagination) (*common.BatchListingResponse, error) {
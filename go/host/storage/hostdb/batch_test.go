@@ -447,6 +447,35 @@ func TestGetBatchTransactions(t *testing.T) {
 	}
 }
 
+func TestGetBatchFees(t *testing.T) {
+	db, _ := createSQLiteDB(t)
+	dbtx, _ := db.NewDBTransaction()
+	for i := int64(1); i <= 5; i++ {
+		batch := createBatch(i, []common.L2TxHash{})
+		batch.Header.BaseFee = big.NewInt(i * 1000)
+		batch.Header.GasUsed = uint64(i * 10)
+		batch.Header.GasLimit = 100
+		if err := AddBatch(dbtx, db.GetSQLStatement(), &batch); err != nil {
+			t.Fatalf("could not store batch. Cause: %s", err)
+		}
+	}
+	dbtx.Write()
+
+	fees, err := GetBatchFees(db, 2, 4)
+	if err != nil {
+		t.Fatalf("could not retrieve batch fees. Cause: %s", err)
+	}
+	if len(fees) != 3 {
+		t.Fatalf("expected 3 batches, got %d", len(fees))
+	}
+	for i, f := range fees {
+		height := int64(i + 2)
+		if f.Height != uint64(height) || f.BaseFee.Int64() != height*1000 || f.GasUsed != uint64(height*10) || f.GasLimit != 100 {
+			t.Errorf("batch fees were not retrieved correctly: %+v", f)
+		}
+	}
+}
+
 func createBatch(batchNum int64, txHashes []common.L2BatchHash) common.ExtBatch {
 	header := common.BatchHeader{
 		SequencerOrderNo: big.NewInt(batchNum),
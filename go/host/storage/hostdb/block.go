@@ -3,12 +3,14 @@ package hostdb
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
 )
 
 const (
@@ -53,6 +55,9 @@ func GetBlock(db HostDB, statements *SQLStatements, hash *gethcommon.Hash) (*typ
 	var header []byte
 	err := db.GetSQLDB().QueryRow(query, hash.Bytes()).Scan(&header)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errutil.ErrNotFound
+		}
 		return nil, fmt.Errorf("query execution for select block failed: %w", err)
 	}
 	h := new(types.Header)
@@ -0,0 +1,56 @@
+package hostdb
+
+import (
+	"fmt"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+const (
+	selectPendingBundles = "SELECT rollup_hash, from_seq, to_seq FROM pending_bundle_host ORDER BY from_seq ASC"
+	deletePendingBundle  = "DELETE FROM pending_bundle_host WHERE rollup_hash = "
+)
+
+// AddPendingBundle stores the batch range of a published rollup whose cross chain bundle was not published yet.
+// A bundle which is already stored is ignored.
+func AddPendingBundle(db HostDB, bundle *common.PendingCrossChainBundle) error {
+	_, err := db.GetSQLDB().Exec(db.GetSQLStatement().InsertPendingBundle, bundle.RollupHash.Bytes(), bundle.FromSeqNo, bundle.ToSeqNo)
+	if err != nil {
+		return fmt.Errorf("could not store pending cross chain bundle. Cause: %w", err)
+	}
+	return nil
+}
+
+// DeletePendingBundle removes the pending bundle of the rollup
+func DeletePendingBundle(db HostDB, rollupHash gethcommon.Hash) error {
+	_, err := db.GetSQLDB().Exec(deletePendingBundle+db.GetSQLStatement().Placeholder, rollupHash.Bytes())
+	if err != nil {
+		return fmt.Errorf("could not delete pending cross chain bundle. Cause: %w", err)
+	}
+	return nil
+}
+
+// GetPendingBundles returns the pending bundles in the order of the rollups
+func GetPendingBundles(db HostDB) ([]*common.PendingCrossChainBundle, error) {
+	rows, err := db.GetSQLDB().Query(selectPendingBundles)
+	if err != nil {
+		return nil, fmt.Errorf("query execution for select pending cross chain bundles failed: %w", err)
+	}
+	defer rows.Close()
+
+	bundles := make([]*common.PendingCrossChainBundle, 0)
+	for rows.Next() {
+		var rollupHash []byte
+		bundle := new(common.PendingCrossChainBundle)
+		if err := rows.Scan(&rollupHash, &bundle.FromSeqNo, &bundle.ToSeqNo); err != nil {
+			return nil, fmt.Errorf("could not read pending cross chain bundle. Cause: %w", err)
+		}
+		bundle.RollupHash = gethcommon.BytesToHash(rollupHash)
+		bundles = append(bundles, bundle)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return bundles, nil
+}
@@ -0,0 +1,30 @@
+package hostdb
+
+import (
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+func TestCanStoreAndDeletePendingBundles(t *testing.T) {
+	db, err := createSQLiteDB(t)
+	require.NoError(t, err)
+
+	first := &common.PendingCrossChainBundle{RollupHash: gethcommon.Hash{1}, FromSeqNo: 1, ToSeqNo: 10}
+	second := &common.PendingCrossChainBundle{RollupHash: gethcommon.Hash{2}, FromSeqNo: 11, ToSeqNo: 20}
+	require.NoError(t, AddPendingBundle(db, second))
+	require.NoError(t, AddPendingBundle(db, first))
+	// a bundle can be stored again, e.g. when the rollup is re-published
+	require.NoError(t, AddPendingBundle(db, first))
+
+	bundles, err := GetPendingBundles(db)
+	require.NoError(t, err)
+	require.Equal(t, []*common.PendingCrossChainBundle{first, second}, bundles)
+
+	require.NoError(t, DeletePendingBundle(db, first.RollupHash))
+	bundles, err = GetPendingBundles(db)
+	require.NoError(t, err)
+	require.Equal(t, []*common.PendingCrossChainBundle{second}, bundles)
+}
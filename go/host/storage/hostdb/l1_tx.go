@@ -0,0 +1,103 @@
+package hostdb
+
+import (
+	"fmt"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	selectPendingL1Txs      = "SELECT tx FROM pending_l1_tx_host ORDER BY nonce ASC"
+	selectPendingL1TxHashes = "SELECT hash FROM pending_l1_tx_hash_host WHERE nonce = "
+	deletePendingL1Tx       = "DELETE FROM pending_l1_tx_host WHERE nonce = "
+	deletePendingL1TxHashes = "DELETE FROM pending_l1_tx_hash_host WHERE nonce = "
+)
+
+// UpsertPendingL1Tx stores the latest signed version of an L1 tx which was not included yet. It replaces the version
+// with the same nonce, if any, while the hashes of all the versions are kept.
+func UpsertPendingL1Tx(db HostDB, tx *types.Transaction) error {
+	// the binary encoding includes the blobs sidecar, so a blob tx can be re-sent with higher fees after a restart
+	encoded, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("could not encode L1 tx. Cause: %w", err)
+	}
+	dbtx, err := db.NewDBTransaction()
+	if err != nil {
+		return err
+	}
+	defer dbtx.Rollback()
+
+	if _, err := dbtx.Tx.Exec(db.GetSQLStatement().UpsertPendingL1Tx, tx.Nonce(), tx.Hash().Bytes(), encoded); err != nil {
+		return fmt.Errorf("could not store pending L1 tx. Cause: %w", err)
+	}
+	if _, err := dbtx.Tx.Exec(db.GetSQLStatement().InsertPendingL1TxHash, tx.Hash().Bytes(), tx.Nonce()); err != nil {
+		return fmt.Errorf("could not store pending L1 tx hash. Cause: %w", err)
+	}
+	return dbtx.Write()
+}
+
+// DeletePendingL1Tx removes the L1 tx with the given nonce, along with the hashes of its versions
+func DeletePendingL1Tx(db HostDB, nonce uint64) error {
+	dbtx, err := db.NewDBTransaction()
+	if err != nil {
+		return err
+	}
+	defer dbtx.Rollback()
+
+	if _, err := dbtx.Tx.Exec(deletePendingL1Tx+db.GetSQLStatement().Placeholder, nonce); err != nil {
+		return fmt.Errorf("could not delete pending L1 tx. Cause: %w", err)
+	}
+	if _, err := dbtx.Tx.Exec(deletePendingL1TxHashes+db.GetSQLStatement().Placeholder, nonce); err != nil {
+		return fmt.Errorf("could not delete pending L1 tx hashes. Cause: %w", err)
+	}
+	return dbtx.Write()
+}
+
+// GetPendingL1TxHashes returns the hashes of all the versions of the pending L1 tx with the given nonce
+func GetPendingL1TxHashes(db HostDB, nonce uint64) ([]gethcommon.Hash, error) {
+	rows, err := db.GetSQLDB().Query(selectPendingL1TxHashes+db.GetSQLStatement().Placeholder, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("query execution for select pending L1 tx hashes failed: %w", err)
+	}
+	defer rows.Close()
+
+	hashes := make([]gethcommon.Hash, 0)
+	for rows.Next() {
+		var hash []byte
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("could not read pending L1 tx hash. Cause: %w", err)
+		}
+		hashes = append(hashes, gethcommon.BytesToHash(hash))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// GetPendingL1Txs returns the pending L1 txs ordered by nonce
+func GetPendingL1Txs(db HostDB) ([]*types.Transaction, error) {
+	rows, err := db.GetSQLDB().Query(selectPendingL1Txs)
+	if err != nil {
+		return nil, fmt.Errorf("query execution for select pending L1 txs failed: %w", err)
+	}
+	defer rows.Close()
+
+	txs := make([]*types.Transaction, 0)
+	for rows.Next() {
+		var encoded []byte
+		if err := rows.Scan(&encoded); err != nil {
+			return nil, fmt.Errorf("could not read pending L1 tx. Cause: %w", err)
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(encoded); err != nil {
+			return nil, fmt.Errorf("could not decode pending L1 tx. Cause: %w", err)
+		}
+		txs = append(txs, tx)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
@@ -0,0 +1,59 @@
+package hostdb
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanStoreAndReplacePendingL1Txs(t *testing.T) {
+	db, err := createSQLiteDB(t)
+	require.NoError(t, err)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(big.NewInt(1337))
+
+	signedTx := func(nonce uint64, tip int64) *types.Transaction {
+		to := gethcommon.HexToAddress("0x01")
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{Nonce: nonce, GasTipCap: big.NewInt(tip), GasFeeCap: big.NewInt(tip), Gas: 21_000, To: &to})
+		require.NoError(t, err)
+		return tx
+	}
+
+	require.NoError(t, UpsertPendingL1Tx(db, signedTx(2, 1)))
+	require.NoError(t, UpsertPendingL1Tx(db, signedTx(1, 1)))
+	// the replacement overwrites the previous version with the same nonce
+	replacement := signedTx(2, 2)
+	require.NoError(t, UpsertPendingL1Tx(db, replacement))
+
+	txs, err := GetPendingL1Txs(db)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Equal(t, uint64(1), txs[0].Nonce())
+	require.Equal(t, replacement.Hash(), txs[1].Hash())
+
+	// the hashes of all the versions are kept, as any of them may be included
+	hashes, err := GetPendingL1TxHashes(db, 2)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []gethcommon.Hash{signedTx(2, 1).Hash(), replacement.Hash()}, hashes)
+	// storing a version again doesn't duplicate its hash
+	require.NoError(t, UpsertPendingL1Tx(db, replacement))
+	hashes, err = GetPendingL1TxHashes(db, 2)
+	require.NoError(t, err)
+	require.Len(t, hashes, 2)
+
+	require.NoError(t, DeletePendingL1Tx(db, 1))
+	txs, err = GetPendingL1Txs(db)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, replacement.Hash(), txs[0].Hash())
+
+	require.NoError(t, DeletePendingL1Tx(db, 2))
+	hashes, err = GetPendingL1TxHashes(db, 2)
+	require.NoError(t, err)
+	require.Empty(t, hashes)
+}
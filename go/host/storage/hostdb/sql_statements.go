@@ -10,6 +10,9 @@ type SQLStatements struct {
 	InsertRollup            string
 	InsertCrossChainMessage string
 	InsertBlock             string
+	UpsertPendingL1Tx       string
+	InsertPendingL1TxHash   string
+	InsertPendingBundle     string
 	Pagination              string
 	Placeholder             string
 }
@@ -23,12 +26,15 @@ func (s SQLStatements) GetPlaceHolder(pos int) string {
 
 func SQLiteSQLStatements() *SQLStatements {
 	return &SQLStatements{
-		InsertBatch:             "INSERT INTO batch_host (sequence, hash, height, ext_batch) VALUES (?, ?, ?, ?)",
+		InsertBatch:             "INSERT INTO batch_host (sequence, hash, height, ext_batch, base_fee, gas_used, gas_limit) VALUES (?, ?, ?, ?, ?, ?, ?)",
 		InsertTransactions:      "INSERT INTO transaction_host (hash, b_sequence) VALUES ",
 		UpdateTxCount:           "UPDATE transaction_count SET total=? WHERE id=1",
 		InsertRollup:            "INSERT INTO rollup_host (hash, start_seq, end_seq, time_stamp, ext_rollup, compression_block) values (?,?,?,?,?,?)",
 		InsertBlock:             "INSERT INTO block_host (hash, header) values (?,?)",
 		InsertCrossChainMessage: "INSERT INTO cross_chain_message_host (message_hash, message_type, rollup_id) values (?,?,?)",
+		UpsertPendingL1Tx:       "INSERT INTO pending_l1_tx_host (nonce, hash, tx) VALUES (?, ?, ?) ON CONFLICT (nonce) DO UPDATE SET hash=excluded.hash, tx=excluded.tx",
+		InsertPendingL1TxHash:   "INSERT INTO pending_l1_tx_hash_host (hash, nonce) VALUES (?, ?) ON CONFLICT (hash) DO NOTHING",
+		InsertPendingBundle:     "INSERT INTO pending_bundle_host (rollup_hash, from_seq, to_seq) VALUES (?, ?, ?) ON CONFLICT (rollup_hash) DO NOTHING",
 		Pagination:              "LIMIT ? OFFSET ?",
 		Placeholder:             "?",
 	}
@@ -36,12 +42,15 @@ func SQLiteSQLStatements() *SQLStatements {
 
 func PostgresSQLStatements() *SQLStatements {
 	return &SQLStatements{
-		InsertBatch:             "INSERT INTO batch_host (sequence, hash, height, ext_batch) VALUES ($1, $2, $3, $4)",
+		InsertBatch:             "INSERT INTO batch_host (sequence, hash, height, ext_batch, base_fee, gas_used, gas_limit) VALUES ($1, $2, $3, $4, $5, $6, $7)",
 		InsertTransactions:      "INSERT INTO transaction_host (hash, b_sequence) VALUES ",
 		UpdateTxCount:           "UPDATE transaction_count SET total=$1 WHERE id=1",
 		InsertRollup:            "INSERT INTO rollup_host (hash, start_seq, end_seq, time_stamp, ext_rollup, compression_block) values ($1, $2, $3, $4, $5, $6)",
 		InsertBlock:             "INSERT INTO block_host (hash, header) VALUES ($1, $2)",
 		InsertCrossChainMessage: "INSERT INTO cross_chain_message_host (message_hash, message_type, rollup_id) values ($1, $2, $3)",
+		UpsertPendingL1Tx:       "INSERT INTO pending_l1_tx_host (nonce, hash, tx) VALUES ($1, $2, $3) ON CONFLICT (nonce) DO UPDATE SET hash=excluded.hash, tx=excluded.tx",
+		InsertPendingL1TxHash:   "INSERT INTO pending_l1_tx_hash_host (hash, nonce) VALUES ($1, $2) ON CONFLICT (hash) DO NOTHING",
+		InsertPendingBundle:     "INSERT INTO pending_bundle_host (rollup_hash, from_seq, to_seq) VALUES ($1, $2, $3) ON CONFLICT (rollup_hash) DO NOTHING",
 		Pagination:              "LIMIT $1 OFFSET $2",
 		Placeholder:             "$1",
 	}
@@ -0,0 +1,4 @@
+-- the gas fields of the batch header, used to calculate the fee history without decoding the batches
+ALTER TABLE batch_host ADD COLUMN IF NOT EXISTS base_fee BYTEA;
+ALTER TABLE batch_host ADD COLUMN IF NOT EXISTS gas_used BIGINT;
+ALTER TABLE batch_host ADD COLUMN IF NOT EXISTS gas_limit BIGINT;
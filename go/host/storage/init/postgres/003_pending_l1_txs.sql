@@ -0,0 +1,15 @@
+-- the L1 txs sent by the host which were not included yet, so they can be tracked again after a restart
+CREATE TABLE IF NOT EXISTS pending_l1_tx_host
+(
+    nonce       BIGINT PRIMARY KEY,
+    hash        BYTEA  NOT NULL,
+    tx          BYTEA  NOT NULL
+);
+
+-- the hashes of all the versions of the pending L1 txs, as any of the replaced versions may be the one that is included
+CREATE TABLE IF NOT EXISTS pending_l1_tx_hash_host
+(
+    hash        BYTEA  PRIMARY KEY,
+    nonce       BIGINT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS IDX_PENDING_L1_TX_HASH_NONCE ON pending_l1_tx_hash_host (nonce);
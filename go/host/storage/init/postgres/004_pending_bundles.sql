@@ -0,0 +1,7 @@
+-- the batch ranges of the published rollups whose cross chain bundles were not published yet
+CREATE TABLE IF NOT EXISTS pending_bundle_host
+(
+    rollup_hash BYTEA  PRIMARY KEY,
+    from_seq    BIGINT NOT NULL,
+    to_seq      BIGINT NOT NULL
+);
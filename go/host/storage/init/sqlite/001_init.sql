@@ -39,7 +39,10 @@ create table if not exists batch_host
     sequence       int primary key,
     hash           binary(32) NOT NULL,
     height         int        NOT NULL,
-    ext_batch      mediumblob NOT NULL
+    ext_batch      mediumblob NOT NULL,
+    base_fee       blob,
+    gas_used       int,
+    gas_limit      int
 );
 create index IDX_BATCH_HASH_HOST on batch_host (hash);
 create index IDX_BATCH_HEIGHT_HOST on batch_host (height);
@@ -59,4 +62,25 @@ create table if not exists transaction_count
 );
 
 insert into transaction_count (id, total)
-values (1, 0) on CONFLICT (id) DO NOTHING;
\ No newline at end of file
+values (1, 0) on CONFLICT (id) DO NOTHING;
+
+create table if not exists pending_l1_tx_host
+(
+    nonce          int  PRIMARY KEY,
+    hash           binary(32) NOT NULL,
+    tx             mediumblob NOT NULL
+);
+
+create table if not exists pending_l1_tx_hash_host
+(
+    hash           binary(32) PRIMARY KEY,
+    nonce          int  NOT NULL
+);
+create index IDX_PENDING_L1_TX_HASH_NONCE on pending_l1_tx_hash_host (nonce);
+
+create table if not exists pending_bundle_host
+(
+    rollup_hash    binary(32) PRIMARY KEY,
+    from_seq       int  NOT NULL,
+    to_seq         int  NOT NULL
+);
@@ -12,6 +12,8 @@ import (
 type Storage interface {
 	BatchResolver
 	BlockResolver
+	PendingL1TxResolver
+	PendingBundleResolver
 	io.Closer
 }
 
@@ -42,6 +44,8 @@ type BatchResolver interface {
 	FetchBatchHeaderByHeight(height *big.Int) (*common.BatchHeader, error)
 	// FetchBatchByHeight returns the `PublicBatch` with the given height
 	FetchBatchByHeight(height *big.Int) (*common.PublicBatch, error)
+	// FetchBatchFees returns the gas data of the batches with heights in the [from, to] range
+	FetchBatchFees(from uint64, to uint64) ([]*common.BatchFees, error)
 	// FetchTotalTxCount returns the value of the transactions_count table. Fast but inaccurate for Tenscan
 	FetchTotalTxCount() (*big.Int, error)
 	// FetchTotalTxsQuery returns the number of transactions in the DB. Required for e2e tests
@@ -75,3 +79,25 @@ type BlockResolver interface {
 	// FetchRollupBatches returns a list of public batch data within a given rollup hash
 	FetchRollupBatches(rollupHash gethcommon.Hash) (*common.BatchListingResponse, error)
 }
+
+type PendingL1TxResolver interface {
+	// StorePendingL1Tx stores the latest signed version of an L1 tx which was not included yet, replacing the previous
+	// version with the same nonce
+	StorePendingL1Tx(tx *types.Transaction) error
+	// DeletePendingL1Tx removes the pending L1 tx with the given nonce
+	DeletePendingL1Tx(nonce uint64) error
+	// FetchPendingL1Txs returns the L1 txs which were sent but not included yet, ordered by nonce
+	FetchPendingL1Txs() ([]*types.Transaction, error)
+	// FetchPendingL1TxHashes returns the hashes of all the versions of the pending L1 tx with the given nonce, as any of
+	// them may be the one that is included
+	FetchPendingL1TxHashes(nonce uint64) ([]gethcommon.Hash, error)
+}
+
+type PendingBundleResolver interface {
+	// StorePendingBundle stores the batch range of a published rollup whose cross chain bundle was not published yet
+	StorePendingBundle(bundle *common.PendingCrossChainBundle) error
+	// DeletePendingBundle removes the pending bundle of the rollup
+	DeletePendingBundle(rollupHash gethcommon.Hash) error
+	// FetchPendingBundles returns the pending bundles in the order of the rollups
+	FetchPendingBundles() ([]*common.PendingCrossChainBundle, error)
+}
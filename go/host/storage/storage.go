@@ -172,6 +172,10 @@ func (s *storageImpl) FetchBatchHeaderByHeight(height *big.Int) (*common.BatchHe
 	return hostdb.GetBatchHeaderByHeight(s.db, height)
 }
 
+func (s *storageImpl) FetchBatchFees(from uint64, to uint64) ([]*common.BatchFees, error) {
+	return hostdb.GetBatchFees(s.db, from, to)
+}
+
 func (s *storageImpl) FetchBatchByHeight(height *big.Int) (*common.PublicBatch, error) {
 	return hostdb.GetBatchByHeight(s.db, height)
 }
@@ -228,6 +232,34 @@ func (s *storageImpl) FetchTransactionListing(pagination *common.QueryPagination
 	return hostdb.GetTransactionListing(s.db, pagination)
 }
 
+func (s *storageImpl) StorePendingL1Tx(tx *types.Transaction) error {
+	return hostdb.UpsertPendingL1Tx(s.db, tx)
+}
+
+func (s *storageImpl) DeletePendingL1Tx(nonce uint64) error {
+	return hostdb.DeletePendingL1Tx(s.db, nonce)
+}
+
+func (s *storageImpl) FetchPendingL1Txs() ([]*types.Transaction, error) {
+	return hostdb.GetPendingL1Txs(s.db)
+}
+
+func (s *storageImpl) FetchPendingL1TxHashes(nonce uint64) ([]gethcommon.Hash, error) {
+	return hostdb.GetPendingL1TxHashes(s.db, nonce)
+}
+
+func (s *storageImpl) StorePendingBundle(bundle *common.PendingCrossChainBundle) error {
+	return hostdb.AddPendingBundle(s.db, bundle)
+}
+
+func (s *storageImpl) DeletePendingBundle(rollupHash gethcommon.Hash) error {
+	return hostdb.DeletePendingBundle(s.db, rollupHash)
+}
+
+func (s *storageImpl) FetchPendingBundles() ([]*common.PendingCrossChainBundle, error) {
+	return hostdb.GetPendingBundles(s.db)
+}
+
 func (s *storageImpl) Close() error {
 	return s.db.GetSQLDB().Close()
 }
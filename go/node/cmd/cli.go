@@ -55,6 +55,7 @@ type NodeConfigCLI struct {
 	postgresDBHost          string
 	l1BeaconUrl             string
 	l1BlobArchiveUrl        string
+	l1BlobArchiveStore      string
 }
 
 // ParseConfigCLI returns a NodeConfigCLI based the cli params and defaults.
@@ -95,6 +96,7 @@ func ParseConfigCLI() *NodeConfigCLI {
 	postgresDBHost := flag.String(postgresDBHostFlag, "dd", flagUsageMap[postgresDBHostFlag])
 	l1BeaconUrl := flag.String(l1BeaconUrlFlag, "eth2network:126000", flagUsageMap[l1BeaconUrlFlag])
 	l1BlobArchiveUrl := flag.String(l1BlobArchiveUrlFlag, "", flagUsageMap[l1BlobArchiveUrlFlag])
+	l1BlobArchiveStore := flag.String(l1BlobArchiveStoreFlag, "", flagUsageMap[l1BlobArchiveStoreFlag])
 	systemContractsUpgrader := flag.String(systemContractsUpgraderFlag, "", flagUsageMap[systemContractsUpgraderFlag])
 	flag.Parse()
 	cfg.nodeName = *nodeName
@@ -130,6 +132,7 @@ func ParseConfigCLI() *NodeConfigCLI {
 	cfg.postgresDBHost = *postgresDBHost
 	cfg.l1BeaconUrl = *l1BeaconUrl
 	cfg.l1BlobArchiveUrl = *l1BlobArchiveUrl
+	cfg.l1BlobArchiveStore = *l1BlobArchiveStore
 	cfg.sequencerUpgraderAddr = *systemContractsUpgrader
 
 	cfg.nodeAction = flag.Arg(0)
@@ -211,6 +214,7 @@ func NodeCLIConfigToTenConfig(cliCfg *NodeConfigCLI) *config.TenConfig {
 	tenCfg.Host.L1.WebsocketURL = cliCfg.l1WebsocketURL
 	tenCfg.Host.L1.L1BeaconUrl = cliCfg.l1BeaconUrl
 	tenCfg.Host.L1.L1BlobArchiveUrl = cliCfg.l1BlobArchiveUrl
+	tenCfg.Host.L1.L1BlobArchiveStore = cliCfg.l1BlobArchiveStore
 	tenCfg.Host.P2P.BindAddress = fmt.Sprintf("%s:%d", cliCfg.hostP2PHost, cliCfg.hostP2PPort)
 	tenCfg.Host.P2P.IsDisabled = cliCfg.isInboundP2PDisabled
 	tenCfg.Host.RPC.HTTPPort = uint64(cliCfg.hostHTTPPort)
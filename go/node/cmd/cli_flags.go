@@ -35,6 +35,7 @@ const (
 	postgresDBHostFlag          = "postgres_db_host"
 	l1BeaconUrlFlag             = "l1_beacon_url"
 	l1BlobArchiveUrlFlag        = "l1_blob_archive_url"
+	l1BlobArchiveStoreFlag      = "l1_blob_archive_store"
 	systemContractsUpgraderFlag = "system_contracts_upgrader"
 )
 
@@ -75,6 +76,7 @@ func getFlagUsageMap() map[string]string {
 		postgresDBHostFlag:          "Host connection details for Postgres DB",
 		l1BeaconUrlFlag:             "Url for the beacon chain API",
 		l1BlobArchiveUrlFlag:        "Url for the blob archive endpoint",
+		l1BlobArchiveStoreFlag:      "Where the published rollup blobs are archived (file:///dir, s3://host/bucket with the AWS credential chain, or mem://)",
 		systemContractsUpgraderFlag: "Address of the system contracts upgrader",
 	}
 }
@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
@@ -127,6 +128,12 @@ func (d *DockerNode) startHost() error {
 	}
 
 	envVariables := d.cfg.ToEnvironmentVariables()
+	// the credentials of the s3 blob archive are not part of the config, they are passed on from the environment
+	for _, key := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_REGION"} {
+		if value, found := os.LookupEnv(key); found {
+			envVariables[key] = value
+		}
+	}
 
 	_, err = docker.StartNewContainer(d.cfg.Node.Name+"-host", d.hostImage, cmd, exposedPorts, envVariables, nil, nil, true)
 
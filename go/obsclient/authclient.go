@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/tracers"
 	"github.com/ten-protocol/go-ten/go/common/viewingkey"
 	"github.com/ten-protocol/go-ten/go/responses"
 	"github.com/ten-protocol/go-ten/go/rpc"
@@ -327,3 +328,17 @@ func (ac *AuthObsClient) GetPrivateTransactions(ctx context.Context, address *ge
 
 	return result.Receipts, result.Total, nil
 }
+
+// TraceTransaction returns the trace of a transaction sent by the account registered on this client, produced by the
+// tracer selected in the config. A nil config uses the struct logger.
+func (ac *AuthObsClient) TraceTransaction(ctx context.Context, txHash gethcommon.Hash, config *tracers.TraceConfig) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := ac.rpcClient.CallContext(ctx, &result, tenrpc.ERPCDebugTraceTransaction, txHash, config)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 || string(result) == "null" {
+		return nil, ethereum.NotFound
+	}
+	return result, nil
+}
@@ -1,14 +1,18 @@
 package obsclient
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/rpc"
+	gethrpc "github.com/ten-protocol/go-ten/lib/gethfork/rpc"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	hostcommon "github.com/ten-protocol/go-ten/go/common/host"
@@ -37,6 +41,12 @@ func (oc *ObsClient) Close() {
 	oc.rpcClient.Stop()
 }
 
+// IsNotFound returns whether the error is a not found error. The not found errors returned by the node only keep their
+// message over RPC.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ethereum.NotFound) || strings.Contains(err.Error(), ethereum.NotFound.Error())
+}
+
 // Blockchain Access
 
 // ChainID retrieves the current chain ID for transaction replay protection.
@@ -229,6 +239,16 @@ func (oc *ObsClient) GetRollupListing(pagination *common.QueryPagination) (*comm
 	return &result, nil
 }
 
+// GetL1BlockByHash returns the header of the L1 block given its hash, if the block contains rollups
+func (oc *ObsClient) GetL1BlockByHash(hash gethcommon.Hash) (*types.Header, error) {
+	var header *types.Header
+	err := oc.rpcClient.Call(&header, rpc.GetL1BlockByHash, hash)
+	if err == nil && header == nil {
+		err = ethereum.NotFound
+	}
+	return header, err
+}
+
 // GetRollupByHash returns the public rollup data given its hash
 func (oc *ObsClient) GetRollupByHash(hash gethcommon.Hash) (*common.PublicRollup, error) {
 	var rollup *common.PublicRollup
@@ -259,6 +279,11 @@ func (oc *ObsClient) GetBatchTransactions(hash gethcommon.Hash) (*common.Transac
 	return txListing, err
 }
 
+// SubscribeNewHeads subscribes to the headers of the new batches. It requires a websocket connection to the node.
+func (oc *ObsClient) SubscribeNewHeads(ctx context.Context, ch chan<- *common.BatchHeader) (*gethrpc.ClientSubscription, error) {
+	return oc.rpcClient.Subscribe(ctx, rpc.SubscribeNamespace, ch, rpc.SubscriptionTypeNewHeads)
+}
+
 // GetConfig returns the network config for obscuro
 func (oc *ObsClient) GetConfig() (*common.TenNetworkInfo, error) {
 	var result common.TenNetworkInfo
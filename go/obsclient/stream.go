@@ -0,0 +1,269 @@
+package obsclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/retry"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// the number of heights for which the hash of the emitted batch is kept, to detect the reorgs
+	_reorgWindow = 128
+)
+
+var _resubscribeIntervals = []time.Duration{time.Second, 2 * time.Second, 5 * time.Second}
+
+// BatchEvent is a batch emitted by the batch iterator or subscription. When a reorg replaces batches which were already
+// emitted, the batches at the replaced heights are emitted again with Replaced set.
+type BatchEvent struct {
+	Batch    *common.PublicBatch
+	Replaced bool
+}
+
+// RollupEvent is a rollup emitted by the rollup iterator, along with the batches it contains
+type RollupEvent struct {
+	Rollup  *common.PublicRollup
+	Batches []common.PublicBatch
+}
+
+// batchWalker fetches the batches height by height, and walks back the heights replaced by a reorg
+type batchWalker struct {
+	client  *ObsClient
+	emitted map[uint64]gethcommon.Hash // the hashes of the recently emitted batches, by height
+}
+
+func newBatchWalker(client *ObsClient) *batchWalker {
+	return &batchWalker{client: client, emitted: map[uint64]gethcommon.Hash{}}
+}
+
+// next returns the events for the batch at the given height. If the batch does not extend the batch emitted at the
+// previous height, the replaced heights are fetched again and emitted first. No event is returned if the batch was
+// already emitted.
+func (w *batchWalker) next(height uint64) ([]*BatchEvent, error) {
+	batch, err := w.client.GetBatchByHeight(new(big.Int).SetUint64(height))
+	if err != nil {
+		return nil, err
+	}
+	previous, seen := w.emitted[height]
+	if seen && previous == batch.FullHash {
+		return nil, nil
+	}
+	events := []*BatchEvent{{Batch: batch, Replaced: seen && previous != batch.FullHash}}
+
+	for h := height; h > 0; h-- {
+		parentHash, found := w.emitted[h-1]
+		if !found || parentHash == events[0].Batch.Header.ParentHash {
+			break
+		}
+		parent, err := w.client.GetBatchByHeight(new(big.Int).SetUint64(h - 1))
+		if err != nil {
+			return nil, err
+		}
+		events = append([]*BatchEvent{{Batch: parent, Replaced: true}}, events...)
+	}
+
+	// the heights above were emitted on the replaced chain
+	for h := range w.emitted {
+		if h > height || h+_reorgWindow < height {
+			delete(w.emitted, h)
+		}
+	}
+	for _, e := range events {
+		w.emitted[e.Batch.Height.Uint64()] = e.Batch.FullHash
+	}
+	return events, nil
+}
+
+// BatchIterator walks the batches of a range of heights, see IterateBatches
+type BatchIterator struct {
+	client *ObsClient
+	walker *batchWalker
+	next   uint64
+	to     *big.Int
+
+	pending []*BatchEvent
+	event   *BatchEvent
+	err     error
+}
+
+// IterateBatches returns an iterator over the batches from the given height up to the `to` height (inclusive). If `to`
+// is nil, the iteration stops at the head batch at the time of the first call to Next.
+func (oc *ObsClient) IterateBatches(from *big.Int, to *big.Int) *BatchIterator {
+	return &BatchIterator{client: oc, walker: newBatchWalker(oc), next: from.Uint64(), to: to}
+}
+
+// Next advances the iterator to the next batch event, it returns false at the end of the range or after an error
+func (it *BatchIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.to == nil {
+		head, err := it.client.BatchNumber()
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.to = new(big.Int).SetUint64(head)
+	}
+	for len(it.pending) == 0 {
+		if it.next > it.to.Uint64() {
+			return false
+		}
+		events, err := it.walker.next(it.next)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.pending = events
+		it.next++
+	}
+	it.event, it.pending = it.pending[0], it.pending[1:]
+	return true
+}
+
+// Event returns the current batch event
+func (it *BatchIterator) Event() *BatchEvent {
+	return it.event
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *BatchIterator) Err() error {
+	return it.err
+}
+
+// SubscribeBatches emits the batches produced after the subscription, built on the new heads subscription (which
+// requires a websocket connection to the node). The heads subscription is renewed when it fails, and the batches
+// missed in the meantime are backfilled.
+func (oc *ObsClient) SubscribeBatches(ctx context.Context, ch chan<- *BatchEvent) (ethereum.Subscription, error) {
+	return oc.subscribeBatches(ctx, ch, func(ctx context.Context, heads chan<- *common.BatchHeader) (ethereum.Subscription, error) {
+		return oc.SubscribeNewHeads(ctx, heads)
+	})
+}
+
+// newHeadsSubscriber - subscribes to the new heads, see ObsClient.SubscribeNewHeads
+type newHeadsSubscriber func(ctx context.Context, heads chan<- *common.BatchHeader) (ethereum.Subscription, error)
+
+func (oc *ObsClient) subscribeBatches(ctx context.Context, ch chan<- *BatchEvent, subscribeNewHeads newHeadsSubscriber) (ethereum.Subscription, error) {
+	head, err := oc.BatchNumber()
+	if err != nil {
+		return nil, err
+	}
+	heads := make(chan *common.BatchHeader)
+	headsSub, err := subscribeNewHeads(ctx, heads)
+	if err != nil {
+		return nil, err
+	}
+
+	walker := newBatchWalker(oc)
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer func() { headsSub.Unsubscribe() }()
+		lastHeight := head // the batches up to the head at the time of the subscription are not emitted
+		for {
+			select {
+			case <-quit:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-headsSub.Err():
+				headsSub.Unsubscribe()
+				sub, err := resubscribeNewHeads(ctx, quit, heads, subscribeNewHeads)
+				if err != nil {
+					return err
+				}
+				headsSub = sub
+			case h := <-heads:
+				target := h.Number.Uint64()
+				// a head at or below the last height means the batches above were replaced
+				from := min(lastHeight+1, target)
+				for height := from; height <= target; height++ {
+					events, err := walker.next(height)
+					if err != nil {
+						break // the remaining batches are backfilled with the next head
+					}
+					for _, e := range events {
+						select {
+						case ch <- e:
+						case <-quit:
+							return nil
+						case <-ctx.Done():
+							return ctx.Err()
+						}
+					}
+					lastHeight = height
+				}
+			}
+		}
+	}), nil
+}
+
+func resubscribeNewHeads(ctx context.Context, quit <-chan struct{}, heads chan *common.BatchHeader, subscribeNewHeads newHeadsSubscriber) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := retry.Do(func() error {
+		select {
+		case <-quit:
+			return retry.FailFast(errors.New("subscription stopped"))
+		case <-ctx.Done():
+			return retry.FailFast(ctx.Err())
+		default:
+		}
+		var err error
+		sub, err = subscribeNewHeads(ctx, heads)
+		return err
+	}, retry.NewBackoffAndRetryForeverStrategy(_resubscribeIntervals, _resubscribeIntervals[len(_resubscribeIntervals)-1]))
+	return sub, err
+}
+
+// RollupIterator walks the rollups and their batches, see IterateRollups
+type RollupIterator struct {
+	client    *ObsClient
+	nextSeqNo uint64
+
+	event *RollupEvent
+	err   error
+}
+
+// IterateRollups returns an iterator over the rollups, starting with the rollup containing the batch with the given
+// sequence number. The iteration stops at the latest rollup.
+func (oc *ObsClient) IterateRollups(fromBatchSeqNo uint64) *RollupIterator {
+	return &RollupIterator{client: oc, nextSeqNo: fromBatchSeqNo}
+}
+
+// Next advances the iterator to the next rollup, it returns false after the latest rollup or after an error
+func (it *RollupIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	rollup, err := it.client.GetRollupBySeqNo(it.nextSeqNo)
+	if err != nil {
+		if !IsNotFound(err) {
+			it.err = err
+		}
+		return false
+	}
+	batches, err := it.client.GetRollupBatches(gethcommon.HexToHash(rollup.Hash))
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.event = &RollupEvent{Rollup: rollup, Batches: batches.BatchesData}
+	it.nextSeqNo = rollup.LastSeq.Uint64() + 1
+	return true
+}
+
+// Event returns the current rollup and its batches
+func (it *RollupIterator) Event() *RollupEvent {
+	return it.event
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *RollupIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,282 @@
+package obsclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/rpc"
+)
+
+func TestIterateBatchesReemitsReplacedHeights(t *testing.T) {
+	// chain maps the heights to the batches currently served by the mocked node
+	chain := map[uint64]*common.PublicBatch{}
+	extend := func(height uint64, fork byte) {
+		parentHash := gethcommon.Hash{}
+		if parent, ok := chain[height-1]; ok {
+			parentHash = parent.FullHash
+		}
+		chain[height] = &common.PublicBatch{
+			Height:   new(big.Int).SetUint64(height),
+			FullHash: gethcommon.Hash{fork, byte(height)},
+			Header:   &common.BatchHeader{ParentHash: parentHash},
+		}
+	}
+	for height := uint64(1); height <= 3; height++ {
+		extend(height, 'a')
+	}
+
+	mockRPC := new(rpcClientMock)
+	mockRPC.On("Call", mock.Anything, rpc.GetBatchByHeight, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		height := args.Get(2).([]interface{})[0].(*big.Int)
+		*args.Get(0).(**common.PublicBatch) = chain[height.Uint64()]
+	})
+	it := NewObsClient(mockRPC).IterateBatches(big.NewInt(1), big.NewInt(3))
+
+	for height := uint64(1); height <= 2; height++ {
+		require.True(t, it.Next())
+		require.Equal(t, chain[height].FullHash, it.Event().Batch.FullHash)
+		require.False(t, it.Event().Replaced)
+	}
+
+	// the batches at heights 2 and 3 are replaced before height 3 is reached
+	extend(2, 'b')
+	extend(3, 'b')
+
+	require.True(t, it.Next())
+	require.Equal(t, chain[2].FullHash, it.Event().Batch.FullHash)
+	require.True(t, it.Event().Replaced)
+	require.True(t, it.Next())
+	require.Equal(t, chain[3].FullHash, it.Event().Batch.FullHash)
+	require.False(t, it.Event().Replaced)
+
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+}
+
+// testChain - the batches served by the mocked node, by height. The heights in failing can't be fetched.
+type testChain struct {
+	mutex   sync.Mutex
+	batches map[uint64]*common.PublicBatch
+	failing map[uint64]bool
+}
+
+func newTestChain(height uint64) *testChain {
+	c := &testChain{batches: map[uint64]*common.PublicBatch{}, failing: map[uint64]bool{}}
+	for h := uint64(1); h <= height; h++ {
+		c.extend(h)
+	}
+	return c
+}
+
+func (c *testChain) extend(height uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	parentHash := gethcommon.Hash{}
+	if parent, ok := c.batches[height-1]; ok {
+		parentHash = parent.FullHash
+	}
+	c.batches[height] = &common.PublicBatch{
+		Height:   new(big.Int).SetUint64(height),
+		FullHash: gethcommon.Hash{'a', byte(height)},
+		Header:   &common.BatchHeader{Number: new(big.Int).SetUint64(height), ParentHash: parentHash},
+	}
+}
+
+func (c *testChain) setFailing(height uint64, failing bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.failing[height] = failing
+}
+
+func (c *testChain) head() *common.BatchHeader {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.batches[uint64(len(c.batches))].Header
+}
+
+func (c *testChain) client(headHeight uint64) *ObsClient {
+	mockRPC := new(rpcClientMock)
+	mockRPC.On("Call", mock.Anything, rpc.BatchNumber, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(0).(*hexutil.Uint64) = hexutil.Uint64(headHeight)
+	})
+	// the heights which can't be fetched are not found
+	mockRPC.On("Call", mock.Anything, rpc.GetBatchByHeight, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		height := args.Get(2).([]interface{})[0].(*big.Int).Uint64()
+		if !c.failing[height] {
+			*args.Get(0).(**common.PublicBatch) = c.batches[height]
+		}
+	})
+	return NewObsClient(mockRPC)
+}
+
+// headsSubscription - a new heads subscription which can be failed by the test
+type headsSubscription struct {
+	heads        chan<- *common.BatchHeader
+	errCh        chan error
+	unsubscribed chan struct{}
+	once         sync.Once
+}
+
+func (s *headsSubscription) Err() <-chan error {
+	return s.errCh
+}
+
+func (s *headsSubscription) Unsubscribe() {
+	s.once.Do(func() { close(s.unsubscribed) })
+}
+
+// headsSubscriptions - hands out the new heads subscriptions, after failing the next `failures` attempts
+type headsSubscriptions struct {
+	mutex    sync.Mutex
+	failures int
+	subs     chan *headsSubscription
+}
+
+func (s *headsSubscriptions) subscribe(_ context.Context, heads chan<- *common.BatchHeader) (ethereum.Subscription, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return nil, errors.New("connection refused")
+	}
+	sub := &headsSubscription{heads: heads, errCh: make(chan error, 1), unsubscribed: make(chan struct{})}
+	s.subs <- sub
+	return sub, nil
+}
+
+func (s *headsSubscriptions) failNext(failures int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.failures = failures
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out")
+	}
+	var zero T
+	return zero
+}
+
+func requireBatchHeights(t *testing.T, events <-chan *BatchEvent, heights ...uint64) {
+	for _, height := range heights {
+		e := receive(t, events)
+		require.Equal(t, height, e.Batch.Height.Uint64())
+		require.False(t, e.Replaced)
+	}
+}
+
+func TestSubscribeBatchesResubscribesAndBackfills(t *testing.T) {
+	_resubscribeIntervals = []time.Duration{10 * time.Millisecond}
+	t.Cleanup(func() { _resubscribeIntervals = []time.Duration{time.Second, 2 * time.Second, 5 * time.Second} })
+
+	chain := newTestChain(2)
+	subscriptions := &headsSubscriptions{subs: make(chan *headsSubscription, 10)}
+	events := make(chan *BatchEvent)
+	sub, err := chain.client(2).subscribeBatches(context.Background(), events, subscriptions.subscribe)
+	require.NoError(t, err)
+	headsSub := receive(t, subscriptions.subs)
+
+	// the batches up to the head at the time of the subscription are not emitted
+	chain.extend(3)
+	headsSub.heads <- chain.head()
+	requireBatchHeights(t, events, 3)
+
+	// the failed heads subscription is released, and renewed after the failed attempts
+	subscriptions.failNext(2)
+	headsSub.errCh <- errors.New("connection lost")
+	receive(t, headsSub.unsubscribed)
+	headsSub = receive(t, subscriptions.subs)
+
+	// the batches produced while the subscription was down are backfilled
+	chain.extend(4)
+	chain.extend(5)
+	chain.extend(6)
+	headsSub.heads <- chain.head()
+	requireBatchHeights(t, events, 4, 5, 6)
+
+	// the heads subscription is released with the batch subscription
+	sub.Unsubscribe()
+	receive(t, headsSub.unsubscribed)
+}
+
+func TestSubscribeBatchesBackfillsTheBatchesWhichCouldNotBeFetched(t *testing.T) {
+	chain := newTestChain(1)
+	subscriptions := &headsSubscriptions{subs: make(chan *headsSubscription, 10)}
+	events := make(chan *BatchEvent, 10)
+	sub, err := chain.client(1).subscribeBatches(context.Background(), events, subscriptions.subscribe)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	headsSub := receive(t, subscriptions.subs)
+
+	// the batch at height 3 can't be fetched yet, so the emission stops at height 2
+	chain.extend(2)
+	chain.extend(3)
+	chain.setFailing(3, true)
+	headsSub.heads <- chain.head()
+	requireBatchHeights(t, events, 2)
+
+	// the gap is filled with the next head, in order
+	chain.setFailing(3, false)
+	chain.extend(4)
+	headsSub.heads <- chain.head()
+	requireBatchHeights(t, events, 3, 4)
+	require.Empty(t, events)
+}
+
+func TestIterateRollups(t *testing.T) {
+	rollups := map[uint64]*common.PublicRollup{}
+	for i, seqNos := range [][2]uint64{{1, 5}, {6, 8}, {9, 12}} {
+		rollup := &common.PublicRollup{ID: big.NewInt(int64(i)), Hash: gethcommon.Hash{byte(i + 1)}.Hex(), FirstSeq: new(big.Int).SetUint64(seqNos[0]), LastSeq: new(big.Int).SetUint64(seqNos[1])}
+		for seqNo := seqNos[0]; seqNo <= seqNos[1]; seqNo++ {
+			rollups[seqNo] = rollup
+		}
+	}
+	rollupsClient := func(rollupErr error) *ObsClient {
+		mockRPC := new(rpcClientMock)
+		mockRPC.On("Call", mock.Anything, rpc.GetRollupBySeqNo, mock.Anything).Return(rollupErr).Run(func(args mock.Arguments) {
+			if rollupErr == nil {
+				*args.Get(0).(**common.PublicRollup) = rollups[args.Get(2).([]interface{})[0].(uint64)]
+			}
+		})
+		mockRPC.On("Call", mock.Anything, rpc.GetRollupBatches, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(0).(**common.BatchListingResponse) = &common.BatchListingResponse{BatchesData: []common.PublicBatch{{FullHash: args.Get(2).([]interface{})[0].(gethcommon.Hash)}}}
+		})
+		return NewObsClient(mockRPC)
+	}
+
+	// the iteration starts with the rollup containing the batch, and stops after the latest rollup
+	it := rollupsClient(nil).IterateRollups(7)
+	for _, expectedFirstSeq := range []int64{6, 9} {
+		require.True(t, it.Next())
+		require.Equal(t, big.NewInt(expectedFirstSeq), it.Event().Rollup.FirstSeq)
+		require.Equal(t, it.Event().Rollup.Hash, it.Event().Batches[0].FullHash.Hex())
+	}
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+
+	// the not found errors of the node only keep their message over RPC, they also end the iteration
+	it = rollupsClient(errors.New("not found")).IterateRollups(1)
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+
+	// the other errors are reported
+	it = rollupsClient(errors.New("connection refused")).IterateRollups(1)
+	require.False(t, it.Next())
+	require.ErrorContains(t, it.Err(), "connection refused")
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ten-protocol/go-ten/go/common/errutil"
 
 	"github.com/ten-protocol/go-ten/go/common/syserr"
@@ -151,6 +152,25 @@ func DecodeResponse[T any](encoded []byte) (*T, error) {
 	return resp.Result, nil
 }
 
+// AsAccountResponse - wraps the result or the error of one of the accounts of a request with several viewing keys
+func AsAccountResponse[T any](account common.Address, data *T, err error) AccountResponse {
+	resp := AccountResponse{Account: account}
+	if err != nil {
+		resp.Err = convertError(err)
+		return resp
+	}
+	if data == nil {
+		return resp
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		resp.Err = convertError(err)
+		return resp
+	}
+	resp.Result = encoded
+	return resp
+}
+
 func convertError(err error) *errutil.DataError {
 	// check if it's a serialized error and handle any error wrapping that might have occurred
 	var e *errutil.DataError
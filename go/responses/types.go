@@ -1,6 +1,8 @@
 package responses
 
 import (
+	"encoding/json"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -21,6 +23,29 @@ func (ur *UserResponse[T]) Error() error {
 	return ur.Err
 }
 
+// AccountResponse - the result of a request with several viewing keys for one of the accounts. Result is nil when the
+// resource was not found for the account.
+type AccountResponse struct {
+	Account common.Address
+	Result  json.RawMessage
+	Err     *errutil.DataError
+}
+
+// Error - returns the error of the account, if any
+func (ar *AccountResponse) Error() error {
+	if ar.Err == nil {
+		return nil
+	}
+	return ar.Err
+}
+
+// AccountResponses - the response of a request with several viewing keys, one entry per account.
+// It is an object rather than a list, so that a client can tell it apart from the response of an older enclave, which
+// ignores the extra viewing keys and returns the result of the main account.
+type AccountResponses struct {
+	Accounts []AccountResponse
+}
+
 // Responses
 
 type (
@@ -33,6 +33,7 @@ const (
 	GetPublicTransactionData = "scan_getPublicTransactionData"
 	GetBatchListing          = "scan_getBatchListing"
 	GetBlockListing          = "scan_getBlockListing"
+	GetL1BlockByHash         = "scan_getL1BlockByHash"
 	GetBatch                 = "scan_getBatch"
 	GetLatestBatch           = "scan_getLatestBatch"
 	GetBatchByHeight         = "scan_getBatchByHeight"
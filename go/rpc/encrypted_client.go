@@ -26,6 +26,10 @@ import (
 	gethlog "github.com/ethereum/go-ethereum/log"
 )
 
+// ErrMultipleViewingKeysNotSupported - returned when the enclave answered a request with several viewing keys for the
+// main account only, because it predates them. The caller must send a request for each account instead.
+var ErrMultipleViewingKeysNotSupported = errors.New("the enclave does not support requests with multiple viewing keys")
+
 // EncRPCClient is a Client wrapper that implements Client but also has extra functionality for managing viewing key registration and decryption
 type EncRPCClient struct {
 	obscuroClient    Client
@@ -72,29 +76,61 @@ func (c *EncRPCClient) CallContext(ctx context.Context, result interface{}, meth
 	}
 
 	if rpc.IsEncryptedMethod(method) {
-		err := c.executeEncryptedCall(ctx, result, method, args...)
-		// this should only be triggered during testing
-		if err != nil && errors.Is(err, common.FailedDecryptErr) {
-			c.logger.Warn("Reconnecting to new backend. Reading the enclave key.")
-			newKey, err := ReadEnclaveKey(c.obscuroClient)
-			if err != nil {
-				return fmt.Errorf("could not refresh enclave key: %w", err)
-			}
-			enclPubECDSA, err := crypto.DecompressPubkey(newKey)
-			if err != nil {
-				return fmt.Errorf("failed to decompress key for RPC client: %w", err)
-			}
-			c.enclavePublicKey = ecies.ImportECDSAPublic(enclPubECDSA)
-			// retry with the updated key
-			return c.executeEncryptedCall(ctx, result, method, args...)
-		}
-		return err
+		return c.callEncrypted(ctx, result, method, nil, args...)
 	}
 
 	// for non-sensitive methods or when viewing keys are disabled we just delegate directly to the geth RPC client
 	return c.executeRPCCall(ctx, result, method, args...)
 }
 
+// CallContextForVKs executes an encrypted method for the account of the client and for the accounts of the extra
+// viewing keys, in a single request. The extra viewing keys must be signed for the viewing key of the client, because the
+// response is encrypted with it. The responses are returned in the order of the accounts, starting with the client's.
+// It returns ErrMultipleViewingKeysNotSupported if the enclave ignored the extra viewing keys.
+func (c *EncRPCClient) CallContextForVKs(ctx context.Context, extraVKs []*viewingkey.RPCSignedViewingKey, method string, args ...interface{}) ([]responses.AccountResponse, error) {
+	if !rpc.IsEncryptedMethod(method) {
+		return nil, fmt.Errorf("method %s is not an encrypted method", method)
+	}
+	if len(extraVKs) == 0 {
+		return nil, fmt.Errorf("no extra viewing keys for %s call", method)
+	}
+	var result json.RawMessage
+	if err := c.callEncrypted(ctx, &result, method, extraVKs, args...); err != nil {
+		return nil, err
+	}
+	return DecodeAccountResponses(result, len(extraVKs)+1)
+}
+
+// DecodeAccountResponses decodes the response of a request with the viewing keys of nrAccounts accounts. An older
+// enclave returns the plain result of the main account instead, which is reported as ErrMultipleViewingKeysNotSupported.
+func DecodeAccountResponses(result json.RawMessage, nrAccounts int) ([]responses.AccountResponse, error) {
+	var accountResponses responses.AccountResponses
+	if err := json.Unmarshal(result, &accountResponses); err != nil || len(accountResponses.Accounts) != nrAccounts {
+		return nil, ErrMultipleViewingKeysNotSupported
+	}
+	return accountResponses.Accounts, nil
+}
+
+func (c *EncRPCClient) callEncrypted(ctx context.Context, result interface{}, method string, extraVKs []*viewingkey.RPCSignedViewingKey, args ...interface{}) error {
+	err := c.executeEncryptedCall(ctx, result, method, extraVKs, args...)
+	// this should only be triggered during testing
+	if err != nil && errors.Is(err, common.FailedDecryptErr) {
+		c.logger.Warn("Reconnecting to new backend. Reading the enclave key.")
+		newKey, err := ReadEnclaveKey(c.obscuroClient)
+		if err != nil {
+			return fmt.Errorf("could not refresh enclave key: %w", err)
+		}
+		enclPubECDSA, err := crypto.DecompressPubkey(newKey)
+		if err != nil {
+			return fmt.Errorf("failed to decompress key for RPC client: %w", err)
+		}
+		c.enclavePublicKey = ecies.ImportECDSAPublic(enclPubECDSA)
+		// retry with the updated key
+		return c.executeEncryptedCall(ctx, result, method, extraVKs, args...)
+	}
+	return err
+}
+
 func (c *EncRPCClient) Subscribe(ctx context.Context, namespace string, ch interface{}, args ...interface{}) (*gethrpc.ClientSubscription, error) {
 	if len(args) == 0 {
 		return nil, fmt.Errorf("missing subscription type")
@@ -110,15 +146,15 @@ func (c *EncRPCClient) Subscribe(ctx context.Context, namespace string, ch inter
 	}
 }
 
-func (c *EncRPCClient) executeEncryptedCall(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+func (c *EncRPCClient) executeEncryptedCall(ctx context.Context, result interface{}, method string, extraVKs []*viewingkey.RPCSignedViewingKey, args ...interface{}) error {
 	// encode the params into a json blob and encrypt them
-	encryptedParams, err := c.encryptArgs(method, args...)
+	encryptedParams, err := c.encryptArgs(method, extraVKs, args...)
 	if err != nil {
 		return fmt.Errorf("failed to encrypt args for %s call - %w", method, err)
 	}
 
 	// we need to inform the TEN node that the call is a transaction because it needs to broadcast it
-	isTx := method == rpc.ERPCSendRawTransaction
+	isTx := method == rpc.ERPCSendRawTransaction || method == rpc.ERPCResend
 
 	var rawResult responses.EnclaveResponse
 	err = c.executeRPCCall(ctx, &rawResult, rpc.EncRPC, common.EncryptedRPCRequest{Req: encryptedParams, IsTx: isTx})
@@ -197,7 +233,7 @@ func (c *EncRPCClient) Account() *gethcommon.Address {
 	return c.viewingKey.Account
 }
 
-func (c *EncRPCClient) encryptArgs(method string, args ...interface{}) ([]byte, error) {
+func (c *EncRPCClient) encryptArgs(method string, extraVKs []*viewingkey.RPCSignedViewingKey, args ...interface{}) ([]byte, error) {
 	if len(args) == 0 {
 		return nil, nil
 	}
@@ -206,7 +242,7 @@ func (c *EncRPCClient) encryptArgs(method string, args ...interface{}) ([]byte,
 		SignatureWithAccountKey: c.viewingKey.SignatureWithAccountKey,
 		SignatureType:           c.viewingKey.SignatureType,
 	}
-	argsWithVK := &rpc.RequestWithVk{VK: &vk, Method: method, Params: args}
+	argsWithVK := &rpc.RequestWithVk{VK: &vk, ExtraVKs: extraVKs, Method: method, Params: args}
 
 	paramsJSON, err := json.Marshal(argsWithVK)
 	if err != nil {
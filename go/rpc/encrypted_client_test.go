@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ten-protocol/go-ten/go/responses"
+)
+
+func TestDecodeAccountResponses(t *testing.T) {
+	encoded, err := json.Marshal(responses.AccountResponses{Accounts: []responses.AccountResponse{
+		{Account: gethcommon.Address{1}, Result: json.RawMessage(`[]`)},
+		{Account: gethcommon.Address{2}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountResponses, err := DecodeAccountResponses(encoded, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accountResponses) != 2 || accountResponses[1].Account != (gethcommon.Address{2}) {
+		t.Errorf("unexpected account responses %v", accountResponses)
+	}
+
+	// the responses of an older enclave, which only executed the request for the main account
+	for _, result := range []string{`[{"address":"0x0000000000000000000000000000000000000001"}]`, `[]`, `{"status":"0x1"}`, ``} {
+		if _, err := DecodeAccountResponses(json.RawMessage(result), 2); !errors.Is(err, ErrMultipleViewingKeysNotSupported) {
+			t.Errorf("expected the extra viewing keys to be unsupported for the result %q, got %v", result, err)
+		}
+	}
+}
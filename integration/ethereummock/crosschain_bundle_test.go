@@ -0,0 +1,104 @@
+package ethereummock
+
+import (
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
+	hostconfig "github.com/ten-protocol/go-ten/go/host/config"
+	"github.com/ten-protocol/go-ten/go/host/l1"
+	"github.com/ten-protocol/go-ten/go/host/storage"
+	"github.com/ten-protocol/go-ten/integration"
+	"github.com/ten-protocol/go-ten/integration/datagenerator"
+	"github.com/ten-protocol/go-ten/integration/simulation/stats"
+)
+
+// countingEthClient - counts the transactions sent to the L1
+type countingEthClient struct {
+	*Node
+	sent atomic.Int32
+}
+
+func (c *countingEthClient) SendTransaction(tx *types.Transaction) error {
+	c.sent.Add(1)
+	return c.Node.SendTransaction(tx)
+}
+
+func TestPublishCrossChainBundle(t *testing.T) {
+	logger := log.New(log.EthereumL1Cmp, int(gethlog.LvlInfo), log.SysOut)
+	blobResolver := NewMockBlobResolver()
+	stats := stats.NewStats(2)
+
+	// the txs are gossiped to the other nodes only, so the network needs two miners. The second one is faster, so
+	// the chain does not fork
+	powTimes := []time.Duration{250 * time.Millisecond, 50 * time.Millisecond}
+	nodes := make([]*Node, len(powTimes))
+	for i := range nodes {
+		powTime := powTimes[i]
+		cfg := MiningConfig{PowTime: func() time.Duration { return powTime }}
+		network := NewMockEthNetwork(50*time.Millisecond, 5*time.Millisecond, stats)
+		nodes[i] = NewMiner(gethcommon.BigToAddress(big.NewInt(int64(i))), cfg, network, stats, blobResolver, logger)
+		network.CurrentNode = nodes[i]
+	}
+	for _, n := range nodes {
+		n.Network.(*MockEthNetwork).AllNodes = nodes
+		go n.Start()
+	}
+	defer func() {
+		for _, n := range nodes {
+			n.Stop()
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		head, _ := nodes[0].FetchHeadBlock()
+		return head != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	client := &countingEthClient{Node: nodes[0]}
+	contractLib := NewMgmtContractLibMock()
+	hostStorage := storage.NewHostStorageFromConfig(&hostconfig.HostConfig{ID: "crosschain-bundle", UseInMemoryDB: true}, logger)
+	defer hostStorage.Close()
+	publisher := l1.NewL1Publisher(host.Identity{}, datagenerator.RandomWallet(integration.EthereumChainID), client, contractLib,
+		nil, blobResolver, stopcontrol.New(), logger, time.Second, 50*time.Millisecond, hostStorage)
+
+	rollup := &common.ExtRollup{Header: &common.RollupHeader{CompressionL1Number: big.NewInt(0), LastBatchSeqNo: 10}}
+	require.NoError(t, publisher.PublishRollup(rollup))
+
+	bundle := &common.ExtCrossChainBundle{
+		L1BlockNum:           big.NewInt(0),
+		CrossChainRootHashes: common.CrossChainRootHashes{gethcommon.HexToHash("0x01").Bytes(), gethcommon.HexToHash("0x02").Bytes()},
+	}
+	isAvailable := func() bool {
+		msg, err := contractLib.IsBundleAvailableMsg(bundle)
+		require.NoError(t, err)
+		resp, err := client.CallContract(msg)
+		require.NoError(t, err)
+		available, err := contractLib.DecodeIsBundleAvailableResponse(resp)
+		require.NoError(t, err)
+		return available
+	}
+
+	// a bundle bound to a rollup that was not published is never available
+	require.NoError(t, publisher.PublishCrossChainBundle(bundle, big.NewInt(10), gethcommon.HexToHash("0xdead")))
+	// a bundle without roots is not published
+	require.NoError(t, publisher.PublishCrossChainBundle(&common.ExtCrossChainBundle{}, big.NewInt(10), rollup.Hash()))
+	require.Equal(t, int32(2), client.sent.Load())
+
+	require.NoError(t, publisher.PublishCrossChainBundle(bundle, big.NewInt(10), rollup.Hash()))
+	require.Equal(t, int32(3), client.sent.Load())
+	require.Eventually(t, isAvailable, 10*time.Second, 50*time.Millisecond)
+
+	// publishing the same bundle again is a no-op
+	require.NoError(t, publisher.PublishCrossChainBundle(bundle, big.NewInt(10), rollup.Hash()))
+	require.Equal(t, int32(3), client.sent.Load())
+}
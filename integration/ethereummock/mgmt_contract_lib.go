@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/gob"
 	"fmt"
+	"math/big"
 
 	"github.com/ten-protocol/go-ten/go/common"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
 )
 
@@ -28,6 +30,7 @@ var (
 	requestSecretTxAddr    = datagenerator.RandomAddress()
 	initializeSecretTxAddr = datagenerator.RandomAddress()
 	grantSeqTxAddr         = datagenerator.RandomAddress()
+	crossChainBundleTxAddr = datagenerator.RandomAddress()
 
 	messageBusAddr = datagenerator.RandomAddress()
 
@@ -47,6 +50,13 @@ var (
 	}
 )
 
+// crossChainBundleTx - the mocked call data of a cross chain bundle publication
+type crossChainBundleTx struct {
+	Bundle    common.ExtCrossChainBundle
+	RollupNum *big.Int
+	ForkID    gethcommon.Hash
+}
+
 // mockContractLib is an implementation of the mgmtcontractlib.MgmtContractLib
 // it creates ethereum mocked transactions from common.L1Transaction
 // and converts ethereum mocked transactions to common.L1Transaction
@@ -75,6 +85,11 @@ func (m *mockContractLib) DecodeTx(tx *types.Transaction) common.L1TenTransactio
 		return nil
 	}
 
+	// the cross chain bundles are only consumed by the L1 contracts
+	if tx.To().Hex() == crossChainBundleTxAddr.Hex() {
+		return nil
+	}
+
 	if tx.To().Hex() == rollupTxAddr.Hex() {
 		return &common.L1RollupHashes{
 			BlobHashes: tx.BlobHashes(),
@@ -125,6 +140,18 @@ func (m *mockContractLib) CreateInitializeSecret(tx *common.L1InitializeSecretTx
 	return encodeTx(tx, initializeSecretTxAddr)
 }
 
+func (m *mockContractLib) CreateCrossChainBundle(bundle *common.ExtCrossChainBundle, rollupNum *big.Int, forkID gethcommon.Hash) (types.TxData, error) {
+	return encodeTx(&crossChainBundleTx{Bundle: *bundle, RollupNum: rollupNum, ForkID: forkID}, crossChainBundleTxAddr), nil
+}
+
+func (m *mockContractLib) IsBundleAvailableMsg(bundle *common.ExtCrossChainBundle) (ethereum.CallMsg, error) {
+	return ethereum.CallMsg{To: &crossChainBundleTxAddr, Data: bundleHash(bundle.CrossChainRootHashes).Bytes()}, nil
+}
+
+func (m *mockContractLib) DecodeIsBundleAvailableResponse(callResponse []byte) (bool, error) {
+	return len(callResponse) == 1 && callResponse[0] == 1, nil
+}
+
 func (m *mockContractLib) GetHostAddressesMsg() (ethereum.CallMsg, error) {
 	return ethereum.CallMsg{}, nil
 }
@@ -157,6 +184,19 @@ func MockGrantSeqTxAddress() gethcommon.Address {
 	return grantSeqTxAddr
 }
 
+// bundleHash - identifies the cross chain roots of a bundle
+func bundleHash(roots common.CrossChainRootHashes) gethcommon.Hash {
+	return crypto.Keccak256Hash(roots...)
+}
+
+func decodeBundleTx(tx *types.Transaction) (*crossChainBundleTx, error) {
+	var t crossChainBundleTx
+	if err := gob.NewDecoder(bytes.NewBuffer(tx.Data())).Decode(&t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
 func decodeTx(tx *types.Transaction) common.L1TenTransaction {
 	if len(tx.Data()) == 0 {
 		panic("Data cannot be 0 in the mock implementation")
@@ -36,7 +36,7 @@ func NewMockBlobResolver() l1.BlobResolver {
 	}
 }
 
-func (b *BlobResolverInMem) StoreBlobs(_ uint64, blobs []*kzg4844.Blob) error {
+func (b *BlobResolverInMem) StoreBlobs(_ context.Context, blobs []*kzg4844.Blob) error {
 	for _, blob := range blobs {
 		versionedHash, _, _, err := MockBlobHasher{}.BlobHash(blob)
 		if err != nil {
@@ -583,8 +583,58 @@ func (m *Node) BlocksBetween(blockA *types.Header, blockB *types.Header) ([]*typ
 	return result, nil
 }
 
-func (m *Node) CallContract(ethereum.CallMsg) ([]byte, error) {
-	return nil, nil
+// CallContract - only the cross chain bundle availability is mocked. A bundle is available when it was published on
+// the canonical chain, bound to a rollup that is also on the canonical chain.
+func (m *Node) CallContract(msg ethereum.CallMsg) ([]byte, error) {
+	if msg.To == nil || msg.To.Hex() != crossChainBundleTxAddr.Hex() {
+		return nil, nil
+	}
+	if m.isBundleAvailable(gethcommon.BytesToHash(msg.Data)) {
+		return []byte{1}, nil
+	}
+	return []byte{0}, nil
+}
+
+func (m *Node) isBundleAvailable(hash gethcommon.Hash) bool {
+	head, err := m.FetchHeadBlock()
+	if err != nil || head == nil {
+		return false
+	}
+
+	blk, err := m.BlockByHash(head.Hash())
+	if err != nil {
+		return false
+	}
+
+	// the rollups the published bundles are bound to, by rollup hash
+	forks := make(map[gethcommon.Hash]*big.Int)
+	for {
+		for _, tx := range blk.Transactions() {
+			if tx.To() == nil || tx.To().Hex() != crossChainBundleTxAddr.Hex() {
+				continue
+			}
+			bundleTx, err := decodeBundleTx(tx)
+			if err != nil || bundleHash(bundleTx.Bundle.CrossChainRootHashes) != hash {
+				continue
+			}
+			forks[bundleTx.ForkID] = bundleTx.RollupNum
+		}
+		if len(forks) > 0 {
+			if rollup := m.getRollupFromBlock(blk); rollup != nil {
+				if seqNo, found := forks[rollup.Hash()]; found && seqNo.Uint64() == rollup.Header.LastBatchSeqNo {
+					return true
+				}
+			}
+		}
+		if blk.NumberU64() == 0 {
+			return false
+		}
+		blk, err = m.BlockByHash(blk.ParentHash())
+		if err != nil {
+			m.logger.Error("Error fetching block by hash", "error", err)
+			return false
+		}
+	}
 }
 
 func (m *Node) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
@@ -628,8 +678,7 @@ func (m *Node) ProcessBlobs(b *types.Block) error {
 	copy(blobPointers, blobs)
 
 	if len(blobs) > 0 {
-		// dummy slot to simplify the in memory blob storage
-		err := m.BlobResolver.StoreBlobs(0, blobs)
+		err := m.BlobResolver.StoreBlobs(context.Background(), blobs)
 		if err != nil {
 			return fmt.Errorf("could not store blobs. Cause: %w", err)
 		}
@@ -139,6 +139,7 @@ func (n *InMemNodeOperator) createHostContainer() *hostcontainer.HostContainer {
 		EnclaveRPCAddresses:       enclaveAddresses,
 		P2PBindAddress:            p2pAddr,
 		P2PPublicAddress:          p2pAddr,
+		P2PExemptLoopback:         true,
 		EnclaveRPCTimeout:         network.EnclaveClientRPCTimeout,
 		L1RPCTimeout:              network.DefaultL1RPCTimeout,
 		ManagementContractAddress: n.l1Data.MgmtContractAddress,
@@ -163,7 +164,7 @@ func (n *InMemNodeOperator) createHostContainer() *hostcontainer.HostContainer {
 	// create a socket P2P layer
 	p2pLogger := hostLogger.New(log.CmpKey, log.P2PCmp)
 	svcLocator := host.NewServicesRegistry(n.logger)
-	nodeP2p := p2p.NewSocketP2PLayer(hostConfig, svcLocator, p2pLogger, nil)
+	nodeP2p := p2p.NewSocketP2PLayer(hostConfig, svcLocator, n.l1Wallet, p2pLogger, nil)
 
 	var enclaveClients []common.Enclave
 	for i, enclaveAddr := range hostConfig.EnclaveRPCAddresses {
@@ -184,7 +185,7 @@ func (n *InMemNodeOperator) createHostContainer() *hostcontainer.HostContainer {
 		l1.MgmtContract: {hostConfig.ManagementContractAddress},
 		l1.MsgBus:       {hostConfig.MessageBusAddress},
 	}
-	blobResolver := l1.NewBlobResolver(ethadapter.NewL1BeaconClient(ethadapter.NewBeaconHTTPClient(new(http.Client), fmt.Sprintf("127.0.0.1:%d", n.config.L1BeaconPort))))
+	blobResolver := l1.NewBlobResolver(ethadapter.NewL1BeaconClient(ethadapter.NewBeaconHTTPClient(new(http.Client), fmt.Sprintf("127.0.0.1:%d", n.config.L1BeaconPort))), nil, n.logger)
 	l1Data := l1.NewL1DataService(n.l1Client, n.logger, mgmtContractLib, blobResolver, contractAddresses)
 	return hostcontainer.NewHostContainer(hostConfig, svcLocator, nodeP2p, n.l1Client, l1Data, enclaveClients, mgmtContractLib, n.l1Wallet, rpcServer, hostLogger, metrics.New(false, 0, n.logger), blobResolver)
 }
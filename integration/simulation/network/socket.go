@@ -65,7 +65,7 @@ func (n *networkOfSocketNodes) Create(simParams *params.SimParams, _ *stats.Stat
 	)
 	beaconURL := fmt.Sprintf("127.0.0.1:%d", simParams.L1BeaconPort)
 	simParams.BlobResolver = l1.NewBlobResolver(ethadapter.NewL1BeaconClient(
-		ethadapter.NewBeaconHTTPClient(new(http.Client), beaconURL)))
+		ethadapter.NewBeaconHTTPClient(new(http.Client), beaconURL)), ethadapter.NewInMemBlobArchiveStore(), testlog.Logger())
 
 	// get the sequencer Address
 	seqPrivateKey := n.wallets.NodeWallets[0].PrivateKey()
@@ -2,7 +2,9 @@ package p2p
 
 import (
 	"context"
+	"errors"
 	"math/big"
+	"math/rand"
 	"strconv"
 	"sync/atomic"
 	"time"
@@ -48,9 +50,28 @@ func (m *MockP2PNetwork) NewNode(id int) host.P2PHostService {
 	return node
 }
 
-func (m *MockP2PNetwork) RequestBatchesFromSequencer(id string, fromSeqNo *big.Int) {
-	seqNode := m.nodes[_sequencerID]
-	async.Schedule(m.delay()/2, func() { seqNode.ReceiveBatchRequest(id, fromSeqNo) })
+func (m *MockP2PNetwork) RequestBatchesFromSequencer(id string, fromSeqNo *big.Int, toSeqNo *big.Int) {
+	m.RequestBatches(id, _sequencerID, fromSeqNo, toSeqNo)
+}
+
+// RequestBatchesFromPeer requests the batches from a random validator other than the requester
+func (m *MockP2PNetwork) RequestBatchesFromPeer(id string, fromSeqNo *big.Int, toSeqNo *big.Int) error {
+	var peers []string
+	for peerID, node := range m.nodes {
+		if peerID != id && peerID != _sequencerID && !node.isIncomingP2PDisabled {
+			peers = append(peers, peerID)
+		}
+	}
+	if len(peers) == 0 {
+		return errors.New("no validator peers to request batches from")
+	}
+	m.RequestBatches(id, peers[rand.Intn(len(peers))], fromSeqNo, toSeqNo) //nolint:gosec
+	return nil
+}
+
+func (m *MockP2PNetwork) RequestBatches(id string, peerID string, fromSeqNo *big.Int, toSeqNo *big.Int) {
+	peer := m.nodes[peerID]
+	async.Schedule(m.delay()/2, func() { peer.ReceiveBatchRequest(id, fromSeqNo, toSeqNo) })
 }
 
 func (m *MockP2PNetwork) SendTransactionToSequencer(tx common.EncryptedTx) {
@@ -172,7 +193,7 @@ func (n *MockP2P) SubscribeForBatchRequests(handler host.P2PBatchRequestHandler)
 	return n.batchReqHandlers.Subscribe(handler)
 }
 
-func (n *MockP2P) RequestBatchesFromSequencer(fromSeqNo *big.Int) error {
+func (n *MockP2P) RequestBatchesFromSequencer(fromSeqNo *big.Int, toSeqNo *big.Int) error {
 	if n.isIncomingP2PDisabled {
 		return nil
 	}
@@ -180,10 +201,21 @@ func (n *MockP2P) RequestBatchesFromSequencer(fromSeqNo *big.Int) error {
 	if atomic.LoadInt32(n.listenerInterrupt) == 1 {
 		return nil
 	}
-	n.network.RequestBatchesFromSequencer(n.id, fromSeqNo)
+	n.network.RequestBatchesFromSequencer(n.id, fromSeqNo, toSeqNo)
 	return nil
 }
 
+func (n *MockP2P) RequestBatchesFromPeer(fromSeqNo *big.Int, toSeqNo *big.Int) error {
+	if n.isIncomingP2PDisabled {
+		return nil
+	}
+
+	if atomic.LoadInt32(n.listenerInterrupt) == 1 {
+		return nil
+	}
+	return n.network.RequestBatchesFromPeer(n.id, fromSeqNo, toSeqNo)
+}
+
 func (n *MockP2P) RespondToBatchRequest(requesterID string, batches []*common.ExtBatch) error {
 	if n.isIncomingP2PDisabled {
 		return nil
@@ -215,13 +247,13 @@ func (n *MockP2P) ReceiveBatches(batches []*common.ExtBatch, isLive bool) {
 }
 
 // ReceiveBatchRequest is a mock method that simulates receiving a batch request from a peer and then forwarding to all subscribers
-func (n *MockP2P) ReceiveBatchRequest(requestID string, fromSeqNo *big.Int) {
+func (n *MockP2P) ReceiveBatchRequest(requestID string, fromSeqNo *big.Int, toSeqNo *big.Int) {
 	if n.isIncomingP2PDisabled {
 		return
 	}
 
 	for _, sub := range n.batchReqHandlers.Subscribers() {
-		sub.HandleBatchRequest(requestID, fromSeqNo)
+		sub.HandleBatchRequest(requestID, fromSeqNo, toSeqNo)
 	}
 }
 
@@ -2,6 +2,7 @@ package simulation
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"sort"
@@ -37,6 +38,7 @@ import (
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/tracers"
 )
 
 const (
@@ -443,6 +445,7 @@ func checkBlockchainOfTenNode(t *testing.T, rpcHandles *network.RPCHandles, minT
 	}
 
 	checkTransactionReceipts(s.ctx, t, nodeIdx, rpcHandles, s.TxInjector)
+	checkTransactionTraces(s.ctx, t, nodeIdx, rpcHandles, s.TxInjector)
 	totalSuccessfullyWithdrawn := extractWithdrawals(t, tenClient, nodeIdx)
 
 	// todo: @siliev check that the total amount deposited is the same as the total amount withdraw
@@ -616,6 +619,49 @@ func checkTransactionReceipts(ctx context.Context, t *testing.T, nodeIdx int, rp
 	}
 }
 
+// checkTransactionTraces - the sender of a transaction can trace it, while the other users can't
+func checkTransactionTraces(ctx context.Context, t *testing.T, nodeIdx int, rpcHandles *network.RPCHandles, txInjector *TransactionInjector) {
+	callTracer := "callTracer"
+	traceCfg := &tracers.TraceConfig{Tracer: &callTracer}
+	for _, tx := range txInjector.TxTracker.TransferL2Transactions {
+		sender := getSender(tx)
+		senderClient := rpcHandles.TenWalletClient(sender, nodeIdx)
+		receipt, err := senderClient.TransactionReceipt(ctx, tx.Hash())
+		if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+
+		trace, err := senderClient.TraceTransaction(ctx, tx.Hash(), traceCfg)
+		if err != nil {
+			t.Errorf("node %d: could not trace transaction %s. Cause: %s", nodeIdx, tx.Hash().Hex(), err)
+			return
+		}
+		var callFrame struct {
+			From gethcommon.Address `json:"from"`
+			To   gethcommon.Address `json:"to"`
+		}
+		if err := json.Unmarshal(trace, &callFrame); err != nil {
+			t.Errorf("node %d: invalid trace for transaction %s. Cause: %s", nodeIdx, tx.Hash().Hex(), err)
+			return
+		}
+		if callFrame.From != sender || callFrame.To != *tx.To() {
+			t.Errorf("node %d: the trace of transaction %s does not match the transaction", nodeIdx, tx.Hash().Hex())
+		}
+
+		for _, w := range txInjector.wallets.SimObsWallets {
+			if w.Address() == sender {
+				continue
+			}
+			if _, err := rpcHandles.TenWalletClient(w.Address(), nodeIdx).TraceTransaction(ctx, tx.Hash(), traceCfg); err == nil {
+				t.Errorf("node %d: transaction %s was traced by an account that did not send it", nodeIdx, tx.Hash().Hex())
+			}
+			break
+		}
+		// tracing re-executes the batch, so a single transaction is enough
+		return
+	}
+}
+
 func extractWithdrawals(t *testing.T, tenClient *obsclient.ObsClient, nodeIdx int) (totalSuccessfullyWithdrawn *big.Int) {
 	totalSuccessfullyWithdrawn = big.NewInt(0)
 	header, err := getHeadBatchHeader(tenClient)
@@ -116,7 +116,8 @@ func TestTenGateway(t *testing.T) {
 		"testDifferentMessagesOnRegister":      testDifferentMessagesOnRegister,
 		"testInvokeNonSensitiveMethod":         testInvokeNonSensitiveMethod,
 		// "testRateLimiter":                      testRateLimiter,
-		"testSessionKeys": testSessionKeys,
+		"testSessionKeys":                   testSessionKeys,
+		"testSendRawTxWithMultipleAccounts": testSendRawTxWithMultipleAccounts,
 	} {
 		t.Run(name, func(t *testing.T) {
 			test(t, startPort, httpURL, wsURL, w)
@@ -332,6 +333,36 @@ func testNewHeadsSubscription(t *testing.T, _ int, httpURL, wsURL string, w wall
 	require.True(t, len(receivedHeads) > 1)
 }
 
+func testSendRawTxWithMultipleAccounts(t *testing.T, _ int, httpURL, wsURL string, w wallet.Wallet) {
+	unfundedWallet := datagenerator.RandomWallet(integration.TenChainID)
+	user, err := NewGatewayUser([]wallet.Wallet{unfundedWallet, w}, httpURL, wsURL)
+	require.NoError(t, err)
+	err = user.RegisterAccountsPersonalSign()
+	require.NoError(t, err)
+
+	// the validation error of the transaction is returned to the user, instead of the transaction being submitted
+	// once for each account of the user
+	to := datagenerator.RandomAddress()
+	unfundedTx := &types.LegacyTx{
+		Nonce:    unfundedWallet.GetNonceAndIncrement(),
+		To:       &to,
+		Value:    big.NewInt(1),
+		Gas:      uint64(1_000_000),
+		GasPrice: gethcommon.Big1,
+	}
+	err = getFeeAndGas(user.HTTPClient, unfundedWallet, unfundedTx)
+	require.NoError(t, err)
+	signedTx, err := unfundedWallet.SignTransaction(unfundedTx)
+	require.NoError(t, err)
+	err = user.HTTPClient.SendTransaction(context.Background(), signedTx)
+	require.Error(t, err)
+
+	// the transactions of any of the accounts are submitted
+	receipt, err := transferETHToAddress(user.HTTPClient, w, to, 1)
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+}
+
 func testMultipleAccountsSubscription(t *testing.T, _ int, httpURL, wsURL string, w wallet.Wallet) {
 	user0, err := NewGatewayUser([]wallet.Wallet{w, datagenerator.RandomWallet(integration.TenChainID)}, httpURL, wsURL)
 	require.NoError(t, err)
@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/params"
 
@@ -34,6 +35,26 @@ const (
 	defaultAmountName    = "defaultAmount"
 	defaultAmountDefault = 100.0
 	defaultAmountUsage   = "Default amount of token to fund (in ETH)"
+
+	tokensName    = "tokens"
+	tokensDefault = ""
+	tokensUsage   = "The ERC20 tokens that can be funded, as a comma separated list of symbol:address:amount (amount in the smallest unit of the token)"
+
+	addressCooldownName    = "addressCooldown"
+	addressCooldownDefault = 0
+	addressCooldownUsage   = "The minimum time between two fundings of the same address. Default: 0 (disabled)."
+
+	ipCooldownName    = "ipCooldown"
+	ipCooldownDefault = 0
+	ipCooldownUsage   = "The minimum time between two fundings requested from the same IP. Default: 0 (disabled)."
+
+	dbPathName    = "dbPath"
+	dbPathDefault = ""
+	dbPathUsage   = "The path of the sqlite funding log. Default: in memory."
+
+	trustedProxiesName    = "trustedProxies"
+	trustedProxiesDefault = ""
+	trustedProxiesUsage   = "The comma separated IPs or CIDRs of the proxies trusted to set the client IP in the forwarding headers. Default: none."
 )
 
 func parseCLIArgs() *faucet.Config {
@@ -43,8 +64,18 @@ func parseCLIArgs() *faucet.Config {
 	jwtSecret := flag.String(jwtSecretName, jwtSecretDefault, jwtSecretUsage)
 	serverPort := flag.Int(serverPortName, serverPortDefault, serverPortUsage)
 	defaultAmount := flag.Float64(defaultAmountName, defaultAmountDefault, defaultAmountUsage)
+	tokens := flag.String(tokensName, tokensDefault, tokensUsage)
+	addressCooldown := flag.Duration(addressCooldownName, addressCooldownDefault, addressCooldownUsage)
+	ipCooldown := flag.Duration(ipCooldownName, ipCooldownDefault, ipCooldownUsage)
+	dbPath := flag.String(dbPathName, dbPathDefault, dbPathUsage)
+	trustedProxies := flag.String(trustedProxiesName, trustedProxiesDefault, trustedProxiesUsage)
 	flag.Parse()
 
+	tokenRegistry, err := faucet.ParseTokenRegistry(*tokens)
+	if err != nil {
+		panic(err)
+	}
+
 	return &faucet.Config{
 		Host:              *nodeHost,
 		HTTPPort:          *nodeHTTPPort,
@@ -53,7 +84,22 @@ func parseCLIArgs() *faucet.Config {
 		ServerPort:        *serverPort,
 		ChainID:           big.NewInt(443), // TODO make this configurable
 		DefaultFundAmount: toWei(defaultAmount),
+		Tokens:            tokenRegistry,
+		AddressCooldown:   *addressCooldown,
+		IPCooldown:        *ipCooldown,
+		DBPath:            *dbPath,
+		TrustedProxies:    parseTrustedProxies(*trustedProxies),
+	}
+}
+
+func parseTrustedProxies(proxies string) []string {
+	var trustedProxies []string
+	for _, proxy := range strings.Split(proxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
 	}
+	return trustedProxies
 }
 
 func toWei(amount *float64) *big.Int {
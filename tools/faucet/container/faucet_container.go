@@ -16,12 +16,21 @@ func NewFaucetContainerFromConfig(cfg *faucet.Config) (*FaucetContainer, error)
 	// we connect to the node via HTTP (config HTTPPort must not be the WSPort for the host)
 	nodeAddr := fmt.Sprintf("http://%s:%d", cfg.Host, cfg.HTTPPort)
 
-	f, err := faucet.NewFaucet(nodeAddr, cfg.ChainID.Int64(), cfg.PK[2:])
+	fundingLog, err := faucet.NewFundingLog(cfg.DBPath)
+	if err != nil {
+		return nil, err
+	}
+	cooldowns := faucet.NewCooldowns(cfg.AddressCooldown, cfg.IPCooldown)
+
+	f, err := faucet.NewFaucet(nodeAddr, cfg.ChainID.Int64(), cfg.PK[2:], cfg.Tokens, cooldowns, fundingLog)
 	if err != nil {
 		return nil, err
 	}
 	bindAddress := fmt.Sprintf(":%d", cfg.ServerPort)
-	server := webserver.NewWebServer(f, bindAddress, []byte(cfg.JWTSecret), cfg.DefaultFundAmount)
+	server, err := webserver.NewWebServer(f, bindAddress, []byte(cfg.JWTSecret), cfg.DefaultFundAmount, cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
 
 	return NewFaucetContainer(f, server)
 }
@@ -38,5 +47,8 @@ func (c *FaucetContainer) Start() error {
 }
 
 func (c *FaucetContainer) Stop() error {
-	return c.webServer.Stop()
+	if err := c.webServer.Stop(); err != nil {
+		return err
+	}
+	return c.faucetServer.Stop()
 }
@@ -0,0 +1,91 @@
+package faucet
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CooldownError is returned when an address or IP requests funds again before its cooldown expired
+type CooldownError struct {
+	Key       string
+	Remaining time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("%s was funded recently, try again in %s", e.Key, e.Remaining.Round(time.Second))
+}
+
+// Cooldowns throttles the funding requests per address and per IP. A zero cooldown disables the check.
+type Cooldowns struct {
+	addressCooldown time.Duration
+	ipCooldown      time.Duration
+
+	mu         sync.Mutex
+	lastFunded map[string]time.Time
+}
+
+func NewCooldowns(addressCooldown, ipCooldown time.Duration) *Cooldowns {
+	return &Cooldowns{
+		addressCooldown: addressCooldown,
+		ipCooldown:      ipCooldown,
+		lastFunded:      map[string]time.Time{},
+	}
+}
+
+// Reserve records a funding of the address from the IP, unless one of them is still cooling down.
+// The returned function reverts the reservation, to be called when the funding fails.
+func (c *Cooldowns) Reserve(address string, ip string) (func(), error) {
+	now := time.Now()
+	keys := make(map[string]time.Duration, 2)
+	if c.addressCooldown > 0 {
+		keys["address "+strings.ToLower(address)] = c.addressCooldown
+	}
+	if c.ipCooldown > 0 && ip != "" {
+		keys["ip "+ip] = c.ipCooldown
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prune(now)
+
+	for key, cooldown := range keys {
+		if last, ok := c.lastFunded[key]; ok && now.Sub(last) < cooldown {
+			return nil, &CooldownError{Key: key, Remaining: cooldown - now.Sub(last)}
+		}
+	}
+
+	previous := make(map[string]time.Time, len(keys))
+	for key := range keys {
+		if last, ok := c.lastFunded[key]; ok {
+			previous[key] = last
+		}
+		c.lastFunded[key] = now
+	}
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for key := range keys {
+			if c.lastFunded[key] != now {
+				continue // reserved again in the meantime
+			}
+			if last, ok := previous[key]; ok {
+				c.lastFunded[key] = last
+			} else {
+				delete(c.lastFunded, key)
+			}
+		}
+	}, nil
+}
+
+// prune drops the entries whose cooldown expired, so the map does not grow with every funded address
+func (c *Cooldowns) prune(now time.Time) {
+	maxCooldown := max(c.addressCooldown, c.ipCooldown)
+	for key, last := range c.lastFunded {
+		if now.Sub(last) >= maxCooldown {
+			delete(c.lastFunded, key)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package faucet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCooldownsThrottleAddressesAndIPs(t *testing.T) {
+	cooldowns := NewCooldowns(time.Hour, time.Hour)
+
+	_, err := cooldowns.Reserve("0xAB", "1.1.1.1")
+	require.NoError(t, err)
+
+	// the address is throttled independently of the IP and of the address case
+	_, err = cooldowns.Reserve("0xab", "2.2.2.2")
+	var cooldownErr *CooldownError
+	require.True(t, errors.As(err, &cooldownErr))
+
+	// the IP is throttled for other addresses
+	_, err = cooldowns.Reserve("0xcd", "1.1.1.1")
+	require.True(t, errors.As(err, &cooldownErr))
+
+	// a failed funding does not count
+	release, err := cooldowns.Reserve("0xcd", "2.2.2.2")
+	require.NoError(t, err)
+	release()
+	_, err = cooldowns.Reserve("0xcd", "2.2.2.2")
+	require.NoError(t, err)
+}
+
+func TestZeroCooldownsAreDisabled(t *testing.T) {
+	cooldowns := NewCooldowns(0, 0)
+	for i := 0; i < 3; i++ {
+		_, err := cooldowns.Reserve("0xab", "1.1.1.1")
+		require.NoError(t, err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/go/ethadapter/erc20contractlib"
 	"github.com/ten-protocol/go-ten/go/obsclient"
 	"github.com/ten-protocol/go-ten/go/wallet"
 )
@@ -23,19 +24,25 @@ const (
 	NativeToken = "eth"
 	// DeprecatedNativeToken is left in temporarily for tooling that is getting native funds using `/ten` URL
 	DeprecatedNativeToken = "ten" // todo (@matt) remove this once we have fixed the /ten usages
-	WrappedOBX            = "wobx"
-	WrappedEth            = "weth"
-	WrappedUSDC           = "usdc"
 )
 
 type Faucet struct {
-	client    *obsclient.AuthObsClient
-	fundMutex sync.Mutex
-	wallet    wallet.Wallet
-	Logger    log.Logger
+	client      *obsclient.AuthObsClient
+	fundMutex   sync.Mutex
+	nonce       uint64 // the nonce of the next funding tx, guarded by fundMutex
+	nonceSynced bool   // whether the nonce was fetched from the node since the last failed funding
+	wallet      wallet.Wallet
+	tokens      TokenRegistry
+	cooldowns   *Cooldowns
+	fundingLog  *FundingLog
+	Logger      log.Logger
+
+	// cancels the receipt confirmations when the faucet stops
+	ctx       context.Context
+	ctxCancel context.CancelFunc
 }
 
-func NewFaucet(rpcURL string, chainID int64, pkString string) (*Faucet, error) {
+func NewFaucet(rpcURL string, chainID int64, pkString string, tokens TokenRegistry, cooldowns *Cooldowns, fundingLog *FundingLog) (*Faucet, error) {
 	logger := log.New()
 	w := wallet.NewInMemoryWalletFromConfig(pkString, chainID, logger)
 	obsClient, err := obsclient.DialWithAuth(rpcURL, w, logger)
@@ -43,24 +50,45 @@ func NewFaucet(rpcURL string, chainID int64, pkString string) (*Faucet, error) {
 		return nil, fmt.Errorf("unable to connect with the node: %w", err)
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Faucet{
-		client: obsClient,
-		wallet: w,
-		Logger: logger,
+		client:     obsClient,
+		wallet:     w,
+		tokens:     tokens,
+		cooldowns:  cooldowns,
+		fundingLog: fundingLog,
+		Logger:     logger,
+		ctx:        ctx,
+		ctxCancel:  cancel,
 	}, nil
 }
 
-func (f *Faucet) Fund(address *common.Address, token string, amount *big.Int) (string, error) {
-	var err error
-	var signedTx *types.Transaction
+// Fund sends the token to the address and returns the tx hash without waiting for the receipt, which is confirmed
+// asynchronously and recorded in the funding log. The amount only applies to the native token, the amount of the
+// ERC20 tokens is configured in the token registry.
+func (f *Faucet) Fund(address *common.Address, token string, amount *big.Int, ip string) (string, error) {
+	var erc20 *Token
+	if token != NativeToken && token != DeprecatedNativeToken {
+		var ok bool
+		if erc20, ok = f.tokens[token]; !ok {
+			return "", fmt.Errorf("token not fundable: %s", token)
+		}
+		amount = erc20.Amount
+	}
+
+	release, err := f.cooldowns.Reserve(address.Hex(), ip)
+	if err != nil {
+		return "", err
+	}
 
-	if token == NativeToken || token == DeprecatedNativeToken {
+	var signedTx *types.Transaction
+	if erc20 == nil {
 		signedTx, err = f.fundNativeToken(address, amount)
 	} else {
-		return "", fmt.Errorf("token not fundable atm")
-		// todo implement this when contracts are deployable somewhere
+		signedTx, err = f.fundERC20Token(address, erc20)
 	}
 	if err != nil {
+		release()
 		return "", err
 	}
 
@@ -69,61 +97,100 @@ func (f *Faucet) Fund(address *common.Address, token string, amount *big.Int) (s
 	if err != nil {
 		return "", err
 	}
-	f.Logger.Info(fmt.Sprintf("Funded address: %s - tx: %+v\n", address.Hex(), string(txMarshal)))
-	// todo handle tx receipt
+	f.Logger.Info(fmt.Sprintf("Funded address: %s - token: %s - tx: %+v\n", address.Hex(), token, string(txMarshal)))
 
-	if err := f.validateTx(signedTx); err != nil {
-		return "", fmt.Errorf("unable to validate tx %s: %w", signedTx.Hash(), err)
+	// the tx was sent, so a failure to record it must not fail the request
+	if err := f.fundingLog.Record(signedTx.Hash(), *address, ip, token, amount); err != nil {
+		f.Logger.Error("Could not record funding", "tx", signedTx.Hash(), "err", err)
 	}
+	go f.confirmTx(signedTx)
 
 	return signedTx.Hash().Hex(), nil
 }
 
-func (f *Faucet) validateTx(tx *types.Transaction) error {
-	for now := time.Now(); time.Since(now) < _timeout; time.Sleep(time.Second) {
-		receipt, err := f.client.TransactionReceipt(context.Background(), tx.Hash())
-		// end eagerly for unexpected errors
-		if err != nil && !errors.Is(err, ethereum.NotFound) {
-			return fmt.Errorf("could not retrieve transaction receipt in eth_getTransactionReceipt request. Cause: %w", err)
-		}
+// History returns the latest fundings, optionally only the ones of the given address
+func (f *Faucet) History(address *common.Address, limit int) ([]*Funding, error) {
+	return f.fundingLog.History(address, limit)
+}
 
-		// try again until timeout
-		if receipt == nil {
-			continue
+// confirmTx waits for the receipt of the funding tx and records its outcome in the funding log
+func (f *Faucet) confirmTx(tx *types.Transaction) {
+	status := StatusUnconfirmed
+	receipt, err := f.waitForReceipt(tx)
+	switch {
+	case err != nil:
+		if f.ctx.Err() != nil {
+			return // the faucet is stopping, the funding stays pending
 		}
+		f.Logger.Warn("Could not confirm funding tx", "tx", tx.Hash(), "err", err)
+	case receipt.Status != types.ReceiptStatusSuccessful:
+		status = StatusFailed
+		f.Logger.Warn("Funding tx failed", "tx", tx.Hash())
+	default:
+		status = StatusConfirmed
+	}
 
-		txReceiptBytes, err := receipt.MarshalJSON()
-		if err != nil {
-			return fmt.Errorf("could not marshal transaction receipt to JSON in eth_getTransactionReceipt request. Cause: %w", err)
-		}
-		fmt.Println(string(txReceiptBytes))
+	if err := f.fundingLog.UpdateStatus(tx.Hash(), status); err != nil {
+		f.Logger.Error("Could not update funding status", "tx", tx.Hash(), "err", err)
+	}
+}
 
-		if receipt.Status != 1 {
-			return fmt.Errorf("tx status is not 0x1")
+func (f *Faucet) waitForReceipt(tx *types.Transaction) (*types.Receipt, error) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	timeout := time.After(_timeout)
+
+	for {
+		select {
+		case <-f.ctx.Done():
+			return nil, f.ctx.Err()
+		case <-timeout:
+			return nil, fmt.Errorf("unable to fetch tx receipt after %s", _timeout)
+		case <-ticker.C:
+			receipt, err := f.client.TransactionReceipt(f.ctx, tx.Hash())
+			// end eagerly for unexpected errors
+			if err != nil && !errors.Is(err, ethereum.NotFound) {
+				return nil, fmt.Errorf("could not retrieve transaction receipt in eth_getTransactionReceipt request. Cause: %w", err)
+			}
+			// try again until timeout
+			if receipt != nil {
+				return receipt, nil
+			}
 		}
-		return nil
 	}
-	return fmt.Errorf("unable to fetch tx receipt after %s", _timeout)
 }
 
 func (f *Faucet) fundNativeToken(address *common.Address, amount *big.Int) (*types.Transaction, error) {
+	return f.signAndSend(&types.LegacyTx{
+		To:    address,
+		Value: amount,
+	})
+}
+
+func (f *Faucet) fundERC20Token(address *common.Address, token *Token) (*types.Transaction, error) {
+	return f.signAndSend(&types.LegacyTx{
+		To:   &token.Address,
+		Data: erc20contractlib.CreateTransferTxData(*address, token.Amount),
+	})
+}
+
+// signAndSend sets the nonce, gas and gas price of the tx, then signs and sends it
+func (f *Faucet) signAndSend(tx *types.LegacyTx) (*types.Transaction, error) {
 	// only one funding at the time
 	f.fundMutex.Lock()
 	defer f.fundMutex.Unlock()
 
-	nonce, err := f.client.NonceAt(context.Background(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("unable to fetch %s nonce: %w", f.wallet.Address(), err)
-	}
-	// this isn't great as the tx count might be incremented in between calls
-	// but only after removing the pk from other apps can we use a proper counter
-
-	tx := &types.LegacyTx{
-		Nonce:    nonce,
-		GasPrice: big.NewInt(225),
-		To:       address,
-		Value:    amount,
+	// the nonce is tracked locally, as the node only returns the nonce of the latest batch, which doesn't count the
+	// fundings sent since
+	if !f.nonceSynced {
+		nonce, err := f.client.NonceAt(context.Background(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch %s nonce: %w", f.wallet.Address(), err)
+		}
+		f.nonce = nonce
+		f.nonceSynced = true
 	}
+	tx.Nonce = f.nonce
 
 	estimatedTx := f.client.EstimateGasAndGasPrice(tx)
 
@@ -133,8 +200,11 @@ func (f *Faucet) fundNativeToken(address *common.Address, amount *big.Int) (*typ
 	}
 
 	if err = f.client.SendTransaction(context.Background(), signedTx); err != nil {
+		// the nonce might be out of sync (e.g. if the pk was used elsewhere), so it is fetched again for the next funding
+		f.nonceSynced = false
 		return signedTx, err
 	}
+	f.nonce++
 
 	return signedTx, nil
 }
@@ -142,3 +212,8 @@ func (f *Faucet) fundNativeToken(address *common.Address, amount *big.Int) (*typ
 func (f *Faucet) Balance(ctx context.Context) (*big.Int, error) {
 	return f.client.BalanceAt(ctx, nil)
 }
+
+func (f *Faucet) Stop() error {
+	f.ctxCancel()
+	return f.fundingLog.Close()
+}
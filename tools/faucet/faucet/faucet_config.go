@@ -1,6 +1,9 @@
 package faucet
 
-import "math/big"
+import (
+	"math/big"
+	"time"
+)
 
 type Config struct {
 	Host              string
@@ -9,5 +12,10 @@ type Config struct {
 	JWTSecret         string
 	ChainID           *big.Int
 	ServerPort        int
-	DefaultFundAmount *big.Int // how much token to fund by default (in wei)
+	DefaultFundAmount *big.Int      // how much token to fund by default (in wei)
+	Tokens            TokenRegistry // the ERC20 tokens the faucet can fund
+	AddressCooldown   time.Duration // the minimum time between two fundings of an address (0 disables it)
+	IPCooldown        time.Duration // the minimum time between two fundings requested from an IP (0 disables it)
+	DBPath            string        // the path of the sqlite funding log, kept in memory when empty
+	TrustedProxies    []string      // the proxies whose forwarding headers give the client IP (none when empty)
 }
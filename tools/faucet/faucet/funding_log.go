@@ -0,0 +1,121 @@
+package faucet
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	_ "github.com/mattn/go-sqlite3" // sqlite driver for sql.Open()
+)
+
+// The statuses of a funding tx
+const (
+	StatusPending     = "pending"
+	StatusConfirmed   = "confirmed"
+	StatusFailed      = "failed"
+	StatusUnconfirmed = "unconfirmed" // no receipt was found before the timeout
+)
+
+const (
+	sqliteCfg = "_journal_mode=wal&_synchronous=normal"
+
+	createFundingTable = `CREATE TABLE IF NOT EXISTS funding (
+		tx_hash    TEXT PRIMARY KEY,
+		address    TEXT NOT NULL,
+		ip         TEXT NOT NULL,
+		token      TEXT NOT NULL,
+		amount     TEXT NOT NULL,
+		status     TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS funding_address_idx ON funding (address, created_at);`
+	insertFunding       = "INSERT INTO funding (tx_hash, address, ip, token, amount, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	updateFundingStatus = "UPDATE funding SET status = ?, updated_at = ? WHERE tx_hash = ?"
+	selectFunding       = "SELECT tx_hash, address, token, amount, status, created_at FROM funding"
+)
+
+// Funding is an entry of the funding log
+type Funding struct {
+	TxHash    string    `json:"txHash"`
+	Address   string    `json:"address"`
+	Token     string    `json:"token"`
+	Amount    string    `json:"amount"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// FundingLog persists the fundings issued by the faucet and the outcome of their txs
+type FundingLog struct {
+	db *sql.DB
+}
+
+// NewFundingLog opens the sqlite funding log at the given path. An empty path keeps the log in memory.
+func NewFundingLog(dbPath string) (*FundingLog, error) {
+	dsn := fmt.Sprintf("file:%s?%s", dbPath, sqliteCfg)
+	if dbPath == "" {
+		dsn = "file:faucet?mode=memory&cache=shared"
+	}
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("could not open funding log: %w", err)
+	}
+	if _, err := db.Exec(createFundingTable); err != nil {
+		return nil, fmt.Errorf("could not create funding table: %w", err)
+	}
+	return &FundingLog{db: db}, nil
+}
+
+func (l *FundingLog) Record(txHash common.Hash, address common.Address, ip string, token string, amount *big.Int) error {
+	now := time.Now().Unix()
+	_, err := l.db.Exec(insertFunding, txHash.Hex(), strings.ToLower(address.Hex()), ip, token, amount.String(), StatusPending, now, now)
+	if err != nil {
+		return fmt.Errorf("could not record funding: %w", err)
+	}
+	return nil
+}
+
+func (l *FundingLog) UpdateStatus(txHash common.Hash, status string) error {
+	_, err := l.db.Exec(updateFundingStatus, status, time.Now().Unix(), txHash.Hex())
+	if err != nil {
+		return fmt.Errorf("could not update funding status: %w", err)
+	}
+	return nil
+}
+
+// History returns the latest fundings, optionally only the ones of the given address
+func (l *FundingLog) History(address *common.Address, limit int) ([]*Funding, error) {
+	query := selectFunding
+	args := make([]any, 0, 2)
+	if address != nil {
+		query += " WHERE address = ?"
+		args = append(args, strings.ToLower(address.Hex()))
+	}
+	query += " ORDER BY created_at DESC, rowid DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := l.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not query funding history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([]*Funding, 0)
+	for rows.Next() {
+		var f Funding
+		var createdAt int64
+		if err := rows.Scan(&f.TxHash, &f.Address, &f.Token, &f.Amount, &f.Status, &createdAt); err != nil {
+			return nil, fmt.Errorf("could not read funding: %w", err)
+		}
+		f.CreatedAt = time.Unix(createdAt, 0).UTC()
+		history = append(history, &f)
+	}
+	return history, rows.Err()
+}
+
+func (l *FundingLog) Close() error {
+	return l.db.Close()
+}
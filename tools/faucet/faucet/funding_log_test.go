@@ -0,0 +1,35 @@
+package faucet
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFundingLogHistory(t *testing.T) {
+	fundingLog, err := NewFundingLog(filepath.Join(t.TempDir(), "faucet.db"))
+	require.NoError(t, err)
+	defer fundingLog.Close()
+
+	addr1, addr2 := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	require.NoError(t, fundingLog.Record(common.HexToHash("0xa1"), addr1, "1.1.1.1", NativeToken, big.NewInt(100)))
+	require.NoError(t, fundingLog.Record(common.HexToHash("0xa2"), addr2, "1.1.1.1", "usdc", big.NewInt(5)))
+	require.NoError(t, fundingLog.Record(common.HexToHash("0xa3"), addr1, "1.1.1.1", "usdc", big.NewInt(5)))
+	require.NoError(t, fundingLog.UpdateStatus(common.HexToHash("0xa1"), StatusConfirmed))
+
+	history, err := fundingLog.History(&addr1, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	// the latest funding comes first
+	require.Equal(t, common.HexToHash("0xa3").Hex(), history[0].TxHash)
+	require.Equal(t, StatusPending, history[0].Status)
+	require.Equal(t, StatusConfirmed, history[1].Status)
+	require.Equal(t, "100", history[1].Amount)
+
+	history, err = fundingLog.History(nil, 2)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+}
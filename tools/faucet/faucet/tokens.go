@@ -0,0 +1,54 @@
+package faucet
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Token is an ERC20 token the faucet can fund
+type Token struct {
+	Symbol  string
+	Address common.Address // the address of the token contract on the L2
+	Amount  *big.Int       // how much of the token to fund (in the smallest unit of the token)
+}
+
+// TokenRegistry maps the token symbols to the ERC20 tokens the faucet can fund
+type TokenRegistry map[string]*Token
+
+// ParseTokenRegistry parses a comma separated list of tokens in the format `symbol:address:amount`
+func ParseTokenRegistry(tokens string) (TokenRegistry, error) {
+	registry := TokenRegistry{}
+	if strings.TrimSpace(tokens) == "" {
+		return registry, nil
+	}
+
+	for _, entry := range strings.Split(tokens, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid token entry %q, expected symbol:address:amount", entry)
+		}
+		symbol := strings.ToLower(parts[0])
+		if symbol == NativeToken || symbol == DeprecatedNativeToken {
+			return nil, fmt.Errorf("token symbol %s is reserved for the native token", symbol)
+		}
+		if _, ok := registry[symbol]; ok {
+			return nil, fmt.Errorf("token %s is configured twice", symbol)
+		}
+		if !common.IsHexAddress(parts[1]) {
+			return nil, fmt.Errorf("invalid address %s for token %s", parts[1], symbol)
+		}
+		amount, ok := new(big.Int).SetString(parts[2], 10)
+		if !ok || amount.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid amount %s for token %s", parts[2], symbol)
+		}
+		registry[symbol] = &Token{
+			Symbol:  symbol,
+			Address: common.HexToAddress(parts[1]),
+			Amount:  amount,
+		}
+	}
+	return registry, nil
+}
@@ -0,0 +1,33 @@
+package faucet
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTokenRegistry(t *testing.T) {
+	registry, err := ParseTokenRegistry("USDC:0x0000000000000000000000000000000000000001:1000000, weth:0x0000000000000000000000000000000000000002:5")
+	require.NoError(t, err)
+	require.Len(t, registry, 2)
+	require.Equal(t, common.HexToAddress("0x01"), registry["usdc"].Address)
+	require.Equal(t, big.NewInt(1_000_000), registry["usdc"].Amount)
+	require.Equal(t, big.NewInt(5), registry["weth"].Amount)
+
+	registry, err = ParseTokenRegistry("")
+	require.NoError(t, err)
+	require.Empty(t, registry)
+
+	for _, invalid := range []string{
+		"usdc:0x0000000000000000000000000000000000000001",
+		"usdc:notAnAddress:5",
+		"usdc:0x0000000000000000000000000000000000000001:-5",
+		"eth:0x0000000000000000000000000000000000000001:5",
+		"usdc:0x0000000000000000000000000000000000000001:5,usdc:0x0000000000000000000000000000000000000002:5",
+	} {
+		_, err := ParseTokenRegistry(invalid)
+		require.Error(t, err, invalid)
+	}
+}
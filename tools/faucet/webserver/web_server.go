@@ -2,9 +2,11 @@ package webserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +16,11 @@ import (
 	"github.com/ten-protocol/go-ten/tools/faucet/faucet"
 )
 
+const (
+	_defaultHistoryLimit = 20
+	_maxHistoryLimit     = 100
+)
+
 type WebServer struct {
 	engine      *gin.Engine
 	faucet      *faucet.Faucet
@@ -25,10 +32,16 @@ type requestAddr struct {
 	Address string `json:"address" binding:"required"`
 }
 
-func NewWebServer(faucetServer *faucet.Faucet, bindAddress string, jwtSecret []byte, defaultAmount *big.Int) *WebServer {
+func NewWebServer(faucetServer *faucet.Faucet, bindAddress string, jwtSecret []byte, defaultAmount *big.Int, trustedProxies []string) (*WebServer, error) {
 	r := gin.New()
 	gin.SetMode(gin.ReleaseMode)
 
+	// the client IP the cooldowns apply to is only read from the forwarding headers set by the trusted proxies,
+	// otherwise any client could pick its IP
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
 	// authed endpoint
 	r.POST("/auth/fund/:token", jwtTokenChecker(jwtSecret, faucetServer.Logger), fundingHandler(faucetServer, defaultAmount))
 
@@ -37,13 +50,15 @@ func NewWebServer(faucetServer *faucet.Faucet, bindAddress string, jwtSecret []b
 
 	r.GET("/balance", balanceReqHandler(faucetServer))
 
+	r.GET("/history", historyReqHandler(faucetServer))
+
 	r.GET("/health", healthReqHandler())
 
 	return &WebServer{
 		engine:      r,
 		faucet:      faucetServer,
 		bindAddress: bindAddress,
-	}
+	}, nil
 }
 
 func jwtTokenChecker(jwtSecret []byte, logger log.Logger) gin.HandlerFunc {
@@ -113,25 +128,10 @@ func extractBearerToken(header string) (string, error) {
 
 func fundingHandler(faucetServer *faucet.Faucet, defaultAmount *big.Int) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tokenReq := c.Params.ByName("token")
-		var token string
-
-		// check the token request type
-		switch tokenReq {
-		case faucet.NativeToken:
-			token = faucet.NativeToken
+		token := strings.ToLower(c.Params.ByName("token"))
 		// we leave this option in temporarily for tools that are still using `/ten` endpoint for native funds
-		case faucet.DeprecatedNativeToken:
+		if token == faucet.DeprecatedNativeToken {
 			token = faucet.NativeToken
-		case faucet.WrappedOBX:
-			token = faucet.WrappedOBX
-		case faucet.WrappedEth:
-			token = faucet.WrappedEth
-		case faucet.WrappedUSDC:
-			token = faucet.WrappedUSDC
-		default:
-			errorHandler(c, fmt.Errorf("token not recognized: %s", tokenReq), faucetServer.Logger)
-			return
 		}
 
 		// make sure there's an address
@@ -149,7 +149,14 @@ func fundingHandler(faucetServer *faucet.Faucet, defaultAmount *big.Int) gin.Han
 
 		// fund the address
 		addr := common.HexToAddress(req.Address)
-		hash, err := faucetServer.Fund(&addr, token, defaultAmount)
+		hash, err := faucetServer.Fund(&addr, token, defaultAmount, c.ClientIP())
+		var cooldownErr *faucet.CooldownError
+		if errors.As(err, &cooldownErr) {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, map[string]string{
+				"error": err.Error(),
+			})
+			return
+		}
 		if err != nil {
 			errorHandler(c, fmt.Errorf("unable to fund request %w", err), faucetServer.Logger)
 			return
@@ -173,6 +180,39 @@ func balanceReqHandler(faucetServer *faucet.Faucet) gin.HandlerFunc {
 	}
 }
 
+// returns the latest fundings, optionally filtered by the `address` query parameter
+func historyReqHandler(faucetServer *faucet.Faucet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var address *common.Address
+		if addrParam := c.Query("address"); addrParam != "" {
+			if !common.IsHexAddress(addrParam) {
+				errorHandler(c, fmt.Errorf("unexpected address %s", addrParam), faucetServer.Logger)
+				return
+			}
+			addr := common.HexToAddress(addrParam)
+			address = &addr
+		}
+
+		limit := _defaultHistoryLimit
+		if limitParam := c.Query("limit"); limitParam != "" {
+			var err error
+			limit, err = strconv.Atoi(limitParam)
+			if err != nil || limit <= 0 || limit > _maxHistoryLimit {
+				errorHandler(c, fmt.Errorf("limit must be between 1 and %d", _maxHistoryLimit), faucetServer.Logger)
+				return
+			}
+		}
+
+		history, err := faucetServer.History(address, limit)
+		if err != nil {
+			errorHandler(c, fmt.Errorf("unable to get funding history %w", err), faucetServer.Logger)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"fundings": history})
+	}
+}
+
 // returns the remaining native balance of the faucet
 func healthReqHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
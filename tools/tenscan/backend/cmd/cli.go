@@ -2,37 +2,65 @@ package main
 
 import (
 	"flag"
+	"strings"
 
 	"github.com/ten-protocol/go-ten/tools/tenscan/backend/config"
 )
 
 func parseCLIArgs() *config.Config {
 	defaultConfig := &config.Config{
-		NodeHostAddress: "http://erpc.dev-testnet.ten.xyz:80",
-		ServerAddress:   "0.0.0.0:80",
-		LogPath:         "tenscan_logs.txt",
+		NodeHostAddress:    "http://erpc.dev-testnet.ten.xyz:80",
+		NodeHostWSAddress:  "ws://erpc.dev-testnet.ten.xyz:81",
+		ServerAddress:      "0.0.0.0:80",
+		LogPath:            "tenscan_logs.txt",
+		LiveMaxConnections: 1000,
 	}
 
 	nodeHostAddress := flag.String(nodeHostAddressName, defaultConfig.NodeHostAddress, nodeHostAddressUsage)
+	nodeHostWSAddress := flag.String(nodeHostWSAddressName, defaultConfig.NodeHostWSAddress, nodeHostWSAddressUsage)
 	serverAddress := flag.String(serverAddressName, defaultConfig.ServerAddress, serverAddressUsage)
 	logPath := flag.String(logPathName, defaultConfig.LogPath, logPathUsage)
+	liveMaxConnections := flag.Int(liveMaxConnectionsName, defaultConfig.LiveMaxConnections, liveMaxConnectionsUsage)
+	liveAllowedOrigins := flag.String(liveAllowedOriginsName, "", liveAllowedOriginsUsage)
 
 	flag.Parse()
 
 	return &config.Config{
-		NodeHostAddress: *nodeHostAddress,
-		ServerAddress:   *serverAddress,
-		LogPath:         *logPath,
+		NodeHostAddress:    *nodeHostAddress,
+		NodeHostWSAddress:  *nodeHostWSAddress,
+		ServerAddress:      *serverAddress,
+		LogPath:            *logPath,
+		LiveMaxConnections: *liveMaxConnections,
+		LiveAllowedOrigins: parseOrigins(*liveAllowedOrigins),
 	}
 }
 
+func parseOrigins(origins string) []string {
+	var result []string
+	for _, origin := range strings.Split(origins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			result = append(result, origin)
+		}
+	}
+	return result
+}
+
 const (
 	nodeHostAddressName  = "nodeHostAddress"
 	nodeHostAddressUsage = "The Obscuro Host Node address"
 
+	nodeHostWSAddressName  = "nodeHostWSAddress"
+	nodeHostWSAddressUsage = "The Obscuro Host Node websocket address, used for the live feed. The live feed is disabled if empty"
+
 	serverAddressName  = "serverAddress"
 	serverAddressUsage = "The address to serve tenscan on"
 
 	logPathName  = "logPath"
 	logPathUsage = "The path to use for tenscan's log file"
+
+	liveMaxConnectionsName  = "liveMaxConnections"
+	liveMaxConnectionsUsage = "The maximum number of live feed websocket connections"
+
+	liveAllowedOriginsName  = "liveAllowedOrigins"
+	liveAllowedOriginsUsage = "The comma-separated origins allowed to open a live feed connection, e.g. https://*.tenscan.io. \"*\" allows any origin. Only the same host is allowed if empty"
 )
@@ -1,7 +1,10 @@
 package config
 
 type Config struct {
-	NodeHostAddress string
-	ServerAddress   string
-	LogPath         string
+	NodeHostAddress    string
+	NodeHostWSAddress  string   // the live feed is disabled when empty
+	LiveMaxConnections int      // the maximum number of live feed websocket connections
+	LiveAllowedOrigins []string // the origins allowed to open a live feed connection, "*" allows any origin
+	ServerAddress      string
+	LogPath            string
 }
@@ -15,6 +15,7 @@ import (
 
 type TenScanContainer struct {
 	backend   *backend.Backend
+	liveFeed  *backend.LiveFeed
 	webServer *webserver.WebServer
 }
 
@@ -28,19 +29,37 @@ func NewTenScanContainer(config *config.Config) (*TenScanContainer, error) {
 
 	scanBackend := backend.NewBackend(obsClient)
 	logger := log.New(log.TenscanCmp, int(gethlog.LvlInfo), config.LogPath)
-	webServer := webserver.New(scanBackend, config.ServerAddress, logger)
+
+	var liveFeed *backend.LiveFeed
+	if config.NodeHostWSAddress != "" {
+		wsClient, err := rpc.NewNetworkClient(config.NodeHostWSAddress)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to the obscuro node websocket - %w", err)
+		}
+		liveFeed = backend.NewLiveFeed(obsclient.NewObsClient(wsClient), scanBackend, config.LiveMaxConnections, logger)
+	}
+	webServer := webserver.New(scanBackend, liveFeed, config.LiveAllowedOrigins, config.ServerAddress, logger)
 
 	logger.Info("Created Obscuro Scan with the following: ", "args", config)
 	return &TenScanContainer{
-		backend:   backend.NewBackend(obsClient),
+		backend:   scanBackend,
+		liveFeed:  liveFeed,
 		webServer: webServer,
 	}, nil
 }
 
 func (c *TenScanContainer) Start() error {
+	if c.liveFeed != nil {
+		if err := c.liveFeed.Start(); err != nil {
+			return err
+		}
+	}
 	return c.webServer.Start()
 }
 
 func (c *TenScanContainer) Stop() error {
+	if c.liveFeed != nil {
+		_ = c.liveFeed.Stop()
+	}
 	return c.webServer.Stop()
 }
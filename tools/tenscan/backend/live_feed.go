@@ -0,0 +1,183 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/go/common"
+	tenlog "github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/retry"
+	"github.com/ten-protocol/go-ten/go/obsclient"
+)
+
+const (
+	// the number of events buffered per listener, the events are dropped for the listeners that fall behind
+	_listenerBufferSize = 64
+	_resubscribeTimeout = 10 * time.Minute
+)
+
+// ErrTooManyListeners is returned when the live feed already has the maximum number of listeners
+var ErrTooManyListeners = errors.New("too many live feed listeners")
+
+// The types of the live events
+const (
+	LiveEventBatch  = "batch"
+	LiveEventRollup = "rollup"
+)
+
+// LiveEvent is a new batch header or rollup header pushed to the live feed listeners
+type LiveEvent struct {
+	Type string `json:"type"`
+	Item any    `json:"item"`
+}
+
+// LiveFeed shares a single new heads subscription to the node between all the live feed listeners. After each new
+// batch it checks whether a new rollup was published.
+type LiveFeed struct {
+	subscribeNewHeads func(ctx context.Context, ch chan<- *common.BatchHeader) (ethereum.Subscription, error)
+	latestRollup      func() (*common.RollupHeader, error)
+	logger            log.Logger
+
+	listenersMutex sync.Mutex
+	listeners      map[uint64]chan *LiveEvent
+	nextListenerID uint64
+	maxListeners   int
+
+	lastRollupHash gethcommon.Hash
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+// NewLiveFeed returns a live feed for at most maxListeners listeners. The new heads subscription requires a websocket
+// connection to the node.
+func NewLiveFeed(wsClient *obsclient.ObsClient, backend *Backend, maxListeners int, logger log.Logger) *LiveFeed {
+	subscribeNewHeads := func(ctx context.Context, ch chan<- *common.BatchHeader) (ethereum.Subscription, error) {
+		return wsClient.SubscribeNewHeads(ctx, ch)
+	}
+	return newLiveFeed(subscribeNewHeads, backend.GetLatestRollupHeader, maxListeners, logger)
+}
+
+func newLiveFeed(subscribeNewHeads func(ctx context.Context, ch chan<- *common.BatchHeader) (ethereum.Subscription, error), latestRollup func() (*common.RollupHeader, error), maxListeners int, logger log.Logger) *LiveFeed {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &LiveFeed{
+		subscribeNewHeads: subscribeNewHeads,
+		latestRollup:      latestRollup,
+		logger:            logger,
+		listeners:         map[uint64]chan *LiveEvent{},
+		maxListeners:      maxListeners,
+		ctx:               ctx,
+		ctxCancel:         cancel,
+	}
+}
+
+func (f *LiveFeed) Start() error {
+	if latest, err := f.latestRollup(); err == nil {
+		f.lastRollupHash = latest.Hash()
+	}
+	go f.run()
+	return nil
+}
+
+func (f *LiveFeed) Stop() error {
+	f.ctxCancel()
+	return nil
+}
+
+// Listen registers a listener for the live events. The returned function unregisters it.
+// It returns ErrTooManyListeners when the maximum number of listeners is reached.
+func (f *LiveFeed) Listen() (<-chan *LiveEvent, func(), error) {
+	f.listenersMutex.Lock()
+	defer f.listenersMutex.Unlock()
+
+	if len(f.listeners) >= f.maxListeners {
+		return nil, nil, ErrTooManyListeners
+	}
+	id := f.nextListenerID
+	f.nextListenerID++
+	ch := make(chan *LiveEvent, _listenerBufferSize)
+	f.listeners[id] = ch
+
+	return ch, func() {
+		f.listenersMutex.Lock()
+		defer f.listenersMutex.Unlock()
+		delete(f.listeners, id)
+	}, nil
+}
+
+// run (re)subscribes to the new heads until the feed is stopped
+func (f *LiveFeed) run() {
+	for f.ctx.Err() == nil {
+		heads := make(chan *common.BatchHeader)
+		var sub ethereum.Subscription
+		err := retry.Do(func() error {
+			if f.ctx.Err() != nil {
+				return retry.FailFast(f.ctx.Err())
+			}
+			var err error
+			sub, err = f.subscribeNewHeads(f.ctx, heads)
+			if err != nil {
+				f.logger.Info("Could not subscribe to new heads", tenlog.ErrKey, err)
+				return err
+			}
+			return nil
+		}, retry.NewTimeoutStrategy(_resubscribeTimeout, time.Second))
+		if err != nil {
+			if f.ctx.Err() == nil {
+				f.logger.Error("Could not subscribe to new heads, the live feed is stopped", tenlog.ErrKey, err)
+			}
+			return
+		}
+
+		f.forward(heads, sub.Err())
+		// the failed subscription is released before resubscribing, and the subscription is closed when the feed stops
+		sub.Unsubscribe()
+	}
+}
+
+// forward pushes the events for the new heads until the subscription fails or the feed is stopped
+func (f *LiveFeed) forward(heads <-chan *common.BatchHeader, errCh <-chan error) {
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case err := <-errCh:
+			f.logger.Warn("New heads subscription failed, resubscribing", tenlog.ErrKey, err)
+			return
+		case head := <-heads:
+			f.broadcast(&LiveEvent{Type: LiveEventBatch, Item: head})
+			f.checkNewRollup()
+		}
+	}
+}
+
+func (f *LiveFeed) checkNewRollup() {
+	rollup, err := f.latestRollup()
+	if err != nil {
+		f.logger.Debug("Could not fetch the latest rollup header", tenlog.ErrKey, err)
+		return
+	}
+	if rollup.Hash() == f.lastRollupHash {
+		return
+	}
+	f.lastRollupHash = rollup.Hash()
+	f.broadcast(&LiveEvent{Type: LiveEventRollup, Item: rollup})
+}
+
+func (f *LiveFeed) broadcast(event *LiveEvent) {
+	f.listenersMutex.Lock()
+	defer f.listenersMutex.Unlock()
+	for id, ch := range f.listeners {
+		select {
+		case ch <- event:
+		default:
+			f.logger.Debug(fmt.Sprintf("Dropping %s event for slow live feed listener %d", event.Type, id))
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+// headsSubscription - a new heads subscription which can be failed by the test
+type headsSubscription struct {
+	errCh        chan error
+	unsubscribed chan struct{}
+	once         sync.Once
+}
+
+func (s *headsSubscription) Err() <-chan error {
+	return s.errCh
+}
+
+func (s *headsSubscription) Unsubscribe() {
+	s.once.Do(func() { close(s.unsubscribed) })
+}
+
+// headsNode - hands out the new heads subscriptions of the live feed, and the latest rollup
+type headsNode struct {
+	subscriptions chan *headsSubscription
+	heads         chan chan<- *common.BatchHeader
+
+	rollupMutex sync.Mutex
+	rollup      *common.RollupHeader
+}
+
+func newHeadsNode() *headsNode {
+	return &headsNode{
+		subscriptions: make(chan *headsSubscription, 10),
+		heads:         make(chan chan<- *common.BatchHeader, 10),
+		rollup:        &common.RollupHeader{LastBatchSeqNo: 1},
+	}
+}
+
+func (n *headsNode) subscribeNewHeads(_ context.Context, ch chan<- *common.BatchHeader) (ethereum.Subscription, error) {
+	sub := &headsSubscription{errCh: make(chan error, 1), unsubscribed: make(chan struct{})}
+	n.subscriptions <- sub
+	n.heads <- ch
+	return sub, nil
+}
+
+func (n *headsNode) latestRollup() (*common.RollupHeader, error) {
+	n.rollupMutex.Lock()
+	defer n.rollupMutex.Unlock()
+	return n.rollup, nil
+}
+
+func (n *headsNode) publishRollup(rollup *common.RollupHeader) {
+	n.rollupMutex.Lock()
+	defer n.rollupMutex.Unlock()
+	n.rollup = rollup
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+	}
+	var zero T
+	return zero
+}
+
+func TestLiveFeed(t *testing.T) {
+	node := newHeadsNode()
+	feed := newLiveFeed(node.subscribeNewHeads, node.latestRollup, 10, gethlog.New())
+	events, unsubscribe, err := feed.Listen()
+	require.NoError(t, err)
+	defer unsubscribe()
+	require.NoError(t, feed.Start())
+	sub, heads := receive(t, node.subscriptions), receive(t, node.heads)
+
+	// a new batch without a new rollup
+	heads <- &common.BatchHeader{Number: big.NewInt(1)}
+	event := receive(t, events)
+	require.Equal(t, LiveEventBatch, event.Type)
+	require.Equal(t, big.NewInt(1), event.Item.(*common.BatchHeader).Number)
+
+	// a new batch after a new rollup was published
+	rollup := &common.RollupHeader{LastBatchSeqNo: 2}
+	node.publishRollup(rollup)
+	heads <- &common.BatchHeader{Number: big.NewInt(2)}
+	require.Equal(t, LiveEventBatch, receive(t, events).Type)
+	event = receive(t, events)
+	require.Equal(t, LiveEventRollup, event.Type)
+	require.Equal(t, rollup, event.Item)
+
+	// the failed subscription is released, and the feed resubscribes
+	sub.errCh <- errors.New("connection lost")
+	receive(t, sub.unsubscribed)
+	sub, heads = receive(t, node.subscriptions), receive(t, node.heads)
+	heads <- &common.BatchHeader{Number: big.NewInt(3)}
+	require.Equal(t, big.NewInt(3), receive(t, events).Item.(*common.BatchHeader).Number)
+
+	// the subscription is released when the feed stops
+	require.NoError(t, feed.Stop())
+	receive(t, sub.unsubscribed)
+}
+
+func TestLiveFeedListeners(t *testing.T) {
+	node := newHeadsNode()
+	feed := newLiveFeed(node.subscribeNewHeads, node.latestRollup, 2, gethlog.New())
+	events1, unsubscribe1, err := feed.Listen()
+	require.NoError(t, err)
+	events2, _, err := feed.Listen()
+	require.NoError(t, err)
+
+	// the number of listeners is capped
+	_, _, err = feed.Listen()
+	require.ErrorIs(t, err, ErrTooManyListeners)
+	unsubscribe1()
+	events3, _, err := feed.Listen()
+	require.NoError(t, err)
+
+	// the events are dropped for the listeners which fall behind, without blocking the others
+	for i := 0; i < _listenerBufferSize+1; i++ {
+		feed.broadcast(&LiveEvent{Type: LiveEventBatch, Item: i})
+	}
+	require.Len(t, events2, _listenerBufferSize)
+	require.Len(t, events3, _listenerBufferSize)
+	require.Empty(t, events1)
+}
@@ -0,0 +1,83 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ten-protocol/go-ten/go/obsclient"
+)
+
+// The types of the items a search can resolve to
+const (
+	SearchTypeBatch       = "batch"
+	SearchTypeTransaction = "transaction"
+	SearchTypeRollup      = "rollup"
+	SearchTypeL1Block     = "l1Block"
+)
+
+var (
+	// ErrInvalidSearchQuery is returned for queries which are neither a hash nor a height
+	ErrInvalidSearchQuery = errors.New("the search query must be a hash or a batch height")
+	// ErrNoSearchResult is returned when no item matches the query
+	ErrNoSearchResult = errors.New("no item matches the search query")
+
+	hashRegex   = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{64}$`)
+	heightRegex = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// SearchResult is the item found by a search, along with its type
+type SearchResult struct {
+	Type string `json:"type"`
+	Item any    `json:"item"`
+}
+
+// Search finds the item the query refers to. Heights are looked up as batch heights, while hashes are tried in turn as
+// batch, transaction, rollup and L1 block hashes, as nothing in a hash tells these apart.
+func (b *Backend) Search(query string) (*SearchResult, error) {
+	query = strings.TrimSpace(query)
+
+	// a hash without the 0x prefix can be made of decimal digits only, so the hashes are recognised first
+	if !hashRegex.MatchString(query) {
+		if !heightRegex.MatchString(query) {
+			return nil, ErrInvalidSearchQuery
+		}
+		height, _ := new(big.Int).SetString(query, 10)
+		batch, err := b.obsClient.GetBatchByHeight(height)
+		if err != nil {
+			return nil, searchErr(err)
+		}
+		return &SearchResult{Type: SearchTypeBatch, Item: batch}, nil
+	}
+	hash := gethcommon.HexToHash(query)
+
+	lookups := []struct {
+		searchType string
+		fetch      func(gethcommon.Hash) (any, error)
+	}{
+		{SearchTypeBatch, func(h gethcommon.Hash) (any, error) { return b.obsClient.GetBatchByHash(h) }},
+		{SearchTypeTransaction, func(h gethcommon.Hash) (any, error) { return b.obsClient.GetTransaction(h) }},
+		{SearchTypeRollup, func(h gethcommon.Hash) (any, error) { return b.obsClient.GetRollupByHash(h) }},
+		{SearchTypeL1Block, func(h gethcommon.Hash) (any, error) { return b.obsClient.GetL1BlockByHash(h) }},
+	}
+	for _, lookup := range lookups {
+		item, err := lookup.fetch(hash)
+		if err == nil {
+			return &SearchResult{Type: lookup.searchType, Item: item}, nil
+		}
+		if !obsclient.IsNotFound(err) {
+			return nil, fmt.Errorf("could not look up %s %s - %w", lookup.searchType, hash, err)
+		}
+	}
+	return nil, ErrNoSearchResult
+}
+
+func searchErr(err error) error {
+	if obsclient.IsNotFound(err) {
+		return ErrNoSearchResult
+	}
+	return err
+}
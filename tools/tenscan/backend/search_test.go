@@ -0,0 +1,100 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/obsclient"
+	"github.com/ten-protocol/go-ten/go/rpc"
+)
+
+// scanClient - a node client returning the items stored by rpc method and argument. Like the node, it returns a nil
+// result for the items it doesn't have, unless an error is set for the method.
+type scanClient struct {
+	rpc.Client
+	items  map[string]map[string]any
+	errors map[string]error
+}
+
+func (c *scanClient) Call(result interface{}, method string, args ...interface{}) error {
+	if err := c.errors[method]; err != nil {
+		return err
+	}
+	if item, found := c.items[method][fmt.Sprint(args...)]; found {
+		reflect.ValueOf(result).Elem().Set(reflect.ValueOf(item))
+	}
+	return nil
+}
+
+func TestSearch(t *testing.T) {
+	batchHash, txHash, rollupHash, blockHash, unknownHash := gethcommon.Hash{1}, gethcommon.Hash{2}, gethcommon.Hash{3}, gethcommon.Hash{4}, gethcommon.Hash{5}
+	batch := &common.ExtBatch{Header: &common.BatchHeader{Number: big.NewInt(7)}}
+	batchAtHeight := &common.PublicBatch{Height: big.NewInt(7)}
+	tx := &common.PublicTransaction{TransactionHash: txHash}
+	rollup := &common.PublicRollup{Hash: rollupHash.Hex()}
+	block := &types.Header{Number: big.NewInt(3)}
+	client := &scanClient{
+		items: map[string]map[string]any{
+			rpc.GetBatch:         {fmt.Sprint(batchHash): batch},
+			rpc.GetBatchByHeight: {fmt.Sprint(big.NewInt(7)): batchAtHeight},
+			rpc.GetTransaction:   {fmt.Sprint(txHash): tx},
+			rpc.GetRollupByHash:  {fmt.Sprint(rollupHash): rollup},
+			rpc.GetL1BlockByHash: {fmt.Sprint(blockHash): block},
+		},
+		errors: map[string]error{},
+	}
+	b := NewBackend(obsclient.NewObsClient(client))
+
+	for query, expected := range map[string]*SearchResult{
+		"7":              {Type: SearchTypeBatch, Item: batchAtHeight},
+		batchHash.Hex():  {Type: SearchTypeBatch, Item: batch},
+		txHash.Hex():     {Type: SearchTypeTransaction, Item: tx},
+		rollupHash.Hex(): {Type: SearchTypeRollup, Item: rollup},
+		blockHash.Hex():  {Type: SearchTypeL1Block, Item: block},
+		// a hash without the prefix is not mistaken for a height, even when it only has decimal digits
+		" " + txHash.Hex()[2:] + " ": {Type: SearchTypeTransaction, Item: tx},
+	} {
+		result, err := b.Search(query)
+		require.NoError(t, err, query)
+		require.Equal(t, expected, result, query)
+	}
+
+	for query, expectedErr := range map[string]error{
+		"8":                  ErrNoSearchResult,
+		unknownHash.Hex():    ErrNoSearchResult,
+		"0x1234":             ErrInvalidSearchQuery,
+		"batch 7":            ErrInvalidSearchQuery,
+		"-7":                 ErrInvalidSearchQuery,
+		blockHash.Hex()[:65]: ErrInvalidSearchQuery,
+	} {
+		_, err := b.Search(query)
+		require.ErrorIs(t, err, expectedErr, query)
+	}
+}
+
+func TestSearchErrors(t *testing.T) {
+	txHash := gethcommon.Hash{2}
+	client := &scanClient{
+		items: map[string]map[string]any{rpc.GetTransaction: {fmt.Sprint(txHash): &common.PublicTransaction{TransactionHash: txHash}}},
+		// the not found errors of the node only keep their message over RPC
+		errors: map[string]error{rpc.GetBatch: errors.New("not found")},
+	}
+	b := NewBackend(obsclient.NewObsClient(client))
+
+	result, err := b.Search(txHash.Hex())
+	require.NoError(t, err)
+	require.Equal(t, SearchTypeTransaction, result.Type)
+
+	// the other errors stop the search, instead of reporting that nothing matches
+	client.errors[rpc.GetBatch] = errors.New("connection refused")
+	_, err = b.Search(txHash.Hex())
+	require.ErrorContains(t, err, "connection refused")
+	require.NotErrorIs(t, err, ErrNoSearchResult)
+}
@@ -10,17 +10,20 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 )
 
 type WebServer struct {
-	engine      *gin.Engine
-	backend     *backend.Backend
-	bindAddress string
-	logger      log.Logger
-	server      *http.Server
+	engine       *gin.Engine
+	backend      *backend.Backend
+	liveFeed     *backend.LiveFeed // nil when the node websocket address is not configured
+	liveUpgrader *websocket.Upgrader
+	bindAddress  string
+	logger       log.Logger
+	server       *http.Server
 }
 
-func New(backend *backend.Backend, bindAddress string, logger log.Logger) *WebServer {
+func New(backend *backend.Backend, liveFeed *backend.LiveFeed, liveAllowedOrigins []string, bindAddress string, logger log.Logger) *WebServer {
 	r := gin.New()
 	r.RedirectTrailingSlash = false
 	gin.SetMode(gin.ReleaseMode)
@@ -33,15 +36,19 @@ func New(backend *backend.Backend, bindAddress string, logger log.Logger) *WebSe
 	r.Use(cors.New(config))
 
 	server := &WebServer{
-		engine:      r,
-		backend:     backend,
-		bindAddress: bindAddress,
-		logger:      logger,
+		engine:       r,
+		backend:      backend,
+		liveFeed:     liveFeed,
+		liveUpgrader: newLiveUpgrader(liveAllowedOrigins),
+		bindAddress:  bindAddress,
+		logger:       logger,
 	}
 
 	// routes
 	routeItems(r, server)
 	routeCounts(r, server)
+	routeSearch(r, server)
+	routeLive(r, server)
 
 	// todo group/format these into items, counts, actions
 	r.GET("/health/", server.health)
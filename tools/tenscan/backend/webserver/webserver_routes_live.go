@@ -0,0 +1,93 @@
+package webserver
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/ten-protocol/go-ten/go/common/log"
+)
+
+const _liveWriteTimeout = 10 * time.Second
+
+// newLiveUpgrader returns the upgrader of the live feed connections, which only accepts the allowed origins.
+// The origins are matched exactly, "*" allows any origin, and a "*." in an origin matches any subdomain
+// (e.g. "https://*.tenscan.io"). Without allowed origins, only the connections from the same host are accepted.
+func newLiveUpgrader(allowedOrigins []string) *websocket.Upgrader {
+	if len(allowedOrigins) == 0 {
+		// the default check of the upgrader
+		return &websocket.Upgrader{}
+	}
+	return &websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			return isAllowedOrigin(r.Header.Get("Origin"), allowedOrigins)
+		},
+	}
+}
+
+func isAllowedOrigin(origin string, allowedOrigins []string) bool {
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+		prefix, suffix, wildcard := strings.Cut(allowed, "*.")
+		if wildcard && len(origin) > len(prefix)+len(suffix) &&
+			strings.HasPrefix(strings.ToLower(origin), strings.ToLower(prefix)) &&
+			strings.HasSuffix(strings.ToLower(origin), "."+strings.ToLower(suffix)) {
+			return true
+		}
+	}
+	return false
+}
+
+func routeLive(r *gin.Engine, server *WebServer) {
+	r.GET("/live", server.live)
+}
+
+// live streams the new batch headers and rollup headers to the websocket client
+func (w *WebServer) live(c *gin.Context) {
+	if w.liveFeed == nil {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, map[string]string{"error": "the live feed is not enabled"})
+		return
+	}
+
+	events, unsubscribe, err := w.liveFeed.Listen()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+		return
+	}
+	defer unsubscribe()
+
+	conn, err := w.liveUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		w.logger.Debug("Could not upgrade live feed connection", log.ErrKey, err)
+		return
+	}
+	defer conn.Close()
+
+	// the client does not send anything, but reading is required to notice that it closed the connection
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event := <-events:
+			_ = conn.SetWriteDeadline(time.Now().Add(_liveWriteTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				w.logger.Debug("Could not write to live feed connection", log.ErrKey, err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package webserver
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveAllowedOrigins(t *testing.T) {
+	allowed := []string{"https://tenscan.io", "https://*.tenscan.io"}
+	for _, origin := range []string{"https://tenscan.io", "https://sepolia.tenscan.io", "HTTPS://Sepolia.Tenscan.io"} {
+		require.True(t, isAllowedOrigin(origin, allowed), origin)
+	}
+	for _, origin := range []string{"", "http://tenscan.io", "https://eviltenscan.io", "https://tenscan.io.evil.com", "https://sepolia.tenscan.io:8080"} {
+		require.False(t, isAllowedOrigin(origin, allowed), origin)
+	}
+	require.True(t, isAllowedOrigin("https://any.com", []string{"*"}))
+}
+
+func TestLiveUpgraderChecksTheOrigin(t *testing.T) {
+	request := func(origin string) *http.Request {
+		r, err := http.NewRequest(http.MethodGet, "http://api.tenscan.io/live", nil)
+		require.NoError(t, err)
+		r.Header.Set("Origin", origin)
+		return r
+	}
+
+	upgrader := newLiveUpgrader([]string{"https://*.tenscan.io"})
+	require.True(t, upgrader.CheckOrigin(request("https://sepolia.tenscan.io")))
+	require.False(t, upgrader.CheckOrigin(request("https://evil.com")))
+
+	// without allowed origins, only the same host is accepted
+	upgrader = newLiveUpgrader(nil)
+	require.Nil(t, upgrader.CheckOrigin)
+}
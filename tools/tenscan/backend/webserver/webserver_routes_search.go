@@ -0,0 +1,31 @@
+package webserver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ten-protocol/go-ten/tools/tenscan/backend"
+)
+
+func routeSearch(r *gin.Engine, server *WebServer) {
+	r.GET("/search/:query", server.search)
+}
+
+func (w *WebServer) search(c *gin.Context) {
+	result, err := w.backend.Search(c.Param("query"))
+	switch {
+	case errors.Is(err, backend.ErrInvalidSearchQuery):
+		c.AbortWithStatusJSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	case errors.Is(err, backend.ErrNoSearchResult):
+		c.AbortWithStatusJSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		return
+	case err != nil:
+		errorHandler(c, fmt.Errorf("unable to execute search request %w", err), w.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": result})
+}
@@ -0,0 +1,203 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/redis/go-redis/v9"
+	tenlog "github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/tools/walletextension/encryption"
+)
+
+const (
+	redisKeyPrefix       = "gw:cache:"
+	redisEvictionChannel = "gw:cache:evictions"
+	// the cache is an optimisation, so a slow server must not delay the requests
+	redisOpTimeout = 200 * time.Millisecond
+)
+
+// redisCache is a Cache shared by all the gateway replicas connected to the same Redis server.
+// The values are serialised to json and encrypted with the key shared by the replicas, because the cached responses
+// of the user-scoped requests contain private data. The keys are hashed with the same key.
+// The eviction of the short-living elements is broadcast to the other replicas, so that all of them stop returning
+// the responses cached before a new batch, even if their own subscription to new heads is lagging.
+type redisCache struct {
+	client             *redis.Client
+	encryptor          *encryption.Encryptor
+	quit               chan struct{}
+	closeOnce          sync.Once
+	lastEviction       *atomic.Int64 // unix nanoseconds
+	shortLivingEnabled *atomic.Bool
+	logger             log.Logger
+}
+
+// NewRedisCache returns a Cache backed by the Redis server at the url. The encryptionKey must be the same for all replicas.
+func NewRedisCache(url string, encryptionKey []byte, logger log.Logger) (Cache, error) {
+	encryptor, err := encryption.NewEncryptor(encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache encryption key - %w", err)
+	}
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url - %w", err)
+	}
+	client := redis.NewClient(options)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("could not connect to redis - %w", err)
+	}
+
+	c := &redisCache{
+		client:             client,
+		encryptor:          encryptor,
+		quit:               make(chan struct{}),
+		lastEviction:       &atomic.Int64{},
+		shortLivingEnabled: &atomic.Bool{},
+		logger:             logger,
+	}
+	c.lastEviction.Store(time.Now().UnixNano())
+	c.shortLivingEnabled.Store(true)
+
+	// subscribe before returning, so that no eviction is missed once the gateway is serving requests
+	pubSub := client.Subscribe(context.Background(), redisEvictionChannel)
+	if _, err := pubSub.Receive(ctx); err != nil {
+		_ = pubSub.Close()
+		_ = client.Close()
+		return nil, fmt.Errorf("could not subscribe to cache evictions - %w", err)
+	}
+	go c.listenForEvictions(pubSub)
+
+	return c, nil
+}
+
+func (c *redisCache) EvictShortLiving() {
+	c.evictShortLiving()
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+	if err := c.client.Publish(ctx, redisEvictionChannel, "").Err(); err != nil {
+		c.logger.Warn("Could not broadcast the cache eviction", tenlog.ErrKey, err)
+	}
+}
+
+func (c *redisCache) DisableShortLiving() {
+	c.shortLivingEnabled.Store(false)
+}
+
+func (c *redisCache) IsEvicted(key []byte, originalTTL time.Duration) bool {
+	if !c.shortLivingEnabled.Load() {
+		return true
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+	remainingTTL, err := c.client.PTTL(ctx, c.redisKey(key)).Result()
+	// negative values mean that the key does not exist or has no expiry
+	if err != nil || remainingTTL <= 0 {
+		return true
+	}
+	cachedTime := time.Now().Add(remainingTTL).Add(-originalTTL)
+	return time.Unix(0, c.lastEviction.Load()).After(cachedTime)
+}
+
+// Set serialises and encrypts the value. The key is encrypted together with the value, so that a value can't be
+// moved to the entry of another key by someone with write access to the server.
+func (c *redisCache) Set(key []byte, value any, ttl time.Duration) bool {
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		c.logger.Debug("Could not encode the cached value", tenlog.ErrKey, err)
+		return false
+	}
+	encryptedValue, err := c.encryptor.Encrypt(append(c.encryptor.HashWithHMAC(key), encodedValue...))
+	if err != nil {
+		c.logger.Debug("Could not encrypt the cached value", tenlog.ErrKey, err)
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+	if err := c.client.Set(ctx, c.redisKey(key), encryptedValue, ttl).Err(); err != nil {
+		c.logger.Debug("Could not cache the value", tenlog.ErrKey, err)
+		return false
+	}
+	return true
+}
+
+// Get returns the json encoding of the cached value as a json.RawMessage.
+func (c *redisCache) Get(key []byte) (value any, ok bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+	encryptedValue, err := c.client.Get(ctx, c.redisKey(key)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			c.logger.Debug("Could not read the cached value", tenlog.ErrKey, err)
+		}
+		return nil, false
+	}
+	decryptedValue, err := c.encryptor.Decrypt(encryptedValue)
+	if err != nil {
+		c.logger.Warn("Could not decrypt the cached value", tenlog.ErrKey, err)
+		return nil, false
+	}
+	keyHash := c.encryptor.HashWithHMAC(key)
+	if !bytes.HasPrefix(decryptedValue, keyHash) {
+		c.logger.Warn("The cached value does not belong to the key")
+		return nil, false
+	}
+	return json.RawMessage(decryptedValue[len(keyHash):]), true
+}
+
+func (c *redisCache) Remove(key []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+	if err := c.client.Del(ctx, c.redisKey(key)).Err(); err != nil {
+		c.logger.Debug("Could not remove the cached value", tenlog.ErrKey, err)
+	}
+}
+
+// Close stops the eviction listener, which closes the subscription, and closes the client
+func (c *redisCache) Close() {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+		if err := c.client.Close(); err != nil {
+			c.logger.Debug("Could not close the redis client", tenlog.ErrKey, err)
+		}
+	})
+}
+
+func (c *redisCache) evictShortLiving() {
+	// this event happens when a new batch is received, so the cache can be enabled
+	c.shortLivingEnabled.Store(true)
+	c.lastEviction.Store(time.Now().UnixNano())
+}
+
+// redisKey hashes the key, so that the keys derived from the request parameters are not readable on the server
+func (c *redisCache) redisKey(key []byte) string {
+	return redisKeyPrefix + fmt.Sprintf("%x", c.encryptor.HashWithHMAC(key))
+}
+
+// listenForEvictions applies the evictions broadcast by all replicas, including this one.
+// The go-redis client re-subscribes automatically after a connection failure.
+func (c *redisCache) listenForEvictions(pubSub *redis.PubSub) {
+	defer pubSub.Close()
+	evictions := pubSub.Channel()
+	for {
+		select {
+		case _, ok := <-evictions:
+			if !ok {
+				return
+			}
+			c.evictShortLiving()
+		case <-c.quit:
+			return
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisCache(t *testing.T) {
+	server := miniredis.RunT(t)
+	encryptionKey := make([]byte, 32)
+	_, err := rand.Read(encryptionKey)
+	require.NoError(t, err)
+
+	// two gateway replicas sharing the cache
+	replica1, err := NewRedisCache("redis://"+server.Addr(), encryptionKey, gethlog.New())
+	require.NoError(t, err)
+	replica2, err := NewRedisCache("redis://"+server.Addr(), encryptionKey, gethlog.New())
+	require.NoError(t, err)
+
+	key := []byte("user-scoped key")
+	value := hexutil.Big(*hexutil.MustDecodeBig("0x1234567890abcdef"))
+	onCacheMiss := func() (*hexutil.Big, error) { return &value, nil }
+	_, err = WithCache(replica1, &Cfg{Type: LatestBatch}, key, onCacheMiss)
+	require.NoError(t, err)
+
+	// the value cached by a replica is returned by the other one
+	cachedValue, err := WithCache(replica2, &Cfg{Type: LatestBatch}, key, func() (*hexutil.Big, error) {
+		t.Fatal("the value should be cached")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, value.String(), cachedValue.String())
+
+	// neither the key nor the value are readable on the server
+	for _, storedKey := range server.Keys() {
+		require.NotContains(t, storedKey, string(key))
+		storedValue, err := server.Get(storedKey)
+		require.NoError(t, err)
+		require.NotContains(t, storedValue, "1234567890abcdef")
+	}
+
+	// a value can't be served for another key
+	otherKey := []byte("other key")
+	storedValue, err := server.Get(replica1.(*redisCache).redisKey(key))
+	require.NoError(t, err)
+	require.NoError(t, server.Set(replica1.(*redisCache).redisKey(otherKey), storedValue))
+	_, found := replica2.Get(otherKey)
+	require.False(t, found)
+
+	// the eviction on a new batch is broadcast to the other replicas
+	require.False(t, replica2.IsEvicted(key, shortCacheTTL))
+	lastEviction := replica2.(*redisCache).lastEviction.Load()
+	replica1.EvictShortLiving()
+	require.Eventually(t, func() bool {
+		return replica2.(*redisCache).lastEviction.Load() > lastEviction
+	}, time.Second, 10*time.Millisecond)
+	// miniredis only decreases the TTLs when the time is forwarded
+	server.FastForward(time.Second)
+	require.True(t, replica2.IsEvicted(key, shortCacheTTL))
+
+	// a wrong key can't read the cached values
+	otherEncryptionKey := make([]byte, 32)
+	_, err = rand.Read(otherEncryptionKey)
+	require.NoError(t, err)
+	otherReplica, err := NewRedisCache("redis://"+server.Addr(), otherEncryptionKey, gethlog.New())
+	require.NoError(t, err)
+	_, found = otherReplica.Get(key)
+	require.False(t, found)
+}
+
+func TestRedisCacheClose(t *testing.T) {
+	server := miniredis.RunT(t)
+	encryptionKey := make([]byte, 32)
+	_, err := rand.Read(encryptionKey)
+	require.NoError(t, err)
+	c, err := NewRedisCache("redis://"+server.Addr(), encryptionKey, gethlog.New())
+	require.NoError(t, err)
+	require.Equal(t, 1, server.PubSubNumSub(redisEvictionChannel)[redisEvictionChannel])
+
+	// the eviction listener stops and releases its subscription
+	c.Close()
+	require.Eventually(t, func() bool {
+		return server.PubSubNumSub(redisEvictionChannel)[redisEvictionChannel] == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// the requests served while the gateway stops are cache misses, and closing again is a no-op
+	require.False(t, c.Set([]byte("key"), "value", shortCacheTTL))
+	_, found := c.Get([]byte("key"))
+	require.False(t, found)
+	require.True(t, c.IsEvicted([]byte("key"), shortCacheTTL))
+	c.Close()
+}
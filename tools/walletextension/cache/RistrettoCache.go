@@ -2,6 +2,7 @@ package cache
 
 import (
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -18,6 +19,7 @@ const (
 type ristrettoCache struct {
 	cache              *ristretto.Cache[[]byte, any]
 	quit               chan struct{}
+	closeOnce          sync.Once
 	lastEviction       time.Time
 	shortLivingEnabled *atomic.Bool
 }
@@ -86,6 +88,14 @@ func (c *ristrettoCache) Remove(key []byte) {
 	c.cache.Del(key)
 }
 
+// Close stops the metrics logging and the goroutines of the underlying cache
+func (c *ristrettoCache) Close() {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+		c.cache.Close()
+	})
+}
+
 // startMetricsLogging starts logging cache metrics every hour.
 func (c *ristrettoCache) startMetricsLogging(logger log.Logger) {
 	ticker := time.NewTicker(1 * time.Hour)
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -20,12 +21,20 @@ type Cache interface {
 	Set(key []byte, value any, ttl time.Duration) bool
 	Get(key []byte) (value any, ok bool)
 	Remove(key []byte)
+
+	// Close stops the background routines of the cache and releases its connections. It can be called more than once.
+	Close()
 }
 
 func NewCache(nrElems int, logger log.Logger) (Cache, error) {
 	return NewRistrettoCacheWithEviction(nrElems, logger)
 }
 
+// NewSharedCache returns a Cache shared by the gateway replicas connected to the Redis server at the url
+func NewSharedCache(url string, encryptionKey []byte, logger log.Logger) (Cache, error) {
+	return NewRedisCache(url, encryptionKey, logger)
+}
+
 type Strategy uint8
 
 const (
@@ -68,6 +77,14 @@ func WithCache[R any](cache Cache, cfg *Cfg, cacheKey []byte, onCacheMiss func()
 
 	if !isEvicted {
 		cachedValue, foundInCache := cache.Get(cacheKey)
+		// the shared caches return the serialised value
+		if encodedValue, isEncoded := cachedValue.(json.RawMessage); foundInCache && isEncoded {
+			returnValue := new(R)
+			if err := json.Unmarshal(encodedValue, returnValue); err != nil {
+				return nil, fmt.Errorf("unexpected error. Invalid format cached. %w", err)
+			}
+			return returnValue, nil
+		}
 		if foundInCache {
 			returnValue, ok := cachedValue.(*R)
 			if !ok {
@@ -110,3 +127,5 @@ func (c *noOpCache) Get(key []byte) (value any, ok bool) {
 }
 
 func (c *noOpCache) Remove(key []byte) {}
+
+func (c *noOpCache) Close() {}
@@ -58,6 +58,20 @@ func CreateEncClient(conn *gethrpc.Client, encKey []byte, addressBytes []byte, p
 	return encClient, nil
 }
 
+// CreateSignedViewingKey returns the viewing key of the account's user signed by the account, in the format sent to the
+// enclave alongside a request
+func CreateSignedViewingKey(account *GWAccount) (*viewingkey.RPCSignedViewingKey, error) {
+	privateKey, err := BytesToPrivateKey(account.User.UserKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert bytes to ecies private key: %w", err)
+	}
+	return &viewingkey.RPCSignedViewingKey{
+		PublicKey:               PrivateKeyToCompressedPubKey(privateKey),
+		SignatureWithAccountKey: account.Signature,
+		SignatureType:           account.SignatureType,
+	}, nil
+}
+
 type RPCRequest struct {
 	ID     json.RawMessage
 	Method string
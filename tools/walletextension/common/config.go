@@ -31,4 +31,5 @@ type Config struct {
 	TLSDomain                    string
 	EncryptingCertificateEnabled bool
 	DisableCaching               bool
+	CacheRedisURL                string // When set, the response cache is shared by the gateway replicas through this Redis server
 }
@@ -38,4 +38,5 @@ const (
 	PathVersion                   = "/version/"
 	DeduplicationBufferSize       = 20
 	DefaultGatewayAuthMessageType = "EIP712"
+	MaxSessionKeysPerUser         = 100
 )
@@ -0,0 +1,258 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common/viewingkey"
+)
+
+const (
+	// SelectorLen - the length of the function selector at the start of the calldata
+	SelectorLen = 4
+	// the maximum age of the signature of a policy change
+	policyAuthValidity = 5 * time.Minute
+)
+
+// SessionKeyPolicy - restrictions enforced by the gateway before signing a transaction with a session key
+// All the fields are optional. An empty policy allows every transaction.
+type SessionKeyPolicy struct {
+	MaxValuePerTx    *big.Int // the maximum value transferred by a single transaction
+	MaxGasPerTx      uint64   // the maximum gas limit of a single transaction
+	SpendLimit       *big.Int // the maximum total value transferred within SpendWindow
+	SpendWindow      time.Duration
+	AllowedContracts []common.Address // the allowed "to" addresses. Contract deployments are rejected when set
+	AllowedSelectors [][SelectorLen]byte
+}
+
+// SessionKeySpend - the maximum cost of a transaction submitted with a session key
+type SessionKeySpend struct {
+	Timestamp time.Time
+	Nonce     uint64 // a replacement transaction has the same nonce, so its spend replaces the one of the original
+	Amount    *big.Int
+}
+
+// CheckTx - validates the static restrictions of the policy against the transaction.
+// The SpendLimit is enforced with CheckSpend, against the maximum cost of the transaction: the value plus the gas limit
+// multiplied by the fee cap.
+func (p *SessionKeyPolicy) CheckTx(tx *types.Transaction) error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxValuePerTx != nil && tx.Value().Cmp(p.MaxValuePerTx) > 0 {
+		return fmt.Errorf("transaction value %s exceeds the session key limit of %s", tx.Value(), p.MaxValuePerTx)
+	}
+	if p.MaxGasPerTx > 0 && tx.Gas() > p.MaxGasPerTx {
+		return fmt.Errorf("transaction gas %d exceeds the session key limit of %d", tx.Gas(), p.MaxGasPerTx)
+	}
+	if p.SpendLimit != nil && tx.Cost().Cmp(p.SpendLimit) > 0 {
+		return fmt.Errorf("transaction cost %s exceeds the session key spend limit of %s", tx.Cost(), p.SpendLimit)
+	}
+	if len(p.AllowedContracts) > 0 {
+		if tx.To() == nil {
+			return fmt.Errorf("contract deployments are not allowed for this session key")
+		}
+		if !p.isContractAllowed(*tx.To()) {
+			return fmt.Errorf("destination %s is not allowed for this session key", tx.To().Hex())
+		}
+	}
+	if len(p.AllowedSelectors) > 0 {
+		if len(tx.Data()) < SelectorLen {
+			return fmt.Errorf("transactions without a function selector are not allowed for this session key")
+		}
+		if !p.isSelectorAllowed(tx.Data()[:SelectorLen]) {
+			return fmt.Errorf("function selector %s is not allowed for this session key", hexutil.Encode(tx.Data()[:SelectorLen]))
+		}
+	}
+	return nil
+}
+
+// CheckSpend - returns an error if the amount of the transaction with the given nonce added to the spends within the
+// spend window exceeds the spend limit. The spend of a transaction with the same nonce is replaced, so it doesn't count.
+func (p *SessionKeyPolicy) CheckSpend(spends []SessionKeySpend, nonce uint64, amount *big.Int, now time.Time) error {
+	if p == nil || p.SpendLimit == nil {
+		return nil
+	}
+	total := new(big.Int).Set(amount)
+	for _, spend := range p.SpendsInWindow(spends, now) {
+		if spend.Nonce != nonce {
+			total.Add(total, spend.Amount)
+		}
+	}
+	if total.Cmp(p.SpendLimit) > 0 {
+		return fmt.Errorf("session key spend limit of %s exceeded", p.SpendLimit)
+	}
+	return nil
+}
+
+// SpendsInWindow - returns the spends which count against the spend limit at the given time
+func (p *SessionKeyPolicy) SpendsInWindow(spends []SessionKeySpend, now time.Time) []SessionKeySpend {
+	windowStart := now.Add(-p.SpendWindow)
+	res := make([]SessionKeySpend, 0, len(spends))
+	for _, spend := range spends {
+		if spend.Timestamp.After(windowStart) {
+			res = append(res, spend)
+		}
+	}
+	return res
+}
+
+// IsRelaxedBy - returns true if the new policy allows any transaction which this policy rejects.
+// A nil policy allows every transaction.
+func (p *SessionKeyPolicy) IsRelaxedBy(newPolicy *SessionKeyPolicy) bool {
+	if p == nil {
+		return false
+	}
+	if newPolicy == nil {
+		newPolicy = &SessionKeyPolicy{}
+	}
+	if p.MaxValuePerTx != nil && (newPolicy.MaxValuePerTx == nil || newPolicy.MaxValuePerTx.Cmp(p.MaxValuePerTx) > 0) {
+		return true
+	}
+	if p.MaxGasPerTx > 0 && (newPolicy.MaxGasPerTx == 0 || newPolicy.MaxGasPerTx > p.MaxGasPerTx) {
+		return true
+	}
+	if p.SpendLimit != nil && (newPolicy.SpendLimit == nil || newPolicy.SpendLimit.Cmp(p.SpendLimit) > 0 || newPolicy.SpendWindow < p.SpendWindow) {
+		return true
+	}
+	if len(p.AllowedContracts) > 0 {
+		if len(newPolicy.AllowedContracts) == 0 {
+			return true
+		}
+		for _, c := range newPolicy.AllowedContracts {
+			if !p.isContractAllowed(c) {
+				return true
+			}
+		}
+	}
+	if len(p.AllowedSelectors) > 0 {
+		if len(newPolicy.AllowedSelectors) == 0 {
+			return true
+		}
+		for _, s := range newPolicy.AllowedSelectors {
+			if !p.isSelectorAllowed(s[:]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *SessionKeyPolicy) isContractAllowed(to common.Address) bool {
+	for _, c := range p.AllowedContracts {
+		if c == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *SessionKeyPolicy) isSelectorAllowed(selector []byte) bool {
+	for _, s := range p.AllowedSelectors {
+		if [SelectorLen]byte(selector) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// SessionKeyPolicyJSON - the representation of a SessionKeyPolicy in the API requests and responses
+type SessionKeyPolicyJSON struct {
+	MaxValuePerTx    *hexutil.Big     `json:"maxValuePerTx,omitempty"`
+	MaxGasPerTx      hexutil.Uint64   `json:"maxGasPerTx,omitempty"`
+	SpendLimit       *hexutil.Big     `json:"spendLimit,omitempty"`
+	SpendWindow      uint64           `json:"spendWindow,omitempty"` // seconds
+	AllowedContracts []common.Address `json:"allowedContracts,omitempty"`
+	AllowedSelectors []hexutil.Bytes  `json:"allowedSelectors,omitempty"`
+}
+
+func (p *SessionKeyPolicyJSON) ToPolicy() (*SessionKeyPolicy, error) {
+	if p.SpendLimit != nil && p.SpendWindow == 0 {
+		return nil, fmt.Errorf("spendWindow is required when spendLimit is set")
+	}
+	policy := &SessionKeyPolicy{
+		MaxValuePerTx:    (*big.Int)(p.MaxValuePerTx),
+		MaxGasPerTx:      uint64(p.MaxGasPerTx),
+		SpendLimit:       (*big.Int)(p.SpendLimit),
+		SpendWindow:      time.Duration(p.SpendWindow) * time.Second,
+		AllowedContracts: p.AllowedContracts,
+	}
+	for _, s := range p.AllowedSelectors {
+		if len(s) != SelectorLen {
+			return nil, fmt.Errorf("invalid function selector %s", s)
+		}
+		policy.AllowedSelectors = append(policy.AllowedSelectors, [SelectorLen]byte(s))
+	}
+	return policy, nil
+}
+
+func NewSessionKeyPolicyJSON(p *SessionKeyPolicy) *SessionKeyPolicyJSON {
+	if p == nil {
+		return nil
+	}
+	res := &SessionKeyPolicyJSON{
+		MaxValuePerTx:    (*hexutil.Big)(p.MaxValuePerTx),
+		MaxGasPerTx:      hexutil.Uint64(p.MaxGasPerTx),
+		SpendLimit:       (*hexutil.Big)(p.SpendLimit),
+		SpendWindow:      uint64(p.SpendWindow / time.Second),
+		AllowedContracts: p.AllowedContracts,
+	}
+	for _, s := range p.AllowedSelectors {
+		selector := s
+		res.AllowedSelectors = append(res.AllowedSelectors, selector[:])
+	}
+	return res
+}
+
+// SessionKeyPolicyAuth - the signature by one of the accounts of the user over a change which relaxes or removes the
+// policy of a session key. The user token alone is not enough for such changes.
+type SessionKeyPolicyAuth struct {
+	Signature hexutil.Bytes `json:"signature"` // personal_sign signature over SessionKeyPolicyMessage
+	IssuedAt  uint64        `json:"issuedAt"`  // unix seconds
+}
+
+// SessionKeyPolicyMessage - the message signed to authorise a policy change. The policy is encoded as
+// SessionKeyPolicyJSON, and a missing expiry is encoded as 0
+func SessionKeyPolicyMessage(userID []byte, sessionKey common.Address, policy *SessionKeyPolicy, expiresAt *time.Time, issuedAt uint64) ([]byte, error) {
+	policyJSON := NewSessionKeyPolicyJSON(policy)
+	if policyJSON == nil {
+		policyJSON = &SessionKeyPolicyJSON{}
+	}
+	encodedPolicy, err := json.Marshal(policyJSON)
+	if err != nil {
+		return nil, err
+	}
+	var expiry int64
+	if expiresAt != nil {
+		expiry = expiresAt.Unix()
+	}
+	return []byte(fmt.Sprintf("Update the policy of session key %s of user %s\nPolicy: %s\nExpires at: %d\nIssued at: %d",
+		sessionKey.Hex(), hexutil.Encode(userID), encodedPolicy, expiry, issuedAt)), nil
+}
+
+// VerifySigner - returns the account which signed the policy change, if the signature is recent
+func (a *SessionKeyPolicyAuth) VerifySigner(userID []byte, sessionKey common.Address, policy *SessionKeyPolicy, expiresAt *time.Time, now time.Time) (*common.Address, error) {
+	issuedAt := time.Unix(int64(a.IssuedAt), 0)
+	if issuedAt.After(now.Add(policyAuthValidity)) || issuedAt.Before(now.Add(-policyAuthValidity)) {
+		return nil, fmt.Errorf("the policy change signature expired")
+	}
+	if len(a.Signature) != 65 {
+		return nil, fmt.Errorf("invalid signature length: %d", len(a.Signature))
+	}
+	msg, err := SessionKeyPolicyMessage(userID, sessionKey, policy, expiresAt, a.IssuedAt)
+	if err != nil {
+		return nil, err
+	}
+	signature := common.CopyBytes(a.Signature)
+	// wallets return the legacy V of 27/28
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+	return viewingkey.CheckSignatureAndReturnAccountAddress(accounts.TextHash(msg), signature)
+}
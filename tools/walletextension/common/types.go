@@ -1,6 +1,8 @@
 package common
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/crypto/ecies"
 	"github.com/ten-protocol/go-ten/go/common/viewingkey"
 	"golang.org/x/exp/maps"
@@ -12,6 +14,22 @@ import (
 type GWSessionKey struct {
 	Account    *GWAccount
 	PrivateKey *ecies.PrivateKey // the private key corresponding to the account
+	Label      string            // user supplied name of the key (e.g. the dApp or device using it)
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time // nil means the key never expires
+	Active     bool       // the session key is active, and it can be used to sign incoming transactions
+	Policy     *SessionKeyPolicy
+	Spends     []SessionKeySpend // the spends recorded within the spend window of the policy
+}
+
+// IsExpired returns true if the session key has an expiry time in the past
+func (sk *GWSessionKey) IsExpired() bool {
+	return sk.ExpiresAt != nil && time.Now().After(*sk.ExpiresAt)
+}
+
+// IsUsable returns true if the session key can be used to sign transactions
+func (sk *GWSessionKey) IsUsable() bool {
+	return sk.Active && !sk.IsExpired()
 }
 
 type GWAccount struct {
@@ -22,17 +40,16 @@ type GWAccount struct {
 }
 
 type GWUser struct {
-	ID         []byte
-	Accounts   map[common.Address]*GWAccount
-	UserKey    []byte
-	SessionKey *GWSessionKey
-	ActiveSK   bool // the session key is active, and it must be used to sign all incoming transactions, and used as the preferred account
+	ID          []byte
+	Accounts    map[common.Address]*GWAccount
+	UserKey     []byte
+	SessionKeys map[common.Address]*GWSessionKey
 }
 
 func (u GWUser) AllAccounts() map[common.Address]*GWAccount {
 	res := maps.Clone(u.Accounts)
-	if u.SessionKey != nil {
-		res[*u.SessionKey.Account.Address] = u.SessionKey.Account
+	for addr, sk := range u.SessionKeys {
+		res[addr] = sk.Account
 	}
 	return res
 }
@@ -40,3 +57,60 @@ func (u GWUser) AllAccounts() map[common.Address]*GWAccount {
 func (u GWUser) GetAllAddresses() []common.Address {
 	return maps.Keys(u.AllAccounts())
 }
+
+// ActiveSessionKeys returns the session keys that are active and not expired
+func (u GWUser) ActiveSessionKeys() []*GWSessionKey {
+	res := make([]*GWSessionKey, 0)
+	for _, sk := range u.SessionKeys {
+		if sk.IsUsable() {
+			res = append(res, sk)
+		}
+	}
+	return res
+}
+
+// SelectSessionKey returns the session key that must be used to sign a transaction.
+// If `from` is one of the user's session keys, that key is returned if it is usable. Otherwise, the user must have
+// exactly one usable key.
+// Returns nil if there is no suitable session key.
+func (u GWUser) SelectSessionKey(from *common.Address) *GWSessionKey {
+	if from != nil {
+		if sk, found := u.SessionKeys[*from]; found {
+			if sk.IsUsable() {
+				return sk
+			}
+			return nil
+		}
+	}
+	active := u.ActiveSessionKeys()
+	if len(active) == 1 {
+		return active[0]
+	}
+	return nil
+}
+
+// SessionKeyInfo - the public view of a session key returned to users. It never contains the private key
+type SessionKeyInfo struct {
+	Address   common.Address        `json:"address"`
+	Label     string                `json:"label"`
+	CreatedAt int64                 `json:"createdAt"`
+	ExpiresAt int64                 `json:"expiresAt,omitempty"`
+	Active    bool                  `json:"active"`
+	Expired   bool                  `json:"expired"`
+	Policy    *SessionKeyPolicyJSON `json:"policy,omitempty"`
+}
+
+func (sk *GWSessionKey) Info() SessionKeyInfo {
+	info := SessionKeyInfo{
+		Address:   *sk.Account.Address,
+		Label:     sk.Label,
+		CreatedAt: sk.CreatedAt.Unix(),
+		Active:    sk.Active,
+		Expired:   sk.IsExpired(),
+		Policy:    NewSessionKeyPolicyJSON(sk.Policy),
+	}
+	if sk.ExpiresAt != nil {
+		info.ExpiresAt = sk.ExpiresAt.Unix()
+	}
+	return info
+}
@@ -7,6 +7,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
 
 	tencommon "github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/tools/walletextension/keymanager"
@@ -90,6 +93,10 @@ func NewHTTPRoutes(walletExt *services.Services) []node.Route {
 			Name: common.APIVersion1 + common.PathSessionKeys + "list",
 			Func: httpHandler(walletExt, listSKRequestHandler),
 		},
+		{
+			Name: common.APIVersion1 + common.PathSessionKeys + "policy",
+			Func: httpHandler(walletExt, setSKPolicyRequestHandler),
+		},
 	}
 }
 
@@ -547,49 +554,96 @@ func getMessageRequestHandler(walletExt *services.Services, conn UserConn) {
 	}
 }
 
+// sessionKeyRequest - the optional JSON body of the /session-key/ requests
+type sessionKeyRequest struct {
+	Address   *gethcommon.Address `json:"address"`
+	Label     string              `json:"label"`
+	ExpiresIn uint64              `json:"expiresIn"` // seconds. 0 means the key never expires
+
+	// used by the policy request
+	Policy    *common.SessionKeyPolicyJSON `json:"policy"`
+	ExpiresAt uint64                       `json:"expiresAt"` // unix seconds. 0 means the key never expires
+	Auth      *common.SessionKeyPolicyAuth `json:"auth"`      // required to relax the policy
+}
+
 func listSKRequestHandler(walletExt *services.Services, conn UserConn) {
+	withUser(walletExt, conn, func(user *common.GWUser, _ *sessionKeyRequest) ([]byte, error) {
+		sks := walletExt.SKManager.ListSessionKeys(user)
+		res := make([]common.SessionKeyInfo, len(sks))
+		for i, sk := range sks {
+			res[i] = sk.Info()
+		}
+		return json.Marshal(res)
+	})
 }
 
 func createSKRequestHandler(walletExt *services.Services, conn UserConn) {
-	withUser(walletExt, conn, func(user *common.GWUser) ([]byte, error) {
-		sk, err := walletExt.SKManager.CreateSessionKey(user)
+	withUser(walletExt, conn, func(user *common.GWUser, req *sessionKeyRequest) ([]byte, error) {
+		sk, err := walletExt.SKManager.CreateSessionKey(user, req.Label, time.Duration(req.ExpiresIn)*time.Second)
 		if err != nil {
-			handleError(conn, walletExt.Logger(), fmt.Errorf("could not create session key: %w", err))
-			return nil, err
+			return nil, fmt.Errorf("could not create session key: %w", err)
 		}
 		return []byte(hexutils.BytesToHex(sk.Account.Address.Bytes())), nil
 	})
 }
 
 func deleteSKRequestHandler(walletExt *services.Services, conn UserConn) {
-	withUser(walletExt, conn, func(user *common.GWUser) ([]byte, error) {
-		res, err := walletExt.SKManager.DeleteSessionKey(user)
+	withUser(walletExt, conn, func(user *common.GWUser, req *sessionKeyRequest) ([]byte, error) {
+		res, err := walletExt.SKManager.DeleteSessionKey(user, req.Address)
 		return []byte{boolToByte(res)}, err
 	})
 }
 
 func activateSKRequestHandler(walletExt *services.Services, conn UserConn) {
-	withUser(walletExt, conn, func(user *common.GWUser) ([]byte, error) {
-		res, err := walletExt.SKManager.ActivateSessionKey(user)
+	withUser(walletExt, conn, func(user *common.GWUser, req *sessionKeyRequest) ([]byte, error) {
+		res, err := walletExt.SKManager.ActivateSessionKey(user, req.Address)
 		return []byte{boolToByte(res)}, err
 	})
 }
 
 func deactivateSKRequestHandler(walletExt *services.Services, conn UserConn) {
-	withUser(walletExt, conn, func(user *common.GWUser) ([]byte, error) {
-		res, err := walletExt.SKManager.DeactivateSessionKey(user)
+	withUser(walletExt, conn, func(user *common.GWUser, req *sessionKeyRequest) ([]byte, error) {
+		res, err := walletExt.SKManager.DeactivateSessionKey(user, req.Address)
 		return []byte{boolToByte(res)}, err
 	})
 }
 
-// extracts the user from the request, and writes the response to the connection
-func withUser(walletExt *services.Services, conn UserConn, withUser func(user *common.GWUser) ([]byte, error)) {
-	_, err := conn.ReadRequest()
+func setSKPolicyRequestHandler(walletExt *services.Services, conn UserConn) {
+	withUser(walletExt, conn, func(user *common.GWUser, req *sessionKeyRequest) ([]byte, error) {
+		policy := &common.SessionKeyPolicy{}
+		if req.Policy != nil {
+			var err error
+			policy, err = req.Policy.ToPolicy()
+			if err != nil {
+				return nil, fmt.Errorf("invalid session key policy: %w", err)
+			}
+		}
+		var expiresAt *time.Time
+		if req.ExpiresAt > 0 {
+			t := time.Unix(int64(req.ExpiresAt), 0)
+			expiresAt = &t
+		}
+		res, err := walletExt.SKManager.SetSessionKeyPolicy(user, req.Address, policy, expiresAt, req.Auth)
+		return []byte{boolToByte(res)}, err
+	})
+}
+
+// extracts the user and the optional session key parameters from the request, and writes the response to the connection
+func withUser(walletExt *services.Services, conn UserConn, withUser func(user *common.GWUser, req *sessionKeyRequest) ([]byte, error)) {
+	body, err := conn.ReadRequest()
 	if err != nil {
 		handleError(conn, walletExt.Logger(), fmt.Errorf("error reading request: %w", err))
 		return
 	}
 
+	req := &sessionKeyRequest{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, req); err != nil {
+			handleError(conn, walletExt.Logger(), fmt.Errorf("could not unmarshal request body - %w", err))
+			return
+		}
+	}
+
 	userID, err := getUserID(conn)
 	if err != nil {
 		handleError(conn, walletExt.Logger(), fmt.Errorf("user ('u') not found in query parameters"))
@@ -603,7 +657,7 @@ func withUser(walletExt *services.Services, conn UserConn, withUser func(user *c
 		return
 	}
 
-	resp, err := withUser(user)
+	resp, err := withUser(user, req)
 	if err != nil {
 		handleError(conn, walletExt.Logger(), fmt.Errorf("could not process request: %w", err))
 		return
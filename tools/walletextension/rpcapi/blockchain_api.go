@@ -88,8 +88,26 @@ type StorageResult struct {
 	Proof []string     `json:"proof"`
 }
 
-func (s *BlockChainAPI) GetProof(ctx context.Context, address gethcommon.Address, storageKeys []string, blockNrOrHash rpc.BlockNumberOrHash) (*AccountResult, error) {
-	return nil, rpcNotImplemented
+// GetProof returns the account proof only for the accounts registered to the user. The storage proofs of a contract
+// are returned for the slots the user is allowed to read with eth_getStorageAt.
+func (api *BlockChainAPI) GetProof(ctx context.Context, address gethcommon.Address, storageKeys []string, blockNrOrHash rpc.BlockNumberOrHash) (*AccountResult, error) {
+	return ExecAuthRPC[AccountResult](
+		ctx,
+		api.we,
+		&AuthExecCfg{
+			cacheCfg: &cache.Cfg{
+				DynamicType: func() cache.Strategy {
+					return cacheBlockNumberOrHash(blockNrOrHash)
+				},
+			},
+			account: &address,
+			tryAll:  true, // the storage proofs of a contract don't require a specific account
+		},
+		tenrpc.ERPCGetProof,
+		address,
+		storageKeys,
+		blockNrOrHash,
+	)
 }
 
 func (api *BlockChainAPI) GetHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (map[string]interface{}, error) {
@@ -207,19 +225,19 @@ func (api *BlockChainAPI) GetStorageAt(ctx context.Context, address gethcommon.A
 		}
 		return serialised, nil
 	case common.CreateSessionKeyCQMethod:
-		sk, err := api.we.SKManager.CreateSessionKey(user)
+		sk, err := api.we.SKManager.CreateSessionKey(user, "", 0)
 		if err != nil {
 			return nil, fmt.Errorf("unable to create session key: %w", err)
 		}
 		return sk.Account.Address.Bytes(), nil
 	case common.ActivateSessionKeyCQMethod:
-		res, err := api.we.SKManager.ActivateSessionKey(user)
+		res, err := api.we.SKManager.ActivateSessionKey(user, extractOptionalSessionKeyAddress(params))
 		return []byte{boolToByte(res)}, err
 	case common.DeactivateSessionKeyCQMethod:
-		res, err := api.we.SKManager.DeactivateSessionKey(user)
+		res, err := api.we.SKManager.DeactivateSessionKey(user, extractOptionalSessionKeyAddress(params))
 		return []byte{boolToByte(res)}, err
 	case common.DeleteSessionKeyCQMethod:
-		res, err := api.we.SKManager.DeleteSessionKey(user)
+		res, err := api.we.SKManager.DeleteSessionKey(user, extractOptionalSessionKeyAddress(params))
 		return []byte{boolToByte(res)}, err
 	default: // address was not a recognised custom query method address
 		resp, err := ExecAuthRPC[any](ctx, api.we, &AuthExecCfg{tryUntilAuthorised: true}, tenrpc.ERPCGetStorageAt, address, params, nil)
@@ -338,14 +356,87 @@ func cloneArgs(args gethapi.TransactionArgs) gethapi.TransactionArgs {
 	return argsClone
 }
 
-type accessListResult struct {
-	Accesslist *types.AccessList `json:"accessList"`
-	Error      string            `json:"error,omitempty"`
-	GasUsed    hexutil.Uint64    `json:"gasUsed"`
+func (api *BlockChainAPI) CreateAccessList(ctx context.Context, args gethapi.TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash) (*gethapi.AccessListResult, error) {
+	return ExecAuthRPC[gethapi.AccessListResult](ctx, api.we, &AuthExecCfg{
+		cacheCfg: &cache.Cfg{
+			DynamicType: func() cache.Strategy {
+				if blockNrOrHash != nil {
+					return cacheBlockNumberOrHash(*blockNrOrHash)
+				}
+				return cache.LatestBatch
+			},
+		},
+		computeFromCallback: func(user *wecommon.GWUser) *gethcommon.Address {
+			return searchFromAndData(user.GetAllAddresses(), args)
+		},
+		adjustArgs: func(acct *wecommon.GWAccount) []any {
+			argsClone := populateFrom(acct, args)
+			return []any{argsClone, blockNrOrHash}
+		},
+		tryAll: true,
+	}, tenrpc.ERPCCreateAccessList, args, blockNrOrHash)
 }
 
-func (s *BlockChainAPI) CreateAccessList(ctx context.Context, args gethapi.TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash) (*accessListResult, error) {
-	return nil, rpcNotImplemented
+func (api *BlockChainAPI) SimulateV1(ctx context.Context, opts gethapi.SimOpts, blockNrOrHash *rpc.BlockNumberOrHash) ([]gethapi.SimBlockResult, error) {
+	resp, err := ExecAuthRPC[[]gethapi.SimBlockResult](ctx, api.we, &AuthExecCfg{
+		cacheCfg: &cache.Cfg{
+			DynamicType: func() cache.Strategy {
+				if blockNrOrHash != nil {
+					return cacheBlockNumberOrHash(*blockNrOrHash)
+				}
+				return cache.LatestBatch
+			},
+		},
+		computeFromCallback: func(user *wecommon.GWUser) *gethcommon.Address {
+			for _, block := range opts.BlockStateCalls {
+				for _, call := range block.Calls {
+					if from := searchFromAndData(user.GetAllAddresses(), call); from != nil {
+						return from
+					}
+				}
+			}
+			return nil
+		},
+		adjustArgs: func(acct *wecommon.GWAccount) []any {
+			return []any{populateSimulationFrom(acct, opts), blockNrOrHash}
+		},
+		tryAll: true,
+	}, tenrpc.ERPCSimulate, opts, blockNrOrHash)
+	if resp == nil {
+		return nil, err
+	}
+	return *resp, err
+}
+
+// populateSimulationFrom - the enclave requires all the simulated calls to be made by the account of the viewing key
+func populateSimulationFrom(acct *wecommon.GWAccount, opts gethapi.SimOpts) gethapi.SimOpts {
+	optsClone := gethapi.SimOpts{
+		BlockStateCalls:        make([]gethapi.SimBlock, len(opts.BlockStateCalls)),
+		TraceTransfers:         opts.TraceTransfers,
+		Validation:             opts.Validation,
+		ReturnFullTransactions: opts.ReturnFullTransactions,
+	}
+	for i, block := range opts.BlockStateCalls {
+		optsClone.BlockStateCalls[i] = gethapi.SimBlock{
+			BlockOverrides: block.BlockOverrides,
+			StateOverrides: block.StateOverrides,
+			Calls:          make([]gethapi.TransactionArgs, len(block.Calls)),
+		}
+		for j, call := range block.Calls {
+			optsClone.BlockStateCalls[i].Calls[j] = populateFrom(acct, call)
+		}
+	}
+	return optsClone
+}
+
+// extractOptionalSessionKeyAddress - the session key custom queries can specify the key using the "address" convention.
+// When the address is missing, the SKManager falls back to the single session key of the user
+func extractOptionalSessionKeyAddress(params string) *gethcommon.Address {
+	addr, err := extractCustomQueryAddress(params)
+	if err != nil {
+		return nil
+	}
+	return addr
 }
 
 func extractCustomQueryAddress(params any) (*gethcommon.Address, error) {
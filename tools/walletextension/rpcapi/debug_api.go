@@ -2,6 +2,7 @@ package rpcapi
 
 import (
 	"context"
+	"encoding/json"
 
 	tenrpc "github.com/ten-protocol/go-ten/go/common/rpc"
 
@@ -12,6 +13,7 @@ import (
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/tracers"
 	"github.com/ten-protocol/go-ten/lib/gethfork/rpc"
 )
 
@@ -77,3 +79,26 @@ func (api *DebugAPI) EventLogRelevancy(ctx context.Context, crit common.FilterCr
 	}
 	return *l, nil
 }
+
+// TraceTransaction - returns the trace of the transaction, produced by the tracer selected in the config
+// ("callTracer", "prestateTracer", "4byteTracer" or the struct logger by default).
+// The trace reveals the state touched by the transaction, so only the sender can request it.
+func (api *DebugAPI) TraceTransaction(ctx context.Context, hash gethcommon.Hash, config *tracers.TraceConfig) (json.RawMessage, error) {
+	trace, err := ExecAuthRPC[json.RawMessage](
+		ctx,
+		api.we,
+		&AuthExecCfg{
+			cacheCfg: &cache.Cfg{
+				Type: cache.NoCache,
+			},
+			tryUntilAuthorised: true,
+		},
+		tenrpc.ERPCDebugTraceTransaction,
+		hash,
+		config,
+	)
+	if err != nil || trace == nil {
+		return nil, err
+	}
+	return *trace, nil
+}
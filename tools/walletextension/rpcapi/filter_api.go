@@ -2,15 +2,20 @@ package rpcapi
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"sort"
 	"sync/atomic"
 	"time"
 
+	tenlog "github.com/ten-protocol/go-ten/go/common/log"
 	rpc2 "github.com/ten-protocol/go-ten/go/common/rpc"
+	enclaverpc "github.com/ten-protocol/go-ten/go/enclave/rpc"
 	tenrpc "github.com/ten-protocol/go-ten/go/rpc"
 
 	"github.com/ten-protocol/go-ten/tools/walletextension/cache"
+	"golang.org/x/exp/maps"
 
 	"github.com/ten-protocol/go-ten/tools/walletextension/services"
 
@@ -41,17 +46,96 @@ func NewFilterAPI(we *services.Services) *FilterAPI {
 	}
 }
 
-func (api *FilterAPI) NewPendingTransactionFilter(_ *bool) rpc.ID {
-	return "not supported"
+// the interval at which the mempool is polled for the pending transactions subscriptions
+const pendingTxsPollInterval = time.Second
+
+// NewPendingTransactionFilter installs a polling filter for the transactions of the user's accounts which enter the
+// mempool. The transactions of other senders are not visible to the user.
+func (api *FilterAPI) NewPendingTransactionFilter(ctx context.Context, fullTx *bool) (rpc.ID, error) {
+	user, err := extractUserForRequest(ctx, api.we)
+	if err != nil {
+		return "", err
+	}
+	pendingTxs, err := fetchUserPendingTxs(ctx, api.we, user)
+	if err != nil {
+		return "", err
+	}
+	hashes, _ := pendingTxsByHash(pendingTxs)
+	audit(api.we, "RPC NewPendingTransactionFilter. uid=%s", hexutils.BytesToHex(user.ID))
+	return api.we.Filters.InstallPendingTxFilter(user.ID, fullTx != nil && *fullTx, hashes)
 }
 
+// NewPendingTransactions notifies the hashes (or the full transactions) of the transactions of the user's accounts
+// which enter the mempool. The transactions of other senders are not visible to the user.
+// Each subscription polls the mempool, so the number of subscriptions is limited.
 func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error) {
-	return nil, fmt.Errorf("not supported")
+	audit(api.we, "start NewPendingTransactions subscription")
+	subNotifier, user, err := getUserAndNotifier(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+	// the transactions already pending are not notified
+	pendingTxs, err := fetchUserPendingTxs(ctx, api.we, user)
+	if err != nil {
+		return nil, err
+	}
+	seen, _ := pendingTxsByHash(pendingTxs)
+
+	if err := api.we.Filters.AddPendingTxSubscription(user.ID); err != nil {
+		return nil, err
+	}
+	subscription := subNotifier.CreateSubscription()
+	go func() {
+		defer api.we.Filters.RemovePendingTxSubscription(user.ID)
+		ticker := time.NewTicker(pendingTxsPollInterval)
+		defer ticker.Stop()
+		seenTxs := make(map[gethcommon.Hash]bool)
+		for _, hash := range seen {
+			seenTxs[hash] = true
+		}
+		for {
+			select {
+			case <-subscription.Err():
+				return
+			case <-ticker.C:
+				// the subscription outlives the request, so its context can't be used
+				pendingTxs, err := fetchUserPendingTxs(context.Background(), api.we, user)
+				if err != nil {
+					api.logger.Debug("Could not read the pending transactions", tenlog.ErrKey, err)
+					continue
+				}
+				hashes, txs := pendingTxsByHash(pendingTxs)
+				currentTxs := make(map[gethcommon.Hash]bool, len(hashes))
+				for _, hash := range hashes {
+					currentTxs[hash] = true
+					if seenTxs[hash] {
+						continue
+					}
+					var notification any = hash
+					if fullTx != nil && *fullTx {
+						notification = txs[hash]
+					}
+					if err := subNotifier.Notify(subscription.ID, notification); err != nil {
+						api.logger.Debug("Could not notify the pending transaction", tenlog.ErrKey, err)
+						return
+					}
+				}
+				// only the currently pending transactions are kept, so the set does not grow with the mined transactions
+				seenTxs = currentTxs
+			}
+		}
+	}()
+	return subscription, nil
 }
 
-func (api *FilterAPI) NewBlockFilter() rpc.ID {
-	// not implemented
-	return ""
+// NewBlockFilter installs a polling filter for the new batches, the hashes are accumulated from the new heads
+func (api *FilterAPI) NewBlockFilter(ctx context.Context) (rpc.ID, error) {
+	user, err := extractUserForRequest(ctx, api.we)
+	if err != nil {
+		return "", err
+	}
+	audit(api.we, "RPC NewBlockFilter. uid=%s", hexutils.BytesToHex(user.ID))
+	return api.we.Filters.Install(user.ID, services.BlockFilter, common.FilterCriteria{})
 }
 
 func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
@@ -189,8 +273,17 @@ func searchForAddressInFilterCriteria(filterCriteria common.FilterCriteria, poss
 	return result
 }
 
-func (api *FilterAPI) NewFilter(crit common.FilterCriteria) (rpc.ID, error) {
-	return rpc.NewID(), rpcNotImplemented
+// NewFilter installs a polling logs filter. The logs are read with the viewing keys of the user when the filter is polled.
+func (api *FilterAPI) NewFilter(ctx context.Context, crit common.FilterCriteria) (rpc.ID, error) {
+	user, err := extractUserForRequest(ctx, api.we)
+	if err != nil {
+		return "", err
+	}
+	if crit.BlockHash != nil {
+		return "", fmt.Errorf("filters with a block hash are not supported, use eth_getLogs instead")
+	}
+	audit(api.we, "RPC NewFilter. uid=%s, crit=%v", hexutils.BytesToHex(user.ID), crit)
+	return api.we.Filters.Install(user.ID, services.LogsFilter, crit)
 }
 
 func (api *FilterAPI) GetLogs(ctx context.Context, crit common.FilterCriteria) ([]*types.Log, error) {
@@ -224,45 +317,7 @@ func (api *FilterAPI) GetLogs(ctx context.Context, crit common.FilterCriteria) (
 		},
 		generateCacheKey([]any{user.ID, method, common.SerializableFilterCriteria(crit)}),
 		func() (*[]*types.Log, error) { // called when there is no entry in the cache
-			allEventLogsMap := make(map[LogKey]*types.Log)
-			// for each account registered for the current user
-			// execute the get_Logs function
-			// dedupe and concatenate the results
-			for _, acct := range user.AllAccounts() {
-				eventLogs, err := services.WithEncRPCConnection(ctx, api.we.BackendRPC, acct, func(rpcClient *tenrpc.EncRPCClient) (*[]*types.Log, error) {
-					var result []*types.Log
-
-					// wrap the context with a timeout to prevent long executions
-					timeoutContext, cancelCtx := context.WithTimeout(ctx, maximumRPCCallDuration)
-					defer cancelCtx()
-
-					err := rpcClient.CallContext(timeoutContext, &result, method, common.SerializableFilterCriteria(crit))
-					return &result, err
-				})
-				if err != nil {
-					return nil, fmt.Errorf("could not read logs. cause: %w", err)
-				}
-				// dedupe event logs
-				for _, eventLog := range *eventLogs {
-					allEventLogsMap[LogKey{
-						BlockHash: eventLog.BlockHash,
-						TxHash:    eventLog.TxHash,
-						Index:     eventLog.Index,
-					}] = eventLog
-				}
-			}
-
-			result := make([]*types.Log, 0)
-			for _, eventLog := range allEventLogsMap {
-				result = append(result, eventLog)
-			}
-			sort.Slice(result, func(i, j int) bool {
-				if result[i].BlockNumber == result[j].BlockNumber {
-					return result[i].Index < result[j].Index
-				}
-				return result[i].BlockNumber < result[j].BlockNumber
-			})
-			return &result, nil
+			return fetchUserLogs(ctx, api.we, user, crit)
 		})
 	if err != nil {
 		return nil, err
@@ -271,22 +326,154 @@ func (api *FilterAPI) GetLogs(ctx context.Context, crit common.FilterCriteria) (
 	return *res, err
 }
 
-func (api *FilterAPI) UninstallFilter(id rpc.ID) bool {
-	// not implemented
-	return false
+func (api *FilterAPI) UninstallFilter(ctx context.Context, id rpc.ID) bool {
+	user, err := extractUserForRequest(ctx, api.we)
+	if err != nil {
+		return false
+	}
+	return api.we.Filters.Uninstall(user.ID, id)
 }
 
+// GetFilterLogs returns all the logs matching the criteria of the logs filter
 func (api *FilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*types.Log, error) {
-	//txRec, err := ExecAuthRPC[[]*types.Log](ctx, api.we, "GetFilterLogs", AuthExecCfg{account: args.From}, id)
-	//if txRec != nil {
-	//	return *txRec, err
-	//}
-	//return common.Hash{}, err
-
-	// not implemented
-	return nil, nil
+	user, err := extractUserForRequest(ctx, api.we)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := api.we.Filters.Get(user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	if filter.Type != services.LogsFilter {
+		return nil, fmt.Errorf("filter %s is not a logs filter", id)
+	}
+	return api.GetLogs(ctx, filter.Criteria)
+}
+
+// GetFilterChanges returns the hashes of the new batches for a block filter, or the new logs for a logs filter, since
+// the last poll
+func (api *FilterAPI) GetFilterChanges(ctx context.Context, id rpc.ID) (interface{}, error) {
+	user, err := extractUserForRequest(ctx, api.we)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := api.we.Filters.Get(user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	switch filter.Type {
+	case services.BlockFilter:
+		return api.we.Filters.TakeBlockHashes(user.ID, id)
+	case services.PendingTxFilter:
+		return api.pendingTxFilterChanges(ctx, user, filter)
+	}
+
+	from, to, err := api.we.Filters.PendingLogsRange(user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	crit := filter.Criteria
+	// the range of the filter criteria is applied on top of the range of the new batches
+	if crit.FromBlock != nil && crit.FromBlock.Sign() > 0 && crit.FromBlock.Uint64() > from {
+		from = crit.FromBlock.Uint64()
+	}
+	if crit.ToBlock != nil && crit.ToBlock.Sign() > 0 && crit.ToBlock.Uint64() < to {
+		to = crit.ToBlock.Uint64()
+	}
+	if from > to {
+		return []*types.Log{}, nil
+	}
+
+	rateLimitAllowed, requestUUID := api.we.RateLimiter.Allow(gethcommon.Address(user.ID))
+	defer api.we.RateLimiter.SetRequestEnd(gethcommon.Address(user.ID), requestUUID)
+	if !rateLimitAllowed {
+		return nil, fmt.Errorf("rate limit exceeded")
+	}
+
+	crit.FromBlock = new(big.Int).SetUint64(from)
+	crit.ToBlock = new(big.Int).SetUint64(to)
+	logs, err := fetchUserLogs(ctx, api.we, user, crit)
+	if err != nil {
+		return nil, err
+	}
+	api.we.Filters.MarkLogsReturned(user.ID, id, to)
+	audit(api.we, "RPC GetFilterChanges. uid=%s, id=%s, from=%d, to=%d, logs=%d", hexutils.BytesToHex(user.ID), id, from, to, len(*logs))
+	return *logs, nil
 }
 
-func (api *FilterAPI) GetFilterChanges(id rpc.ID) (interface{}, error) {
-	return nil, rpcNotImplemented
+// pendingTxFilterChanges returns the hashes (or the full transactions) of the transactions of the user's accounts which
+// entered the mempool since the last poll of the filter
+func (api *FilterAPI) pendingTxFilterChanges(ctx context.Context, user *wecommon.GWUser, filter services.Filter) (interface{}, error) {
+	rateLimitAllowed, requestUUID := api.we.RateLimiter.Allow(gethcommon.Address(user.ID))
+	defer api.we.RateLimiter.SetRequestEnd(gethcommon.Address(user.ID), requestUUID)
+	if !rateLimitAllowed {
+		return nil, fmt.Errorf("rate limit exceeded")
+	}
+
+	pendingTxs, err := fetchUserPendingTxs(ctx, api.we, user)
+	if err != nil {
+		return nil, err
+	}
+	hashes, txs := pendingTxsByHash(pendingTxs)
+	newHashes, err := api.we.Filters.TakeNewPendingTxs(user.ID, filter.ID, hashes)
+	if err != nil {
+		return nil, err
+	}
+	audit(api.we, "RPC GetFilterChanges. uid=%s, id=%s, pendingTxs=%d", hexutils.BytesToHex(user.ID), filter.ID, len(newHashes))
+	if !filter.FullTx {
+		return newHashes, nil
+	}
+	newTxs := make([]*enclaverpc.RpcTransaction, 0, len(newHashes))
+	for _, hash := range newHashes {
+		newTxs = append(newTxs, txs[hash])
+	}
+	return newTxs, nil
+}
+
+// fetchUserLogs reads the logs with the viewing keys of all the accounts of the user, deduping the results
+func fetchUserLogs(ctx context.Context, we *services.Services, user *wecommon.GWUser, crit common.FilterCriteria) (*[]*types.Log, error) {
+	method := rpc2.ERPCGetLogs
+	allEventLogsMap := make(map[LogKey]*types.Log)
+
+	// wrap the context with a timeout to prevent long executions
+	timeoutContext, cancelCtx := context.WithTimeout(ctx, maximumRPCCallDuration)
+	defer cancelCtx()
+
+	// execute the get_Logs function for all the accounts registered for the current user at once
+	// dedupe and concatenate the results
+	accountResponses, err := services.ExecForAccounts(timeoutContext, we.BackendRPC, maps.Values(user.AllAccounts()), method, common.SerializableFilterCriteria(crit))
+	if err != nil {
+		return nil, fmt.Errorf("could not read logs. cause: %w", err)
+	}
+	for _, resp := range accountResponses {
+		if err := resp.Error(); err != nil {
+			return nil, fmt.Errorf("could not read logs. cause: %w", err)
+		}
+		var eventLogs []*types.Log
+		if len(resp.Result) > 0 {
+			if err := json.Unmarshal(resp.Result, &eventLogs); err != nil {
+				return nil, fmt.Errorf("could not decode logs. cause: %w", err)
+			}
+		}
+		// dedupe event logs
+		for _, eventLog := range eventLogs {
+			allEventLogsMap[LogKey{
+				BlockHash: eventLog.BlockHash,
+				TxHash:    eventLog.TxHash,
+				Index:     eventLog.Index,
+			}] = eventLog
+		}
+	}
+
+	result := make([]*types.Log, 0)
+	for _, eventLog := range allEventLogsMap {
+		result = append(result, eventLog)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].BlockNumber == result[j].BlockNumber {
+			return result[i].Index < result[j].Index
+		}
+		return result[i].BlockNumber < result[j].BlockNumber
+	})
+	return &result, nil
 }
@@ -3,7 +3,11 @@ package rpcapi
 import (
 	"context"
 	"fmt"
+	"time"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ten-protocol/go-ten/tools/walletextension/common"
 	"github.com/ten-protocol/go-ten/tools/walletextension/services"
 )
 
@@ -16,42 +20,91 @@ func NewSessionKeyAPI(we *services.Services) *SessionKeyAPI {
 }
 
 // Create - returns hex-encoded checksum address of the newly created SK
-func (api *SessionKeyAPI) Create(ctx context.Context) (string, error) {
+// label and expiresIn (in seconds) are optional. When expiresIn is missing, the key never expires
+func (api *SessionKeyAPI) Create(ctx context.Context, label *string, expiresIn *hexutil.Uint64) (string, error) {
 	user, err := extractUserForRequest(ctx, api.we)
 	if err != nil {
 		return "", err
 	}
 
-	sk, err := api.we.SKManager.CreateSessionKey(user)
+	skLabel := ""
+	if label != nil {
+		skLabel = *label
+	}
+	var expiry time.Duration
+	if expiresIn != nil {
+		expiry = time.Duration(*expiresIn) * time.Second
+	}
+
+	sk, err := api.we.SKManager.CreateSessionKey(user, skLabel, expiry)
 	if err != nil {
 		return "", fmt.Errorf("unable to create session key: %w", err)
 	}
 	return (*sk.Account.Address).Hex(), nil
 }
 
-func (api *SessionKeyAPI) Activate(ctx context.Context) (bool, error) {
+// List - returns all the session keys of the user
+func (api *SessionKeyAPI) List(ctx context.Context) ([]common.SessionKeyInfo, error) {
+	user, err := extractUserForRequest(ctx, api.we)
+	if err != nil {
+		return nil, err
+	}
+
+	sks := api.we.SKManager.ListSessionKeys(user)
+	res := make([]common.SessionKeyInfo, len(sks))
+	for i, sk := range sks {
+		res[i] = sk.Info()
+	}
+	return res, nil
+}
+
+// Activate - activates the session key with the given address. The address can be omitted if the user has a single SK
+func (api *SessionKeyAPI) Activate(ctx context.Context, address *gethcommon.Address) (bool, error) {
 	user, err := extractUserForRequest(ctx, api.we)
 	if err != nil {
 		return false, err
 	}
 
-	return api.we.SKManager.ActivateSessionKey(user)
+	return api.we.SKManager.ActivateSessionKey(user, address)
 }
 
-func (api *SessionKeyAPI) Deactivate(ctx context.Context) (bool, error) {
+// Deactivate - deactivates the session key with the given address. The address can be omitted if the user has a single SK
+func (api *SessionKeyAPI) Deactivate(ctx context.Context, address *gethcommon.Address) (bool, error) {
 	user, err := extractUserForRequest(ctx, api.we)
 	if err != nil {
 		return false, err
 	}
 
-	return api.we.SKManager.DeactivateSessionKey(user)
+	return api.we.SKManager.DeactivateSessionKey(user, address)
+}
+
+// SetPolicy - sets the spending limits, allow-lists and expiry (unix seconds) of the session key.
+// The policy replaces the previous one, and a missing expiry means the key never expires.
+// Relaxing or removing the policy, or extending the expiry, requires the auth signed by an account of the user.
+func (api *SessionKeyAPI) SetPolicy(ctx context.Context, address *gethcommon.Address, policy common.SessionKeyPolicyJSON, expiresAt *hexutil.Uint64, auth *common.SessionKeyPolicyAuth) (bool, error) {
+	user, err := extractUserForRequest(ctx, api.we)
+	if err != nil {
+		return false, err
+	}
+
+	skPolicy, err := policy.ToPolicy()
+	if err != nil {
+		return false, fmt.Errorf("invalid session key policy: %w", err)
+	}
+	var expiry *time.Time
+	if expiresAt != nil {
+		t := time.Unix(int64(*expiresAt), 0)
+		expiry = &t
+	}
+	return api.we.SKManager.SetSessionKeyPolicy(user, address, skPolicy, expiry, auth)
 }
 
-func (api *SessionKeyAPI) Delete(ctx context.Context) (bool, error) {
+// Delete - revokes the session key with the given address. The address can be omitted if the user has a single SK
+func (api *SessionKeyAPI) Delete(ctx context.Context, address *gethcommon.Address) (bool, error) {
 	user, err := extractUserForRequest(ctx, api.we)
 	if err != nil {
 		return false, err
 	}
 
-	return api.we.SKManager.DeleteSessionKey(user)
+	return api.we.SKManager.DeleteSessionKey(user, address)
 }
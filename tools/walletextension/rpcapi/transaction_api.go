@@ -3,8 +3,11 @@ package rpcapi
 import (
 	"context"
 	"fmt"
+	"math/big"
 
+	tenlog "github.com/ten-protocol/go-ten/go/common/log"
 	tenrpc "github.com/ten-protocol/go-ten/go/common/rpc"
+	wecommon "github.com/ten-protocol/go-ten/tools/walletextension/common"
 
 	"github.com/ten-protocol/go-ten/tools/walletextension/cache"
 
@@ -12,6 +15,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ten-protocol/go-ten/go/common/gethapi"
 	"github.com/ten-protocol/go-ten/go/enclave/rpc"
@@ -110,22 +114,17 @@ func (s *TransactionAPI) SendTransaction(ctx context.Context, args gethapi.Trans
 	if err != nil {
 		return common.Hash{}, err
 	}
-	if !user.ActiveSK {
+	if len(user.ActiveSessionKeys()) == 0 {
 		return common.Hash{}, fmt.Errorf("please activate session key")
 	}
 
 	// when there is an active Session Key, sign all incoming transactions with that SK
-	signedTx, err := s.we.SKManager.SignTx(ctx, user, args.ToTransaction())
+	signedTx, err := s.we.SKManager.SignTx(ctx, user, args.From, args.ToTransaction())
 	if err != nil {
 		return common.Hash{}, err
 	}
 
-	blob, err := signedTx.MarshalBinary()
-	if err != nil {
-		return common.Hash{}, err
-	}
-
-	return s.sendRawTx(ctx, blob)
+	return s.sendSessionKeyTx(ctx, user, signedTx)
 }
 
 type SignTransactionResult struct {
@@ -143,42 +142,144 @@ func (s *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil.B
 		return common.Hash{}, err
 	}
 
-	signedTxBlob := input
 	// when there is an active Session Key, sign all incoming transactions with that SK
-	if user.ActiveSK && user.SessionKey != nil {
+	if len(user.ActiveSessionKeys()) > 0 {
 		tx := new(types.Transaction)
 		if err = tx.UnmarshalBinary(input); err != nil {
 			return common.Hash{}, err
 		}
-		signedTx, err := s.we.SKManager.SignTx(ctx, user, tx)
+		signedTx, err := s.we.SKManager.SignTx(ctx, user, rawTxSender(tx), tx)
 		if err != nil {
 			return common.Hash{}, err
 		}
-		signedTxBlob, err = signedTx.MarshalBinary()
-		if err != nil {
-			return common.Hash{}, err
+		return s.sendSessionKeyTx(ctx, user, signedTx)
+	}
+
+	return s.sendRawTx(ctx, input)
+}
+
+// bumpFee - returns the fee increased by the minimum percentage the mempool requires for a replacement
+func bumpFee(fee *hexutil.Big) *hexutil.Big {
+	if fee == nil {
+		return nil
+	}
+	bumped := new(big.Int).Mul(fee.ToInt(), big.NewInt(int64(100+legacypool.DefaultConfig.PriceBump)))
+	bumped.Add(bumped, big.NewInt(99))
+	return (*hexutil.Big)(bumped.Div(bumped, big.NewInt(100)))
+}
+
+// setReplacementFees - sets the fees of the replacement of the transaction described by sendArgs. The mempool only
+// accepts the replacement if the fees of the original are bumped: the gas price of a legacy transaction, or both the fee
+// cap and the tip of a dynamic fee transaction. The requested gas price is used instead when it is higher.
+func setReplacementFees(sendArgs *gethapi.TransactionArgs, gasPrice *hexutil.Big) {
+	if gasPrice != nil && gasPrice.ToInt().Sign() == 0 {
+		gasPrice = nil
+	}
+	atLeastGasPrice := func(fee *hexutil.Big) *hexutil.Big {
+		if gasPrice != nil && (fee == nil || gasPrice.ToInt().Cmp(fee.ToInt()) > 0) {
+			return gasPrice
 		}
+		return fee
+	}
+	if sendArgs.MaxFeePerGas != nil || sendArgs.MaxPriorityFeePerGas != nil {
+		sendArgs.MaxFeePerGas = atLeastGasPrice(bumpFee(sendArgs.MaxFeePerGas))
+		sendArgs.MaxPriorityFeePerGas = bumpFee(sendArgs.MaxPriorityFeePerGas)
+		return
 	}
+	sendArgs.GasPrice = atLeastGasPrice(bumpFee(sendArgs.GasPrice))
+}
 
-	return s.sendRawTx(ctx, signedTxBlob)
+// rawTxSender - the account which signed the raw transaction, so that a transaction already signed with one of the
+// session keys of the user is signed again with the same key. Returns nil for unsigned transactions, which are signed
+// with the single active session key of the user.
+func rawTxSender(tx *types.Transaction) *common.Address {
+	if v, r, s := tx.RawSignatureValues(); v.Sign() == 0 && r.Sign() == 0 && s.Sign() == 0 {
+		return nil
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil
+	}
+	return &sender
+}
+
+// sendSessionKeyTx - submits a transaction signed with a session key. The cost reserved against the spend limit of the
+// key when signing is released if the submission fails.
+func (s *TransactionAPI) sendSessionKeyTx(ctx context.Context, user *wecommon.GWUser, signedTx *types.Transaction) (common.Hash, error) {
+	blob, err := signedTx.MarshalBinary()
+	if err != nil {
+		s.releaseSpend(user, signedTx)
+		return common.Hash{}, err
+	}
+	txHash, err := s.sendRawTx(ctx, blob)
+	if err != nil {
+		s.releaseSpend(user, signedTx)
+		return common.Hash{}, err
+	}
+	return txHash, nil
+}
+
+// releaseSpend - the submission already failed, so a failure to release the spend is only logged
+func (s *TransactionAPI) releaseSpend(user *wecommon.GWUser, signedTx *types.Transaction) {
+	if err := s.we.SKManager.ReleaseSpend(user, signedTx); err != nil {
+		s.we.Logger().Warn("Could not release the session key spend", tenlog.TxKey, signedTx.Hash(), tenlog.ErrKey, err)
+	}
 }
 
 func (s *TransactionAPI) sendRawTx(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
-	txRec, err := ExecAuthRPC[common.Hash](ctx, s.we, &AuthExecCfg{tryAll: true, timeout: sendTransactionDuration}, tenrpc.ERPCSendRawTransaction, input)
+	txRec, err := ExecAuthRPC[common.Hash](ctx, s.we, sendRawTxCfg(), tenrpc.ERPCSendRawTransaction, input)
 	if err != nil {
 		return common.Hash{}, err
 	}
 	return *txRec, err
 }
 
+// sendRawTxCfg - the transaction can be submitted by any account of the user, but only once
+func sendRawTxCfg() *AuthExecCfg {
+	return &AuthExecCfg{tryAll: true, hasSideEffects: true, timeout: sendTransactionDuration}
+}
+
 func (s *TransactionAPI) PendingTransactions() ([]*rpc.RpcTransaction, error) {
 	return nil, rpcNotImplemented
 }
 
+// Resend - replaces a pending transaction with the same nonce and new gas parameters.
+// The gateway can only sign the replacement for session keys. Other accounts must sign the replacement themselves
+// and submit it with eth_sendRawTransaction.
 func (s *TransactionAPI) Resend(ctx context.Context, sendArgs gethapi.TransactionArgs, gasPrice *hexutil.Big, gasLimit *hexutil.Uint64) (common.Hash, error) {
-	txRec, err := ExecAuthRPC[common.Hash](ctx, s.we, &AuthExecCfg{account: sendArgs.From}, tenrpc.ERPCResend, sendArgs, gasPrice, gasLimit)
-	if txRec != nil {
-		return *txRec, err
+	user, err := extractUserForRequest(ctx, s.we)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if sendArgs.From == nil {
+		return common.Hash{}, fmt.Errorf("missing from in transaction spec")
+	}
+	if sendArgs.Nonce == nil {
+		return common.Hash{}, fmt.Errorf("missing transaction nonce in transaction spec")
+	}
+	if _, found := user.SessionKeys[*sendArgs.From]; !found {
+		return common.Hash{}, fmt.Errorf("resend is only supported for session keys. Please sign the replacement transaction and use eth_sendRawTransaction")
+	}
+
+	setReplacementFees(&sendArgs, gasPrice)
+	if gasLimit != nil && *gasLimit != 0 {
+		sendArgs.Gas = gasLimit
+	}
+
+	signedTx, err := s.we.SKManager.SignTx(ctx, user, sendArgs.From, sendArgs.ToTransaction())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	blob, err := signedTx.MarshalBinary()
+	if err != nil {
+		s.releaseSpend(user, signedTx)
+		return common.Hash{}, err
+	}
+
+	txHash, err := ExecAuthRPC[common.Hash](ctx, s.we, &AuthExecCfg{account: sendArgs.From, hasSideEffects: true, timeout: sendTransactionDuration}, tenrpc.ERPCResend, hexutil.Bytes(blob))
+	if err != nil {
+		s.releaseSpend(user, signedTx)
+		return common.Hash{}, err
 	}
-	return common.Hash{}, err
+	return *txHash, nil
 }
@@ -0,0 +1,122 @@
+package rpcapi
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/gethapi"
+	"github.com/ten-protocol/go-ten/go/common/viewingkey"
+	"github.com/ten-protocol/go-ten/integration/common/testlog"
+	"github.com/ten-protocol/go-ten/tools/walletextension/common"
+	"github.com/ten-protocol/go-ten/tools/walletextension/services"
+	"github.com/ten-protocol/go-ten/tools/walletextension/storage"
+)
+
+func TestRawTxSignedWithSessionKeyOfItsSender(t *testing.T) {
+	encryptionKey, err := common.GenerateRandomKey()
+	require.NoError(t, err)
+	userStorage, err := storage.New("sqlite", "", "", encryptionKey, testlog.Logger())
+	require.NoError(t, err)
+	m := services.NewSKManager(userStorage, &common.Config{TenChainID: 443}, gethlog.New())
+
+	userID := make([]byte, 20)
+	_, _ = rand.Read(userID)
+	require.NoError(t, userStorage.AddUser(userID, encryptionKey))
+	require.NoError(t, userStorage.AddAccount(userID, gethcommon.HexToAddress("0x1").Bytes(), make([]byte, 65), viewingkey.PersonalSign))
+
+	// the user has two active session keys
+	sessionKeys := make([]*common.GWSessionKey, 2)
+	for i := range sessionKeys {
+		user, err := userStorage.GetUser(userID)
+		require.NoError(t, err)
+		sessionKeys[i], err = m.CreateSessionKey(user, "game", 0)
+		require.NoError(t, err)
+		user, err = userStorage.GetUser(userID)
+		require.NoError(t, err)
+		_, err = m.ActivateSessionKey(user, sessionKeys[i].Account.Address)
+		require.NoError(t, err)
+	}
+	user, err := userStorage.GetUser(userID)
+	require.NoError(t, err)
+	require.Len(t, user.ActiveSessionKeys(), 2)
+
+	to := gethcommon.HexToAddress("0x1234")
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(443), Gas: 21_000, GasFeeCap: big.NewInt(2), GasTipCap: big.NewInt(1), To: &to})
+
+	// an unsigned transaction doesn't tell which session key to use
+	require.Nil(t, rawTxSender(tx))
+	_, err = m.SignTx(context.Background(), user, rawTxSender(tx), tx)
+	require.ErrorContains(t, err, "multiple active session keys")
+
+	// a transaction signed with the second session key is signed with it again
+	secondKey := sessionKeys[1]
+	rawTx, err := types.SignTx(tx, types.LatestSignerForChainID(big.NewInt(443)), secondKey.PrivateKey.ExportECDSA())
+	require.NoError(t, err)
+	require.Equal(t, *secondKey.Account.Address, *rawTxSender(rawTx))
+	signedTx, err := m.SignTx(context.Background(), user, rawTxSender(rawTx), rawTx)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(443)), signedTx)
+	require.NoError(t, err)
+	require.Equal(t, *secondKey.Account.Address, sender)
+
+	// a transaction signed with a key which isn't a session key of the user is rejected
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	rawTx, err = types.SignTx(tx, types.LatestSignerForChainID(big.NewInt(443)), otherKey)
+	require.NoError(t, err)
+	_, err = m.SignTx(context.Background(), user, rawTxSender(rawTx), rawTx)
+	require.Error(t, err)
+
+	// a transaction signed with a deactivated session key is not signed with the other active key
+	_, err = m.DeactivateSessionKey(user, secondKey.Account.Address)
+	require.NoError(t, err)
+	user, err = userStorage.GetUser(userID)
+	require.NoError(t, err)
+	require.Len(t, user.ActiveSessionKeys(), 1)
+	rawTx, err = types.SignTx(tx, types.LatestSignerForChainID(big.NewInt(443)), secondKey.PrivateKey.ExportECDSA())
+	require.NoError(t, err)
+	_, err = m.SignTx(context.Background(), user, rawTxSender(rawTx), rawTx)
+	require.ErrorContains(t, err, "no active session key")
+}
+
+func TestBumpFee(t *testing.T) {
+	require.Nil(t, bumpFee(nil))
+	require.Equal(t, big.NewInt(11), bumpFee((*hexutil.Big)(big.NewInt(10))).ToInt())
+	// the bump is rounded up, so a small fee is still increased
+	require.Equal(t, big.NewInt(2), bumpFee((*hexutil.Big)(big.NewInt(1))).ToInt())
+}
+
+func TestReplacementFees(t *testing.T) {
+	fee := func(v int64) *hexutil.Big { return (*hexutil.Big)(big.NewInt(v)) }
+
+	// the gas price of a legacy transaction is bumped, or replaced by a higher requested gas price
+	legacyArgs := gethapi.TransactionArgs{GasPrice: fee(100)}
+	setReplacementFees(&legacyArgs, nil)
+	require.Equal(t, fee(110), legacyArgs.GasPrice)
+	legacyArgs = gethapi.TransactionArgs{GasPrice: fee(100)}
+	setReplacementFees(&legacyArgs, fee(105))
+	require.Equal(t, fee(110), legacyArgs.GasPrice)
+	legacyArgs = gethapi.TransactionArgs{GasPrice: fee(100)}
+	setReplacementFees(&legacyArgs, fee(150))
+	require.Equal(t, fee(150), legacyArgs.GasPrice)
+	require.Nil(t, legacyArgs.MaxFeePerGas)
+
+	// both the fee cap and the tip of a dynamic fee transaction are bumped, the requested gas price only raises the cap
+	dynamicArgs := gethapi.TransactionArgs{MaxFeePerGas: fee(200), MaxPriorityFeePerGas: fee(10)}
+	setReplacementFees(&dynamicArgs, fee(0))
+	require.Equal(t, fee(220), dynamicArgs.MaxFeePerGas)
+	require.Equal(t, fee(11), dynamicArgs.MaxPriorityFeePerGas)
+	dynamicArgs = gethapi.TransactionArgs{MaxFeePerGas: fee(200), MaxPriorityFeePerGas: fee(10)}
+	setReplacementFees(&dynamicArgs, fee(300))
+	require.Equal(t, fee(300), dynamicArgs.MaxFeePerGas)
+	require.Equal(t, fee(11), dynamicArgs.MaxPriorityFeePerGas)
+	require.Nil(t, dynamicArgs.GasPrice)
+}
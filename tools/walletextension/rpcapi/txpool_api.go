@@ -1,9 +1,16 @@
 package rpcapi
 
 import (
+	"context"
+	"fmt"
+	"sort"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	tenrpc "github.com/ten-protocol/go-ten/go/common/rpc"
 	rpc2 "github.com/ten-protocol/go-ten/go/enclave/rpc"
+	tenclient "github.com/ten-protocol/go-ten/go/rpc"
+	wecommon "github.com/ten-protocol/go-ten/tools/walletextension/common"
 	"github.com/ten-protocol/go-ten/tools/walletextension/services"
 )
 
@@ -15,22 +22,107 @@ func NewTxPoolAPI(we *services.Services) *TxPoolAPI {
 	return &TxPoolAPI{we}
 }
 
-func (s *TxPoolAPI) Content() map[string]map[string]map[string]*rpc2.RpcTransaction {
-	// not implemented
-	return nil
+// Content returns the pending and queued transactions of all the accounts registered to the user
+func (s *TxPoolAPI) Content(ctx context.Context) (map[string]map[string]map[string]*rpc2.RpcTransaction, error) {
+	user, err := extractUserForRequest(ctx, s.we)
+	if err != nil {
+		return nil, err
+	}
+	pendingTxs, err := fetchUserPendingTxs(ctx, s.we, user)
+	if err != nil {
+		return nil, err
+	}
+	content := map[string]map[string]map[string]*rpc2.RpcTransaction{
+		rpc2.PendingTxsKey: make(map[string]map[string]*rpc2.RpcTransaction),
+		rpc2.QueuedTxsKey:  make(map[string]map[string]*rpc2.RpcTransaction),
+	}
+	for address, txs := range pendingTxs {
+		for key, txsByNonce := range txs {
+			if len(txsByNonce) > 0 {
+				content[key][address.Hex()] = txsByNonce
+			}
+		}
+	}
+	return content, nil
 }
 
-func (s *TxPoolAPI) ContentFrom(_ common.Address) map[string]map[string]*rpc2.RpcTransaction {
-	// not implemented
-	return nil
+// ContentFrom returns the pending and queued transactions of the address, which must be registered to the user
+func (s *TxPoolAPI) ContentFrom(ctx context.Context, address common.Address) (*rpc2.PendingTransactions, error) {
+	user, err := extractUserForRequest(ctx, s.we)
+	if err != nil {
+		return nil, err
+	}
+	if _, found := user.AllAccounts()[address]; !found {
+		return nil, fmt.Errorf("account: %s not registered to current user. Please register first", address.Hex())
+	}
+	return ExecAuthRPC[rpc2.PendingTransactions](ctx, s.we, &AuthExecCfg{account: &address}, tenrpc.ERPCGetPendingTransactions, address)
 }
 
-func (s *TxPoolAPI) Status() map[string]hexutil.Uint {
-	// not implemented
-	return nil
+// Status returns the number of pending and queued transactions of the accounts registered to the user
+func (s *TxPoolAPI) Status(ctx context.Context) (map[string]hexutil.Uint, error) {
+	user, err := extractUserForRequest(ctx, s.we)
+	if err != nil {
+		return nil, err
+	}
+	pendingTxs, err := fetchUserPendingTxs(ctx, s.we, user)
+	if err != nil {
+		return nil, err
+	}
+	status := map[string]hexutil.Uint{rpc2.PendingTxsKey: 0, rpc2.QueuedTxsKey: 0}
+	for _, txs := range pendingTxs {
+		for key, txsByNonce := range txs {
+			status[key] += hexutil.Uint(len(txsByNonce))
+		}
+	}
+	return status, nil
 }
 
 func (s *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	// not implemented
 	return nil
 }
+
+// fetchUserPendingTxs reads the transactions waiting in the mempool for each account registered to the user
+func fetchUserPendingTxs(ctx context.Context, we *services.Services, user *wecommon.GWUser) (map[common.Address]rpc2.PendingTransactions, error) {
+	result := make(map[common.Address]rpc2.PendingTransactions)
+	for address, acct := range user.AllAccounts() {
+		pendingTxs, err := services.WithEncRPCConnection(ctx, we.BackendRPC, acct, func(rpcClient *tenclient.EncRPCClient) (*rpc2.PendingTransactions, error) {
+			var result rpc2.PendingTransactions
+
+			// wrap the context with a timeout to prevent long executions
+			timeoutContext, cancelCtx := context.WithTimeout(ctx, maximumRPCCallDuration)
+			defer cancelCtx()
+
+			err := rpcClient.CallContext(timeoutContext, &result, tenrpc.ERPCGetPendingTransactions, address)
+			return &result, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not read the pending transactions. cause: %w", err)
+		}
+		result[address] = *pendingTxs
+	}
+	return result, nil
+}
+
+// pendingTxsByHash flattens the pending and queued transactions of the accounts. The hashes are sorted by sender and
+// nonce, so the transactions are reported in the order in which they can be included.
+func pendingTxsByHash(pendingTxs map[common.Address]rpc2.PendingTransactions) ([]common.Hash, map[common.Hash]*rpc2.RpcTransaction) {
+	hashes := make([]common.Hash, 0)
+	txs := make(map[common.Hash]*rpc2.RpcTransaction)
+	for _, accountTxs := range pendingTxs {
+		for _, txsByNonce := range accountTxs {
+			for _, tx := range txsByNonce {
+				hashes = append(hashes, tx.Hash)
+				txs[tx.Hash] = tx
+			}
+		}
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		a, b := txs[hashes[i]], txs[hashes[j]]
+		if a.From == b.From {
+			return a.Nonce < b.Nonce
+		}
+		return a.From.Cmp(b.From) < 0
+	})
+	return hashes, txs
+}
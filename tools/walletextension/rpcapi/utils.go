@@ -47,6 +47,8 @@ type AuthExecCfg struct {
 	computeFromCallback func(user *common.GWUser) *gethcommon.Address
 	tryAll              bool
 	tryUntilAuthorised  bool
+	// the call changes the state (e.g. submits a transaction), so it is executed for one account at a time
+	hasSideEffects bool
 
 	adjustArgs func(acct *common.GWAccount) []any
 	cacheCfg   *cache.Cfg
@@ -112,7 +114,6 @@ func ExecAuthRPC[R any](ctx context.Context, w *services.Services, cfg *AuthExec
 		if len(candidateAccts) == 0 {
 			return nil, fmt.Errorf("illegal access")
 		}
-
 		var rpcErr error
 		for i := range candidateAccts {
 			acct := candidateAccts[i]
@@ -141,7 +142,8 @@ func ExecAuthRPC[R any](ctx context.Context, w *services.Services, cfg *AuthExec
 			})
 			if err != nil {
 				// for calls where we know the expected error we can return early
-				if cfg.tryUntilAuthorised && err.Error() != notAuthorised {
+				// calls with side effects are not repeated once they were executed
+				if (cfg.tryUntilAuthorised || cfg.hasSideEffects) && err.Error() != notAuthorised {
 					return nil, err
 				}
 				rpcErr = err
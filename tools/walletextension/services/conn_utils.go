@@ -2,14 +2,20 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	gethlog "github.com/ethereum/go-ethereum/log"
 	pool "github.com/jolestar/go-commons-pool/v2"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/common/measure"
+	tencommonrpc "github.com/ten-protocol/go-ten/go/common/rpc"
+	"github.com/ten-protocol/go-ten/go/common/viewingkey"
 	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/responses"
 	tenrpc "github.com/ten-protocol/go-ten/go/rpc"
 	"github.com/ten-protocol/go-ten/lib/gethfork/rpc"
 	gethrpc "github.com/ten-protocol/go-ten/lib/gethfork/rpc"
@@ -124,6 +130,77 @@ func WithEncRPCConnection[R any](ctx context.Context, rpc *BackendRPC, acct *wec
 	return execute(rpcClient)
 }
 
+// ExecForAccounts executes the encrypted method for each of the accounts of a user, with as few round-trips to the
+// enclave as possible. The responses are returned in the order of the accounts.
+// Only the methods whose results are merged by the caller (the logs) can be executed for several accounts at once.
+// Enclaves which predate the requests with several viewing keys are called once for each account.
+func ExecForAccounts(ctx context.Context, rpc *BackendRPC, accts []*wecommon.GWAccount, method string, args ...any) ([]responses.AccountResponse, error) {
+	result := make([]responses.AccountResponse, 0, len(accts))
+	for start := 0; start < len(accts); start += tencommonrpc.MaxViewingKeysPerRequest {
+		batch := accts[start:min(start+tencommonrpc.MaxViewingKeysPerRequest, len(accts))]
+		batchResponses, err := execForBatch(ctx, rpc, batch, method, args...)
+		if errors.Is(err, tenrpc.ErrMultipleViewingKeysNotSupported) {
+			batchResponses = make([]responses.AccountResponse, 0, len(batch))
+			for _, acct := range batch {
+				resp, err := execForAccount(ctx, rpc, acct, method, args...)
+				if err != nil {
+					return nil, err
+				}
+				batchResponses = append(batchResponses, *resp)
+			}
+		} else if err != nil {
+			return nil, err
+		}
+		result = append(result, batchResponses...)
+	}
+	return result, nil
+}
+
+// execForBatch executes the method for up to MaxViewingKeysPerRequest accounts, in a single request
+func execForBatch(ctx context.Context, rpc *BackendRPC, batch []*wecommon.GWAccount, method string, args ...any) ([]responses.AccountResponse, error) {
+	if len(batch) == 1 {
+		resp, err := execForAccount(ctx, rpc, batch[0], method, args...)
+		if err != nil {
+			return nil, err
+		}
+		return []responses.AccountResponse{*resp}, nil
+	}
+
+	extraVKs := make([]*viewingkey.RPCSignedViewingKey, 0, len(batch)-1)
+	for _, acct := range batch[1:] {
+		vk, err := wecommon.CreateSignedViewingKey(acct)
+		if err != nil {
+			return nil, err
+		}
+		extraVKs = append(extraVKs, vk)
+	}
+	batchResponses, err := WithEncRPCConnection(ctx, rpc, batch[0], func(rpcClient *tenrpc.EncRPCClient) (*[]responses.AccountResponse, error) {
+		resp, err := rpcClient.CallContextForVKs(ctx, extraVKs, method, args...)
+		return &resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return *batchResponses, nil
+}
+
+// execForAccount executes the method for a single account with a plain request, and reports its error like the
+// errors of the accounts of a request with several viewing keys
+func execForAccount(ctx context.Context, rpc *BackendRPC, acct *wecommon.GWAccount, method string, args ...any) (*responses.AccountResponse, error) {
+	return WithEncRPCConnection(ctx, rpc, acct, func(rpcClient *tenrpc.EncRPCClient) (*responses.AccountResponse, error) {
+		var res json.RawMessage
+		resp := &responses.AccountResponse{Account: *acct.Address}
+		if err := rpcClient.CallContext(ctx, &res, method, args...); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, err
+			}
+			resp.Err = &errutil.DataError{Err: err.Error()}
+		}
+		resp.Result = res
+		return resp, nil
+	})
+}
+
 func WithPlainRPCConnection[R any](ctx context.Context, b *BackendRPC, execute func(client *rpc.Client) (*R, error)) (*R, error) {
 	connectionObj, err := connectPlain(ctx, b.rpcHTTPConnPool, b.logger)
 	if err != nil {
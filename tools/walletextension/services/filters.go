@@ -0,0 +1,298 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	tencommon "github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
+	"github.com/ten-protocol/go-ten/lib/gethfork/rpc"
+)
+
+const (
+	// filters which are not polled for this long are uninstalled (same as geth)
+	_filterTimeout = 5 * time.Minute
+	// the maximum number of filters a user can have installed at the same time
+	_maxFiltersPerUser = 100
+	// the maximum number of block hashes kept for a block filter between two polls
+	_maxFilterBlockHashes = 1024
+	// each pending transactions subscription polls the mempool of the node, so their number is limited
+	_maxPendingTxSubscriptionsPerUser = 10
+	_maxPendingTxSubscriptions        = 1000
+)
+
+var (
+	ErrFilterNotFound                = errors.New("filter not found")
+	ErrTooManyFilters                = errors.New("too many filters installed, uninstall some of them first")
+	ErrTooManyPendingTxSubscriptions = errors.New("too many pending transactions subscriptions")
+	errWrongFilterType               = errors.New("wrong filter type")
+)
+
+// The types of the polling filters
+const (
+	LogsFilter FilterType = iota
+	BlockFilter
+	PendingTxFilter
+)
+
+type FilterType int
+
+// Filter is the state of a polling filter installed by a user with eth_newFilter, eth_newBlockFilter or
+// eth_newPendingTransactionFilter
+type Filter struct {
+	ID       rpc.ID
+	UserID   []byte
+	Type     FilterType
+	Criteria tencommon.FilterCriteria // only used by the logs filters
+	FullTx   bool                     // only used by the pending transaction filters
+
+	lastPolled  time.Time
+	blockHashes []gethcommon.Hash            // the hashes of the new heads since the last poll, for the block filters
+	nextHeight  uint64                       // the first batch height not returned yet, for the logs filters
+	pendingTxs  map[gethcommon.Hash]struct{} // the pending transactions seen at the last poll, for the pending transaction filters
+}
+
+// FilterManager keeps the polling filters of the users. The block filters accumulate the hashes of the new heads, while
+// the logs filters keep the next batch height to read the logs from, as the logs are read with the user's viewing keys
+// when the filter is polled.
+type FilterManager struct {
+	mutex      sync.Mutex
+	filters    map[rpc.ID]*Filter
+	headHeight uint64 // the height of the last new head, 0 until the first one is received
+
+	pendingTxSubscriptions      map[string]int // the number of active pending transactions subscriptions per user
+	totalPendingTxSubscriptions int
+
+	stopControl *stopcontrol.StopControl
+	logger      gethlog.Logger
+}
+
+func NewFilterManager(stopControl *stopcontrol.StopControl, logger gethlog.Logger) *FilterManager {
+	fm := &FilterManager{
+		filters:                map[rpc.ID]*Filter{},
+		pendingTxSubscriptions: map[string]int{},
+		stopControl:            stopControl,
+		logger:                 logger,
+	}
+	go fm.evictIdleFilters()
+	return fm
+}
+
+// Install adds a new filter for the user and returns its ID
+func (fm *FilterManager) Install(userID []byte, filterType FilterType, crit tencommon.FilterCriteria) (rpc.ID, error) {
+	return fm.install(&Filter{UserID: userID, Type: filterType, Criteria: crit})
+}
+
+// InstallPendingTxFilter adds a new pending transaction filter for the user. The transactions already pending when the
+// filter is installed are not returned by the filter.
+func (fm *FilterManager) InstallPendingTxFilter(userID []byte, fullTx bool, pendingTxs []gethcommon.Hash) (rpc.ID, error) {
+	return fm.install(&Filter{UserID: userID, Type: PendingTxFilter, FullTx: fullTx, pendingTxs: toHashSet(pendingTxs)})
+}
+
+func (fm *FilterManager) install(f *Filter) (rpc.ID, error) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+
+	count := 0
+	for _, existing := range fm.filters {
+		if bytes.Equal(existing.UserID, f.UserID) {
+			count++
+		}
+	}
+	if count >= _maxFiltersPerUser {
+		return "", ErrTooManyFilters
+	}
+
+	f.ID = rpc.NewID()
+	f.lastPolled = time.Now()
+	if fm.headHeight > 0 {
+		f.nextHeight = fm.headHeight + 1
+	}
+	fm.filters[f.ID] = f
+	return f.ID, nil
+}
+
+// Uninstall removes the filter, returns false if the user has no such filter
+func (fm *FilterManager) Uninstall(userID []byte, id rpc.ID) bool {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	if _, err := fm.get(userID, id); err != nil {
+		return false
+	}
+	delete(fm.filters, id)
+	return true
+}
+
+// Get returns a copy of the user's filter and marks it as polled
+func (fm *FilterManager) Get(userID []byte, id rpc.ID) (Filter, error) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	f, err := fm.get(userID, id)
+	if err != nil {
+		return Filter{}, err
+	}
+	f.lastPolled = time.Now()
+	return *f, nil
+}
+
+// TakeBlockHashes returns the hashes of the new heads since the last poll of the block filter
+func (fm *FilterManager) TakeBlockHashes(userID []byte, id rpc.ID) ([]gethcommon.Hash, error) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	f, err := fm.get(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	hashes := f.blockHashes
+	f.blockHashes = make([]gethcommon.Hash, 0)
+	f.lastPolled = time.Now()
+	return hashes, nil
+}
+
+// PendingLogsRange returns the range of batch heights whose logs were not returned yet by the logs filter. The range is
+// empty (to < from) when there is no new batch. The filter only moves past the range with MarkLogsReturned.
+func (fm *FilterManager) PendingLogsRange(userID []byte, id rpc.ID) (from uint64, to uint64, err error) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	f, err := fm.get(userID, id)
+	if err != nil {
+		return 0, 0, err
+	}
+	if f.Type != LogsFilter {
+		return 0, 0, errWrongFilterType
+	}
+	f.lastPolled = time.Now()
+	if fm.headHeight == 0 {
+		return 1, 0, nil // no new head received yet
+	}
+	if f.nextHeight == 0 {
+		// the filter was installed before the first new head, so it starts after the current head
+		f.nextHeight = fm.headHeight + 1
+	}
+	return f.nextHeight, fm.headHeight, nil
+}
+
+// MarkLogsReturned moves the logs filter past the given height, once the logs up to it were returned to the user
+func (fm *FilterManager) MarkLogsReturned(userID []byte, id rpc.ID, toHeight uint64) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	f, err := fm.get(userID, id)
+	if err != nil {
+		return // uninstalled in the meantime
+	}
+	if toHeight+1 > f.nextHeight {
+		f.nextHeight = toHeight + 1
+	}
+}
+
+// AddPendingTxSubscription reserves a slot for a new pending transactions subscription of the user. The slot must be
+// released with RemovePendingTxSubscription when the subscription ends.
+func (fm *FilterManager) AddPendingTxSubscription(userID []byte) error {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	if fm.totalPendingTxSubscriptions >= _maxPendingTxSubscriptions || fm.pendingTxSubscriptions[string(userID)] >= _maxPendingTxSubscriptionsPerUser {
+		return ErrTooManyPendingTxSubscriptions
+	}
+	fm.pendingTxSubscriptions[string(userID)]++
+	fm.totalPendingTxSubscriptions++
+	return nil
+}
+
+// RemovePendingTxSubscription releases the slot of a pending transactions subscription of the user
+func (fm *FilterManager) RemovePendingTxSubscription(userID []byte) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	if fm.pendingTxSubscriptions[string(userID)] == 0 {
+		return
+	}
+	fm.pendingTxSubscriptions[string(userID)]--
+	if fm.pendingTxSubscriptions[string(userID)] == 0 {
+		delete(fm.pendingTxSubscriptions, string(userID))
+	}
+	fm.totalPendingTxSubscriptions--
+}
+
+// TakeNewPendingTxs returns the transactions of the currently pending ones which were not pending at the last poll of
+// the pending transaction filter
+func (fm *FilterManager) TakeNewPendingTxs(userID []byte, id rpc.ID, pendingTxs []gethcommon.Hash) ([]gethcommon.Hash, error) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	f, err := fm.get(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if f.Type != PendingTxFilter {
+		return nil, errWrongFilterType
+	}
+	f.lastPolled = time.Now()
+	newTxs := make([]gethcommon.Hash, 0)
+	for _, hash := range pendingTxs {
+		if _, seen := f.pendingTxs[hash]; !seen {
+			newTxs = append(newTxs, hash)
+		}
+	}
+	// only the currently pending transactions are kept, so the set does not grow with the mined transactions
+	f.pendingTxs = toHashSet(pendingTxs)
+	return newTxs, nil
+}
+
+// OnNewHead accumulates the new head for the block filters
+func (fm *FilterManager) OnNewHead(head *tencommon.BatchHeader) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	if head.Number.Uint64() > fm.headHeight {
+		fm.headHeight = head.Number.Uint64()
+	}
+	hash := head.Hash()
+	for _, f := range fm.filters {
+		if f.Type != BlockFilter {
+			continue
+		}
+		if len(f.blockHashes) >= _maxFilterBlockHashes {
+			// the filter is not polled often enough, the oldest hashes are dropped
+			f.blockHashes = f.blockHashes[1:]
+		}
+		f.blockHashes = append(f.blockHashes, hash)
+	}
+}
+
+// the caller must hold the lock
+func (fm *FilterManager) get(userID []byte, id rpc.ID) (*Filter, error) {
+	f, found := fm.filters[id]
+	if !found || !bytes.Equal(f.UserID, userID) {
+		return nil, ErrFilterNotFound
+	}
+	return f, nil
+}
+
+func toHashSet(hashes []gethcommon.Hash) map[gethcommon.Hash]struct{} {
+	set := make(map[gethcommon.Hash]struct{}, len(hashes))
+	for _, hash := range hashes {
+		set[hash] = struct{}{}
+	}
+	return set
+}
+
+func (fm *FilterManager) evictIdleFilters() {
+	ticker := time.NewTicker(_filterTimeout / 5)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-fm.stopControl.Done():
+			return
+		case <-ticker.C:
+			fm.mutex.Lock()
+			for id, f := range fm.filters {
+				if time.Since(f.lastPolled) > _filterTimeout {
+					fm.logger.Debug("Uninstalling idle filter", "id", id)
+					delete(fm.filters, id)
+				}
+			}
+			fm.mutex.Unlock()
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	tencommon "github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
+	"github.com/ten-protocol/go-ten/integration/common/testlog"
+)
+
+func TestFilterManager(t *testing.T) {
+	stopControl := stopcontrol.New()
+	defer stopControl.Stop()
+	fm := NewFilterManager(stopControl, testlog.Logger())
+	user, otherUser := []byte{1}, []byte{2}
+
+	blockFilter, err := fm.Install(user, BlockFilter, tencommon.FilterCriteria{})
+	require.NoError(t, err)
+	logsFilter, err := fm.Install(user, LogsFilter, tencommon.FilterCriteria{})
+	require.NoError(t, err)
+
+	// no new head was received yet
+	from, to, err := fm.PendingLogsRange(user, logsFilter)
+	require.NoError(t, err)
+	require.Greater(t, from, to)
+
+	head := &tencommon.BatchHeader{Number: big.NewInt(10)}
+	fm.OnNewHead(head)
+	hashes, err := fm.TakeBlockHashes(user, blockFilter)
+	require.NoError(t, err)
+	require.Equal(t, head.Hash(), hashes[0])
+	hashes, err = fm.TakeBlockHashes(user, blockFilter)
+	require.NoError(t, err)
+	require.Empty(t, hashes)
+
+	// the logs filter installed before the first head starts after it
+	from, to, err = fm.PendingLogsRange(user, logsFilter)
+	require.NoError(t, err)
+	require.Greater(t, from, to)
+
+	fm.OnNewHead(&tencommon.BatchHeader{Number: big.NewInt(11)})
+	fm.OnNewHead(&tencommon.BatchHeader{Number: big.NewInt(12)})
+	from, to, err = fm.PendingLogsRange(user, logsFilter)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{11, 12}, []uint64{from, to})
+	// the range is returned again until the logs are marked as returned
+	fm.MarkLogsReturned(user, logsFilter, to)
+	from, to, err = fm.PendingLogsRange(user, logsFilter)
+	require.NoError(t, err)
+	require.Greater(t, from, to)
+
+	// the filters are only visible to the user who installed them
+	_, err = fm.Get(otherUser, logsFilter)
+	require.ErrorIs(t, err, ErrFilterNotFound)
+	require.False(t, fm.Uninstall(otherUser, logsFilter))
+	require.True(t, fm.Uninstall(user, logsFilter))
+	_, err = fm.Get(user, logsFilter)
+	require.ErrorIs(t, err, ErrFilterNotFound)
+}
+
+func TestPendingTxFilter(t *testing.T) {
+	stopControl := stopcontrol.New()
+	defer stopControl.Stop()
+	fm := NewFilterManager(stopControl, testlog.Logger())
+	user := []byte{1}
+	tx1, tx2, tx3 := gethcommon.Hash{1}, gethcommon.Hash{2}, gethcommon.Hash{3}
+
+	// the transactions pending when the filter is installed are not returned
+	id, err := fm.InstallPendingTxFilter(user, false, []gethcommon.Hash{tx1})
+	require.NoError(t, err)
+	newTxs, err := fm.TakeNewPendingTxs(user, id, []gethcommon.Hash{tx1, tx2})
+	require.NoError(t, err)
+	require.Equal(t, []gethcommon.Hash{tx2}, newTxs)
+
+	// tx1 was mined
+	newTxs, err = fm.TakeNewPendingTxs(user, id, []gethcommon.Hash{tx2, tx3})
+	require.NoError(t, err)
+	require.Equal(t, []gethcommon.Hash{tx3}, newTxs)
+
+	// only the pending transaction filters can be polled for pending transactions
+	logsFilter, err := fm.Install(user, LogsFilter, tencommon.FilterCriteria{})
+	require.NoError(t, err)
+	_, err = fm.TakeNewPendingTxs(user, logsFilter, nil)
+	require.Error(t, err)
+}
+
+func TestPendingTxSubscriptionsAreLimited(t *testing.T) {
+	stopControl := stopcontrol.New()
+	defer stopControl.Stop()
+	fm := NewFilterManager(stopControl, testlog.Logger())
+	user, otherUser := []byte{1}, []byte{2}
+
+	for i := 0; i < _maxPendingTxSubscriptionsPerUser; i++ {
+		require.NoError(t, fm.AddPendingTxSubscription(user))
+	}
+	require.ErrorIs(t, fm.AddPendingTxSubscription(user), ErrTooManyPendingTxSubscriptions)
+	// the limit is per user
+	require.NoError(t, fm.AddPendingTxSubscription(otherUser))
+
+	// the slot of an ended subscription can be reused
+	fm.RemovePendingTxSubscription(user)
+	require.NoError(t, fm.AddPendingTxSubscription(user))
+}
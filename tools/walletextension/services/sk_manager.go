@@ -4,29 +4,39 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sort"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/maps"
 
 	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/ecies"
 	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/common/viewingkey"
 	"github.com/ten-protocol/go-ten/tools/walletextension/common"
 	"github.com/ten-protocol/go-ten/tools/walletextension/storage"
 )
 
 // SKManager - session keys are Private Keys managed by the Gateway
-// At the moment, each user can have a single Session Key. Which is either active or inactive
-// when the SK is active, then all transactions submitted by that user will be signed with the session key
-// The SK is also considered an "Account" of that user
+// Each user can have multiple Session Keys (e.g. one per dApp or device). Each key has a label, an optional expiry and
+// can be either active or inactive.
+// when a SK is active, then transactions submitted by that user will be signed with it
+// Each SK is also considered an "Account" of that user
 // when the SK is created, it signs over the VK of the user so that it can interact with a node the standard way
 // From the POV of the Ten network - a session key is a normal account key
 type SKManager interface {
-	CreateSessionKey(user *common.GWUser) (*common.GWSessionKey, error)
-	ActivateSessionKey(user *common.GWUser) (bool, error)
-	DeactivateSessionKey(user *common.GWUser) (bool, error)
-	DeleteSessionKey(user *common.GWUser) (bool, error)
-	SignTx(ctx context.Context, user *common.GWUser, input *types.Transaction) (*types.Transaction, error)
+	CreateSessionKey(user *common.GWUser, label string, expiresIn time.Duration) (*common.GWSessionKey, error)
+	ListSessionKeys(user *common.GWUser) []*common.GWSessionKey
+	ActivateSessionKey(user *common.GWUser, address *gethcommon.Address) (bool, error)
+	DeactivateSessionKey(user *common.GWUser, address *gethcommon.Address) (bool, error)
+	DeleteSessionKey(user *common.GWUser, address *gethcommon.Address) (bool, error)
+	SetSessionKeyPolicy(user *common.GWUser, address *gethcommon.Address, policy *common.SessionKeyPolicy, expiresAt *time.Time, auth *common.SessionKeyPolicyAuth) (bool, error)
+	SignTx(ctx context.Context, user *common.GWUser, from *gethcommon.Address, input *types.Transaction) (*types.Transaction, error)
+	ReleaseSpend(user *common.GWUser, signedTx *types.Transaction) error
 }
 
 type skManager struct {
@@ -44,14 +54,24 @@ func NewSKManager(storage storage.UserStorage, config *common.Config, logger get
 }
 
 // CreateSessionKey - generates a fresh key and signs over the VK of the user with it
-func (m *skManager) CreateSessionKey(user *common.GWUser) (*common.GWSessionKey, error) {
-	if user.SessionKey != nil {
-		return nil, fmt.Errorf("user already has a session key")
+// expiresIn of 0 means the key never expires
+func (m *skManager) CreateSessionKey(user *common.GWUser, label string, expiresIn time.Duration) (*common.GWSessionKey, error) {
+	if len(user.SessionKeys) >= common.MaxSessionKeysPerUser {
+		return nil, fmt.Errorf("maximum number of session keys (%d) reached", common.MaxSessionKeysPerUser)
+	}
+	if expiresIn < 0 {
+		return nil, fmt.Errorf("invalid session key expiry")
 	}
 	sk, err := m.createSK(user)
 	if err != nil {
 		return nil, err
 	}
+	sk.Label = label
+	sk.CreatedAt = time.Now()
+	if expiresIn > 0 {
+		expiresAt := sk.CreatedAt.Add(expiresIn)
+		sk.ExpiresAt = &expiresAt
+	}
 	err = m.storage.AddSessionKey(user.ID, *sk)
 	if err != nil {
 		return nil, err
@@ -59,48 +79,118 @@ func (m *skManager) CreateSessionKey(user *common.GWUser) (*common.GWSessionKey,
 	return sk, nil
 }
 
-func (m *skManager) ActivateSessionKey(user *common.GWUser) (bool, error) {
-	if user.SessionKey == nil {
-		return false, fmt.Errorf("please create a session key")
+// ListSessionKeys - returns all the session keys of the user sorted by creation time
+func (m *skManager) ListSessionKeys(user *common.GWUser) []*common.GWSessionKey {
+	res := maps.Values(user.SessionKeys)
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+	return res
+}
+
+func (m *skManager) ActivateSessionKey(user *common.GWUser, address *gethcommon.Address) (bool, error) {
+	sk, err := findSessionKey(user, address)
+	if err != nil {
+		return false, err
 	}
-	if user.ActiveSK {
+	if sk.Active {
 		return false, fmt.Errorf("session key already activated")
 	}
-	err := m.storage.ActivateSessionKey(user.ID, true)
+	if sk.IsExpired() {
+		return false, fmt.Errorf("session key expired")
+	}
+	err = m.storage.ActivateSessionKey(user.ID, *sk.Account.Address, true)
 	if err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
-func (m *skManager) DeactivateSessionKey(user *common.GWUser) (bool, error) {
-	if user.SessionKey == nil {
-		return false, fmt.Errorf("please create a session key")
+func (m *skManager) DeactivateSessionKey(user *common.GWUser, address *gethcommon.Address) (bool, error) {
+	sk, err := findSessionKey(user, address)
+	if err != nil {
+		return false, err
 	}
-	if !user.ActiveSK {
+	if !sk.Active {
 		return false, fmt.Errorf("session key is not activated")
 	}
-	err := m.storage.ActivateSessionKey(user.ID, false)
+	err = m.storage.ActivateSessionKey(user.ID, *sk.Account.Address, false)
 	if err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
-func (m *skManager) DeleteSessionKey(user *common.GWUser) (bool, error) {
-	if user.SessionKey == nil {
-		return false, fmt.Errorf("please create a session key")
+func (m *skManager) DeleteSessionKey(user *common.GWUser, address *gethcommon.Address) (bool, error) {
+	sk, err := findSessionKey(user, address)
+	if err != nil {
+		return false, err
 	}
-	if user.ActiveSK {
+	if sk.Active {
 		return false, fmt.Errorf("session key is active. Please deactivate first")
 	}
-	err := m.storage.RemoveSessionKey(user.ID)
+	err = m.storage.RemoveSessionKey(user.ID, *sk.Account.Address)
 	if err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
+// SetSessionKeyPolicy - replaces the policy and the expiry of the session key
+// The policy is enforced by SignTx before any transaction is signed with the key.
+// A change which relaxes or removes the policy, or extends the expiry, must be signed by one of the accounts of the user,
+// so that the user token alone can't lift the restrictions.
+func (m *skManager) SetSessionKeyPolicy(user *common.GWUser, address *gethcommon.Address, policy *common.SessionKeyPolicy, expiresAt *time.Time, auth *common.SessionKeyPolicyAuth) (bool, error) {
+	sk, err := findSessionKey(user, address)
+	if err != nil {
+		return false, err
+	}
+	if isRelaxed(sk, policy, expiresAt) {
+		if auth == nil {
+			return false, fmt.Errorf("relaxing the session key policy requires the signature of an account of the user")
+		}
+		signer, err := auth.VerifySigner(user.ID, *sk.Account.Address, policy, expiresAt, time.Now())
+		if err != nil {
+			return false, fmt.Errorf("invalid policy change signature: %w", err)
+		}
+		if _, found := user.Accounts[*signer]; !found {
+			return false, fmt.Errorf("the policy change was not signed by an account of the user")
+		}
+	}
+	err = m.storage.SetSessionKeyPolicy(user.ID, *sk.Account.Address, policy, expiresAt)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// isRelaxed - returns true if the new policy or expiry allow anything which the current ones don't
+func isRelaxed(sk *common.GWSessionKey, policy *common.SessionKeyPolicy, expiresAt *time.Time) bool {
+	if sk.ExpiresAt != nil && (expiresAt == nil || expiresAt.After(*sk.ExpiresAt)) {
+		return true
+	}
+	return sk.Policy.IsRelaxedBy(policy)
+}
+
+// findSessionKey - returns the session key with the given address
+// when the address is nil, the user must have a single session key, which is returned
+func findSessionKey(user *common.GWUser, address *gethcommon.Address) (*common.GWSessionKey, error) {
+	if len(user.SessionKeys) == 0 {
+		return nil, fmt.Errorf("please create a session key")
+	}
+	if address == nil {
+		if len(user.SessionKeys) > 1 {
+			return nil, fmt.Errorf("user has multiple session keys. Please specify the session key address")
+		}
+		return maps.Values(user.SessionKeys)[0], nil
+	}
+	sk, found := user.SessionKeys[*address]
+	if !found {
+		return nil, fmt.Errorf("session key %s not found", address.Hex())
+	}
+	return sk, nil
+}
+
 func (m *skManager) createSK(user *common.GWUser) (*common.GWSessionKey, error) {
 	// generate new key-pair
 	sk, err := crypto.GenerateKey()
@@ -140,12 +230,37 @@ func (m *skManager) createSK(user *common.GWUser) (*common.GWSessionKey, error)
 	}, nil
 }
 
-func (m *skManager) SignTx(ctx context.Context, user *common.GWUser, tx *types.Transaction) (*types.Transaction, error) {
-	prvKey := user.SessionKey.PrivateKey.ExportECDSA()
+// SignTx - signs the transaction with the session key selected for it
+// The key is the one matching "from" (if it is set), or the single active key of the user.
+// The transaction is rejected if it doesn't satisfy the policy of the key, or if its maximum cost would exceed the spend
+// limit. The cost is reserved in the storage before signing, so concurrent transactions can't exceed the limit together.
+// The reservation must be released with ReleaseSpend if the signed transaction is not submitted.
+func (m *skManager) SignTx(ctx context.Context, user *common.GWUser, from *gethcommon.Address, tx *types.Transaction) (*types.Transaction, error) {
+	sk := user.SelectSessionKey(from)
+	if sk == nil && from == nil && len(user.ActiveSessionKeys()) > 1 {
+		return nil, fmt.Errorf("the user has multiple active session keys. Please specify the session key which signs the transaction")
+	}
+	if sk == nil {
+		return nil, fmt.Errorf("no active session key found for the transaction")
+	}
+
+	if err := sk.Policy.CheckTx(tx); err != nil {
+		return nil, fmt.Errorf("transaction rejected by the session key policy: %w", err)
+	}
+	hasSpend := hasSpendLimit(sk) && tx.Cost().Sign() > 0
+	if hasSpend {
+		if err := m.storage.ReserveSessionKeySpend(user.ID, *sk.Account.Address, tx.Nonce(), tx.Cost()); err != nil {
+			return nil, fmt.Errorf("transaction rejected by the session key policy: %w", err)
+		}
+	}
+	prvKey := sk.PrivateKey.ExportECDSA()
 	signer := types.NewCancunSigner(big.NewInt(int64(m.config.TenChainID)))
 
 	stx, err := types.SignTx(tx, signer, prvKey)
 	if err != nil {
+		if hasSpend {
+			m.releaseSpend(user, *sk.Account.Address, tx)
+		}
 		return nil, err
 	}
 
@@ -153,3 +268,28 @@ func (m *skManager) SignTx(ctx context.Context, user *common.GWUser, tx *types.T
 
 	return stx, nil
 }
+
+// ReleaseSpend - releases the spend reserved by SignTx for a transaction which could not be submitted.
+// The spend of the transaction it was meant to replace counts again.
+func (m *skManager) ReleaseSpend(user *common.GWUser, signedTx *types.Transaction) error {
+	signer := types.NewCancunSigner(big.NewInt(int64(m.config.TenChainID)))
+	sender, err := types.Sender(signer, signedTx)
+	if err != nil {
+		return err
+	}
+	sk, found := user.SessionKeys[sender]
+	if !found || !hasSpendLimit(sk) || signedTx.Cost().Sign() == 0 {
+		return nil
+	}
+	return m.storage.ReleaseSessionKeySpend(user.ID, sender, signedTx.Nonce(), signedTx.Cost())
+}
+
+func (m *skManager) releaseSpend(user *common.GWUser, address gethcommon.Address, tx *types.Transaction) {
+	if err := m.storage.ReleaseSessionKeySpend(user.ID, address, tx.Nonce(), tx.Cost()); err != nil {
+		m.logger.Warn("Could not release the session key spend", log.TxKey, tx.Hash(), log.ErrKey, err)
+	}
+}
+
+func hasSpendLimit(sk *common.GWSessionKey) bool {
+	return sk.Policy != nil && sk.Policy.SpendLimit != nil
+}
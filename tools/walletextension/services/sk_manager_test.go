@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/viewingkey"
+	"github.com/ten-protocol/go-ten/integration/common/testlog"
+	"github.com/ten-protocol/go-ten/tools/walletextension/common"
+	"github.com/ten-protocol/go-ten/tools/walletextension/storage"
+)
+
+func TestSessionKeyPolicy(t *testing.T) {
+	encryptionKey, err := common.GenerateRandomKey()
+	require.NoError(t, err)
+	userStorage, err := storage.New("sqlite", "", "", encryptionKey, testlog.Logger())
+	require.NoError(t, err)
+	m := NewSKManager(userStorage, &common.Config{TenChainID: 443}, gethlog.New())
+
+	userID := make([]byte, 20)
+	_, _ = rand.Read(userID)
+	require.NoError(t, userStorage.AddUser(userID, encryptionKey))
+	accountKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	account := crypto.PubkeyToAddress(accountKey.PublicKey)
+	require.NoError(t, userStorage.AddAccount(userID, account.Bytes(), make([]byte, 65), viewingkey.PersonalSign))
+	user, err := userStorage.GetUser(userID)
+	require.NoError(t, err)
+	sk, err := m.CreateSessionKey(user, "game", 0)
+	require.NoError(t, err)
+	user, err = userStorage.GetUser(userID)
+	require.NoError(t, err)
+	_, err = m.ActivateSessionKey(user, sk.Account.Address)
+	require.NoError(t, err)
+	user, err = userStorage.GetUser(userID)
+	require.NoError(t, err)
+
+	// setting the first policy doesn't require a signature
+	policy := &common.SessionKeyPolicy{SpendLimit: big.NewInt(100_000), SpendWindow: time.Hour}
+	_, err = m.SetSessionKeyPolicy(user, sk.Account.Address, policy, nil, nil)
+	require.NoError(t, err)
+	user, err = userStorage.GetUser(userID)
+	require.NoError(t, err)
+
+	// the gas counts against the spend limit
+	to := gethcommon.HexToAddress("0x1234")
+	newTx := func(nonce uint64, gas uint64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{Nonce: nonce, Gas: gas, GasFeeCap: big.NewInt(2), GasTipCap: big.NewInt(1), To: &to})
+	}
+	_, err = m.SignTx(context.Background(), user, nil, newTx(0, 60_000))
+	require.ErrorContains(t, err, "exceeds the session key spend limit")
+
+	// the spend is reserved when the transaction is signed, so concurrent transactions can't exceed the limit together
+	signedTx, err := m.SignTx(context.Background(), user, nil, newTx(0, 30_000))
+	require.NoError(t, err)
+	_, err = m.SignTx(context.Background(), user, nil, newTx(1, 30_000))
+	require.ErrorContains(t, err, "spend limit of 100000 exceeded")
+
+	// the spend of a transaction which could not be submitted is released
+	require.NoError(t, m.ReleaseSpend(user, signedTx))
+	_, err = m.SignTx(context.Background(), user, nil, newTx(1, 30_000))
+	require.NoError(t, err)
+
+	// a replacement transaction replaces the spend of the transaction with the same nonce
+	replacementTx, err := m.SignTx(context.Background(), user, nil, newTx(1, 40_000))
+	require.NoError(t, err)
+	spends := func() []common.SessionKeySpend {
+		user, err := userStorage.GetUser(userID)
+		require.NoError(t, err)
+		return user.SessionKeys[*sk.Account.Address].Spends
+	}
+	require.Len(t, spends(), 1)
+	require.Equal(t, big.NewInt(80_000), spends()[0].Amount)
+
+	// releasing the replacement restores the spend of the original transaction
+	require.NoError(t, m.ReleaseSpend(user, replacementTx))
+	require.Len(t, spends(), 1)
+	require.Equal(t, big.NewInt(60_000), spends()[0].Amount)
+	_, err = m.SignTx(context.Background(), user, nil, newTx(2, 30_000))
+	require.ErrorContains(t, err, "spend limit of 100000 exceeded")
+
+	// the policy can be tightened with the user token alone
+	stricterPolicy := &common.SessionKeyPolicy{SpendLimit: big.NewInt(50_000), SpendWindow: time.Hour}
+	_, err = m.SetSessionKeyPolicy(user, sk.Account.Address, stricterPolicy, nil, nil)
+	require.NoError(t, err)
+	user, err = userStorage.GetUser(userID)
+	require.NoError(t, err)
+
+	// removing the policy requires the signature of an account of the user
+	removedPolicy := &common.SessionKeyPolicy{}
+	_, err = m.SetSessionKeyPolicy(user, sk.Account.Address, removedPolicy, nil, nil)
+	require.Error(t, err)
+	sign := func(key []byte, issuedAt time.Time) *common.SessionKeyPolicyAuth {
+		msg, err := common.SessionKeyPolicyMessage(userID, *sk.Account.Address, removedPolicy, nil, uint64(issuedAt.Unix()))
+		require.NoError(t, err)
+		signature, err := crypto.Sign(accounts.TextHash(msg), crypto.ToECDSAUnsafe(key))
+		require.NoError(t, err)
+		return &common.SessionKeyPolicyAuth{Signature: signature, IssuedAt: uint64(issuedAt.Unix())}
+	}
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = m.SetSessionKeyPolicy(user, sk.Account.Address, removedPolicy, nil, sign(crypto.FromECDSA(otherKey), time.Now()))
+	require.Error(t, err)
+	_, err = m.SetSessionKeyPolicy(user, sk.Account.Address, removedPolicy, nil, sign(crypto.FromECDSA(accountKey), time.Now().Add(-time.Hour)))
+	require.Error(t, err)
+	_, err = m.SetSessionKeyPolicy(user, sk.Account.Address, removedPolicy, nil, sign(crypto.FromECDSA(accountKey), time.Now()))
+	require.NoError(t, err)
+}
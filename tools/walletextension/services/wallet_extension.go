@@ -49,6 +49,7 @@ type Services struct {
 	SKManager           SKManager
 	Config              *common.Config
 	NewHeadsService     *subscriptioncommon.NewHeadsService
+	Filters             *FilterManager // the polling filters installed with eth_newFilter, eth_newBlockFilter and eth_newPendingTransactionFilter
 	cacheInvalidationCh chan *tencommon.BatchHeader
 	MetricsTracker      metrics.Metrics
 }
@@ -64,15 +65,28 @@ func NewServices(hostAddrHTTP string, hostAddrWS string, storage storage.UserSto
 	var newGatewayCache cache.Cache
 	var err error
 
-	if !config.DisableCaching {
+	switch {
+	case config.DisableCaching:
+		// Create a no-op cache implementation when caching is disabled
+		newGatewayCache = cache.NewNoOpCache()
+	case config.CacheRedisURL != "":
+		// the cached responses are encrypted with the key shared by the replicas through the database
+		encryptionKey := storage.GetEncryptionKey()
+		if encryptionKey == nil {
+			err = fmt.Errorf("the shared cache requires a database with an encryption key")
+		} else {
+			newGatewayCache, err = cache.NewSharedCache(config.CacheRedisURL, encryptionKey, logger)
+		}
+		if err != nil {
+			logger.Error(fmt.Errorf("could not create shared cache. Cause: %w", err).Error())
+			panic(err)
+		}
+	default:
 		newGatewayCache, err = cache.NewCache(rpcResponseCacheSize, logger)
 		if err != nil {
 			logger.Error(fmt.Errorf("could not create cache. Cause: %w", err).Error())
 			panic(err)
 		}
-	} else {
-		// Create a no-op cache implementation when caching is disabled
-		newGatewayCache = cache.NewNoOpCache()
 	}
 
 	rateLimiter := ratelimiter.NewRateLimiter(config.RateLimitUserComputeTime, config.RateLimitWindow, uint32(config.RateLimitMaxConcurrentRequests), logger)
@@ -91,6 +105,7 @@ func NewServices(hostAddrHTTP string, hostAddrWS string, storage storage.UserSto
 		Config:              config,
 		cacheInvalidationCh: make(chan *tencommon.BatchHeader),
 		MetricsTracker:      metricsTracker,
+		Filters:             NewFilterManager(stopControl, logger),
 	}
 
 	services.NewHeadsService = subscriptioncommon.NewNewHeadsService(
@@ -106,6 +121,7 @@ func NewServices(hostAddrHTTP string, hostAddrWS string, storage storage.UserSto
 		true,
 		logger,
 		func(newHead *tencommon.BatchHeader) error {
+			services.Filters.OnNewHead(newHead)
 			services.cacheInvalidationCh <- newHead
 			return nil
 		})
@@ -321,4 +337,5 @@ func (w *Services) GenerateUserMessageToSign(encryptionToken []byte, formatsSlic
 func (w *Services) Stop() {
 	w.BackendRPC.Stop()
 	close(w.cacheInvalidationCh)
+	w.RPCResponsesCache.Close()
 }
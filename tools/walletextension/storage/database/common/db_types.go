@@ -2,6 +2,8 @@ package common
 
 import (
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
 
@@ -12,11 +14,15 @@ import (
 )
 
 type GWUserDB struct {
-	UserId     []byte          `json:"userId"`
-	PrivateKey []byte          `json:"privateKey"`
-	Accounts   []GWAccountDB   `json:"accounts"`
-	SessionKey *GWSessionKeyDB `json:"sessionKey"`
-	ActiveSK   bool            `json:"activeSK"`
+	UserId      []byte           `json:"userId"`
+	PrivateKey  []byte           `json:"privateKey"`
+	Accounts    []GWAccountDB    `json:"accounts"`
+	SessionKeys []GWSessionKeyDB `json:"sessionKeys"`
+
+	// Deprecated: users created before multiple session keys were supported have a single key stored here.
+	// It is moved to SessionKeys by MigrateLegacySessionKey.
+	SessionKey *GWSessionKeyDB `json:"sessionKey,omitempty"`
+	ActiveSK   bool            `json:"activeSK,omitempty"`
 }
 
 type GWAccountDB struct {
@@ -27,16 +33,231 @@ type GWAccountDB struct {
 
 // GWSessionKeyDB - an account key-pair registered for a user
 type GWSessionKeyDB struct {
-	PrivateKey []byte      `json:"privateKey"`
-	Account    GWAccountDB `json:"account"`
+	PrivateKey []byte              `json:"privateKey"`
+	Account    GWAccountDB         `json:"account"`
+	Label      string              `json:"label"`
+	CreatedAt  int64               `json:"createdAt"`
+	ExpiresAt  int64               `json:"expiresAt"` // unix seconds. 0 means the key never expires
+	Active     bool                `json:"active"`
+	Policy     *SessionKeyPolicyDB `json:"policy,omitempty"`
+	Spends     []SessionKeySpendDB `json:"spends,omitempty"` // the spends within the current spend window
+}
+
+type SessionKeyPolicyDB struct {
+	MaxValuePerTx    *big.Int `json:"maxValuePerTx,omitempty"`
+	MaxGasPerTx      uint64   `json:"maxGasPerTx,omitempty"`
+	SpendLimit       *big.Int `json:"spendLimit,omitempty"`
+	SpendWindow      int64    `json:"spendWindow,omitempty"` // seconds
+	AllowedContracts [][]byte `json:"allowedContracts,omitempty"`
+	AllowedSelectors [][]byte `json:"allowedSelectors,omitempty"`
+}
+
+type SessionKeySpendDB struct {
+	Timestamp int64    `json:"timestamp"` // unix seconds
+	Nonce     uint64   `json:"nonce"`
+	Amount    *big.Int `json:"amount"`
+	Replaced  *big.Int `json:"replaced,omitempty"` // the amount of the spend replaced by this one, restored on release
+}
+
+func newSessionKeyPolicyDB(p *wecommon.SessionKeyPolicy) *SessionKeyPolicyDB {
+	if p == nil {
+		return nil
+	}
+	policyDB := &SessionKeyPolicyDB{
+		MaxValuePerTx: p.MaxValuePerTx,
+		MaxGasPerTx:   p.MaxGasPerTx,
+		SpendLimit:    p.SpendLimit,
+		SpendWindow:   int64(p.SpendWindow / time.Second),
+	}
+	for _, c := range p.AllowedContracts {
+		policyDB.AllowedContracts = append(policyDB.AllowedContracts, c.Bytes())
+	}
+	for _, s := range p.AllowedSelectors {
+		selector := s
+		policyDB.AllowedSelectors = append(policyDB.AllowedSelectors, selector[:])
+	}
+	return policyDB
+}
+
+func (p *SessionKeyPolicyDB) toPolicy() *wecommon.SessionKeyPolicy {
+	if p == nil {
+		return nil
+	}
+	policy := &wecommon.SessionKeyPolicy{
+		MaxValuePerTx: p.MaxValuePerTx,
+		MaxGasPerTx:   p.MaxGasPerTx,
+		SpendLimit:    p.SpendLimit,
+		SpendWindow:   time.Duration(p.SpendWindow) * time.Second,
+	}
+	for _, c := range p.AllowedContracts {
+		policy.AllowedContracts = append(policy.AllowedContracts, common.BytesToAddress(c))
+	}
+	for _, s := range p.AllowedSelectors {
+		policy.AllowedSelectors = append(policy.AllowedSelectors, [wecommon.SelectorLen]byte(s))
+	}
+	return policy
+}
+
+// NewGWSessionKeyDB converts a session key to its storage representation
+func NewGWSessionKeyDB(key wecommon.GWSessionKey) GWSessionKeyDB {
+	skDB := GWSessionKeyDB{
+		PrivateKey: crypto.FromECDSA(key.PrivateKey.ExportECDSA()),
+		Account: GWAccountDB{
+			AccountAddress: key.Account.Address.Bytes(),
+			Signature:      key.Account.Signature,
+			SignatureType:  int(key.Account.SignatureType),
+		},
+		Label:     key.Label,
+		CreatedAt: key.CreatedAt.Unix(),
+		Active:    key.Active,
+		Policy:    newSessionKeyPolicyDB(key.Policy),
+	}
+	if key.ExpiresAt != nil {
+		skDB.ExpiresAt = key.ExpiresAt.Unix()
+	}
+	return skDB
+}
+
+// MigrateLegacySessionKey moves the single session key of users stored by older versions into the SessionKeys list
+func (userDB *GWUserDB) MigrateLegacySessionKey() {
+	if userDB.SessionKey == nil {
+		return
+	}
+	legacy := *userDB.SessionKey
+	legacy.Active = userDB.ActiveSK
+	userDB.SessionKeys = append(userDB.SessionKeys, legacy)
+	userDB.SessionKey = nil
+	userDB.ActiveSK = false
+}
+
+// AddSessionKey appends a new session key. Returns an error if a key with the same address already exists
+func (userDB *GWUserDB) AddSessionKey(key GWSessionKeyDB) error {
+	if userDB.findSessionKey(key.Account.AccountAddress) >= 0 {
+		return fmt.Errorf("session key %s already exists", common.BytesToAddress(key.Account.AccountAddress).Hex())
+	}
+	userDB.SessionKeys = append(userDB.SessionKeys, key)
+	return nil
+}
+
+// ActivateSessionKey sets the active flag of the session key with the given address
+func (userDB *GWUserDB) ActivateSessionKey(address common.Address, active bool) error {
+	idx := userDB.findSessionKey(address.Bytes())
+	if idx < 0 {
+		return fmt.Errorf("session key %s not found", address.Hex())
+	}
+	userDB.SessionKeys[idx].Active = active
+	return nil
+}
+
+// RemoveSessionKey deletes the session key with the given address
+func (userDB *GWUserDB) RemoveSessionKey(address common.Address) error {
+	idx := userDB.findSessionKey(address.Bytes())
+	if idx < 0 {
+		return fmt.Errorf("session key %s not found", address.Hex())
+	}
+	userDB.SessionKeys = append(userDB.SessionKeys[:idx], userDB.SessionKeys[idx+1:]...)
+	return nil
+}
+
+// SetSessionKeyPolicy replaces the policy and the expiry of the session key with the given address
+// A nil expiresAt means the key never expires
+func (userDB *GWUserDB) SetSessionKeyPolicy(address common.Address, policy *wecommon.SessionKeyPolicy, expiresAt *time.Time) error {
+	idx := userDB.findSessionKey(address.Bytes())
+	if idx < 0 {
+		return fmt.Errorf("session key %s not found", address.Hex())
+	}
+	sk := &userDB.SessionKeys[idx]
+	sk.Policy = newSessionKeyPolicyDB(policy)
+	sk.ExpiresAt = 0
+	if expiresAt != nil {
+		sk.ExpiresAt = expiresAt.Unix()
+	}
+	return nil
+}
+
+// ReserveSessionKeySpend adds a spend to the session key with the given address. The spend replaces the one recorded
+// for the same nonce, as the transaction replaces the previous one.
+// Returns an error without recording anything if the spend would exceed the spend limit of the key within the window.
+func (userDB *GWUserDB) ReserveSessionKeySpend(address common.Address, nonce uint64, amount *big.Int, now time.Time) error {
+	idx := userDB.findSessionKey(address.Bytes())
+	if idx < 0 {
+		return fmt.Errorf("session key %s not found", address.Hex())
+	}
+	sk := &userDB.SessionKeys[idx]
+	policy := sk.Policy.toPolicy()
+	if policy == nil || policy.SpendLimit == nil {
+		return nil
+	}
+
+	spends := toSessionKeySpends(sk.Spends)
+	if err := policy.CheckSpend(spends, nonce, amount, now); err != nil {
+		return err
+	}
+	// drop the spends that are outside the window, and the spend of the replaced transaction
+	windowStart := now.Add(-policy.SpendWindow)
+	var replaced *big.Int
+	kept := make([]SessionKeySpendDB, 0, len(sk.Spends)+1)
+	for _, spend := range sk.Spends {
+		switch {
+		case !time.Unix(spend.Timestamp, 0).After(windowStart):
+		case spend.Nonce == nonce:
+			replaced = spend.Amount
+		default:
+			kept = append(kept, spend)
+		}
+	}
+	sk.Spends = append(kept, SessionKeySpendDB{Timestamp: now.Unix(), Nonce: nonce, Amount: amount, Replaced: replaced})
+	return nil
+}
+
+// ReleaseSessionKeySpend reverts the reservation of the spend of the transaction with the given nonce and amount, which
+// could not be submitted. The spend it replaced is restored.
+func (userDB *GWUserDB) ReleaseSessionKeySpend(address common.Address, nonce uint64, amount *big.Int) error {
+	idx := userDB.findSessionKey(address.Bytes())
+	if idx < 0 {
+		return fmt.Errorf("session key %s not found", address.Hex())
+	}
+	sk := &userDB.SessionKeys[idx]
+	for i, spend := range sk.Spends {
+		if spend.Nonce != nonce || spend.Amount.Cmp(amount) != 0 {
+			continue
+		}
+		if spend.Replaced != nil {
+			sk.Spends[i].Amount = spend.Replaced
+			sk.Spends[i].Replaced = nil
+		} else {
+			sk.Spends = append(sk.Spends[:i], sk.Spends[i+1:]...)
+		}
+		return nil
+	}
+	return nil
+}
+
+func toSessionKeySpends(spendsDB []SessionKeySpendDB) []wecommon.SessionKeySpend {
+	spends := make([]wecommon.SessionKeySpend, 0, len(spendsDB))
+	for _, spend := range spendsDB {
+		spends = append(spends, wecommon.SessionKeySpend{Timestamp: time.Unix(spend.Timestamp, 0), Nonce: spend.Nonce, Amount: spend.Amount})
+	}
+	return spends
+}
+
+func (userDB *GWUserDB) findSessionKey(address []byte) int {
+	for i, sk := range userDB.SessionKeys {
+		if common.BytesToAddress(sk.Account.AccountAddress) == common.BytesToAddress(address) {
+			return i
+		}
+	}
+	return -1
 }
 
 func (userDB *GWUserDB) ToGWUser() (*wecommon.GWUser, error) {
+	userDB.MigrateLegacySessionKey()
+
 	user := &wecommon.GWUser{
-		ID:       userDB.UserId,
-		Accounts: make(map[common.Address]*wecommon.GWAccount),
-		UserKey:  userDB.PrivateKey,
-		ActiveSK: userDB.ActiveSK,
+		ID:          userDB.UserId,
+		Accounts:    make(map[common.Address]*wecommon.GWAccount),
+		UserKey:     userDB.PrivateKey,
+		SessionKeys: make(map[common.Address]*wecommon.GWSessionKey),
 	}
 
 	for _, accountDB := range userDB.Accounts {
@@ -50,24 +271,34 @@ func (userDB *GWUserDB) ToGWUser() (*wecommon.GWUser, error) {
 		user.Accounts[address] = &gwAccount
 	}
 
-	if userDB.SessionKey != nil {
-		ecdsaPrivateKey, err := crypto.ToECDSA(userDB.SessionKey.PrivateKey)
+	for _, skDB := range userDB.SessionKeys {
+		ecdsaPrivateKey, err := crypto.ToECDSA(skDB.PrivateKey)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse ECDSA private key: %w", err)
 		}
 
 		// Convert ECDSA private key to ECIES private key
 		eciesPrivateKey := ecies.ImportECDSA(ecdsaPrivateKey)
-		acc := userDB.SessionKey.Account
-		user.SessionKey = &wecommon.GWSessionKey{
+		address := common.BytesToAddress(skDB.Account.AccountAddress)
+		sk := &wecommon.GWSessionKey{
 			Account: &wecommon.GWAccount{
 				User:          user,
-				Address:       (*common.Address)(acc.AccountAddress),
-				Signature:     acc.Signature,
-				SignatureType: viewingkey.SignatureType(acc.SignatureType),
+				Address:       &address,
+				Signature:     skDB.Account.Signature,
+				SignatureType: viewingkey.SignatureType(skDB.Account.SignatureType),
 			},
 			PrivateKey: eciesPrivateKey,
+			Label:      skDB.Label,
+			CreatedAt:  time.Unix(skDB.CreatedAt, 0),
+			Active:     skDB.Active,
+			Policy:     skDB.Policy.toPolicy(),
+			Spends:     toSessionKeySpends(skDB.Spends),
+		}
+		if skDB.ExpiresAt != 0 {
+			expiresAt := time.Unix(skDB.ExpiresAt, 0)
+			sk.ExpiresAt = &expiresAt
 		}
+		user.SessionKeys[address] = sk
 	}
 
 	return user, nil
@@ -5,9 +5,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"strings"
+	"time"
 
-	"github.com/ethereum/go-ethereum/crypto"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 
 	dbcommon "github.com/ten-protocol/go-ten/tools/walletextension/storage/database/common"
 
@@ -129,37 +131,52 @@ func (c *CosmosDB) DeleteUser(userID []byte) error {
 	return nil
 }
 
-// Adds or updates a session key for the user, with retries on ETag mismatch
+// Adds a session key for the user, with retries on ETag mismatch
 func (c *CosmosDB) AddSessionKey(userID []byte, key common.GWSessionKey) error {
 	ctx := context.Background()
 	return c.updateUserWithRetries(ctx, userID, func(u *dbcommon.GWUserDB) error {
-		u.SessionKey = &dbcommon.GWSessionKeyDB{
-			PrivateKey: crypto.FromECDSA(key.PrivateKey.ExportECDSA()),
-			Account: dbcommon.GWAccountDB{
-				AccountAddress: key.Account.Address.Bytes(),
-				Signature:      key.Account.Signature,
-				SignatureType:  int(key.Account.SignatureType),
-			},
-		}
-		return nil
+		return u.AddSessionKey(dbcommon.NewGWSessionKeyDB(key))
 	})
 }
 
-// Sets the ActiveSK flag for the user, with retries on ETag mismatch
-func (c *CosmosDB) ActivateSessionKey(userID []byte, active bool) error {
+// Sets the Active flag of the session key with the given address, with retries on ETag mismatch
+func (c *CosmosDB) ActivateSessionKey(userID []byte, address gethcommon.Address, active bool) error {
 	ctx := context.Background()
 	return c.updateUserWithRetries(ctx, userID, func(u *dbcommon.GWUserDB) error {
-		u.ActiveSK = active
-		return nil
+		return u.ActivateSessionKey(address, active)
 	})
 }
 
-// Removes the session key for the user, with retries on ETag mismatch
-func (c *CosmosDB) RemoveSessionKey(userID []byte) error {
+// Removes the session key with the given address, with retries on ETag mismatch
+func (c *CosmosDB) RemoveSessionKey(userID []byte, address gethcommon.Address) error {
 	ctx := context.Background()
 	return c.updateUserWithRetries(ctx, userID, func(u *dbcommon.GWUserDB) error {
-		u.SessionKey = nil
-		return nil
+		return u.RemoveSessionKey(address)
+	})
+}
+
+// Replaces the policy and the expiry of the session key, with retries on ETag mismatch
+func (c *CosmosDB) SetSessionKeyPolicy(userID []byte, address gethcommon.Address, policy *common.SessionKeyPolicy, expiresAt *time.Time) error {
+	ctx := context.Background()
+	return c.updateUserWithRetries(ctx, userID, func(u *dbcommon.GWUserDB) error {
+		return u.SetSessionKeyPolicy(address, policy, expiresAt)
+	})
+}
+
+// Reserves a spend of the session key, with retries on ETag mismatch.
+// The ETag check guarantees that concurrent spends can't exceed the spend limit
+func (c *CosmosDB) ReserveSessionKeySpend(userID []byte, address gethcommon.Address, nonce uint64, amount *big.Int) error {
+	ctx := context.Background()
+	return c.updateUserWithRetries(ctx, userID, func(u *dbcommon.GWUserDB) error {
+		return u.ReserveSessionKeySpend(address, nonce, amount, time.Now())
+	})
+}
+
+// Releases a reserved spend of the session key, with retries on ETag mismatch
+func (c *CosmosDB) ReleaseSessionKeySpend(userID []byte, address gethcommon.Address, nonce uint64, amount *big.Int) error {
+	ctx := context.Background()
+	return c.updateUserWithRetries(ctx, userID, func(u *dbcommon.GWUserDB) error {
+		return u.ReleaseSessionKeySpend(address, nonce, amount)
 	})
 }
 
@@ -211,6 +228,7 @@ func (c *CosmosDB) getUserDB(userID []byte) (userWithETag, error) {
 	if err != nil {
 		return userWithETag{}, fmt.Errorf("failed to unmarshal user data: %w", err)
 	}
+	user.MigrateLegacySessionKey()
 	return userWithETag{user: user, etag: itemResponse.ETag}, nil
 }
 
@@ -0,0 +1,7 @@
+-- Each user is stored as a single encrypted JSON document, mirroring the CosmosDB layout.
+-- The id is the hex encoded HMAC of the userID, so the plain userID is never stored.
+CREATE TABLE IF NOT EXISTS users
+(
+    id        TEXT PRIMARY KEY,
+    user_data BYTEA NOT NULL
+);
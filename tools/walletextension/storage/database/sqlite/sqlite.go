@@ -19,10 +19,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
+	"time"
 
-	"github.com/ethereum/go-ethereum/crypto"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	_ "github.com/mattn/go-sqlite3" // sqlite driver for sql.Open()
 
 	dbcommon "github.com/ten-protocol/go-ten/tools/walletextension/storage/database/common"
@@ -142,36 +144,75 @@ func (s *SqliteDB) AddSessionKey(userID []byte, key common.GWSessionKey) error {
 		if err != nil {
 			return err
 		}
-		user.SessionKey = &dbcommon.GWSessionKeyDB{
-			PrivateKey: crypto.FromECDSA(key.PrivateKey.ExportECDSA()),
-			Account: dbcommon.GWAccountDB{
-				AccountAddress: key.Account.Address.Bytes(),
-				Signature:      key.Account.Signature,
-				SignatureType:  int(key.Account.SignatureType),
-			},
+		if err := user.AddSessionKey(dbcommon.NewGWSessionKeyDB(key)); err != nil {
+			return err
+		}
+		return s.updateUser(dbTx, user)
+	})
+}
+
+func (s *SqliteDB) ActivateSessionKey(userID []byte, address gethcommon.Address, active bool) error {
+	return s.withTx(func(dbTx *sql.Tx) error {
+		user, err := s.readUser(dbTx, userID)
+		if err != nil {
+			return err
+		}
+		if err := user.ActivateSessionKey(address, active); err != nil {
+			return err
+		}
+		return s.updateUser(dbTx, user)
+	})
+}
+
+func (s *SqliteDB) RemoveSessionKey(userID []byte, address gethcommon.Address) error {
+	return s.withTx(func(dbTx *sql.Tx) error {
+		user, err := s.readUser(dbTx, userID)
+		if err != nil {
+			return err
+		}
+		if err := user.RemoveSessionKey(address); err != nil {
+			return err
 		}
 		return s.updateUser(dbTx, user)
 	})
 }
 
-func (s *SqliteDB) ActivateSessionKey(userID []byte, active bool) error {
+func (s *SqliteDB) SetSessionKeyPolicy(userID []byte, address gethcommon.Address, policy *common.SessionKeyPolicy, expiresAt *time.Time) error {
 	return s.withTx(func(dbTx *sql.Tx) error {
 		user, err := s.readUser(dbTx, userID)
 		if err != nil {
 			return err
 		}
-		user.ActiveSK = active
+		if err := user.SetSessionKeyPolicy(address, policy, expiresAt); err != nil {
+			return err
+		}
 		return s.updateUser(dbTx, user)
 	})
 }
 
-func (s *SqliteDB) RemoveSessionKey(userID []byte) error {
+// ReserveSessionKeySpend - the spend limit check and the update happen in the same db transaction
+func (s *SqliteDB) ReserveSessionKeySpend(userID []byte, address gethcommon.Address, nonce uint64, amount *big.Int) error {
 	return s.withTx(func(dbTx *sql.Tx) error {
 		user, err := s.readUser(dbTx, userID)
 		if err != nil {
 			return err
 		}
-		user.SessionKey = nil
+		if err := user.ReserveSessionKeySpend(address, nonce, amount, time.Now()); err != nil {
+			return err
+		}
+		return s.updateUser(dbTx, user)
+	})
+}
+
+func (s *SqliteDB) ReleaseSessionKeySpend(userID []byte, address gethcommon.Address, nonce uint64, amount *big.Int) error {
+	return s.withTx(func(dbTx *sql.Tx) error {
+		user, err := s.readUser(dbTx, userID)
+		if err != nil {
+			return err
+		}
+		if err := user.ReleaseSessionKeySpend(address, nonce, amount); err != nil {
+			return err
+		}
 		return s.updateUser(dbTx, user)
 	})
 }
@@ -204,6 +245,7 @@ func (s *SqliteDB) readUser(dbTx *sql.Tx, userID []byte) (dbcommon.GWUserDB, err
 	if err != nil {
 		return dbcommon.GWUserDB{}, fmt.Errorf("failed to unmarshal user data: %w", err)
 	}
+	user.MigrateLegacySessionKey()
 	return user, nil
 }
 
@@ -2,12 +2,16 @@ package storage
 
 import (
 	"fmt"
+	"math/big"
+	"time"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/ten-protocol/go-ten/go/common/viewingkey"
 
 	"github.com/ten-protocol/go-ten/tools/walletextension/common"
 	"github.com/ten-protocol/go-ten/tools/walletextension/storage/database/cosmosdb"
+	"github.com/ten-protocol/go-ten/tools/walletextension/storage/database/postgres"
 	"github.com/ten-protocol/go-ten/tools/walletextension/storage/database/sqlite"
 )
 
@@ -17,8 +21,11 @@ type UserStorage interface {
 	DeleteUser(userID []byte) error
 	AddAccount(userID []byte, accountAddress []byte, signature []byte, signatureType viewingkey.SignatureType) error
 	AddSessionKey(userID []byte, key common.GWSessionKey) error
-	ActivateSessionKey(userID []byte, active bool) error
-	RemoveSessionKey(userID []byte) error
+	ActivateSessionKey(userID []byte, address gethcommon.Address, active bool) error
+	RemoveSessionKey(userID []byte, address gethcommon.Address) error
+	SetSessionKeyPolicy(userID []byte, address gethcommon.Address, policy *common.SessionKeyPolicy, expiresAt *time.Time) error
+	ReserveSessionKeySpend(userID []byte, address gethcommon.Address, nonce uint64, amount *big.Int) error
+	ReleaseSessionKeySpend(userID []byte, address gethcommon.Address, nonce uint64, amount *big.Int) error
 	GetUser(userID []byte) (*common.GWUser, error)
 	GetEncryptionKey() []byte
 }
@@ -31,6 +38,8 @@ func New(dbType, dbConnectionURL, dbPath string, randomKey []byte, logger gethlo
 		underlyingStorage, err = sqlite.NewSqliteDatabase(dbPath)
 	case "cosmosDB":
 		underlyingStorage, err = cosmosdb.NewCosmosDB(dbConnectionURL, randomKey)
+	case "postgres":
+		underlyingStorage, err = postgres.NewPostgresDatabase(dbConnectionURL, randomKey)
 	default:
 		panic(fmt.Sprintf("unknown db type: %s", dbType))
 	}
@@ -1,6 +1,10 @@
 package storage
 
 import (
+	"math/big"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ten-protocol/go-ten/go/common/viewingkey"
 	"github.com/ten-protocol/go-ten/tools/walletextension/cache"
@@ -42,8 +46,8 @@ func (s *UserStorageWithCache) DeleteUser(userID []byte) error {
 	return nil
 }
 
-func (s *UserStorageWithCache) ActivateSessionKey(userID []byte, active bool) error {
-	err := s.storage.ActivateSessionKey(userID, active)
+func (s *UserStorageWithCache) ActivateSessionKey(userID []byte, address gethcommon.Address, active bool) error {
+	err := s.storage.ActivateSessionKey(userID, address, active)
 	if err != nil {
 		return err
 	}
@@ -60,8 +64,35 @@ func (s *UserStorageWithCache) AddSessionKey(userID []byte, key wecommon.GWSessi
 	return nil
 }
 
-func (s *UserStorageWithCache) RemoveSessionKey(userID []byte) error {
-	err := s.storage.RemoveSessionKey(userID)
+func (s *UserStorageWithCache) RemoveSessionKey(userID []byte, address gethcommon.Address) error {
+	err := s.storage.RemoveSessionKey(userID, address)
+	if err != nil {
+		return err
+	}
+	s.cache.Remove(userID)
+	return nil
+}
+
+func (s *UserStorageWithCache) SetSessionKeyPolicy(userID []byte, address gethcommon.Address, policy *wecommon.SessionKeyPolicy, expiresAt *time.Time) error {
+	err := s.storage.SetSessionKeyPolicy(userID, address, policy, expiresAt)
+	if err != nil {
+		return err
+	}
+	s.cache.Remove(userID)
+	return nil
+}
+
+func (s *UserStorageWithCache) ReserveSessionKeySpend(userID []byte, address gethcommon.Address, nonce uint64, amount *big.Int) error {
+	err := s.storage.ReserveSessionKeySpend(userID, address, nonce, amount)
+	if err != nil {
+		return err
+	}
+	s.cache.Remove(userID)
+	return nil
+}
+
+func (s *UserStorageWithCache) ReleaseSessionKeySpend(userID []byte, address gethcommon.Address, nonce uint64, amount *big.Int) error {
+	err := s.storage.ReleaseSessionKeySpend(userID, address, nonce, amount)
 	if err != nil {
 		return err
 	}